@@ -1,22 +1,117 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/newbpydev/tusk/internal/adapters/api"
+	"github.com/newbpydev/tusk/internal/adapters/db"
+	"github.com/newbpydev/tusk/internal/config"
+	coretask "github.com/newbpydev/tusk/internal/core/task"
+	"github.com/newbpydev/tusk/internal/service/task"
+	"github.com/newbpydev/tusk/internal/service/user"
+	"github.com/newbpydev/tusk/internal/util/logging"
+	"go.uber.org/zap"
 )
 
+// shutdownTimeout bounds how long in-flight requests may run after a stop signal
+const shutdownTimeout = 10 * time.Second
+
 func main() {
-	fmt.Println("Starting Tusk API server...")
+	// Load the configuration from environment variables and .env file
+	cfg := config.Load()
+
+	if err := logging.Init(cfg); err != nil {
+		fmt.Printf("Failed to initialize logger: %v\n", err)
+		os.Exit(1)
+	}
+	defer logging.Sync()
+
+	logger := logging.Logger
+	logger.Info("Tusk API starting up",
+		zap.String("version", "0.1.0"),
+		zap.String("environment", cfg.AppEnv))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := db.Connect(ctx); err != nil {
+		logger.Fatal("Failed to connect to database", zap.Error(err))
+	}
+	defer db.Close()
 
-	// TODO: Implement API server with proper routing and middleware
-	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		w.Write([]byte("Welcome to Tusk API!"))
-	})
+	taskSvc := newTaskService(cfg, logger)
+	defer taskSvc.Close()
 
-	log.Println("Server starting on :8080")
-	err := http.ListenAndServe(":8080", nil)
+	// Every request is authenticated with a token handed out by POST /login
+	auth, err := api.NewAuthenticator(user.NewUserService(db.NewSQLUserRepo(db.Pool)), cfg.APISecret, cfg.APITokenTTL)
 	if err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+		logger.Fatal("API_SECRET is not usable", zap.Error(err))
 	}
+
+	server := &http.Server{
+		Addr:              ":" + cfg.Port,
+		Handler:           api.NewServer(taskSvc, auth, logging.GetComponentLogger("api")).Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			logger.Warn("API server did not shut down cleanly", zap.Error(err))
+		}
+	}()
+
+	logger.Info("API server listening", zap.String("addr", server.Addr))
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logger.Fatal("API server failed", zap.Error(err))
+	}
+}
+
+// newTaskService builds the task service with the same options as the CLI, plus
+// strict validation when API_STRICT is set
+func newTaskService(cfg *config.Config, logger *zap.Logger) *task.AsyncTaskService {
+	taskRepo := db.NewSQLTaskRepository(db.Pool, db.WithPointsWeightedProgress(cfg.ProgressByPoints))
+
+	parentCompletion, err := task.ParseParentCompletionMode(cfg.ParentAutoComplete)
+	if err != nil {
+		logger.Warn("Invalid parent completion mode, falling back to never", zap.Error(err))
+	}
+
+	urgency, err := coretask.ParseUrgencyWeights(cfg.UrgencyWeights)
+	if err != nil {
+		logger.Warn("Invalid urgency weights, falling back to defaults", zap.Error(err))
+	}
+
+	loc, err := cfg.Location()
+	if err != nil {
+		logger.Warn("Invalid time zone, falling back to local time", zap.Error(err))
+	}
+
+	regularTaskSvc := task.NewTaskService(taskRepo,
+		task.WithParentCompletion(parentCompletion),
+		task.WithTagCaseFolding(cfg.TagCaseFold),
+		task.WithMaxDepth(cfg.MaxTaskDepth),
+		task.WithLocation(loc),
+		task.WithStrictValidation(cfg.APIStrict),
+		task.WithDueSoonDays(cfg.DueSoonDays),
+		task.WithUrgencyWeights(urgency))
+
+	completionHook, err := task.NewCompletionHook(cfg.CompletionHook, cfg.CompletionHookTimeout)
+	if err != nil {
+		logger.Warn("Invalid completion hook, disabling it", zap.Error(err))
+	}
+
+	return task.NewAsyncTaskService(regularTaskSvc, logger,
+		task.WithCompletionHook(completionHook),
+		task.WithCacheTTL(cfg.CacheTTL),
+		task.WithJobTimeout(cfg.AsyncJobTimeout))
 }
@@ -1,11 +1,9 @@
 package main
 
 import (
-	"context"
 	"fmt"
 	"os"
 
-	"github.com/newbpydev/tusk/internal/adapters/db"
 	"github.com/newbpydev/tusk/internal/cli"
 	"github.com/newbpydev/tusk/internal/config"
 	"github.com/newbpydev/tusk/internal/util/logging"
@@ -34,19 +32,9 @@ func main() {
 		zap.String("version", "0.1.0"),
 		zap.String("environment", cfg.AppEnv))
 
-	ctx := context.Background() // Create a context for the database connection
-
-	// Initialize the database connection pool
-	if err := db.Connect(ctx); err != nil {
-		// Log to file and show minimal error on screen
-		logging.Logger.Error("Failed to connect to database", zap.Error(err))
-		fmt.Println("Error: Could not connect to database. Check logs for details.")
-		os.Exit(1)
-	}
-
 	// Log silently to the log file
 	logging.CLILogger.Info("Starting CLI execution")
 
-	// Execute CLI commands - services will be initialized inside
+	// Execute CLI commands - services and the database connection are initialized inside
 	cli.Execute()
 }
@@ -0,0 +1,3 @@
+-- Add an optional energy level to tasks ('low', 'medium', 'high').
+-- NULL means the task has no energy level and matches every energy filter.
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS energy VARCHAR(50);
@@ -0,0 +1,11 @@
+-- Cached progress metrics for parent tasks, written by `tusk recompute-metrics`.
+-- These values are derived: the metrics computed on the fly when a task tree is
+-- loaded remain the source of truth, and rows here may be stale until recomputed.
+CREATE TABLE IF NOT EXISTS task_metrics (
+      task_id INT PRIMARY KEY,
+      total_count INT NOT NULL,
+      completed_count INT NOT NULL,
+      progress DOUBLE PRECISION NOT NULL,
+      computed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+      FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+);
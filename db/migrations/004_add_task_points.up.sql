@@ -0,0 +1,3 @@
+-- Add story points / effort to tasks. Parents can weight their progress by the
+-- points of their subtasks; 0 means the task has no points.
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS points INTEGER NOT NULL DEFAULT 0;
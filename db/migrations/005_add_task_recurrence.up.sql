@@ -0,0 +1,3 @@
+-- Add an optional recurrence rule to tasks, e.g. 'weekly' or 'monthly:3'.
+-- NULL means the task does not repeat.
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS recurrence VARCHAR(50);
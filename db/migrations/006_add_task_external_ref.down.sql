@@ -0,0 +1,4 @@
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_external_ref_unique;
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_external_ref_complete;
+ALTER TABLE tasks DROP COLUMN IF EXISTS external_source;
+ALTER TABLE tasks DROP COLUMN IF EXISTS external_id;
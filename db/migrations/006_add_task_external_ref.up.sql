@@ -0,0 +1,9 @@
+-- Anchor tasks to an event in an external calendar for future sync integrations.
+-- Both columns are NULL for tasks that are not synced, and an external event can
+-- be anchored to at most one task.
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS external_id VARCHAR(255);
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS external_source VARCHAR(50);
+ALTER TABLE tasks ADD CONSTRAINT tasks_external_ref_complete
+   CHECK ((external_id IS NULL) = (external_source IS NULL));
+ALTER TABLE tasks ADD CONSTRAINT tasks_external_ref_unique
+   UNIQUE (external_source, external_id);
@@ -0,0 +1,4 @@
+-- Archive tasks instead of deleting them right away. archived_at is NULL for live
+-- tasks; archived tasks are hidden from every listing until they are restored.
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS archived_at TIMESTAMP;
+CREATE INDEX IF NOT EXISTS idx_tasks_archived_at ON tasks(user_id, archived_at) WHERE archived_at IS NOT NULL;
@@ -0,0 +1,13 @@
+-- Dependencies between tasks ("blocked by"). A row means task_id cannot be completed
+-- until depends_on_id is done. Dependencies are separate from the parent/subtask tree
+-- and can link tasks anywhere in it.
+CREATE TABLE IF NOT EXISTS task_dependencies (
+      task_id INT NOT NULL,
+      depends_on_id INT NOT NULL,
+      created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+      PRIMARY KEY (task_id, depends_on_id),
+      CHECK (task_id <> depends_on_id),
+      FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+      FOREIGN KEY (depends_on_id) REFERENCES tasks(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_task_dependencies_depends_on_id ON task_dependencies(depends_on_id);
@@ -0,0 +1,13 @@
+-- Dated notes appended to a task, like a worklog. Notes are never edited, only added,
+-- and go away with their task. user_id is copied from the task so notes can be
+-- scoped to their owner without a join.
+CREATE TABLE IF NOT EXISTS task_notes (
+      id SERIAL PRIMARY KEY,
+      task_id INT NOT NULL,
+      user_id INT NOT NULL,
+      body TEXT NOT NULL,
+      created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+      FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+      FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_task_notes_task_id ON task_notes(task_id);
@@ -0,0 +1,8 @@
+-- Tasks pinned to the top of their day in the agenda. The pin belongs to the task
+-- rather than to a date, so it follows the task when its due date moves and goes
+-- away with the task.
+CREATE TABLE IF NOT EXISTS task_day_pins (
+      task_id INT PRIMARY KEY,
+      pinned_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+      FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+);
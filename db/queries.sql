@@ -63,11 +63,11 @@ WHERE
 
 -- name: CreateTask :one
 INSERT INTO tasks 
-   (user_id, parent_id, title, description, due_date, is_completed, status, priority, tags, display_order)
+   (user_id, parent_id, title, description, due_date, is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source)
 VALUES 
-   ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+   ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 RETURNING
-   id, user_id, parent_id, title, description, created_at, updated_at, due_date, is_completed, status, priority, tags, display_order;
+   id, user_id, parent_id, title, description, created_at, updated_at, due_date, is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at;
 
 -- name: GetTaskById :one
 SELECT * 
@@ -87,32 +87,112 @@ SET
    status = $8, 
    priority = $9, 
    tags = $10, 
-   display_order = $11
+   display_order = $11,
+   energy = $12,
+   points = $13,
+   recurrence = $14,
+   external_id = $15,
+   external_source = $16
 WHERE 
    id = $1
 RETURNING
-   id, user_id, parent_id, title, description, created_at, updated_at, due_date, is_completed, status, priority, tags, display_order; 
+   id, user_id, parent_id, title, description, created_at, updated_at, due_date, is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at; 
 
 -- name: DeleteTask :exec
 DELETE FROM tasks 
 WHERE 
    id = $1;
 
+-- Archiving hides a task and its subtasks instead of deleting them. Tasks archived
+-- together share the timestamp, so restoring brings back exactly that group.
+-- name: ArchiveTask :execrows
+WITH RECURSIVE subtree AS (
+    SELECT id, 1 AS depth
+    FROM tasks
+    WHERE id = $1 AND archived_at IS NULL
+
+    UNION ALL
+
+    SELECT t.id, s.depth + 1
+    FROM tasks t
+    INNER JOIN subtree s ON t.parent_id = s.id
+    WHERE s.depth < 100
+)
+UPDATE tasks
+SET 
+   archived_at = CURRENT_TIMESTAMP
+WHERE 
+   id IN (SELECT id FROM subtree) AND
+   archived_at IS NULL;
+
+-- name: RestoreTask :execrows
+WITH RECURSIVE subtree AS (
+    SELECT id, archived_at, 1 AS depth
+    FROM tasks
+    WHERE id = $1 AND archived_at IS NOT NULL
+
+    UNION ALL
+
+    SELECT t.id, s.archived_at, s.depth + 1
+    FROM tasks t
+    INNER JOIN subtree s ON t.parent_id = s.id
+    WHERE s.depth < 100
+)
+UPDATE tasks
+SET 
+   archived_at = NULL
+FROM subtree
+WHERE 
+   tasks.id = subtree.id AND
+   tasks.archived_at = subtree.archived_at;
+
 -- name: ListRootTasksByUserId :many
 SELECT 
-   id, user_id, parent_id, title, description, created_at, updated_at, due_date, is_completed, status, priority, tags, display_order
+   id, user_id, parent_id, title, description, created_at, updated_at, due_date, is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at
 FROM tasks
 WHERE 
-   user_id = $1 AND parent_id IS NULL
+   user_id = $1 AND parent_id IS NULL AND archived_at IS NULL
 ORDER BY
    display_order, created_at DESC;
 
+-- Pages are ordered by display_order then id so every root lands on exactly one page
+-- name: ListRootTasksByUserIdPaged :many
+SELECT 
+   id, user_id, parent_id, title, description, created_at, updated_at, due_date, is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at
+FROM tasks
+WHERE 
+   user_id = $1 AND parent_id IS NULL AND archived_at IS NULL
+ORDER BY
+   display_order, id
+LIMIT $2 OFFSET $3;
+
+-- name: CountRootTasksByUserId :one
+SELECT 
+   COUNT(*)
+FROM tasks
+WHERE 
+   user_id = $1 AND parent_id IS NULL AND archived_at IS NULL;
+
+-- name: ListArchivedTasks :many
+SELECT 
+   id, user_id, parent_id, title, description, created_at, updated_at, due_date, 
+   is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at
+FROM tasks
+WHERE 
+   user_id = $1 AND
+   archived_at IS NOT NULL AND
+   NOT EXISTS (
+      SELECT 1 FROM tasks p WHERE p.id = tasks.parent_id AND p.archived_at = tasks.archived_at
+   )
+ORDER BY
+   archived_at DESC, display_order;
+
 -- name: GetSubtasksByParentId :many
 SELECT 
-   id, user_id, parent_id, title, description, created_at, updated_at, due_date, is_completed, status, priority, tags, display_order
+   id, user_id, parent_id, title, description, created_at, updated_at, due_date, is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at
 FROM tasks
 WHERE 
-   parent_id = $1
+   parent_id = $1 AND archived_at IS NULL
 ORDER BY
    display_order, created_at DESC;
 
@@ -121,7 +201,8 @@ WITH RECURSIVE task_tree AS (
     -- Base case
     SELECT 
         t.id, t.user_id, t.parent_id, t.title, t.description, t.created_at, t.updated_at, 
-        t.due_date, t.is_completed, t.status, t.priority, t.tags, t.display_order
+        t.due_date, t.is_completed, t.status, t.priority, t.tags, t.display_order, t.energy, t.points, t.recurrence, t.external_id, t.external_source, t.archived_at,
+        1 AS depth
     FROM tasks t
     WHERE t.id = $1
     
@@ -130,9 +211,58 @@ WITH RECURSIVE task_tree AS (
     -- Recursive case
     SELECT 
         t.id, t.user_id, t.parent_id, t.title, t.description, t.created_at, t.updated_at, 
-        t.due_date, t.is_completed, t.status, t.priority, t.tags, t.display_order
+        t.due_date, t.is_completed, t.status, t.priority, t.tags, t.display_order, t.energy, t.points, t.recurrence, t.external_id, t.external_source, t.archived_at,
+        tt.depth + 1
+    FROM tasks t
+    INNER JOIN task_tree tt ON t.parent_id = tt.id
+    -- Depth cap so corrupted (cyclic) parent links cannot recurse forever
+    WHERE tt.depth < 100 AND t.archived_at IS NULL
+)
+SELECT 
+    task_tree.id,
+    task_tree.user_id,
+    task_tree.parent_id,
+    task_tree.title,
+    task_tree.description,
+    task_tree.created_at,
+    task_tree.updated_at,
+    task_tree.due_date,
+    task_tree.is_completed,
+    task_tree.status,
+    task_tree.priority,
+    task_tree.tags,
+    task_tree.display_order,
+    task_tree.energy,
+    task_tree.points,
+    task_tree.recurrence,
+    task_tree.external_id,
+    task_tree.external_source,
+    task_tree.archived_at
+FROM task_tree
+ORDER BY task_tree.display_order, task_tree.created_at DESC;
+
+-- Loads the trees of all of a user's root tasks in one query
+-- name: ListTaskForestByUserId :many
+WITH RECURSIVE task_tree AS (
+    -- Base case
+    SELECT 
+        t.id, t.user_id, t.parent_id, t.title, t.description, t.created_at, t.updated_at, 
+        t.due_date, t.is_completed, t.status, t.priority, t.tags, t.display_order, t.energy, t.points, t.recurrence, t.external_id, t.external_source, t.archived_at,
+        1 AS depth
+    FROM tasks t
+    WHERE t.user_id = $1 AND t.parent_id IS NULL AND t.archived_at IS NULL
+    
+    UNION ALL
+    
+    -- Recursive case
+    SELECT 
+        t.id, t.user_id, t.parent_id, t.title, t.description, t.created_at, t.updated_at, 
+        t.due_date, t.is_completed, t.status, t.priority, t.tags, t.display_order, t.energy, t.points, t.recurrence, t.external_id, t.external_source, t.archived_at,
+        tt.depth + 1
     FROM tasks t
     INNER JOIN task_tree tt ON t.parent_id = tt.id
+    -- Depth cap so corrupted (cyclic) parent links cannot recurse forever
+    WHERE tt.depth < 100 AND t.archived_at IS NULL
 )
 SELECT 
     task_tree.id,
@@ -147,7 +277,59 @@ SELECT
     task_tree.status,
     task_tree.priority,
     task_tree.tags,
-    task_tree.display_order
+    task_tree.display_order,
+    task_tree.energy,
+    task_tree.points,
+    task_tree.recurrence,
+    task_tree.external_id,
+    task_tree.external_source,
+    task_tree.archived_at
+FROM task_tree
+ORDER BY task_tree.display_order, task_tree.created_at DESC;
+
+-- Loads the trees of several roots in one query, e.g. a page of root tasks
+-- name: ListTaskTreesByRootIds :many
+WITH RECURSIVE task_tree AS (
+    -- Base case
+    SELECT 
+        t.id, t.user_id, t.parent_id, t.title, t.description, t.created_at, t.updated_at, 
+        t.due_date, t.is_completed, t.status, t.priority, t.tags, t.display_order, t.energy, t.points, t.recurrence, t.external_id, t.external_source, t.archived_at,
+        1 AS depth
+    FROM tasks t
+    WHERE t.id = ANY(sqlc.arg('root_ids')::int[])
+    
+    UNION ALL
+    
+    -- Recursive case
+    SELECT 
+        t.id, t.user_id, t.parent_id, t.title, t.description, t.created_at, t.updated_at, 
+        t.due_date, t.is_completed, t.status, t.priority, t.tags, t.display_order, t.energy, t.points, t.recurrence, t.external_id, t.external_source, t.archived_at,
+        tt.depth + 1
+    FROM tasks t
+    INNER JOIN task_tree tt ON t.parent_id = tt.id
+    -- Depth cap so corrupted (cyclic) parent links cannot recurse forever
+    WHERE tt.depth < 100 AND t.archived_at IS NULL
+)
+SELECT 
+    task_tree.id,
+    task_tree.user_id,
+    task_tree.parent_id,
+    task_tree.title,
+    task_tree.description,
+    task_tree.created_at,
+    task_tree.updated_at,
+    task_tree.due_date,
+    task_tree.is_completed,
+    task_tree.status,
+    task_tree.priority,
+    task_tree.tags,
+    task_tree.display_order,
+    task_tree.energy,
+    task_tree.points,
+    task_tree.recurrence,
+    task_tree.external_id,
+    task_tree.external_source,
+    task_tree.archived_at
 FROM task_tree
 ORDER BY task_tree.display_order, task_tree.created_at DESC;
 
@@ -158,59 +340,145 @@ SET
 WHERE 
    id = $1;
 
+-- name: ReparentTask :execrows
+UPDATE tasks
+SET 
+   parent_id = $2,
+   updated_at = CURRENT_TIMESTAMP
+WHERE 
+   id = $1;
+
+-- name: SetTaskExternalRef :execrows
+UPDATE tasks
+SET 
+   external_source = $2,
+   external_id = $3,
+   updated_at = CURRENT_TIMESTAMP
+WHERE 
+   id = $1;
+
+-- name: GetTaskByExternalId :one
+SELECT 
+   id, user_id, parent_id, title, description, created_at, updated_at, due_date, 
+   is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at
+FROM tasks
+WHERE 
+   external_source = $1 AND
+   external_id = $2;
+
+-- name: NormalizeDisplayOrder :execrows
+UPDATE tasks
+SET 
+   display_order = ordered.new_order
+FROM (
+   SELECT 
+      id,
+      (ROW_NUMBER() OVER (PARTITION BY parent_id ORDER BY display_order, created_at DESC, id) - 1)::int AS new_order
+   FROM tasks
+   WHERE 
+      user_id = $1
+) AS ordered
+WHERE 
+   tasks.id = ordered.id AND
+   tasks.display_order IS DISTINCT FROM ordered.new_order;
+
 -- Additional queries for enhanced functionality -------------------------
 
 -- name: SearchTasksByTitle :many
 SELECT 
    id, user_id, parent_id, title, description, created_at, updated_at, due_date, 
-   is_completed, status, priority, tags, display_order
+   is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at
 FROM tasks
 WHERE 
    user_id = $1 AND
+   archived_at IS NULL AND
    title ILIKE $2
 ORDER BY
    created_at DESC;
 
+-- Matches the query as plain text (no wildcards) in the title, description or any tag
+-- name: SearchTasksByText :many
+SELECT 
+   id, user_id, parent_id, title, description, created_at, updated_at, due_date, 
+   is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at
+FROM tasks
+WHERE 
+   user_id = $1 AND
+   archived_at IS NULL AND
+   (strpos(lower(title), lower(sqlc.arg('query')::text)) > 0 OR
+      strpos(lower(coalesce(description, '')), lower(sqlc.arg('query')::text)) > 0 OR
+      EXISTS (SELECT 1 FROM unnest(tags) AS t(name) WHERE strpos(lower(t.name), lower(sqlc.arg('query')::text)) > 0))
+ORDER BY
+   created_at DESC;
+
 -- name: SearchTasksByTag :many
 SELECT 
    id, user_id, parent_id, title, description, created_at, updated_at, due_date, 
-   is_completed, status, priority, tags, display_order
+   is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at
 FROM tasks
 WHERE 
    user_id = $1 AND
-   $2 = ANY(tags)
+   archived_at IS NULL AND
+   EXISTS (
+      SELECT 1 FROM unnest(tags) AS t(name) WHERE lower(t.name) = lower(sqlc.arg('tag')::text)
+   )
 ORDER BY
    created_at DESC;
 
--- name: ListTasksByStatus :many
+-- name: ListTasksByStatuses :many
 SELECT 
    id, user_id, parent_id, title, description, created_at, updated_at, due_date, 
-   is_completed, status, priority, tags, display_order
+   is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at
 FROM tasks
 WHERE 
    user_id = $1 AND
-   status = $2
+   archived_at IS NULL AND
+   status = ANY($2::text[])
 ORDER BY
    priority DESC, display_order, created_at DESC;
 
 -- name: ListTasksByPriority :many
 SELECT 
    id, user_id, parent_id, title, description, created_at, updated_at, due_date, 
-   is_completed, status, priority, tags, display_order
+   is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at
 FROM tasks
 WHERE 
    user_id = $1 AND
+   archived_at IS NULL AND
    priority = $2
 ORDER BY
    display_order, created_at DESC;
 
+-- name: ListTasksFiltered :many
+SELECT 
+   id, user_id, parent_id, title, description, created_at, updated_at, due_date, 
+   is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at
+FROM tasks
+WHERE 
+   user_id = $1 AND
+   archived_at IS NULL AND
+   (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status')) AND
+   (sqlc.narg('priority')::text IS NULL OR priority = sqlc.narg('priority')) AND
+   (sqlc.narg('energy')::text IS NULL OR energy IS NULL OR energy = sqlc.narg('energy')) AND
+   (sqlc.narg('due_before')::timestamp IS NULL OR due_date < sqlc.narg('due_before')) AND
+   (sqlc.narg('due_after')::timestamp IS NULL OR due_date >= sqlc.narg('due_after')) AND
+   (sqlc.narg('tag')::text IS NULL OR EXISTS (
+      SELECT 1 FROM unnest(tags) AS t(name) WHERE lower(t.name) = lower(sqlc.narg('tag'))
+   )) AND
+   (sqlc.narg('text')::text IS NULL OR 
+      strpos(lower(title), lower(sqlc.narg('text'))) > 0 OR
+      strpos(lower(coalesce(description, '')), lower(sqlc.narg('text'))) > 0)
+ORDER BY
+   due_date ASC NULLS LAST, display_order, created_at DESC;
+
 -- name: ListTasksDueToday :many
 SELECT 
    id, user_id, parent_id, title, description, created_at, updated_at, due_date, 
-   is_completed, status, priority, tags, display_order
+   is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at
 FROM tasks
 WHERE 
    user_id = $1 AND
+   archived_at IS NULL AND
    due_date::date = CURRENT_DATE AND
    is_completed = false
 ORDER BY
@@ -219,12 +487,13 @@ ORDER BY
 -- name: ListTasksDueSoon :many
 SELECT 
    id, user_id, parent_id, title, description, created_at, updated_at, due_date, 
-   is_completed, status, priority, tags, display_order
+   is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at
 FROM tasks
 WHERE 
    user_id = $1 AND
+   archived_at IS NULL AND
    due_date IS NOT NULL AND
-   due_date::date BETWEEN CURRENT_DATE AND (CURRENT_DATE + interval '7 days')::date AND
+   due_date::date BETWEEN CURRENT_DATE AND (CURRENT_DATE + ($2::int * INTERVAL '1 day'))::date AND
    is_completed = false
 ORDER BY
    due_date, priority DESC;
@@ -232,15 +501,29 @@ ORDER BY
 -- name: ListOverdueTasks :many
 SELECT 
    id, user_id, parent_id, title, description, created_at, updated_at, due_date, 
-   is_completed, status, priority, tags, display_order
+   is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at
 FROM tasks
 WHERE 
    user_id = $1 AND
+   archived_at IS NULL AND
    due_date < CURRENT_DATE AND
    is_completed = false
 ORDER BY
    due_date, priority DESC;
 
+-- name: ListIncompleteDatedTasks :many
+SELECT 
+   id, user_id, parent_id, title, description, created_at, updated_at, due_date, 
+   is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at
+FROM tasks
+WHERE 
+   user_id = $1 AND
+   archived_at IS NULL AND
+   due_date IS NOT NULL AND
+   is_completed = false
+ORDER BY
+   due_date, priority DESC;
+
 -- name: GetTaskCountsByStatus :one
 SELECT
    COUNT(*) FILTER (WHERE status = 'todo') AS todo_count,
@@ -249,7 +532,8 @@ SELECT
    COUNT(*) AS total_count
 FROM tasks
 WHERE
-   user_id = $1;
+   user_id = $1 AND
+   archived_at IS NULL;
 
 -- name: GetTaskCountsByPriority :one
 SELECT
@@ -259,15 +543,17 @@ SELECT
 FROM tasks
 WHERE
    user_id = $1 AND
+   archived_at IS NULL AND
    is_completed = false;
 
 -- name: GetRecentlyCompletedTasks :many
 SELECT 
    id, user_id, parent_id, title, description, created_at, updated_at, due_date, 
-   is_completed, status, priority, tags, display_order
+   is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at
 FROM tasks
 WHERE 
    user_id = $1 AND
+   archived_at IS NULL AND
    is_completed = true
 ORDER BY
    updated_at DESC
@@ -282,11 +568,165 @@ SET
 WHERE 
    id = ANY($1::int[]);
 
+-- name: PostponeTasks :execrows
+UPDATE tasks
+SET 
+   due_date = due_date + ($2::int * INTERVAL '1 day'),
+   updated_at = CURRENT_TIMESTAMP
+WHERE 
+   id = ANY($1::int[]) AND
+   is_completed = false AND
+   due_date IS NOT NULL;
+
+-- name: SnoozeTasks :execrows
+UPDATE tasks
+SET 
+   due_date = $3,
+   updated_at = CURRENT_TIMESTAMP
+WHERE 
+   id = ANY($1::int[]) AND
+   user_id = $2 AND
+   is_completed = false;
+
 -- name: GetAllTagsForUser :many
 SELECT DISTINCT unnest(tags) as tag
 FROM tasks
-WHERE user_id = $1
+WHERE user_id = $1 AND archived_at IS NULL
 ORDER BY tag;
 
+-- name: SetTaskTags :execrows
+UPDATE tasks
+SET 
+   tags = $3,
+   updated_at = CURRENT_TIMESTAMP
+WHERE 
+   id = $1 AND user_id = $2;
+
+
+
+
+-- Cached metrics -------------------------------------------------------
+
+-- name: DeleteUserTaskMetrics :exec
+DELETE FROM task_metrics
+WHERE 
+   task_id IN (SELECT id FROM tasks WHERE user_id = $1);
+
+-- name: UpsertTaskMetrics :exec
+INSERT INTO task_metrics (
+   task_id, total_count, completed_count, progress, computed_at
+) VALUES (
+   $1, $2, $3, $4, CURRENT_TIMESTAMP
+)
+ON CONFLICT (task_id) DO UPDATE
+SET 
+   total_count = EXCLUDED.total_count,
+   completed_count = EXCLUDED.completed_count,
+   progress = EXCLUDED.progress,
+   computed_at = EXCLUDED.computed_at;
+
+-- name: ListTaskMetrics :many
+SELECT 
+   m.task_id, m.total_count, m.completed_count, m.progress, m.computed_at
+FROM task_metrics m
+JOIN tasks t ON t.id = m.task_id
+WHERE 
+   t.user_id = $1
+ORDER BY
+   m.task_id;
+
+
+
+
+-- Dependencies ---------------------------------------------------------
+
+-- name: AddTaskDependency :exec
+INSERT INTO task_dependencies (
+   task_id, depends_on_id
+) VALUES (
+   $1, $2
+)
+ON CONFLICT (task_id, depends_on_id) DO NOTHING;
+
+-- name: RemoveTaskDependency :execrows
+DELETE FROM task_dependencies
+WHERE 
+   task_id = $1 AND depends_on_id = $2;
+
+-- name: ListTaskDependencies :many
+SELECT 
+   depends_on_id
+FROM task_dependencies
+WHERE 
+   task_id = $1
+ORDER BY
+   depends_on_id;
+
+-- Open tasks still waiting on a dependency that is neither done nor archived
+-- name: ListBlockedTaskIds :many
+SELECT DISTINCT 
+   d.task_id
+FROM task_dependencies d
+JOIN tasks t ON t.id = d.task_id
+JOIN tasks dep ON dep.id = d.depends_on_id
+WHERE 
+   t.user_id = $1
+   AND t.archived_at IS NULL
+   AND dep.archived_at IS NULL
+   AND dep.is_completed IS NOT TRUE
+ORDER BY
+   d.task_id;
 
+-- Notes ----------------------------------------------------------------
 
+-- The note takes its user from the task, so it returns no row for a missing task
+-- name: AddTaskNote :one
+INSERT INTO task_notes (
+   task_id, user_id, body
+)
+SELECT 
+   id, user_id, $2::text
+FROM tasks
+WHERE 
+   id = $1
+RETURNING 
+   id, task_id, user_id, body, created_at;
+
+-- name: ListTaskNotes :many
+SELECT 
+   id, task_id, user_id, body, created_at
+FROM task_notes
+WHERE 
+   task_id = $1
+ORDER BY
+   created_at DESC, id DESC;
+
+-- Day pins -------------------------------------------------------------
+
+-- Pinning a task again moves it back to the top of its day
+-- name: PinTaskToDay :exec
+INSERT INTO task_day_pins (
+   task_id
+) VALUES (
+   $1
+)
+ON CONFLICT (task_id) DO UPDATE
+SET 
+   pinned_at = CURRENT_TIMESTAMP;
+
+-- name: UnpinTaskFromDay :execrows
+DELETE FROM task_day_pins
+WHERE 
+   task_id = $1;
+
+-- Most recently pinned first, which is the order pinned tasks take within a day
+-- name: ListDayPinnedTaskIds :many
+SELECT 
+   p.task_id
+FROM task_day_pins p
+JOIN tasks t ON t.id = p.task_id
+WHERE 
+   t.user_id = $1
+   AND t.archived_at IS NULL
+ORDER BY
+   p.pinned_at DESC, p.task_id;
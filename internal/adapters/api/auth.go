@@ -0,0 +1,162 @@
+package api
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/newbpydev/tusk/internal/core/errors"
+	userService "github.com/newbpydev/tusk/internal/service/user"
+)
+
+// MinSecretLength is the shortest secret accepted for signing API tokens
+const MinSecretLength = 32
+
+// DefaultTokenTTL is how long an API token stays valid unless configured otherwise
+const DefaultTokenTTL = 24 * time.Hour
+
+// Authenticator issues API tokens to users logging in with their password and
+// resolves the user behind the token of each request. A token is
+// "<user ID>.<expiry>.<signature>", signed with HMAC-SHA256, so no token is stored;
+// the user is looked up on every request, so deleted or deactivated users lose access.
+type Authenticator struct {
+	users  userService.Service
+	secret []byte
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// NewAuthenticator creates an authenticator signing tokens with secret, which must be
+// at least MinSecretLength bytes. A non-positive ttl uses DefaultTokenTTL.
+func NewAuthenticator(users userService.Service, secret string, ttl time.Duration) (*Authenticator, error) {
+	if len(secret) < MinSecretLength {
+		return nil, fmt.Errorf("API secret must be at least %d characters long", MinSecretLength)
+	}
+	if ttl <= 0 {
+		ttl = DefaultTokenTTL
+	}
+	return &Authenticator{
+		users:  users,
+		secret: []byte(secret),
+		ttl:    ttl,
+		now:    time.Now,
+	}, nil
+}
+
+// Login checks a user's password and returns a new token for them with its expiry
+func (a *Authenticator) Login(ctx context.Context, username, password string) (string, time.Time, error) {
+	u, err := a.users.Login(ctx, username, password)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if !u.IsActive {
+		return "", time.Time{}, errors.Unauthorized("user is deactivated")
+	}
+
+	expires := a.now().Add(a.ttl).Truncate(time.Second)
+	payload := fmt.Sprintf("%d.%d", u.ID, expires.Unix())
+	return payload + "." + a.sign(payload), expires, nil
+}
+
+// UserID returns the ID of the active user the token was issued to
+func (a *Authenticator) UserID(ctx context.Context, token string) (int64, error) {
+	payload, signature, ok := cutLast(token, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(a.sign(payload))) {
+		return 0, errors.Unauthorized("invalid token")
+	}
+
+	rawID, rawExpiry, ok := strings.Cut(payload, ".")
+	userID, idErr := strconv.ParseInt(rawID, 10, 64)
+	expiry, expiryErr := strconv.ParseInt(rawExpiry, 10, 64)
+	if !ok || idErr != nil || expiryErr != nil {
+		return 0, errors.Unauthorized("invalid token")
+	}
+	if !a.now().Before(time.Unix(expiry, 0)) {
+		return 0, errors.Unauthorized("token expired")
+	}
+
+	u, err := a.users.Get(ctx, userID)
+	if err != nil || !u.IsActive {
+		return 0, errors.Unauthorized("invalid token")
+	}
+	return userID, nil
+}
+
+// sign returns the encoded signature of a token payload
+func (a *Authenticator) sign(payload string) string {
+	mac := hmac.New(sha256.New, a.secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// cutLast slices s around the last instance of sep
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+// userIDKey is the request context key of the authenticated user's ID
+type userIDKey struct{}
+
+// requireUser lets requests with a valid bearer token through to next, with the
+// token's user in the request context; every other request is answered with 401
+func (s *Server) requireUser(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			s.writeError(w, r, errors.Unauthorized("missing bearer token"))
+			return
+		}
+
+		userID, err := s.auth.UserID(r.Context(), strings.TrimSpace(token))
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			s.writeError(w, r, err)
+			return
+		}
+		next(w, r.WithContext(context.WithValue(r.Context(), userIDKey{}, userID)))
+	}
+}
+
+// requestUserID returns the ID of the user authenticated by requireUser
+func requestUserID(r *http.Request) int64 {
+	userID, _ := r.Context().Value(userIDKey{}).(int64)
+	return userID
+}
+
+// loginRequest is the body accepted by POST /login
+type loginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// loginResponse is the body returned by POST /login
+type loginResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// login handles POST /login, trading a username and password for a token
+func (s *Server) login(w http.ResponseWriter, r *http.Request) {
+	var req loginRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	token, expires, err := s.auth.Login(r.Context(), req.Username, req.Password)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	s.writeJSON(w, http.StatusOK, loginResponse{Token: token, ExpiresAt: expires})
+}
@@ -0,0 +1,106 @@
+// Package api exposes the task service over a JSON REST API.
+package api
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+
+	"github.com/newbpydev/tusk/internal/core/errors"
+	taskService "github.com/newbpydev/tusk/internal/service/task"
+	"go.uber.org/zap"
+)
+
+// maxRequestBytes bounds the size of a request body
+const maxRequestBytes = 1 << 20
+
+// Server serves the REST API, each request for the tasks of the user whose token
+// it carries
+type Server struct {
+	tasks taskService.Service
+	auth  *Authenticator
+	log   *zap.Logger
+}
+
+// NewServer creates an API server that authenticates requests with auth
+func NewServer(tasks taskService.Service, auth *Authenticator, log *zap.Logger) *Server {
+	return &Server{
+		tasks: tasks,
+		auth:  auth,
+		log:   log,
+	}
+}
+
+// Handler returns the HTTP handler with every API route registered. Every route but
+// POST /login needs a token.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /login", s.login)
+	mux.HandleFunc("GET /tasks", s.requireUser(s.listTasks))
+	mux.HandleFunc("POST /tasks", s.requireUser(s.createTask))
+	mux.HandleFunc("GET /tasks/{id}", s.requireUser(s.getTask))
+	mux.HandleFunc("PUT /tasks/{id}", s.requireUser(s.updateTask))
+	mux.HandleFunc("DELETE /tasks/{id}", s.requireUser(s.deleteTask))
+	mux.HandleFunc("POST /tasks/{id}/complete", s.requireUser(s.completeTask))
+	return mux
+}
+
+// errorResponse is the body of every failed request: a stable machine code, see
+// errors.ErrorCode, and a message for people
+type errorResponse struct {
+	Code    errors.ErrorCode `json:"code"`
+	Message string           `json:"message"`
+}
+
+// errorStatuses maps error codes to HTTP statuses; any other error is a 500
+var errorStatuses = map[errors.ErrorCode]int{
+	errors.CodeInvalidInput: http.StatusBadRequest,
+	errors.CodeNotFound:     http.StatusNotFound,
+	errors.CodeConflict:     http.StatusConflict,
+	errors.CodeUnauthorized: http.StatusUnauthorized,
+	errors.CodeForbidden:    http.StatusForbidden,
+}
+
+// writeJSON writes v as the JSON response body with the given status
+func (s *Server) writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		s.log.Warn("Failed to write API response", zap.Error(err))
+	}
+}
+
+// writeError maps a service error to an HTTP status by its code. Validation errors
+// are reported to the client as-is, unexpected errors are logged and hidden.
+func (s *Server) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	code := errors.CodeOf(err)
+	status, ok := errorStatuses[code]
+	if !ok {
+		code, status = errors.CodeInternalError, http.StatusInternalServerError
+	}
+
+	message := http.StatusText(status)
+	if status == http.StatusInternalServerError {
+		s.log.Error("API request failed",
+			zap.String("method", r.Method),
+			zap.String("path", r.URL.Path),
+			zap.Error(err))
+	} else {
+		var domainErr errors.DomainError
+		if stderrors.As(err, &domainErr) {
+			message = domainErr.Message
+		}
+	}
+
+	s.writeJSON(w, status, errorResponse{Code: code, Message: message})
+}
+
+// decodeJSON reads the request body into v, rejecting unknown fields
+func decodeJSON(w http.ResponseWriter, r *http.Request, v any) error {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return errors.InvalidInput("invalid request body: " + err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/newbpydev/tusk/internal/core/errors"
+	"github.com/newbpydev/tusk/internal/core/task"
+	"github.com/newbpydev/tusk/internal/core/user"
+	taskService "github.com/newbpydev/tusk/internal/service/task"
+	userService "github.com/newbpydev/tusk/internal/service/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeTaskService keeps tasks in memory and implements the service methods the API uses
+type fakeTaskService struct {
+	taskService.Service
+	tasks map[int64]task.Task
+}
+
+func (f *fakeTaskService) Show(ctx context.Context, taskID int64) (task.Task, error) {
+	t, ok := f.tasks[taskID]
+	if !ok {
+		return task.Task{}, errors.NotFound("task not found")
+	}
+	return t, nil
+}
+
+func (f *fakeTaskService) Create(ctx context.Context, userID int64, parentID *int64, title, description string,
+	dueDate *time.Time, priority task.Priority, tags []string, recurrence *task.Recurrence) (task.Task, error) {
+	if title == "" {
+		return task.Task{}, errors.InvalidInput("title is required")
+	}
+	t := task.Task{ID: int32(len(f.tasks) + 1), UserID: int32(userID), Title: title, Priority: priority, Recurrence: recurrence}
+	f.tasks[int64(t.ID)] = t
+	return t, nil
+}
+
+// List fails like a broken database would, to exercise unexpected errors
+func (f *fakeTaskService) List(ctx context.Context, userID int64) ([]task.Task, error) {
+	return nil, fmt.Errorf("connection refused")
+}
+
+func (f *fakeTaskService) Delete(ctx context.Context, taskID int64) error {
+	delete(f.tasks, taskID)
+	return nil
+}
+
+// fakeUserService knows the users by ID; every user's password is "password"
+type fakeUserService struct {
+	userService.Service
+	users map[int64]user.User
+}
+
+func (f *fakeUserService) Login(ctx context.Context, username, password string) (user.User, error) {
+	for _, u := range f.users {
+		if u.Username == username && password == "password" {
+			return u, nil
+		}
+	}
+	return user.User{}, errors.Unauthorized("invalid username or password")
+}
+
+func (f *fakeUserService) Get(ctx context.Context, userID int64) (user.User, error) {
+	u, ok := f.users[userID]
+	if !ok {
+		return user.User{}, errors.NotFound("user not found")
+	}
+	return u, nil
+}
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+// newTestAuthenticator knows the active users 1 (alice) and 2 (bob), and the
+// deactivated user 3 (carol)
+func newTestAuthenticator(t *testing.T) *Authenticator {
+	users := &fakeUserService{users: map[int64]user.User{
+		1: {ID: 1, Username: "alice", IsActive: true},
+		2: {ID: 2, Username: "bob", IsActive: true},
+		3: {ID: 3, Username: "carol"},
+	}}
+	auth, err := NewAuthenticator(users, testSecret, time.Hour)
+	require.NoError(t, err)
+	return auth
+}
+
+// newTestServer returns the server's task service and handler, and a token of user 1
+func newTestServer(t *testing.T) (*fakeTaskService, http.Handler, string) {
+	svc := &fakeTaskService{tasks: map[int64]task.Task{
+		1: {ID: 1, UserID: 1, Title: "Mine"},
+		2: {ID: 2, UserID: 2, Title: "Someone else's"},
+	}}
+	auth := newTestAuthenticator(t)
+	token, _, err := auth.Login(context.Background(), "alice", "password")
+	require.NoError(t, err)
+	return svc, NewServer(svc, auth, zap.NewNop()).Handler(), token
+}
+
+func TestServerRoutes(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Get own task",
+			method:         http.MethodGet,
+			path:           "/tasks/1",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"title":"Mine"`,
+		},
+		{
+			name:           "Other user's task is not found",
+			method:         http.MethodGet,
+			path:           "/tasks/2",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"code":"NOT_FOUND","message":"task 2 not found"}`,
+		},
+		{
+			name:           "Missing task",
+			method:         http.MethodGet,
+			path:           "/tasks/99",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Invalid ID",
+			method:         http.MethodGet,
+			path:           "/tasks/abc",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `invalid task ID`,
+		},
+		{
+			name:           "Create task",
+			method:         http.MethodPost,
+			path:           "/tasks",
+			body:           `{"title":"New","priority":"high","recurrence":"weekly"}`,
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `"frequency":"weekly"`,
+		},
+		{
+			name:           "Validation error",
+			method:         http.MethodPost,
+			path:           "/tasks",
+			body:           `{"title":""}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"code":"INVALID_INPUT","message":"title is required"}`,
+		},
+		{
+			name:           "Unknown field",
+			method:         http.MethodPost,
+			path:           "/tasks",
+			body:           `{"name":"New"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Delete own task",
+			method:         http.MethodDelete,
+			path:           "/tasks/1",
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "Unexpected error is hidden",
+			method:         http.MethodGet,
+			path:           "/tasks",
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"code":"INTERNAL_ERROR","message":"Internal Server Error"}`,
+		},
+		{
+			name:           "Wrong method",
+			method:         http.MethodPatch,
+			path:           "/tasks/1",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler, token := newTestServer(t)
+
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			req.Header.Set("Authorization", "Bearer "+token)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != "" {
+				assert.Contains(t, rec.Body.String(), tc.expectedBody)
+			}
+		})
+	}
+}
+
+func TestCreateTaskUsesTokenUser(t *testing.T) {
+	svc, handler, token := newTestServer(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(`{"title":"New"}`))
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	var created task.Task
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&created))
+	assert.Equal(t, int32(1), created.UserID)
+	assert.Equal(t, "New", svc.tasks[int64(created.ID)].Title)
+}
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/newbpydev/tusk/internal/core/errors"
+	"github.com/newbpydev/tusk/internal/core/task"
+)
+
+// taskRequest is the body accepted by POST /tasks and PUT /tasks/{id}
+type taskRequest struct {
+	ParentID    *int64        `json:"parent_id"`
+	Title       string        `json:"title"`
+	Description string        `json:"description"`
+	DueDate     *time.Time    `json:"due_date"`
+	Priority    task.Priority `json:"priority"`
+	Tags        []string      `json:"tags"`       // normalized by the service, see task.NormalizeTags
+	Recurrence  string        `json:"recurrence"` // e.g. "weekly" or "monthly:3", only used on create
+}
+
+// listTasks handles GET /tasks, returning the user's root tasks with their subtasks
+func (s *Server) listTasks(w http.ResponseWriter, r *http.Request) {
+	tasks, err := s.tasks.List(r.Context(), requestUserID(r))
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	if tasks == nil {
+		tasks = []task.Task{}
+	}
+	s.writeJSON(w, http.StatusOK, tasks)
+}
+
+// createTask handles POST /tasks
+func (s *Server) createTask(w http.ResponseWriter, r *http.Request) {
+	var req taskRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	recurrence, err := task.ParseRecurrence(req.Recurrence)
+	if err != nil {
+		s.writeError(w, r, errors.InvalidInput(err.Error()))
+		return
+	}
+
+	if req.ParentID != nil {
+		if _, err := s.ownedTask(r, *req.ParentID); err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+	}
+
+	created, err := s.tasks.Create(r.Context(), requestUserID(r), req.ParentID, req.Title, req.Description,
+		req.DueDate, req.Priority, req.Tags, recurrence)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	s.writeJSON(w, http.StatusCreated, created)
+}
+
+// getTask handles GET /tasks/{id}
+func (s *Server) getTask(w http.ResponseWriter, r *http.Request) {
+	id, err := taskIDParam(r)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	t, err := s.ownedTask(r, id)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	s.writeJSON(w, http.StatusOK, t)
+}
+
+// updateTask handles PUT /tasks/{id}
+func (s *Server) updateTask(w http.ResponseWriter, r *http.Request) {
+	id, err := taskIDParam(r)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	var req taskRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	if _, err := s.ownedTask(r, id); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	updated, err := s.tasks.Update(r.Context(), id, req.Title, req.Description, req.DueDate, req.Priority, req.Tags)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	s.writeJSON(w, http.StatusOK, updated)
+}
+
+// deleteTask handles DELETE /tasks/{id}
+func (s *Server) deleteTask(w http.ResponseWriter, r *http.Request) {
+	id, err := taskIDParam(r)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	if _, err := s.ownedTask(r, id); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	if err := s.tasks.Delete(r.Context(), id); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// completeTask handles POST /tasks/{id}/complete
+func (s *Server) completeTask(w http.ResponseWriter, r *http.Request) {
+	id, err := taskIDParam(r)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	if _, err := s.ownedTask(r, id); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	completed, err := s.tasks.Complete(r.Context(), id)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	s.writeJSON(w, http.StatusOK, completed)
+}
+
+// taskIDParam parses the {id} path parameter
+func taskIDParam(r *http.Request) (int64, error) {
+	raw := r.PathValue("id")
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, errors.InvalidInput(fmt.Sprintf("invalid task ID %q", raw))
+	}
+	return id, nil
+}
+
+// ownedTask loads a task of the request's user. Tasks of other users are reported
+// as not found so their IDs are not revealed.
+func (s *Server) ownedTask(r *http.Request, id int64) (task.Task, error) {
+	t, err := s.tasks.Show(r.Context(), id)
+	if err != nil {
+		return task.Task{}, err
+	}
+	if int64(t.UserID) != requestUserID(r) {
+		return task.Task{}, errors.NotFound(fmt.Sprintf("task %d not found", id))
+	}
+	return t, nil
+}
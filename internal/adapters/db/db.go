@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"time"
 
@@ -69,6 +70,44 @@ func Connect(ctx context.Context) error {
 	return nil
 }
 
+// SchemaVersion is the migration state golang-migrate records in schema_migrations
+type SchemaVersion struct {
+	Version int64
+	Dirty   bool // a migration failed halfway and needs manual repair
+}
+
+// ErrSchemaVersionUnknown is returned by Probe when the database is reachable but
+// its migration version cannot be read, e.g. because no migration has run yet
+var ErrSchemaVersionUnknown = errors.New("migration version unknown")
+
+// Probe connects to dsn with a single short-lived connection, independent of the
+// global pool, and reads the migration version. Errors wrapping
+// ErrSchemaVersionUnknown mean the connection itself worked.
+func Probe(ctx context.Context, dsn string) (SchemaVersion, error) {
+	poolCfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return SchemaVersion{}, errors.Wrap(err, "failed to parse database URL")
+	}
+	poolCfg.MaxConns = 1
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
+	if err != nil {
+		return SchemaVersion{}, errors.Wrap(err, "failed to connect to database")
+	}
+	defer pool.Close()
+
+	if err := pool.Ping(ctx); err != nil {
+		return SchemaVersion{}, errors.Wrap(err, "failed to ping database")
+	}
+
+	var v SchemaVersion
+	err = pool.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&v.Version, &v.Dirty)
+	if err != nil {
+		return SchemaVersion{}, fmt.Errorf("%w: %v", ErrSchemaVersionUnknown, err)
+	}
+	return v, nil
+}
+
 // Close closes the database connection pool.
 // It should be called when the application is shutting down to release resources.
 func Close() {
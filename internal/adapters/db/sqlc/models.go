@@ -9,19 +9,52 @@ import (
 )
 
 type Task struct {
-	ID           int32            `json:"id"`
-	UserID       int32            `json:"user_id"`
-	ParentID     pgtype.Int4      `json:"parent_id"`
-	Title        string           `json:"title"`
-	Description  pgtype.Text      `json:"description"`
-	CreatedAt    pgtype.Timestamp `json:"created_at"`
-	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
-	DueDate      pgtype.Timestamp `json:"due_date"`
-	IsCompleted  pgtype.Bool      `json:"is_completed"`
-	Status       pgtype.Text      `json:"status"`
-	Priority     pgtype.Text      `json:"priority"`
-	Tags         []string         `json:"tags"`
-	DisplayOrder pgtype.Int4      `json:"display_order"`
+	ID             int32            `json:"id"`
+	UserID         int32            `json:"user_id"`
+	ParentID       pgtype.Int4      `json:"parent_id"`
+	Title          string           `json:"title"`
+	Description    pgtype.Text      `json:"description"`
+	CreatedAt      pgtype.Timestamp `json:"created_at"`
+	UpdatedAt      pgtype.Timestamp `json:"updated_at"`
+	DueDate        pgtype.Timestamp `json:"due_date"`
+	IsCompleted    pgtype.Bool      `json:"is_completed"`
+	Status         pgtype.Text      `json:"status"`
+	Priority       pgtype.Text      `json:"priority"`
+	Tags           []string         `json:"tags"`
+	DisplayOrder   pgtype.Int4      `json:"display_order"`
+	Energy         pgtype.Text      `json:"energy"`
+	Points         int32            `json:"points"`
+	Recurrence     pgtype.Text      `json:"recurrence"`
+	ExternalID     pgtype.Text      `json:"external_id"`
+	ExternalSource pgtype.Text      `json:"external_source"`
+	ArchivedAt     pgtype.Timestamp `json:"archived_at"`
+}
+
+type TaskDayPin struct {
+	TaskID   int32            `json:"task_id"`
+	PinnedAt pgtype.Timestamp `json:"pinned_at"`
+}
+
+type TaskDependency struct {
+	TaskID      int32            `json:"task_id"`
+	DependsOnID int32            `json:"depends_on_id"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
+}
+
+type TaskMetric struct {
+	TaskID         int32            `json:"task_id"`
+	TotalCount     int32            `json:"total_count"`
+	CompletedCount int32            `json:"completed_count"`
+	Progress       float64          `json:"progress"`
+	ComputedAt     pgtype.Timestamp `json:"computed_at"`
+}
+
+type TaskNote struct {
+	ID        int32            `json:"id"`
+	TaskID    int32            `json:"task_id"`
+	UserID    int32            `json:"user_id"`
+	Body      string           `json:"body"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
 }
 
 type User struct {
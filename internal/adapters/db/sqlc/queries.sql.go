@@ -11,6 +11,86 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const addTaskDependency = `-- name: AddTaskDependency :exec
+INSERT INTO task_dependencies (
+   task_id, depends_on_id
+) VALUES (
+   $1, $2
+)
+ON CONFLICT (task_id, depends_on_id) DO NOTHING
+`
+
+type AddTaskDependencyParams struct {
+	TaskID      int32 `json:"task_id"`
+	DependsOnID int32 `json:"depends_on_id"`
+}
+
+func (q *Queries) AddTaskDependency(ctx context.Context, arg AddTaskDependencyParams) error {
+	_, err := q.db.Exec(ctx, addTaskDependency, arg.TaskID, arg.DependsOnID)
+	return err
+}
+
+const addTaskNote = `-- name: AddTaskNote :one
+INSERT INTO task_notes (
+   task_id, user_id, body
+)
+SELECT 
+   id, user_id, $2::text
+FROM tasks
+WHERE 
+   id = $1
+RETURNING 
+   id, task_id, user_id, body, created_at
+`
+
+type AddTaskNoteParams struct {
+	ID      int32  `json:"id"`
+	Column2 string `json:"column_2"`
+}
+
+// The note takes its user from the task, so it returns no row for a missing task
+func (q *Queries) AddTaskNote(ctx context.Context, arg AddTaskNoteParams) (TaskNote, error) {
+	row := q.db.QueryRow(ctx, addTaskNote, arg.ID, arg.Column2)
+	var i TaskNote
+	err := row.Scan(
+		&i.ID,
+		&i.TaskID,
+		&i.UserID,
+		&i.Body,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const archiveTask = `-- name: ArchiveTask :execrows
+WITH RECURSIVE subtree AS (
+    SELECT id, 1 AS depth
+    FROM tasks
+    WHERE id = $1 AND archived_at IS NULL
+
+    UNION ALL
+
+    SELECT t.id, s.depth + 1
+    FROM tasks t
+    INNER JOIN subtree s ON t.parent_id = s.id
+    WHERE s.depth < 100
+)
+UPDATE tasks
+SET 
+   archived_at = CURRENT_TIMESTAMP
+WHERE 
+   id IN (SELECT id FROM subtree) AND
+   archived_at IS NULL
+`
+
+func (q *Queries) ArchiveTask(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.Exec(ctx, archiveTask, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const bulkUpdateTaskStatus = `-- name: BulkUpdateTaskStatus :exec
 UPDATE tasks
 SET 
@@ -32,27 +112,47 @@ func (q *Queries) BulkUpdateTaskStatus(ctx context.Context, arg BulkUpdateTaskSt
 	return err
 }
 
+const countRootTasksByUserId = `-- name: CountRootTasksByUserId :one
+SELECT 
+   COUNT(*)
+FROM tasks
+WHERE 
+   user_id = $1 AND parent_id IS NULL AND archived_at IS NULL
+`
+
+func (q *Queries) CountRootTasksByUserId(ctx context.Context, userID int32) (int64, error) {
+	row := q.db.QueryRow(ctx, countRootTasksByUserId, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createTask = `-- name: CreateTask :one
 
 INSERT INTO tasks 
-   (user_id, parent_id, title, description, due_date, is_completed, status, priority, tags, display_order)
+   (user_id, parent_id, title, description, due_date, is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source)
 VALUES 
-   ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+   ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 RETURNING
-   id, user_id, parent_id, title, description, created_at, updated_at, due_date, is_completed, status, priority, tags, display_order
+   id, user_id, parent_id, title, description, created_at, updated_at, due_date, is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at
 `
 
 type CreateTaskParams struct {
-	UserID       int32            `json:"user_id"`
-	ParentID     pgtype.Int4      `json:"parent_id"`
-	Title        string           `json:"title"`
-	Description  pgtype.Text      `json:"description"`
-	DueDate      pgtype.Timestamp `json:"due_date"`
-	IsCompleted  pgtype.Bool      `json:"is_completed"`
-	Status       pgtype.Text      `json:"status"`
-	Priority     pgtype.Text      `json:"priority"`
-	Tags         []string         `json:"tags"`
-	DisplayOrder pgtype.Int4      `json:"display_order"`
+	UserID         int32            `json:"user_id"`
+	ParentID       pgtype.Int4      `json:"parent_id"`
+	Title          string           `json:"title"`
+	Description    pgtype.Text      `json:"description"`
+	DueDate        pgtype.Timestamp `json:"due_date"`
+	IsCompleted    pgtype.Bool      `json:"is_completed"`
+	Status         pgtype.Text      `json:"status"`
+	Priority       pgtype.Text      `json:"priority"`
+	Tags           []string         `json:"tags"`
+	DisplayOrder   pgtype.Int4      `json:"display_order"`
+	Energy         pgtype.Text      `json:"energy"`
+	Points         int32            `json:"points"`
+	Recurrence     pgtype.Text      `json:"recurrence"`
+	ExternalID     pgtype.Text      `json:"external_id"`
+	ExternalSource pgtype.Text      `json:"external_source"`
 }
 
 // Tasks ---------------------------------------------------------------
@@ -68,6 +168,11 @@ func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) (Task, e
 		arg.Priority,
 		arg.Tags,
 		arg.DisplayOrder,
+		arg.Energy,
+		arg.Points,
+		arg.Recurrence,
+		arg.ExternalID,
+		arg.ExternalSource,
 	)
 	var i Task
 	err := row.Scan(
@@ -84,6 +189,12 @@ func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) (Task, e
 		&i.Priority,
 		&i.Tags,
 		&i.DisplayOrder,
+		&i.Energy,
+		&i.Points,
+		&i.Recurrence,
+		&i.ExternalID,
+		&i.ExternalSource,
+		&i.ArchivedAt,
 	)
 	return i, err
 }
@@ -159,10 +270,21 @@ func (q *Queries) DeleteUser(ctx context.Context, id int32) (int64, error) {
 	return result.RowsAffected(), nil
 }
 
+const deleteUserTaskMetrics = `-- name: DeleteUserTaskMetrics :exec
+DELETE FROM task_metrics
+WHERE 
+   task_id IN (SELECT id FROM tasks WHERE user_id = $1)
+`
+
+func (q *Queries) DeleteUserTaskMetrics(ctx context.Context, userID int32) error {
+	_, err := q.db.Exec(ctx, deleteUserTaskMetrics, userID)
+	return err
+}
+
 const getAllTagsForUser = `-- name: GetAllTagsForUser :many
 SELECT DISTINCT unnest(tags) as tag
 FROM tasks
-WHERE user_id = $1
+WHERE user_id = $1 AND archived_at IS NULL
 ORDER BY tag
 `
 
@@ -189,10 +311,11 @@ func (q *Queries) GetAllTagsForUser(ctx context.Context, userID int32) ([]interf
 const getRecentlyCompletedTasks = `-- name: GetRecentlyCompletedTasks :many
 SELECT 
    id, user_id, parent_id, title, description, created_at, updated_at, due_date, 
-   is_completed, status, priority, tags, display_order
+   is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at
 FROM tasks
 WHERE 
    user_id = $1 AND
+   archived_at IS NULL AND
    is_completed = true
 ORDER BY
    updated_at DESC
@@ -227,6 +350,12 @@ func (q *Queries) GetRecentlyCompletedTasks(ctx context.Context, arg GetRecently
 			&i.Priority,
 			&i.Tags,
 			&i.DisplayOrder,
+			&i.Energy,
+			&i.Points,
+			&i.Recurrence,
+			&i.ExternalID,
+			&i.ExternalSource,
+			&i.ArchivedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -240,10 +369,10 @@ func (q *Queries) GetRecentlyCompletedTasks(ctx context.Context, arg GetRecently
 
 const getSubtasksByParentId = `-- name: GetSubtasksByParentId :many
 SELECT 
-   id, user_id, parent_id, title, description, created_at, updated_at, due_date, is_completed, status, priority, tags, display_order
+   id, user_id, parent_id, title, description, created_at, updated_at, due_date, is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at
 FROM tasks
 WHERE 
-   parent_id = $1
+   parent_id = $1 AND archived_at IS NULL
 ORDER BY
    display_order, created_at DESC
 `
@@ -271,6 +400,12 @@ func (q *Queries) GetSubtasksByParentId(ctx context.Context, parentID pgtype.Int
 			&i.Priority,
 			&i.Tags,
 			&i.DisplayOrder,
+			&i.Energy,
+			&i.Points,
+			&i.Recurrence,
+			&i.ExternalID,
+			&i.ExternalSource,
+			&i.ArchivedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -282,8 +417,50 @@ func (q *Queries) GetSubtasksByParentId(ctx context.Context, parentID pgtype.Int
 	return items, nil
 }
 
+const getTaskByExternalId = `-- name: GetTaskByExternalId :one
+SELECT 
+   id, user_id, parent_id, title, description, created_at, updated_at, due_date, 
+   is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at
+FROM tasks
+WHERE 
+   external_source = $1 AND
+   external_id = $2
+`
+
+type GetTaskByExternalIdParams struct {
+	ExternalSource pgtype.Text `json:"external_source"`
+	ExternalID     pgtype.Text `json:"external_id"`
+}
+
+func (q *Queries) GetTaskByExternalId(ctx context.Context, arg GetTaskByExternalIdParams) (Task, error) {
+	row := q.db.QueryRow(ctx, getTaskByExternalId, arg.ExternalSource, arg.ExternalID)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ParentID,
+		&i.Title,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DueDate,
+		&i.IsCompleted,
+		&i.Status,
+		&i.Priority,
+		&i.Tags,
+		&i.DisplayOrder,
+		&i.Energy,
+		&i.Points,
+		&i.Recurrence,
+		&i.ExternalID,
+		&i.ExternalSource,
+		&i.ArchivedAt,
+	)
+	return i, err
+}
+
 const getTaskById = `-- name: GetTaskById :one
-SELECT id, user_id, parent_id, title, description, created_at, updated_at, due_date, is_completed, status, priority, tags, display_order 
+SELECT id, user_id, parent_id, title, description, created_at, updated_at, due_date, is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at 
 FROM tasks 
 WHERE 
    id = $1
@@ -306,6 +483,12 @@ func (q *Queries) GetTaskById(ctx context.Context, id int32) (Task, error) {
 		&i.Priority,
 		&i.Tags,
 		&i.DisplayOrder,
+		&i.Energy,
+		&i.Points,
+		&i.Recurrence,
+		&i.ExternalID,
+		&i.ExternalSource,
+		&i.ArchivedAt,
 	)
 	return i, err
 }
@@ -318,6 +501,7 @@ SELECT
 FROM tasks
 WHERE
    user_id = $1 AND
+   archived_at IS NULL AND
    is_completed = false
 `
 
@@ -342,7 +526,8 @@ SELECT
    COUNT(*) AS total_count
 FROM tasks
 WHERE
-   user_id = $1
+   user_id = $1 AND
+   archived_at IS NULL
 `
 
 type GetTaskCountsByStatusRow struct {
@@ -436,21 +621,23 @@ func (q *Queries) GetUserByUsername(ctx context.Context, username string) (User,
 	return i, err
 }
 
-const listOverdueTasks = `-- name: ListOverdueTasks :many
+const listArchivedTasks = `-- name: ListArchivedTasks :many
 SELECT 
    id, user_id, parent_id, title, description, created_at, updated_at, due_date, 
-   is_completed, status, priority, tags, display_order
+   is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at
 FROM tasks
 WHERE 
    user_id = $1 AND
-   due_date < CURRENT_DATE AND
-   is_completed = false
+   archived_at IS NOT NULL AND
+   NOT EXISTS (
+      SELECT 1 FROM tasks p WHERE p.id = tasks.parent_id AND p.archived_at = tasks.archived_at
+   )
 ORDER BY
-   due_date, priority DESC
+   archived_at DESC, display_order
 `
 
-func (q *Queries) ListOverdueTasks(ctx context.Context, userID int32) ([]Task, error) {
-	rows, err := q.db.Query(ctx, listOverdueTasks, userID)
+func (q *Queries) ListArchivedTasks(ctx context.Context, userID int32) ([]Task, error) {
+	rows, err := q.db.Query(ctx, listArchivedTasks, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -472,6 +659,12 @@ func (q *Queries) ListOverdueTasks(ctx context.Context, userID int32) ([]Task, e
 			&i.Priority,
 			&i.Tags,
 			&i.DisplayOrder,
+			&i.Energy,
+			&i.Points,
+			&i.Recurrence,
+			&i.ExternalID,
+			&i.ExternalSource,
+			&i.ArchivedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -483,43 +676,67 @@ func (q *Queries) ListOverdueTasks(ctx context.Context, userID int32) ([]Task, e
 	return items, nil
 }
 
-const listRootTasksByUserId = `-- name: ListRootTasksByUserId :many
+const listBlockedTaskIds = `-- name: ListBlockedTaskIds :many
+SELECT DISTINCT 
+   d.task_id
+FROM task_dependencies d
+JOIN tasks t ON t.id = d.task_id
+JOIN tasks dep ON dep.id = d.depends_on_id
+WHERE 
+   t.user_id = $1
+   AND t.archived_at IS NULL
+   AND dep.archived_at IS NULL
+   AND dep.is_completed IS NOT TRUE
+ORDER BY
+   d.task_id
+`
+
+func (q *Queries) ListBlockedTaskIds(ctx context.Context, userID int32) ([]int32, error) {
+	rows, err := q.db.Query(ctx, listBlockedTaskIds, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var task_id int32
+		if err := rows.Scan(&task_id); err != nil {
+			return nil, err
+		}
+		items = append(items, task_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDayPinnedTaskIds = `-- name: ListDayPinnedTaskIds :many
 SELECT 
-   id, user_id, parent_id, title, description, created_at, updated_at, due_date, is_completed, status, priority, tags, display_order
-FROM tasks
+   p.task_id
+FROM task_day_pins p
+JOIN tasks t ON t.id = p.task_id
 WHERE 
-   user_id = $1 AND parent_id IS NULL
+   t.user_id = $1
+   AND t.archived_at IS NULL
 ORDER BY
-   display_order, created_at DESC
+   p.pinned_at DESC, p.task_id
 `
 
-func (q *Queries) ListRootTasksByUserId(ctx context.Context, userID int32) ([]Task, error) {
-	rows, err := q.db.Query(ctx, listRootTasksByUserId, userID)
+// Most recently pinned first, which is the order pinned tasks take within a day
+func (q *Queries) ListDayPinnedTaskIds(ctx context.Context, userID int32) ([]int32, error) {
+	rows, err := q.db.Query(ctx, listDayPinnedTaskIds, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Task
+	var items []int32
 	for rows.Next() {
-		var i Task
-		if err := rows.Scan(
-			&i.ID,
-			&i.UserID,
-			&i.ParentID,
-			&i.Title,
-			&i.Description,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-			&i.DueDate,
-			&i.IsCompleted,
-			&i.Status,
-			&i.Priority,
-			&i.Tags,
-			&i.DisplayOrder,
-		); err != nil {
+		var task_id int32
+		if err := rows.Scan(&task_id); err != nil {
 			return nil, err
 		}
-		items = append(items, i)
+		items = append(items, task_id)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
@@ -527,25 +744,22 @@ func (q *Queries) ListRootTasksByUserId(ctx context.Context, userID int32) ([]Ta
 	return items, nil
 }
 
-const listTasksByPriority = `-- name: ListTasksByPriority :many
+const listIncompleteDatedTasks = `-- name: ListIncompleteDatedTasks :many
 SELECT 
    id, user_id, parent_id, title, description, created_at, updated_at, due_date, 
-   is_completed, status, priority, tags, display_order
+   is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at
 FROM tasks
 WHERE 
    user_id = $1 AND
-   priority = $2
+   archived_at IS NULL AND
+   due_date IS NOT NULL AND
+   is_completed = false
 ORDER BY
-   display_order, created_at DESC
+   due_date, priority DESC
 `
 
-type ListTasksByPriorityParams struct {
-	UserID   int32       `json:"user_id"`
-	Priority pgtype.Text `json:"priority"`
-}
-
-func (q *Queries) ListTasksByPriority(ctx context.Context, arg ListTasksByPriorityParams) ([]Task, error) {
-	rows, err := q.db.Query(ctx, listTasksByPriority, arg.UserID, arg.Priority)
+func (q *Queries) ListIncompleteDatedTasks(ctx context.Context, userID int32) ([]Task, error) {
+	rows, err := q.db.Query(ctx, listIncompleteDatedTasks, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -567,6 +781,12 @@ func (q *Queries) ListTasksByPriority(ctx context.Context, arg ListTasksByPriori
 			&i.Priority,
 			&i.Tags,
 			&i.DisplayOrder,
+			&i.Energy,
+			&i.Points,
+			&i.Recurrence,
+			&i.ExternalID,
+			&i.ExternalSource,
+			&i.ArchivedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -578,25 +798,22 @@ func (q *Queries) ListTasksByPriority(ctx context.Context, arg ListTasksByPriori
 	return items, nil
 }
 
-const listTasksByStatus = `-- name: ListTasksByStatus :many
+const listOverdueTasks = `-- name: ListOverdueTasks :many
 SELECT 
    id, user_id, parent_id, title, description, created_at, updated_at, due_date, 
-   is_completed, status, priority, tags, display_order
+   is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at
 FROM tasks
 WHERE 
    user_id = $1 AND
-   status = $2
+   archived_at IS NULL AND
+   due_date < CURRENT_DATE AND
+   is_completed = false
 ORDER BY
-   priority DESC, display_order, created_at DESC
+   due_date, priority DESC
 `
 
-type ListTasksByStatusParams struct {
-	UserID int32       `json:"user_id"`
-	Status pgtype.Text `json:"status"`
-}
-
-func (q *Queries) ListTasksByStatus(ctx context.Context, arg ListTasksByStatusParams) ([]Task, error) {
-	rows, err := q.db.Query(ctx, listTasksByStatus, arg.UserID, arg.Status)
+func (q *Queries) ListOverdueTasks(ctx context.Context, userID int32) ([]Task, error) {
+	rows, err := q.db.Query(ctx, listOverdueTasks, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -618,6 +835,12 @@ func (q *Queries) ListTasksByStatus(ctx context.Context, arg ListTasksByStatusPa
 			&i.Priority,
 			&i.Tags,
 			&i.DisplayOrder,
+			&i.Energy,
+			&i.Points,
+			&i.Recurrence,
+			&i.ExternalID,
+			&i.ExternalSource,
+			&i.ArchivedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -629,22 +852,18 @@ func (q *Queries) ListTasksByStatus(ctx context.Context, arg ListTasksByStatusPa
 	return items, nil
 }
 
-const listTasksDueSoon = `-- name: ListTasksDueSoon :many
+const listRootTasksByUserId = `-- name: ListRootTasksByUserId :many
 SELECT 
-   id, user_id, parent_id, title, description, created_at, updated_at, due_date, 
-   is_completed, status, priority, tags, display_order
+   id, user_id, parent_id, title, description, created_at, updated_at, due_date, is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at
 FROM tasks
 WHERE 
-   user_id = $1 AND
-   due_date IS NOT NULL AND
-   due_date::date BETWEEN CURRENT_DATE AND (CURRENT_DATE + interval '7 days')::date AND
-   is_completed = false
+   user_id = $1 AND parent_id IS NULL AND archived_at IS NULL
 ORDER BY
-   due_date, priority DESC
+   display_order, created_at DESC
 `
 
-func (q *Queries) ListTasksDueSoon(ctx context.Context, userID int32) ([]Task, error) {
-	rows, err := q.db.Query(ctx, listTasksDueSoon, userID)
+func (q *Queries) ListRootTasksByUserId(ctx context.Context, userID int32) ([]Task, error) {
+	rows, err := q.db.Query(ctx, listRootTasksByUserId, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -666,6 +885,12 @@ func (q *Queries) ListTasksDueSoon(ctx context.Context, userID int32) ([]Task, e
 			&i.Priority,
 			&i.Tags,
 			&i.DisplayOrder,
+			&i.Energy,
+			&i.Points,
+			&i.Recurrence,
+			&i.ExternalID,
+			&i.ExternalSource,
+			&i.ArchivedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -677,21 +902,26 @@ func (q *Queries) ListTasksDueSoon(ctx context.Context, userID int32) ([]Task, e
 	return items, nil
 }
 
-const listTasksDueToday = `-- name: ListTasksDueToday :many
+const listRootTasksByUserIdPaged = `-- name: ListRootTasksByUserIdPaged :many
 SELECT 
-   id, user_id, parent_id, title, description, created_at, updated_at, due_date, 
-   is_completed, status, priority, tags, display_order
+   id, user_id, parent_id, title, description, created_at, updated_at, due_date, is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at
 FROM tasks
 WHERE 
-   user_id = $1 AND
-   due_date::date = CURRENT_DATE AND
-   is_completed = false
+   user_id = $1 AND parent_id IS NULL AND archived_at IS NULL
 ORDER BY
-   priority DESC, display_order
+   display_order, id
+LIMIT $2 OFFSET $3
 `
 
-func (q *Queries) ListTasksDueToday(ctx context.Context, userID int32) ([]Task, error) {
-	rows, err := q.db.Query(ctx, listTasksDueToday, userID)
+type ListRootTasksByUserIdPagedParams struct {
+	UserID int32 `json:"user_id"`
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+}
+
+// Pages are ordered by display_order then id so every root lands on exactly one page
+func (q *Queries) ListRootTasksByUserIdPaged(ctx context.Context, arg ListRootTasksByUserIdPagedParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, listRootTasksByUserIdPaged, arg.UserID, arg.Limit, arg.Offset)
 	if err != nil {
 		return nil, err
 	}
@@ -713,6 +943,12 @@ func (q *Queries) ListTasksDueToday(ctx context.Context, userID int32) ([]Task,
 			&i.Priority,
 			&i.Tags,
 			&i.DisplayOrder,
+			&i.Energy,
+			&i.Points,
+			&i.Recurrence,
+			&i.ExternalID,
+			&i.ExternalSource,
+			&i.ArchivedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -724,23 +960,57 @@ func (q *Queries) ListTasksDueToday(ctx context.Context, userID int32) ([]Task,
 	return items, nil
 }
 
-const listTasksWithSubtasksRecursive = `-- name: ListTasksWithSubtasksRecursive :many
+const listTaskDependencies = `-- name: ListTaskDependencies :many
+SELECT 
+   depends_on_id
+FROM task_dependencies
+WHERE 
+   task_id = $1
+ORDER BY
+   depends_on_id
+`
+
+func (q *Queries) ListTaskDependencies(ctx context.Context, taskID int32) ([]int32, error) {
+	rows, err := q.db.Query(ctx, listTaskDependencies, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var depends_on_id int32
+		if err := rows.Scan(&depends_on_id); err != nil {
+			return nil, err
+		}
+		items = append(items, depends_on_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTaskForestByUserId = `-- name: ListTaskForestByUserId :many
 WITH RECURSIVE task_tree AS (
     -- Base case
     SELECT 
         t.id, t.user_id, t.parent_id, t.title, t.description, t.created_at, t.updated_at, 
-        t.due_date, t.is_completed, t.status, t.priority, t.tags, t.display_order
+        t.due_date, t.is_completed, t.status, t.priority, t.tags, t.display_order, t.energy, t.points, t.recurrence, t.external_id, t.external_source, t.archived_at,
+        1 AS depth
     FROM tasks t
-    WHERE t.id = $1
+    WHERE t.user_id = $1 AND t.parent_id IS NULL AND t.archived_at IS NULL
     
     UNION ALL
     
     -- Recursive case
     SELECT 
         t.id, t.user_id, t.parent_id, t.title, t.description, t.created_at, t.updated_at, 
-        t.due_date, t.is_completed, t.status, t.priority, t.tags, t.display_order
+        t.due_date, t.is_completed, t.status, t.priority, t.tags, t.display_order, t.energy, t.points, t.recurrence, t.external_id, t.external_source, t.archived_at,
+        tt.depth + 1
     FROM tasks t
     INNER JOIN task_tree tt ON t.parent_id = tt.id
+    -- Depth cap so corrupted (cyclic) parent links cannot recurse forever
+    WHERE tt.depth < 100 AND t.archived_at IS NULL
 )
 SELECT 
     task_tree.id,
@@ -755,36 +1025,49 @@ SELECT
     task_tree.status,
     task_tree.priority,
     task_tree.tags,
-    task_tree.display_order
+    task_tree.display_order,
+    task_tree.energy,
+    task_tree.points,
+    task_tree.recurrence,
+    task_tree.external_id,
+    task_tree.external_source,
+    task_tree.archived_at
 FROM task_tree
 ORDER BY task_tree.display_order, task_tree.created_at DESC
 `
 
-type ListTasksWithSubtasksRecursiveRow struct {
-	ID           int32            `json:"id"`
-	UserID       int32            `json:"user_id"`
-	ParentID     pgtype.Int4      `json:"parent_id"`
-	Title        string           `json:"title"`
-	Description  pgtype.Text      `json:"description"`
-	CreatedAt    pgtype.Timestamp `json:"created_at"`
-	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
-	DueDate      pgtype.Timestamp `json:"due_date"`
-	IsCompleted  pgtype.Bool      `json:"is_completed"`
-	Status       pgtype.Text      `json:"status"`
-	Priority     pgtype.Text      `json:"priority"`
-	Tags         []string         `json:"tags"`
-	DisplayOrder pgtype.Int4      `json:"display_order"`
+type ListTaskForestByUserIdRow struct {
+	ID             int32            `json:"id"`
+	UserID         int32            `json:"user_id"`
+	ParentID       pgtype.Int4      `json:"parent_id"`
+	Title          string           `json:"title"`
+	Description    pgtype.Text      `json:"description"`
+	CreatedAt      pgtype.Timestamp `json:"created_at"`
+	UpdatedAt      pgtype.Timestamp `json:"updated_at"`
+	DueDate        pgtype.Timestamp `json:"due_date"`
+	IsCompleted    pgtype.Bool      `json:"is_completed"`
+	Status         pgtype.Text      `json:"status"`
+	Priority       pgtype.Text      `json:"priority"`
+	Tags           []string         `json:"tags"`
+	DisplayOrder   pgtype.Int4      `json:"display_order"`
+	Energy         pgtype.Text      `json:"energy"`
+	Points         int32            `json:"points"`
+	Recurrence     pgtype.Text      `json:"recurrence"`
+	ExternalID     pgtype.Text      `json:"external_id"`
+	ExternalSource pgtype.Text      `json:"external_source"`
+	ArchivedAt     pgtype.Timestamp `json:"archived_at"`
 }
 
-func (q *Queries) ListTasksWithSubtasksRecursive(ctx context.Context, id int32) ([]ListTasksWithSubtasksRecursiveRow, error) {
-	rows, err := q.db.Query(ctx, listTasksWithSubtasksRecursive, id)
+// Loads the trees of all of a user's root tasks in one query
+func (q *Queries) ListTaskForestByUserId(ctx context.Context, userID int32) ([]ListTaskForestByUserIdRow, error) {
+	rows, err := q.db.Query(ctx, listTaskForestByUserId, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListTasksWithSubtasksRecursiveRow
+	var items []ListTaskForestByUserIdRow
 	for rows.Next() {
-		var i ListTasksWithSubtasksRecursiveRow
+		var i ListTaskForestByUserIdRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.UserID,
@@ -799,6 +1082,12 @@ func (q *Queries) ListTasksWithSubtasksRecursive(ctx context.Context, id int32)
 			&i.Priority,
 			&i.Tags,
 			&i.DisplayOrder,
+			&i.Energy,
+			&i.Points,
+			&i.Recurrence,
+			&i.ExternalID,
+			&i.ExternalSource,
+			&i.ArchivedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -810,43 +1099,32 @@ func (q *Queries) ListTasksWithSubtasksRecursive(ctx context.Context, id int32)
 	return items, nil
 }
 
-const listUsers = `-- name: ListUsers :many
+const listTaskMetrics = `-- name: ListTaskMetrics :many
 SELECT 
-   id, username, email, created_at, updated_at, last_login, is_active
-FROM users
+   m.task_id, m.total_count, m.completed_count, m.progress, m.computed_at
+FROM task_metrics m
+JOIN tasks t ON t.id = m.task_id
 WHERE 
-   is_active = true
+   t.user_id = $1
 ORDER BY
-   username
+   m.task_id
 `
 
-type ListUsersRow struct {
-	ID        int32            `json:"id"`
-	Username  string           `json:"username"`
-	Email     string           `json:"email"`
-	CreatedAt pgtype.Timestamp `json:"created_at"`
-	UpdatedAt pgtype.Timestamp `json:"updated_at"`
-	LastLogin pgtype.Timestamp `json:"last_login"`
-	IsActive  pgtype.Bool      `json:"is_active"`
-}
-
-func (q *Queries) ListUsers(ctx context.Context) ([]ListUsersRow, error) {
-	rows, err := q.db.Query(ctx, listUsers)
+func (q *Queries) ListTaskMetrics(ctx context.Context, userID int32) ([]TaskMetric, error) {
+	rows, err := q.db.Query(ctx, listTaskMetrics, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListUsersRow
+	var items []TaskMetric
 	for rows.Next() {
-		var i ListUsersRow
+		var i TaskMetric
 		if err := rows.Scan(
-			&i.ID,
-			&i.Username,
-			&i.Email,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-			&i.LastLogin,
-			&i.IsActive,
+			&i.TaskID,
+			&i.TotalCount,
+			&i.CompletedCount,
+			&i.Progress,
+			&i.ComputedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -858,43 +1136,883 @@ func (q *Queries) ListUsers(ctx context.Context) ([]ListUsersRow, error) {
 	return items, nil
 }
 
-const reorderTask = `-- name: ReorderTask :exec
-UPDATE tasks
-SET 
-   display_order = $2
-WHERE 
-   id = $1
-`
-
-type ReorderTaskParams struct {
-	ID           int32       `json:"id"`
-	DisplayOrder pgtype.Int4 `json:"display_order"`
-}
-
-func (q *Queries) ReorderTask(ctx context.Context, arg ReorderTaskParams) error {
-	_, err := q.db.Exec(ctx, reorderTask, arg.ID, arg.DisplayOrder)
-	return err
-}
-
-const searchTasksByTag = `-- name: SearchTasksByTag :many
+const listTaskNotes = `-- name: ListTaskNotes :many
 SELECT 
-   id, user_id, parent_id, title, description, created_at, updated_at, due_date, 
-   is_completed, status, priority, tags, display_order
-FROM tasks
+   id, task_id, user_id, body, created_at
+FROM task_notes
 WHERE 
-   user_id = $1 AND
-   $2 = ANY(tags)
+   task_id = $1
 ORDER BY
-   created_at DESC
+   created_at DESC, id DESC
 `
 
-type SearchTasksByTagParams struct {
-	UserID int32    `json:"user_id"`
-	Tags   []string `json:"tags"`
-}
-
-func (q *Queries) SearchTasksByTag(ctx context.Context, arg SearchTasksByTagParams) ([]Task, error) {
-	rows, err := q.db.Query(ctx, searchTasksByTag, arg.UserID, arg.Tags)
+func (q *Queries) ListTaskNotes(ctx context.Context, taskID int32) ([]TaskNote, error) {
+	rows, err := q.db.Query(ctx, listTaskNotes, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TaskNote
+	for rows.Next() {
+		var i TaskNote
+		if err := rows.Scan(
+			&i.ID,
+			&i.TaskID,
+			&i.UserID,
+			&i.Body,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTaskTreesByRootIds = `-- name: ListTaskTreesByRootIds :many
+WITH RECURSIVE task_tree AS (
+    -- Base case
+    SELECT 
+        t.id, t.user_id, t.parent_id, t.title, t.description, t.created_at, t.updated_at, 
+        t.due_date, t.is_completed, t.status, t.priority, t.tags, t.display_order, t.energy, t.points, t.recurrence, t.external_id, t.external_source, t.archived_at,
+        1 AS depth
+    FROM tasks t
+    WHERE t.id = ANY($1::int[])
+    
+    UNION ALL
+    
+    -- Recursive case
+    SELECT 
+        t.id, t.user_id, t.parent_id, t.title, t.description, t.created_at, t.updated_at, 
+        t.due_date, t.is_completed, t.status, t.priority, t.tags, t.display_order, t.energy, t.points, t.recurrence, t.external_id, t.external_source, t.archived_at,
+        tt.depth + 1
+    FROM tasks t
+    INNER JOIN task_tree tt ON t.parent_id = tt.id
+    -- Depth cap so corrupted (cyclic) parent links cannot recurse forever
+    WHERE tt.depth < 100 AND t.archived_at IS NULL
+)
+SELECT 
+    task_tree.id,
+    task_tree.user_id,
+    task_tree.parent_id,
+    task_tree.title,
+    task_tree.description,
+    task_tree.created_at,
+    task_tree.updated_at,
+    task_tree.due_date,
+    task_tree.is_completed,
+    task_tree.status,
+    task_tree.priority,
+    task_tree.tags,
+    task_tree.display_order,
+    task_tree.energy,
+    task_tree.points,
+    task_tree.recurrence,
+    task_tree.external_id,
+    task_tree.external_source,
+    task_tree.archived_at
+FROM task_tree
+ORDER BY task_tree.display_order, task_tree.created_at DESC
+`
+
+type ListTaskTreesByRootIdsRow struct {
+	ID             int32            `json:"id"`
+	UserID         int32            `json:"user_id"`
+	ParentID       pgtype.Int4      `json:"parent_id"`
+	Title          string           `json:"title"`
+	Description    pgtype.Text      `json:"description"`
+	CreatedAt      pgtype.Timestamp `json:"created_at"`
+	UpdatedAt      pgtype.Timestamp `json:"updated_at"`
+	DueDate        pgtype.Timestamp `json:"due_date"`
+	IsCompleted    pgtype.Bool      `json:"is_completed"`
+	Status         pgtype.Text      `json:"status"`
+	Priority       pgtype.Text      `json:"priority"`
+	Tags           []string         `json:"tags"`
+	DisplayOrder   pgtype.Int4      `json:"display_order"`
+	Energy         pgtype.Text      `json:"energy"`
+	Points         int32            `json:"points"`
+	Recurrence     pgtype.Text      `json:"recurrence"`
+	ExternalID     pgtype.Text      `json:"external_id"`
+	ExternalSource pgtype.Text      `json:"external_source"`
+	ArchivedAt     pgtype.Timestamp `json:"archived_at"`
+}
+
+// Loads the trees of several roots in one query, e.g. a page of root tasks
+func (q *Queries) ListTaskTreesByRootIds(ctx context.Context, rootIds []int32) ([]ListTaskTreesByRootIdsRow, error) {
+	rows, err := q.db.Query(ctx, listTaskTreesByRootIds, rootIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTaskTreesByRootIdsRow
+	for rows.Next() {
+		var i ListTaskTreesByRootIdsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ParentID,
+			&i.Title,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DueDate,
+			&i.IsCompleted,
+			&i.Status,
+			&i.Priority,
+			&i.Tags,
+			&i.DisplayOrder,
+			&i.Energy,
+			&i.Points,
+			&i.Recurrence,
+			&i.ExternalID,
+			&i.ExternalSource,
+			&i.ArchivedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTasksByPriority = `-- name: ListTasksByPriority :many
+SELECT 
+   id, user_id, parent_id, title, description, created_at, updated_at, due_date, 
+   is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at
+FROM tasks
+WHERE 
+   user_id = $1 AND
+   archived_at IS NULL AND
+   priority = $2
+ORDER BY
+   display_order, created_at DESC
+`
+
+type ListTasksByPriorityParams struct {
+	UserID   int32       `json:"user_id"`
+	Priority pgtype.Text `json:"priority"`
+}
+
+func (q *Queries) ListTasksByPriority(ctx context.Context, arg ListTasksByPriorityParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, listTasksByPriority, arg.UserID, arg.Priority)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ParentID,
+			&i.Title,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DueDate,
+			&i.IsCompleted,
+			&i.Status,
+			&i.Priority,
+			&i.Tags,
+			&i.DisplayOrder,
+			&i.Energy,
+			&i.Points,
+			&i.Recurrence,
+			&i.ExternalID,
+			&i.ExternalSource,
+			&i.ArchivedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTasksByStatuses = `-- name: ListTasksByStatuses :many
+SELECT 
+   id, user_id, parent_id, title, description, created_at, updated_at, due_date, 
+   is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at
+FROM tasks
+WHERE 
+   user_id = $1 AND
+   archived_at IS NULL AND
+   status = ANY($2::text[])
+ORDER BY
+   priority DESC, display_order, created_at DESC
+`
+
+type ListTasksByStatusesParams struct {
+	UserID  int32    `json:"user_id"`
+	Column2 []string `json:"column_2"`
+}
+
+func (q *Queries) ListTasksByStatuses(ctx context.Context, arg ListTasksByStatusesParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, listTasksByStatuses, arg.UserID, arg.Column2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ParentID,
+			&i.Title,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DueDate,
+			&i.IsCompleted,
+			&i.Status,
+			&i.Priority,
+			&i.Tags,
+			&i.DisplayOrder,
+			&i.Energy,
+			&i.Points,
+			&i.Recurrence,
+			&i.ExternalID,
+			&i.ExternalSource,
+			&i.ArchivedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTasksDueSoon = `-- name: ListTasksDueSoon :many
+SELECT 
+   id, user_id, parent_id, title, description, created_at, updated_at, due_date, 
+   is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at
+FROM tasks
+WHERE 
+   user_id = $1 AND
+   archived_at IS NULL AND
+   due_date IS NOT NULL AND
+   due_date::date BETWEEN CURRENT_DATE AND (CURRENT_DATE + ($2::int * INTERVAL '1 day'))::date AND
+   is_completed = false
+ORDER BY
+   due_date, priority DESC
+`
+
+type ListTasksDueSoonParams struct {
+	UserID  int32 `json:"user_id"`
+	Column2 int32 `json:"column_2"`
+}
+
+func (q *Queries) ListTasksDueSoon(ctx context.Context, arg ListTasksDueSoonParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, listTasksDueSoon, arg.UserID, arg.Column2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ParentID,
+			&i.Title,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DueDate,
+			&i.IsCompleted,
+			&i.Status,
+			&i.Priority,
+			&i.Tags,
+			&i.DisplayOrder,
+			&i.Energy,
+			&i.Points,
+			&i.Recurrence,
+			&i.ExternalID,
+			&i.ExternalSource,
+			&i.ArchivedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTasksDueToday = `-- name: ListTasksDueToday :many
+SELECT 
+   id, user_id, parent_id, title, description, created_at, updated_at, due_date, 
+   is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at
+FROM tasks
+WHERE 
+   user_id = $1 AND
+   archived_at IS NULL AND
+   due_date::date = CURRENT_DATE AND
+   is_completed = false
+ORDER BY
+   priority DESC, display_order
+`
+
+func (q *Queries) ListTasksDueToday(ctx context.Context, userID int32) ([]Task, error) {
+	rows, err := q.db.Query(ctx, listTasksDueToday, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ParentID,
+			&i.Title,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DueDate,
+			&i.IsCompleted,
+			&i.Status,
+			&i.Priority,
+			&i.Tags,
+			&i.DisplayOrder,
+			&i.Energy,
+			&i.Points,
+			&i.Recurrence,
+			&i.ExternalID,
+			&i.ExternalSource,
+			&i.ArchivedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTasksFiltered = `-- name: ListTasksFiltered :many
+SELECT 
+   id, user_id, parent_id, title, description, created_at, updated_at, due_date, 
+   is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at
+FROM tasks
+WHERE 
+   user_id = $1 AND
+   archived_at IS NULL AND
+   ($2::text IS NULL OR status = $2) AND
+   ($3::text IS NULL OR priority = $3) AND
+   ($4::text IS NULL OR energy IS NULL OR energy = $4) AND
+   ($5::timestamp IS NULL OR due_date < $5) AND
+   ($6::timestamp IS NULL OR due_date >= $6) AND
+   ($7::text IS NULL OR EXISTS (
+      SELECT 1 FROM unnest(tags) AS t(name) WHERE lower(t.name) = lower($7)
+   )) AND
+   ($8::text IS NULL OR 
+      strpos(lower(title), lower($8)) > 0 OR
+      strpos(lower(coalesce(description, '')), lower($8)) > 0)
+ORDER BY
+   due_date ASC NULLS LAST, display_order, created_at DESC
+`
+
+type ListTasksFilteredParams struct {
+	UserID    int32            `json:"user_id"`
+	Status    pgtype.Text      `json:"status"`
+	Priority  pgtype.Text      `json:"priority"`
+	Energy    pgtype.Text      `json:"energy"`
+	DueBefore pgtype.Timestamp `json:"due_before"`
+	DueAfter  pgtype.Timestamp `json:"due_after"`
+	Tag       pgtype.Text      `json:"tag"`
+	Text      pgtype.Text      `json:"text"`
+}
+
+func (q *Queries) ListTasksFiltered(ctx context.Context, arg ListTasksFilteredParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, listTasksFiltered,
+		arg.UserID,
+		arg.Status,
+		arg.Priority,
+		arg.Energy,
+		arg.DueBefore,
+		arg.DueAfter,
+		arg.Tag,
+		arg.Text,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ParentID,
+			&i.Title,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DueDate,
+			&i.IsCompleted,
+			&i.Status,
+			&i.Priority,
+			&i.Tags,
+			&i.DisplayOrder,
+			&i.Energy,
+			&i.Points,
+			&i.Recurrence,
+			&i.ExternalID,
+			&i.ExternalSource,
+			&i.ArchivedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTasksWithSubtasksRecursive = `-- name: ListTasksWithSubtasksRecursive :many
+WITH RECURSIVE task_tree AS (
+    -- Base case
+    SELECT 
+        t.id, t.user_id, t.parent_id, t.title, t.description, t.created_at, t.updated_at, 
+        t.due_date, t.is_completed, t.status, t.priority, t.tags, t.display_order, t.energy, t.points, t.recurrence, t.external_id, t.external_source, t.archived_at,
+        1 AS depth
+    FROM tasks t
+    WHERE t.id = $1
+    
+    UNION ALL
+    
+    -- Recursive case
+    SELECT 
+        t.id, t.user_id, t.parent_id, t.title, t.description, t.created_at, t.updated_at, 
+        t.due_date, t.is_completed, t.status, t.priority, t.tags, t.display_order, t.energy, t.points, t.recurrence, t.external_id, t.external_source, t.archived_at,
+        tt.depth + 1
+    FROM tasks t
+    INNER JOIN task_tree tt ON t.parent_id = tt.id
+    -- Depth cap so corrupted (cyclic) parent links cannot recurse forever
+    WHERE tt.depth < 100 AND t.archived_at IS NULL
+)
+SELECT 
+    task_tree.id,
+    task_tree.user_id,
+    task_tree.parent_id,
+    task_tree.title,
+    task_tree.description,
+    task_tree.created_at,
+    task_tree.updated_at,
+    task_tree.due_date,
+    task_tree.is_completed,
+    task_tree.status,
+    task_tree.priority,
+    task_tree.tags,
+    task_tree.display_order,
+    task_tree.energy,
+    task_tree.points,
+    task_tree.recurrence,
+    task_tree.external_id,
+    task_tree.external_source,
+    task_tree.archived_at
+FROM task_tree
+ORDER BY task_tree.display_order, task_tree.created_at DESC
+`
+
+type ListTasksWithSubtasksRecursiveRow struct {
+	ID             int32            `json:"id"`
+	UserID         int32            `json:"user_id"`
+	ParentID       pgtype.Int4      `json:"parent_id"`
+	Title          string           `json:"title"`
+	Description    pgtype.Text      `json:"description"`
+	CreatedAt      pgtype.Timestamp `json:"created_at"`
+	UpdatedAt      pgtype.Timestamp `json:"updated_at"`
+	DueDate        pgtype.Timestamp `json:"due_date"`
+	IsCompleted    pgtype.Bool      `json:"is_completed"`
+	Status         pgtype.Text      `json:"status"`
+	Priority       pgtype.Text      `json:"priority"`
+	Tags           []string         `json:"tags"`
+	DisplayOrder   pgtype.Int4      `json:"display_order"`
+	Energy         pgtype.Text      `json:"energy"`
+	Points         int32            `json:"points"`
+	Recurrence     pgtype.Text      `json:"recurrence"`
+	ExternalID     pgtype.Text      `json:"external_id"`
+	ExternalSource pgtype.Text      `json:"external_source"`
+	ArchivedAt     pgtype.Timestamp `json:"archived_at"`
+}
+
+func (q *Queries) ListTasksWithSubtasksRecursive(ctx context.Context, id int32) ([]ListTasksWithSubtasksRecursiveRow, error) {
+	rows, err := q.db.Query(ctx, listTasksWithSubtasksRecursive, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTasksWithSubtasksRecursiveRow
+	for rows.Next() {
+		var i ListTasksWithSubtasksRecursiveRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ParentID,
+			&i.Title,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DueDate,
+			&i.IsCompleted,
+			&i.Status,
+			&i.Priority,
+			&i.Tags,
+			&i.DisplayOrder,
+			&i.Energy,
+			&i.Points,
+			&i.Recurrence,
+			&i.ExternalID,
+			&i.ExternalSource,
+			&i.ArchivedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsers = `-- name: ListUsers :many
+SELECT 
+   id, username, email, created_at, updated_at, last_login, is_active
+FROM users
+WHERE 
+   is_active = true
+ORDER BY
+   username
+`
+
+type ListUsersRow struct {
+	ID        int32            `json:"id"`
+	Username  string           `json:"username"`
+	Email     string           `json:"email"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
+	UpdatedAt pgtype.Timestamp `json:"updated_at"`
+	LastLogin pgtype.Timestamp `json:"last_login"`
+	IsActive  pgtype.Bool      `json:"is_active"`
+}
+
+func (q *Queries) ListUsers(ctx context.Context) ([]ListUsersRow, error) {
+	rows, err := q.db.Query(ctx, listUsers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUsersRow
+	for rows.Next() {
+		var i ListUsersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.Email,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.LastLogin,
+			&i.IsActive,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const normalizeDisplayOrder = `-- name: NormalizeDisplayOrder :execrows
+UPDATE tasks
+SET 
+   display_order = ordered.new_order
+FROM (
+   SELECT 
+      id,
+      (ROW_NUMBER() OVER (PARTITION BY parent_id ORDER BY display_order, created_at DESC, id) - 1)::int AS new_order
+   FROM tasks
+   WHERE 
+      user_id = $1
+) AS ordered
+WHERE 
+   tasks.id = ordered.id AND
+   tasks.display_order IS DISTINCT FROM ordered.new_order
+`
+
+func (q *Queries) NormalizeDisplayOrder(ctx context.Context, userID int32) (int64, error) {
+	result, err := q.db.Exec(ctx, normalizeDisplayOrder, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const pinTaskToDay = `-- name: PinTaskToDay :exec
+INSERT INTO task_day_pins (
+   task_id
+) VALUES (
+   $1
+)
+ON CONFLICT (task_id) DO UPDATE
+SET 
+   pinned_at = CURRENT_TIMESTAMP
+`
+
+// Pinning a task again moves it back to the top of its day
+func (q *Queries) PinTaskToDay(ctx context.Context, taskID int32) error {
+	_, err := q.db.Exec(ctx, pinTaskToDay, taskID)
+	return err
+}
+
+const postponeTasks = `-- name: PostponeTasks :execrows
+UPDATE tasks
+SET 
+   due_date = due_date + ($2::int * INTERVAL '1 day'),
+   updated_at = CURRENT_TIMESTAMP
+WHERE 
+   id = ANY($1::int[]) AND
+   is_completed = false AND
+   due_date IS NOT NULL
+`
+
+type PostponeTasksParams struct {
+	Column1 []int32 `json:"column_1"`
+	Column2 int32   `json:"column_2"`
+}
+
+func (q *Queries) PostponeTasks(ctx context.Context, arg PostponeTasksParams) (int64, error) {
+	result, err := q.db.Exec(ctx, postponeTasks, arg.Column1, arg.Column2)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const snoozeTasks = `-- name: SnoozeTasks :execrows
+UPDATE tasks
+SET 
+   due_date = $3,
+   updated_at = CURRENT_TIMESTAMP
+WHERE 
+   id = ANY($1::int[]) AND
+   user_id = $2 AND
+   is_completed = false
+`
+
+type SnoozeTasksParams struct {
+	Column1 []int32          `json:"column_1"`
+	UserID  int32            `json:"user_id"`
+	DueDate pgtype.Timestamp `json:"due_date"`
+}
+
+func (q *Queries) SnoozeTasks(ctx context.Context, arg SnoozeTasksParams) (int64, error) {
+	result, err := q.db.Exec(ctx, snoozeTasks, arg.Column1, arg.UserID, arg.DueDate)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const removeTaskDependency = `-- name: RemoveTaskDependency :execrows
+DELETE FROM task_dependencies
+WHERE 
+   task_id = $1 AND depends_on_id = $2
+`
+
+type RemoveTaskDependencyParams struct {
+	TaskID      int32 `json:"task_id"`
+	DependsOnID int32 `json:"depends_on_id"`
+}
+
+func (q *Queries) RemoveTaskDependency(ctx context.Context, arg RemoveTaskDependencyParams) (int64, error) {
+	result, err := q.db.Exec(ctx, removeTaskDependency, arg.TaskID, arg.DependsOnID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const reorderTask = `-- name: ReorderTask :exec
+UPDATE tasks
+SET 
+   display_order = $2
+WHERE 
+   id = $1
+`
+
+type ReorderTaskParams struct {
+	ID           int32       `json:"id"`
+	DisplayOrder pgtype.Int4 `json:"display_order"`
+}
+
+func (q *Queries) ReorderTask(ctx context.Context, arg ReorderTaskParams) error {
+	_, err := q.db.Exec(ctx, reorderTask, arg.ID, arg.DisplayOrder)
+	return err
+}
+
+const reparentTask = `-- name: ReparentTask :execrows
+UPDATE tasks
+SET 
+   parent_id = $2,
+   updated_at = CURRENT_TIMESTAMP
+WHERE 
+   id = $1
+`
+
+type ReparentTaskParams struct {
+	ID       int32       `json:"id"`
+	ParentID pgtype.Int4 `json:"parent_id"`
+}
+
+func (q *Queries) ReparentTask(ctx context.Context, arg ReparentTaskParams) (int64, error) {
+	result, err := q.db.Exec(ctx, reparentTask, arg.ID, arg.ParentID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const restoreTask = `-- name: RestoreTask :execrows
+WITH RECURSIVE subtree AS (
+    SELECT id, archived_at, 1 AS depth
+    FROM tasks
+    WHERE id = $1 AND archived_at IS NOT NULL
+
+    UNION ALL
+
+    SELECT t.id, s.archived_at, s.depth + 1
+    FROM tasks t
+    INNER JOIN subtree s ON t.parent_id = s.id
+    WHERE s.depth < 100
+)
+UPDATE tasks
+SET 
+   archived_at = NULL
+FROM subtree
+WHERE 
+   tasks.id = subtree.id AND
+   tasks.archived_at = subtree.archived_at
+`
+
+func (q *Queries) RestoreTask(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.Exec(ctx, restoreTask, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const searchTasksByTag = `-- name: SearchTasksByTag :many
+SELECT 
+   id, user_id, parent_id, title, description, created_at, updated_at, due_date, 
+   is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at
+FROM tasks
+WHERE 
+   user_id = $1 AND
+   archived_at IS NULL AND
+   EXISTS (
+      SELECT 1 FROM unnest(tags) AS t(name) WHERE lower(t.name) = lower($2::text)
+   )
+ORDER BY
+   created_at DESC
+`
+
+type SearchTasksByTagParams struct {
+	UserID int32  `json:"user_id"`
+	Tag    string `json:"tag"`
+}
+
+func (q *Queries) SearchTasksByTag(ctx context.Context, arg SearchTasksByTagParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, searchTasksByTag, arg.UserID, arg.Tag)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ParentID,
+			&i.Title,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DueDate,
+			&i.IsCompleted,
+			&i.Status,
+			&i.Priority,
+			&i.Tags,
+			&i.DisplayOrder,
+			&i.Energy,
+			&i.Points,
+			&i.Recurrence,
+			&i.ExternalID,
+			&i.ExternalSource,
+			&i.ArchivedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchTasksByText = `-- name: SearchTasksByText :many
+SELECT 
+   id, user_id, parent_id, title, description, created_at, updated_at, due_date, 
+   is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at
+FROM tasks
+WHERE 
+   user_id = $1 AND
+   archived_at IS NULL AND
+   (strpos(lower(title), lower($2::text)) > 0 OR
+      strpos(lower(coalesce(description, '')), lower($2::text)) > 0 OR
+      EXISTS (SELECT 1 FROM unnest(tags) AS t(name) WHERE strpos(lower(t.name), lower($2::text)) > 0))
+ORDER BY
+   created_at DESC
+`
+
+type SearchTasksByTextParams struct {
+	UserID int32  `json:"user_id"`
+	Query  string `json:"query"`
+}
+
+// Matches the query as plain text (no wildcards) in the title, description or any tag
+func (q *Queries) SearchTasksByText(ctx context.Context, arg SearchTasksByTextParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, searchTasksByText, arg.UserID, arg.Query)
 	if err != nil {
 		return nil, err
 	}
@@ -916,6 +2034,12 @@ func (q *Queries) SearchTasksByTag(ctx context.Context, arg SearchTasksByTagPara
 			&i.Priority,
 			&i.Tags,
 			&i.DisplayOrder,
+			&i.Energy,
+			&i.Points,
+			&i.Recurrence,
+			&i.ExternalID,
+			&i.ExternalSource,
+			&i.ArchivedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -931,10 +2055,11 @@ const searchTasksByTitle = `-- name: SearchTasksByTitle :many
 
 SELECT 
    id, user_id, parent_id, title, description, created_at, updated_at, due_date, 
-   is_completed, status, priority, tags, display_order
+   is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at
 FROM tasks
 WHERE 
    user_id = $1 AND
+   archived_at IS NULL AND
    title ILIKE $2
 ORDER BY
    created_at DESC
@@ -969,6 +2094,12 @@ func (q *Queries) SearchTasksByTitle(ctx context.Context, arg SearchTasksByTitle
 			&i.Priority,
 			&i.Tags,
 			&i.DisplayOrder,
+			&i.Energy,
+			&i.Points,
+			&i.Recurrence,
+			&i.ExternalID,
+			&i.ExternalSource,
+			&i.ArchivedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -980,6 +2111,67 @@ func (q *Queries) SearchTasksByTitle(ctx context.Context, arg SearchTasksByTitle
 	return items, nil
 }
 
+const setTaskExternalRef = `-- name: SetTaskExternalRef :execrows
+UPDATE tasks
+SET 
+   external_source = $2,
+   external_id = $3,
+   updated_at = CURRENT_TIMESTAMP
+WHERE 
+   id = $1
+`
+
+type SetTaskExternalRefParams struct {
+	ID             int32       `json:"id"`
+	ExternalSource pgtype.Text `json:"external_source"`
+	ExternalID     pgtype.Text `json:"external_id"`
+}
+
+func (q *Queries) SetTaskExternalRef(ctx context.Context, arg SetTaskExternalRefParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setTaskExternalRef, arg.ID, arg.ExternalSource, arg.ExternalID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setTaskTags = `-- name: SetTaskTags :execrows
+UPDATE tasks
+SET 
+   tags = $3,
+   updated_at = CURRENT_TIMESTAMP
+WHERE 
+   id = $1 AND user_id = $2
+`
+
+type SetTaskTagsParams struct {
+	ID     int32    `json:"id"`
+	UserID int32    `json:"user_id"`
+	Tags   []string `json:"tags"`
+}
+
+func (q *Queries) SetTaskTags(ctx context.Context, arg SetTaskTagsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setTaskTags, arg.ID, arg.UserID, arg.Tags)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const unpinTaskFromDay = `-- name: UnpinTaskFromDay :execrows
+DELETE FROM task_day_pins
+WHERE 
+   task_id = $1
+`
+
+func (q *Queries) UnpinTaskFromDay(ctx context.Context, taskID int32) (int64, error) {
+	result, err := q.db.Exec(ctx, unpinTaskFromDay, taskID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateTask = `-- name: UpdateTask :exec
 UPDATE tasks
 SET 
@@ -992,25 +2184,35 @@ SET
    status = $8, 
    priority = $9, 
    tags = $10, 
-   display_order = $11
+   display_order = $11,
+   energy = $12,
+   points = $13,
+   recurrence = $14,
+   external_id = $15,
+   external_source = $16
 WHERE 
    id = $1
 RETURNING
-   id, user_id, parent_id, title, description, created_at, updated_at, due_date, is_completed, status, priority, tags, display_order
+   id, user_id, parent_id, title, description, created_at, updated_at, due_date, is_completed, status, priority, tags, display_order, energy, points, recurrence, external_id, external_source, archived_at
 `
 
 type UpdateTaskParams struct {
-	ID           int32            `json:"id"`
-	UserID       int32            `json:"user_id"`
-	ParentID     pgtype.Int4      `json:"parent_id"`
-	Title        string           `json:"title"`
-	Description  pgtype.Text      `json:"description"`
-	DueDate      pgtype.Timestamp `json:"due_date"`
-	IsCompleted  pgtype.Bool      `json:"is_completed"`
-	Status       pgtype.Text      `json:"status"`
-	Priority     pgtype.Text      `json:"priority"`
-	Tags         []string         `json:"tags"`
-	DisplayOrder pgtype.Int4      `json:"display_order"`
+	ID             int32            `json:"id"`
+	UserID         int32            `json:"user_id"`
+	ParentID       pgtype.Int4      `json:"parent_id"`
+	Title          string           `json:"title"`
+	Description    pgtype.Text      `json:"description"`
+	DueDate        pgtype.Timestamp `json:"due_date"`
+	IsCompleted    pgtype.Bool      `json:"is_completed"`
+	Status         pgtype.Text      `json:"status"`
+	Priority       pgtype.Text      `json:"priority"`
+	Tags           []string         `json:"tags"`
+	DisplayOrder   pgtype.Int4      `json:"display_order"`
+	Energy         pgtype.Text      `json:"energy"`
+	Points         int32            `json:"points"`
+	Recurrence     pgtype.Text      `json:"recurrence"`
+	ExternalID     pgtype.Text      `json:"external_id"`
+	ExternalSource pgtype.Text      `json:"external_source"`
 }
 
 func (q *Queries) UpdateTask(ctx context.Context, arg UpdateTaskParams) error {
@@ -1026,6 +2228,11 @@ func (q *Queries) UpdateTask(ctx context.Context, arg UpdateTaskParams) error {
 		arg.Priority,
 		arg.Tags,
 		arg.DisplayOrder,
+		arg.Energy,
+		arg.Points,
+		arg.Recurrence,
+		arg.ExternalID,
+		arg.ExternalSource,
 	)
 	return err
 }
@@ -1071,3 +2278,34 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 	)
 	return i, err
 }
+
+const upsertTaskMetrics = `-- name: UpsertTaskMetrics :exec
+INSERT INTO task_metrics (
+   task_id, total_count, completed_count, progress, computed_at
+) VALUES (
+   $1, $2, $3, $4, CURRENT_TIMESTAMP
+)
+ON CONFLICT (task_id) DO UPDATE
+SET 
+   total_count = EXCLUDED.total_count,
+   completed_count = EXCLUDED.completed_count,
+   progress = EXCLUDED.progress,
+   computed_at = EXCLUDED.computed_at
+`
+
+type UpsertTaskMetricsParams struct {
+	TaskID         int32   `json:"task_id"`
+	TotalCount     int32   `json:"total_count"`
+	CompletedCount int32   `json:"completed_count"`
+	Progress       float64 `json:"progress"`
+}
+
+func (q *Queries) UpsertTaskMetrics(ctx context.Context, arg UpsertTaskMetricsParams) error {
+	_, err := q.db.Exec(ctx, upsertTaskMetrics,
+		arg.TaskID,
+		arg.TotalCount,
+		arg.CompletedCount,
+		arg.Progress,
+	)
+	return err
+}
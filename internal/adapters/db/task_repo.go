@@ -2,10 +2,12 @@ package db
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	sqlc "github.com/newbpydev/tusk/internal/adapters/db/sqlc"
@@ -21,8 +23,26 @@ var _ output.TaskRepository = (*SQLTaskRepository)(nil)
 
 // SQLTaskRepository implements the output.TaskRepository interface using SQLC and PostgreSQL
 type SQLTaskRepository struct {
-	q   *sqlc.Queries
-	log *zap.Logger
+	q    *sqlc.Queries
+	pool *pgxpool.Pool // nil when the repository was built from bare queries
+	log  *zap.Logger
+
+	// Whether q is bound to the transaction of a surrounding WithTx
+	inTx bool
+
+	// Whether parent progress is weighted by the points of subtasks
+	weightByPoints bool
+}
+
+// TaskRepositoryOption configures an SQLTaskRepository
+type TaskRepositoryOption func(*SQLTaskRepository)
+
+// WithPointsWeightedProgress weights a parent's progress by the points of its
+// subtasks instead of counting every subtask equally
+func WithPointsWeightedProgress(enabled bool) TaskRepositoryOption {
+	return func(r *SQLTaskRepository) {
+		r.weightByPoints = enabled
+	}
 }
 
 // NewTaskRepository creates a new task repository
@@ -34,11 +54,58 @@ func NewTaskRepository(queries *sqlc.Queries) output.TaskRepository {
 }
 
 // NewSQLTaskRepository creates a new SQLTaskRepository with the provided connection pool
-func NewSQLTaskRepository(pool *pgxpool.Pool) *SQLTaskRepository {
-	return &SQLTaskRepository{
-		q:   sqlc.New(pool),
-		log: logging.DBLogger.Named("task_repo"),
+func NewSQLTaskRepository(pool *pgxpool.Pool, opts ...TaskRepositoryOption) *SQLTaskRepository {
+	r := &SQLTaskRepository{
+		q:    sqlc.New(pool),
+		pool: pool,
+		log:  logging.DBLogger.Named("task_repo"),
+	}
+	for _, opt := range opts {
+		opt(r)
 	}
+	return r
+}
+
+// WithTx implements output.TaskRepository.WithTx. Outside of a transaction the
+// methods keep running each query on the pool.
+func (r *SQLTaskRepository) WithTx(ctx context.Context, fn func(repo output.TaskRepository) error) error {
+	if r.pool == nil || r.inTx {
+		return fn(r)
+	}
+
+	return r.withTx(ctx, func(q *sqlc.Queries) error {
+		txRepo := *r
+		txRepo.q = q
+		txRepo.inTx = true
+		return fn(&txRepo)
+	})
+}
+
+// withTx runs fn with queries bound to a new transaction, committing on success and
+// rolling back on error. Without a pool, or when the repository already runs in a
+// transaction, it runs fn on the current queries.
+func (r *SQLTaskRepository) withTx(ctx context.Context, fn func(q *sqlc.Queries) error) error {
+	if r.pool == nil || r.inTx {
+		return fn(r.q)
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return errors.InternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+	defer func() {
+		// Rollback is a no-op once the transaction has been committed
+		_ = tx.Rollback(ctx)
+	}()
+
+	if err := fn(r.q.WithTx(tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return errors.InternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+	return nil
 }
 
 // Create implements output.TaskRepository.Create
@@ -52,7 +119,90 @@ func (r *SQLTaskRepository) Create(ctx context.Context, t task.Task) (task.Task,
 		zap.Int("tag_count", len(t.Tags)))
 
 	// Convert domain model to db params
-	params := sqlc.CreateTaskParams{
+	params := createTaskParams(t)
+
+	// Execute query
+	startTime := time.Now()
+	row, err := r.q.CreateTask(ctx, params)
+	queryDuration := time.Since(startTime)
+
+	if err != nil {
+		r.log.Error("Failed to create task in database",
+			zap.Int32("user_id", t.UserID),
+			zap.Duration("duration_ms", queryDuration),
+			zap.Error(err))
+		return task.Task{}, errors.InternalError(fmt.Sprintf("failed to create task: %v", err))
+	}
+
+	r.log.Info("Task created successfully in database",
+		zap.Int32("task_id", row.ID),
+		zap.Int32("user_id", t.UserID),
+		zap.Duration("duration_ms", queryDuration))
+
+	// Convert result back to domain model
+	result := r.mapDBTaskToDomain(row)
+	return result, nil
+}
+
+// CreateTree implements output.TaskRepository.CreateTree
+func (r *SQLTaskRepository) CreateTree(ctx context.Context, root task.Task) (task.Task, error) {
+	r.log.Debug("Creating task tree in database",
+		zap.Int32("user_id", root.UserID),
+		zap.Bool("has_parent", root.ParentID != nil),
+		zap.Int("subtask_count", len(root.SubTasks)))
+
+	var created task.Task
+	count := 0
+	startTime := time.Now()
+	err := r.withTx(ctx, func(q *sqlc.Queries) error {
+		var err error
+		created, err = r.createTaskTree(ctx, q, root, &count)
+		return err
+	})
+	queryDuration := time.Since(startTime)
+
+	if err != nil {
+		r.log.Error("Failed to create task tree in database",
+			zap.Int32("user_id", root.UserID),
+			zap.Duration("duration_ms", queryDuration),
+			zap.Error(err))
+		return task.Task{}, errors.InternalError(fmt.Sprintf("failed to create task tree: %v", err))
+	}
+
+	r.log.Info("Task tree created successfully in database",
+		zap.Int32("task_id", created.ID),
+		zap.Int32("user_id", root.UserID),
+		zap.Int("task_count", count),
+		zap.Duration("duration_ms", queryDuration))
+
+	return created, nil
+}
+
+// createTaskTree inserts t and then its subtasks under it, counting the inserted tasks
+func (r *SQLTaskRepository) createTaskTree(ctx context.Context, q *sqlc.Queries, t task.Task, count *int) (task.Task, error) {
+	row, err := q.CreateTask(ctx, createTaskParams(t))
+	if err != nil {
+		return task.Task{}, err
+	}
+	*count++
+
+	created := r.mapDBTaskToDomain(row)
+	parentID := created.ID
+	for _, sub := range t.SubTasks {
+		sub.ParentID = &parentID
+		sub.UserID = created.UserID
+		child, err := r.createTaskTree(ctx, q, sub, count)
+		if err != nil {
+			return task.Task{}, err
+		}
+		created.SubTasks = append(created.SubTasks, child)
+	}
+	return created, nil
+}
+
+// createTaskParams converts a domain task into the parameters of an insert
+func createTaskParams(t task.Task) sqlc.CreateTaskParams {
+	return sqlc.CreateTaskParams{
 		UserID:   t.UserID,
 		ParentID: intPtrToNullInt4(t.ParentID),
 		Title:    t.Title,
@@ -60,7 +210,7 @@ func (r *SQLTaskRepository) Create(ctx context.Context, t task.Task) (task.Task,
 			String: stringPtrToString(t.Description),
 			Valid:  t.Description != nil,
 		},
-		DueDate: timePtrToNullTimestamp(t.DueDate),
+		DueDate: dueDateToTimestamp(t.DueDate),
 		IsCompleted: pgtype.Bool{
 			Bool:  t.IsCompleted,
 			Valid: true,
@@ -78,29 +228,15 @@ func (r *SQLTaskRepository) Create(ctx context.Context, t task.Task) (task.Task,
 			Int32: int32(t.DisplayOrder),
 			Valid: true,
 		},
+		Energy: pgtype.Text{
+			String: string(t.Energy),
+			Valid:  t.Energy != "",
+		},
+		Points:         int32(t.Points),
+		Recurrence:     recurrenceToNullText(t.Recurrence),
+		ExternalID:     stringPtrToNullText(t.ExternalID),
+		ExternalSource: stringPtrToNullText(t.ExternalSource),
 	}
-
-	// Execute query
-	startTime := time.Now()
-	row, err := r.q.CreateTask(ctx, params)
-	queryDuration := time.Since(startTime)
-
-	if err != nil {
-		r.log.Error("Failed to create task in database",
-			zap.Int32("user_id", t.UserID),
-			zap.Duration("duration_ms", queryDuration),
-			zap.Error(err))
-		return task.Task{}, errors.InternalError(fmt.Sprintf("failed to create task: %v", err))
-	}
-
-	r.log.Info("Task created successfully in database",
-		zap.Int32("task_id", row.ID),
-		zap.Int32("user_id", t.UserID),
-		zap.Duration("duration_ms", queryDuration))
-
-	// Convert result back to domain model
-	result := mapDBTaskToDomain(row)
-	return result, nil
 }
 
 // Update implements output.TaskRepository.Update
@@ -120,7 +256,7 @@ func (r *SQLTaskRepository) Update(ctx context.Context, t task.Task) error {
 			String: stringPtrToString(t.Description),
 			Valid:  t.Description != nil,
 		},
-		DueDate: timePtrToNullTimestamp(t.DueDate),
+		DueDate: dueDateToTimestamp(t.DueDate),
 		IsCompleted: pgtype.Bool{
 			Bool:  t.IsCompleted,
 			Valid: true,
@@ -138,6 +274,14 @@ func (r *SQLTaskRepository) Update(ctx context.Context, t task.Task) error {
 			Int32: int32(t.DisplayOrder),
 			Valid: true,
 		},
+		Energy: pgtype.Text{
+			String: string(t.Energy),
+			Valid:  t.Energy != "",
+		},
+		Points:         int32(t.Points),
+		Recurrence:     recurrenceToNullText(t.Recurrence),
+		ExternalID:     stringPtrToNullText(t.ExternalID),
+		ExternalSource: stringPtrToNullText(t.ExternalSource),
 	}
 
 	startTime := time.Now()
@@ -186,141 +330,790 @@ func (r *SQLTaskRepository) Delete(ctx context.Context, id int64) error {
 			zap.Int64("task_id", id),
 			zap.Duration("duration_ms", queryDuration),
 			zap.Error(err))
-		return errors.InternalError(fmt.Sprintf("failed to delete task: %v", err))
+		return errors.InternalError(fmt.Sprintf("failed to delete task: %v", err))
+	}
+
+	r.log.Info("Task deleted successfully",
+		zap.Int64("task_id", id),
+		zap.Duration("duration_ms", queryDuration))
+
+	return nil
+}
+
+// Archive implements output.TaskRepository.Archive
+func (r *SQLTaskRepository) Archive(ctx context.Context, id int64) error {
+	startTime := time.Now()
+	affected, err := r.q.ArchiveTask(ctx, int32(id))
+	queryDuration := time.Since(startTime)
+
+	if err != nil {
+		r.log.Error("Failed to archive task",
+			zap.Int64("task_id", id),
+			zap.Duration("duration_ms", queryDuration),
+			zap.Error(err))
+		return errors.InternalError(fmt.Sprintf("failed to archive task: %v", err))
+	}
+	if affected == 0 {
+		return errors.NotFound(fmt.Sprintf("task %d not found or already archived", id))
+	}
+
+	r.log.Info("Task archived successfully",
+		zap.Int64("task_id", id),
+		zap.Int64("archived_count", affected),
+		zap.Duration("duration_ms", queryDuration))
+
+	return nil
+}
+
+// Restore implements output.TaskRepository.Restore
+func (r *SQLTaskRepository) Restore(ctx context.Context, id int64) error {
+	startTime := time.Now()
+	affected, err := r.q.RestoreTask(ctx, int32(id))
+	queryDuration := time.Since(startTime)
+
+	if err != nil {
+		r.log.Error("Failed to restore task",
+			zap.Int64("task_id", id),
+			zap.Duration("duration_ms", queryDuration),
+			zap.Error(err))
+		return errors.InternalError(fmt.Sprintf("failed to restore task: %v", err))
+	}
+	if affected == 0 {
+		return errors.NotFound(fmt.Sprintf("archived task %d not found", id))
+	}
+
+	r.log.Info("Task restored successfully",
+		zap.Int64("task_id", id),
+		zap.Int64("restored_count", affected),
+		zap.Duration("duration_ms", queryDuration))
+
+	return nil
+}
+
+// GetByID implements output.TaskRepository.GetByID
+func (r *SQLTaskRepository) GetByID(ctx context.Context, id int64) (task.Task, error) {
+	r.log.Debug("Fetching task by ID",
+		zap.Int64("task_id", id))
+
+	startTime := time.Now()
+	row, err := r.q.GetTaskById(ctx, int32(id))
+	queryDuration := time.Since(startTime)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			r.log.Warn("Task not found",
+				zap.Int64("task_id", id),
+				zap.Duration("duration_ms", queryDuration))
+			return task.Task{}, errors.NotFound(fmt.Sprintf("task %d not found", id))
+		}
+		r.log.Error("Failed to get task by ID",
+			zap.Int64("task_id", id),
+			zap.Duration("duration_ms", queryDuration),
+			zap.Error(err))
+		return task.Task{}, errors.InternalError(fmt.Sprintf("failed to get task: %v", err))
+	}
+
+	r.log.Debug("Task fetched successfully",
+		zap.Int64("task_id", id),
+		zap.Int32("user_id", row.UserID),
+		zap.Duration("duration_ms", queryDuration))
+
+	t := r.mapDBTaskToDomain(row)
+	dependencies, err := r.q.ListTaskDependencies(ctx, row.ID)
+	if err != nil {
+		r.log.Error("Failed to list task dependencies",
+			zap.Int64("task_id", id),
+			zap.Error(err))
+		return task.Task{}, errors.InternalError(fmt.Sprintf("failed to list task dependencies: %v", err))
+	}
+	t.Dependencies = dependencies
+
+	return t, nil
+}
+
+// ListRootTasks implements output.TaskRepository.ListRootTasks
+func (r *SQLTaskRepository) ListRootTasks(ctx context.Context, userID int64) ([]task.Task, error) {
+	rows, err := r.q.ListRootTasksByUserId(ctx, int32(userID))
+	if err != nil {
+		return nil, errors.InternalError(fmt.Sprintf("failed to list root tasks: %v", err))
+	}
+
+	tasks := make([]task.Task, len(rows))
+	for i, row := range rows {
+		tasks[i] = r.mapDBTaskToDomain(row)
+	}
+	return tasks, nil
+}
+
+// ListRootTasksPaged implements output.TaskRepository.ListRootTasksPaged
+func (r *SQLTaskRepository) ListRootTasksPaged(ctx context.Context, userID int64, limit, offset int) ([]task.Task, error) {
+	startTime := time.Now()
+	rows, err := r.q.ListRootTasksByUserIdPaged(ctx, sqlc.ListRootTasksByUserIdPagedParams{
+		UserID: int32(userID),
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	})
+	queryDuration := time.Since(startTime)
+
+	if err != nil {
+		r.log.Error("Failed to list page of root tasks",
+			zap.Int64("user_id", userID),
+			zap.Int("limit", limit),
+			zap.Int("offset", offset),
+			zap.Duration("duration_ms", queryDuration),
+			zap.Error(err))
+		return nil, errors.InternalError(fmt.Sprintf("failed to list root tasks: %v", err))
+	}
+
+	tasks := make([]task.Task, len(rows))
+	for i, row := range rows {
+		tasks[i] = r.mapDBTaskToDomain(row)
+	}
+	return tasks, nil
+}
+
+// CountRootTasks implements output.TaskRepository.CountRootTasks
+func (r *SQLTaskRepository) CountRootTasks(ctx context.Context, userID int64) (int, error) {
+	count, err := r.q.CountRootTasksByUserId(ctx, int32(userID))
+	if err != nil {
+		return 0, errors.InternalError(fmt.Sprintf("failed to count root tasks: %v", err))
+	}
+	return int(count), nil
+}
+
+// ListArchived implements output.TaskRepository.ListArchived
+func (r *SQLTaskRepository) ListArchived(ctx context.Context, userID int64) ([]task.Task, error) {
+	rows, err := r.q.ListArchivedTasks(ctx, int32(userID))
+	if err != nil {
+		return nil, errors.InternalError(fmt.Sprintf("failed to list archived tasks: %v", err))
+	}
+
+	tasks := make([]task.Task, len(rows))
+	for i, row := range rows {
+		tasks[i] = r.mapDBTaskToDomain(row)
+	}
+	return tasks, nil
+}
+
+// ListSubTasks implements output.TaskRepository.ListSubTasks
+func (r *SQLTaskRepository) ListSubTasks(ctx context.Context, parentID int64) ([]task.Task, error) {
+	pid := pgtype.Int4{
+		Int32: int32(parentID),
+		Valid: true,
+	}
+	rows, err := r.q.GetSubtasksByParentId(ctx, pid)
+	if err != nil {
+		return nil, errors.InternalError(fmt.Sprintf("failed to list subtasks: %v", err))
+	}
+
+	tasks := make([]task.Task, len(rows))
+	for i, row := range rows {
+		tasks[i] = r.mapDBTaskToDomain(row)
+	}
+	return tasks, nil
+}
+
+// GetTaskTree implements output.TaskRepository.GetTaskTree
+func (r *SQLTaskRepository) GetTaskTree(ctx context.Context, rootID int64) (task.Task, error) {
+	r.log.Debug("Fetching task tree",
+		zap.Int64("root_id", rootID))
+
+	startTime := time.Now()
+	rows, err := r.q.ListTasksWithSubtasksRecursive(ctx, int32(rootID))
+	queryDuration := time.Since(startTime)
+
+	if err != nil {
+		r.log.Error("Failed to get task tree",
+			zap.Int64("root_id", rootID),
+			zap.Duration("duration_ms", queryDuration),
+			zap.Error(err))
+		return task.Task{}, errors.InternalError(fmt.Sprintf("failed to get task tree: %v", err))
+	}
+
+	if len(rows) == 0 {
+		r.log.Warn("Task tree not found",
+			zap.Int64("root_id", rootID),
+			zap.Duration("duration_ms", queryDuration))
+		return task.Task{}, errors.NotFound(fmt.Sprintf("task %d not found", rootID))
+	}
+
+	// Map rows to domain tasks
+	domainTasks := make([]task.Task, len(rows))
+	for i, row := range rows {
+		domainTasks[i] = r.mapRecursiveRowToDomain(row)
+	}
+
+	// Build tree
+	tree := buildTaskTree(domainTasks, int32(rootID))
+
+	// Compute metrics
+	computeTaskMetrics(&tree, time.Now(), 1, r.weightByPoints)
+
+	r.log.Debug("Task tree fetched successfully",
+		zap.Int64("root_id", rootID),
+		zap.Int("node_count", len(rows)),
+		zap.Duration("duration_ms", queryDuration))
+
+	return tree, nil
+}
+
+// GetTaskTrees implements output.TaskRepository.GetTaskTrees
+func (r *SQLTaskRepository) GetTaskTrees(ctx context.Context, rootIDs []int64) ([]task.Task, error) {
+	if len(rootIDs) == 0 {
+		return []task.Task{}, nil
+	}
+
+	ids := make([]int32, len(rootIDs))
+	for i, id := range rootIDs {
+		ids[i] = int32(id)
+	}
+
+	startTime := time.Now()
+	rows, err := r.q.ListTaskTreesByRootIds(ctx, ids)
+	queryDuration := time.Since(startTime)
+
+	if err != nil {
+		r.log.Error("Failed to get task trees",
+			zap.Int("root_count", len(rootIDs)),
+			zap.Duration("duration_ms", queryDuration),
+			zap.Error(err))
+		return nil, errors.InternalError(fmt.Sprintf("failed to get task trees: %v", err))
+	}
+
+	// The rows have the same columns as those of a single tree
+	domainTasks := make([]task.Task, len(rows))
+	for i, row := range rows {
+		domainTasks[i] = r.mapRecursiveRowToDomain(sqlc.ListTasksWithSubtasksRecursiveRow(row))
+	}
+
+	trees := buildTaskForest(domainTasks, ids)
+	now := time.Now()
+	for i := range trees {
+		computeTaskMetrics(&trees[i], now, 1, r.weightByPoints)
+	}
+
+	r.log.Debug("Task trees fetched successfully",
+		zap.Int("root_count", len(trees)),
+		zap.Int("node_count", len(rows)),
+		zap.Duration("duration_ms", queryDuration))
+
+	return trees, nil
+}
+
+// GetTaskForest implements output.TaskRepository.GetTaskForest
+func (r *SQLTaskRepository) GetTaskForest(ctx context.Context, userID int64) ([]task.Task, error) {
+	r.log.Debug("Fetching task forest",
+		zap.Int64("user_id", userID))
+
+	startTime := time.Now()
+	rows, err := r.q.ListTaskForestByUserId(ctx, int32(userID))
+	queryDuration := time.Since(startTime)
+
+	if err != nil {
+		r.log.Error("Failed to get task forest",
+			zap.Int64("user_id", userID),
+			zap.Duration("duration_ms", queryDuration),
+			zap.Error(err))
+		return nil, errors.InternalError(fmt.Sprintf("failed to get task forest: %v", err))
+	}
+
+	// Rows come in display order, so the roots among them are in the same order
+	// as ListRootTasks returns them
+	domainTasks := make([]task.Task, len(rows))
+	var rootIDs []int32
+	for i, row := range rows {
+		domainTasks[i] = r.mapRecursiveRowToDomain(sqlc.ListTasksWithSubtasksRecursiveRow(row))
+		if domainTasks[i].ParentID == nil {
+			rootIDs = append(rootIDs, domainTasks[i].ID)
+		}
+	}
+
+	trees := buildTaskForest(domainTasks, rootIDs)
+	now := time.Now()
+	for i := range trees {
+		computeTaskMetrics(&trees[i], now, 1, r.weightByPoints)
+	}
+
+	r.log.Debug("Task forest fetched successfully",
+		zap.Int64("user_id", userID),
+		zap.Int("root_count", len(trees)),
+		zap.Int("node_count", len(rows)),
+		zap.Duration("duration_ms", queryDuration))
+
+	return trees, nil
+}
+
+// ReorderTask implements output.TaskRepository.ReorderTask
+func (r *SQLTaskRepository) ReorderTask(ctx context.Context, taskID int64, newOrder int) error {
+	params := sqlc.ReorderTaskParams{
+		ID: int32(taskID),
+		DisplayOrder: pgtype.Int4{
+			Int32: int32(newOrder),
+			Valid: true,
+		},
+	}
+
+	err := r.q.ReorderTask(ctx, params)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return errors.NotFound(fmt.Sprintf("task %d not found", taskID))
+		}
+		return errors.InternalError(fmt.Sprintf("failed to reorder task: %v", err))
+	}
+	return nil
+}
+
+// SwapTaskOrder implements output.TaskRepository.SwapTaskOrder
+func (r *SQLTaskRepository) SwapTaskOrder(ctx context.Context, aID, bID int64) error {
+	startTime := time.Now()
+	err := r.withTx(ctx, func(q *sqlc.Queries) error {
+		// Read both orders inside the transaction so a concurrent reorder cannot be lost
+		a, err := q.GetTaskById(ctx, int32(aID))
+		if err != nil {
+			return err
+		}
+		b, err := q.GetTaskById(ctx, int32(bID))
+		if err != nil {
+			return err
+		}
+
+		if err := q.ReorderTask(ctx, sqlc.ReorderTaskParams{ID: a.ID, DisplayOrder: b.DisplayOrder}); err != nil {
+			return err
+		}
+		return q.ReorderTask(ctx, sqlc.ReorderTaskParams{ID: b.ID, DisplayOrder: a.DisplayOrder})
+	})
+	queryDuration := time.Since(startTime)
+
+	if err != nil {
+		r.log.Error("Failed to swap task order",
+			zap.Int64("task_a_id", aID),
+			zap.Int64("task_b_id", bID),
+			zap.Duration("duration_ms", queryDuration),
+			zap.Error(err))
+		if err == pgx.ErrNoRows {
+			return errors.NotFound(fmt.Sprintf("task %d or %d not found", aID, bID))
+		}
+		return errors.InternalError(fmt.Sprintf("failed to swap task order: %v", err))
+	}
+
+	r.log.Info("Task order swapped",
+		zap.Int64("task_a_id", aID),
+		zap.Int64("task_b_id", bID),
+		zap.Duration("duration_ms", queryDuration))
+
+	return nil
+}
+
+// ReparentTask implements output.TaskRepository.ReparentTask
+func (r *SQLTaskRepository) ReparentTask(ctx context.Context, taskID int64, newParentID *int64) error {
+	var parentID pgtype.Int4
+	if newParentID != nil {
+		parentID = pgtype.Int4{Int32: int32(*newParentID), Valid: true}
+	}
+
+	startTime := time.Now()
+	affected, err := r.q.ReparentTask(ctx, sqlc.ReparentTaskParams{
+		ID:       int32(taskID),
+		ParentID: parentID,
+	})
+	queryDuration := time.Since(startTime)
+
+	if err != nil {
+		r.log.Error("Failed to reparent task",
+			zap.Int64("task_id", taskID),
+			zap.Duration("duration_ms", queryDuration),
+			zap.Error(err))
+		return errors.InternalError(fmt.Sprintf("failed to reparent task: %v", err))
+	}
+	if affected == 0 {
+		return errors.NotFound(fmt.Sprintf("task %d not found", taskID))
+	}
+
+	r.log.Info("Task reparented",
+		zap.Int64("task_id", taskID),
+		zap.Bool("root", newParentID == nil),
+		zap.Duration("duration_ms", queryDuration))
+
+	return nil
+}
+
+// SetExternalRef implements output.TaskRepository.SetExternalRef
+func (r *SQLTaskRepository) SetExternalRef(ctx context.Context, taskID int64, source, externalID *string) error {
+	startTime := time.Now()
+	affected, err := r.q.SetTaskExternalRef(ctx, sqlc.SetTaskExternalRefParams{
+		ID:             int32(taskID),
+		ExternalSource: stringPtrToNullText(source),
+		ExternalID:     stringPtrToNullText(externalID),
+	})
+	queryDuration := time.Since(startTime)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return errors.Conflict(fmt.Sprintf("external event %s/%s is already attached to another task",
+				stringPtrToString(source), stringPtrToString(externalID)))
+		}
+		r.log.Error("Failed to set task external reference",
+			zap.Int64("task_id", taskID),
+			zap.Duration("duration_ms", queryDuration),
+			zap.Error(err))
+		return errors.InternalError(fmt.Sprintf("failed to set external reference: %v", err))
+	}
+	if affected == 0 {
+		return errors.NotFound(fmt.Sprintf("task %d not found", taskID))
+	}
+
+	r.log.Info("Task external reference set",
+		zap.Int64("task_id", taskID),
+		zap.Bool("cleared", source == nil),
+		zap.Duration("duration_ms", queryDuration))
+
+	return nil
+}
+
+// GetByExternalID implements output.TaskRepository.GetByExternalID
+func (r *SQLTaskRepository) GetByExternalID(ctx context.Context, source, externalID string) (task.Task, error) {
+	startTime := time.Now()
+	row, err := r.q.GetTaskByExternalId(ctx, sqlc.GetTaskByExternalIdParams{
+		ExternalSource: pgtype.Text{String: source, Valid: true},
+		ExternalID:     pgtype.Text{String: externalID, Valid: true},
+	})
+	queryDuration := time.Since(startTime)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return task.Task{}, errors.NotFound(fmt.Sprintf("no task is attached to external event %s/%s", source, externalID))
+		}
+		r.log.Error("Failed to get task by external ID",
+			zap.String("external_source", source),
+			zap.String("external_id", externalID),
+			zap.Duration("duration_ms", queryDuration),
+			zap.Error(err))
+		return task.Task{}, errors.InternalError(fmt.Sprintf("failed to get task by external ID: %v", err))
+	}
+
+	return r.mapDBTaskToDomain(row), nil
+}
+
+// AddDependency implements output.TaskRepository.AddDependency
+func (r *SQLTaskRepository) AddDependency(ctx context.Context, taskID, dependsOnID int64) error {
+	startTime := time.Now()
+	err := r.q.AddTaskDependency(ctx, sqlc.AddTaskDependencyParams{
+		TaskID:      int32(taskID),
+		DependsOnID: int32(dependsOnID),
+	})
+	queryDuration := time.Since(startTime)
+
+	if err != nil {
+		r.log.Error("Failed to add task dependency",
+			zap.Int64("task_id", taskID),
+			zap.Int64("depends_on_id", dependsOnID),
+			zap.Duration("duration_ms", queryDuration),
+			zap.Error(err))
+		return errors.InternalError(fmt.Sprintf("failed to add task dependency: %v", err))
+	}
+
+	r.log.Info("Task dependency added",
+		zap.Int64("task_id", taskID),
+		zap.Int64("depends_on_id", dependsOnID),
+		zap.Duration("duration_ms", queryDuration))
+
+	return nil
+}
+
+// RemoveDependency implements output.TaskRepository.RemoveDependency
+func (r *SQLTaskRepository) RemoveDependency(ctx context.Context, taskID, dependsOnID int64) error {
+	startTime := time.Now()
+	affected, err := r.q.RemoveTaskDependency(ctx, sqlc.RemoveTaskDependencyParams{
+		TaskID:      int32(taskID),
+		DependsOnID: int32(dependsOnID),
+	})
+	queryDuration := time.Since(startTime)
+
+	if err != nil {
+		r.log.Error("Failed to remove task dependency",
+			zap.Int64("task_id", taskID),
+			zap.Int64("depends_on_id", dependsOnID),
+			zap.Duration("duration_ms", queryDuration),
+			zap.Error(err))
+		return errors.InternalError(fmt.Sprintf("failed to remove task dependency: %v", err))
+	}
+	if affected == 0 {
+		return errors.NotFound(fmt.Sprintf("task %d does not depend on task %d", taskID, dependsOnID))
+	}
+
+	r.log.Info("Task dependency removed",
+		zap.Int64("task_id", taskID),
+		zap.Int64("depends_on_id", dependsOnID),
+		zap.Duration("duration_ms", queryDuration))
+
+	return nil
+}
+
+// ListDependencies implements output.TaskRepository.ListDependencies
+func (r *SQLTaskRepository) ListDependencies(ctx context.Context, taskID int64) ([]int32, error) {
+	ids, err := r.q.ListTaskDependencies(ctx, int32(taskID))
+	if err != nil {
+		return nil, errors.InternalError(fmt.Sprintf("failed to list task dependencies: %v", err))
+	}
+	return ids, nil
+}
+
+// ListBlockedTaskIDs implements output.TaskRepository.ListBlockedTaskIDs
+func (r *SQLTaskRepository) ListBlockedTaskIDs(ctx context.Context, userID int64) ([]int32, error) {
+	ids, err := r.q.ListBlockedTaskIds(ctx, int32(userID))
+	if err != nil {
+		return nil, errors.InternalError(fmt.Sprintf("failed to list blocked tasks: %v", err))
+	}
+	return ids, nil
+}
+
+// PinToDay implements output.TaskRepository.PinToDay
+func (r *SQLTaskRepository) PinToDay(ctx context.Context, taskID int64) error {
+	startTime := time.Now()
+	err := r.q.PinTaskToDay(ctx, int32(taskID))
+	queryDuration := time.Since(startTime)
+
+	if err != nil {
+		r.log.Error("Failed to pin task to its day",
+			zap.Int64("task_id", taskID),
+			zap.Duration("duration_ms", queryDuration),
+			zap.Error(err))
+		return errors.InternalError(fmt.Sprintf("failed to pin task: %v", err))
+	}
+
+	r.log.Info("Task pinned to its day",
+		zap.Int64("task_id", taskID),
+		zap.Duration("duration_ms", queryDuration))
+
+	return nil
+}
+
+// UnpinFromDay implements output.TaskRepository.UnpinFromDay
+func (r *SQLTaskRepository) UnpinFromDay(ctx context.Context, taskID int64) error {
+	startTime := time.Now()
+	affected, err := r.q.UnpinTaskFromDay(ctx, int32(taskID))
+	queryDuration := time.Since(startTime)
+
+	if err != nil {
+		r.log.Error("Failed to unpin task from its day",
+			zap.Int64("task_id", taskID),
+			zap.Duration("duration_ms", queryDuration),
+			zap.Error(err))
+		return errors.InternalError(fmt.Sprintf("failed to unpin task: %v", err))
+	}
+	if affected == 0 {
+		return errors.NotFound(fmt.Sprintf("task %d is not pinned", taskID))
 	}
 
-	r.log.Info("Task deleted successfully",
-		zap.Int64("task_id", id),
+	r.log.Info("Task unpinned from its day",
+		zap.Int64("task_id", taskID),
 		zap.Duration("duration_ms", queryDuration))
 
 	return nil
 }
 
-// GetByID implements output.TaskRepository.GetByID
-func (r *SQLTaskRepository) GetByID(ctx context.Context, id int64) (task.Task, error) {
-	r.log.Debug("Fetching task by ID",
-		zap.Int64("task_id", id))
+// ListDayPinnedTaskIDs implements output.TaskRepository.ListDayPinnedTaskIDs
+func (r *SQLTaskRepository) ListDayPinnedTaskIDs(ctx context.Context, userID int64) ([]int32, error) {
+	ids, err := r.q.ListDayPinnedTaskIds(ctx, int32(userID))
+	if err != nil {
+		return nil, errors.InternalError(fmt.Sprintf("failed to list pinned tasks: %v", err))
+	}
+	return ids, nil
+}
 
+// AddNote implements output.TaskRepository.AddNote
+func (r *SQLTaskRepository) AddNote(ctx context.Context, taskID int64, body string) (task.TaskNote, error) {
 	startTime := time.Now()
-	row, err := r.q.GetTaskById(ctx, int32(id))
+	row, err := r.q.AddTaskNote(ctx, sqlc.AddTaskNoteParams{
+		ID:      int32(taskID),
+		Column2: body,
+	})
 	queryDuration := time.Since(startTime)
 
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			r.log.Warn("Task not found",
-				zap.Int64("task_id", id),
-				zap.Duration("duration_ms", queryDuration))
-			return task.Task{}, errors.NotFound(fmt.Sprintf("task %d not found", id))
+			return task.TaskNote{}, errors.NotFound(fmt.Sprintf("task %d not found", taskID))
 		}
-		r.log.Error("Failed to get task by ID",
-			zap.Int64("task_id", id),
+		r.log.Error("Failed to add task note",
+			zap.Int64("task_id", taskID),
 			zap.Duration("duration_ms", queryDuration),
 			zap.Error(err))
-		return task.Task{}, errors.InternalError(fmt.Sprintf("failed to get task: %v", err))
+		return task.TaskNote{}, errors.InternalError(fmt.Sprintf("failed to add task note: %v", err))
 	}
 
-	r.log.Debug("Task fetched successfully",
-		zap.Int64("task_id", id),
-		zap.Int32("user_id", row.UserID),
+	r.log.Info("Task note added",
+		zap.Int64("task_id", taskID),
+		zap.Int32("note_id", row.ID),
 		zap.Duration("duration_ms", queryDuration))
 
-	return mapDBTaskToDomain(row), nil
+	return mapDBNoteToDomain(row), nil
 }
 
-// ListRootTasks implements output.TaskRepository.ListRootTasks
-func (r *SQLTaskRepository) ListRootTasks(ctx context.Context, userID int64) ([]task.Task, error) {
-	rows, err := r.q.ListRootTasksByUserId(ctx, int32(userID))
+// ListNotes implements output.TaskRepository.ListNotes
+func (r *SQLTaskRepository) ListNotes(ctx context.Context, taskID int64) ([]task.TaskNote, error) {
+	rows, err := r.q.ListTaskNotes(ctx, int32(taskID))
 	if err != nil {
-		return nil, errors.InternalError(fmt.Sprintf("failed to list root tasks: %v", err))
+		return nil, errors.InternalError(fmt.Sprintf("failed to list task notes: %v", err))
 	}
 
-	tasks := make([]task.Task, len(rows))
+	notes := make([]task.TaskNote, len(rows))
 	for i, row := range rows {
-		tasks[i] = mapDBTaskToDomain(row)
+		notes[i] = mapDBNoteToDomain(row)
 	}
-	return tasks, nil
+	return notes, nil
 }
 
-// ListSubTasks implements output.TaskRepository.ListSubTasks
-func (r *SQLTaskRepository) ListSubTasks(ctx context.Context, parentID int64) ([]task.Task, error) {
-	pid := pgtype.Int4{
-		Int32: int32(parentID),
-		Valid: true,
+// mapDBNoteToDomain converts a task_notes row to a domain note
+func mapDBNoteToDomain(row sqlc.TaskNote) task.TaskNote {
+	return task.TaskNote{
+		ID:        row.ID,
+		TaskID:    row.TaskID,
+		UserID:    row.UserID,
+		Body:      row.Body,
+		CreatedAt: row.CreatedAt.Time,
 	}
-	rows, err := r.q.GetSubtasksByParentId(ctx, pid)
+}
+
+// NormalizeDisplayOrder implements output.TaskRepository.NormalizeDisplayOrder
+func (r *SQLTaskRepository) NormalizeDisplayOrder(ctx context.Context, userID int64) (int64, error) {
+	r.log.Info("Normalizing task display order",
+		zap.Int64("user_id", userID))
+
+	var affected int64
+	startTime := time.Now()
+	err := r.withTx(ctx, func(q *sqlc.Queries) error {
+		var err error
+		affected, err = q.NormalizeDisplayOrder(ctx, int32(userID))
+		return err
+	})
+	queryDuration := time.Since(startTime)
+
 	if err != nil {
-		return nil, errors.InternalError(fmt.Sprintf("failed to list subtasks: %v", err))
+		r.log.Error("Failed to normalize task display order",
+			zap.Int64("user_id", userID),
+			zap.Duration("duration_ms", queryDuration),
+			zap.Error(err))
+		return 0, errors.InternalError(fmt.Sprintf("failed to normalize display order: %v", err))
 	}
 
-	tasks := make([]task.Task, len(rows))
-	for i, row := range rows {
-		tasks[i] = mapDBTaskToDomain(row)
-	}
-	return tasks, nil
-}
+	r.log.Info("Task display order normalized",
+		zap.Int64("user_id", userID),
+		zap.Int64("tasks_updated", affected),
+		zap.Duration("duration_ms", queryDuration))
 
-// GetTaskTree implements output.TaskRepository.GetTaskTree
-func (r *SQLTaskRepository) GetTaskTree(ctx context.Context, rootID int64) (task.Task, error) {
-	r.log.Debug("Fetching task tree",
-		zap.Int64("root_id", rootID))
+	return affected, nil
+}
 
+// PostponeTasks implements output.TaskRepository.PostponeTasks
+func (r *SQLTaskRepository) PostponeTasks(ctx context.Context, taskIDs []int32, days int) (int64, error) {
 	startTime := time.Now()
-	rows, err := r.q.ListTasksWithSubtasksRecursive(ctx, int32(rootID))
+	affected, err := r.q.PostponeTasks(ctx, sqlc.PostponeTasksParams{
+		Column1: taskIDs,
+		Column2: int32(days),
+	})
 	queryDuration := time.Since(startTime)
 
 	if err != nil {
-		r.log.Error("Failed to get task tree",
-			zap.Int64("root_id", rootID),
+		r.log.Error("Failed to postpone tasks",
+			zap.Int("task_count", len(taskIDs)),
+			zap.Int("days", days),
 			zap.Duration("duration_ms", queryDuration),
 			zap.Error(err))
-		return task.Task{}, errors.InternalError(fmt.Sprintf("failed to get task tree: %v", err))
+		return 0, errors.InternalError(fmt.Sprintf("failed to postpone tasks: %v", err))
 	}
 
-	if len(rows) == 0 {
-		r.log.Warn("Task tree not found",
-			zap.Int64("root_id", rootID),
-			zap.Duration("duration_ms", queryDuration))
-		return task.Task{}, errors.NotFound(fmt.Sprintf("task %d not found", rootID))
-	}
+	r.log.Info("Tasks postponed",
+		zap.Int64("tasks_updated", affected),
+		zap.Int("days", days),
+		zap.Duration("duration_ms", queryDuration))
 
-	// Map rows to domain tasks
-	domainTasks := make([]task.Task, len(rows))
-	for i, row := range rows {
-		domainTasks[i] = mapRecursiveRowToDomain(row)
-	}
+	return affected, nil
+}
 
-	// Build tree
-	tree := buildTaskTree(domainTasks)
+// SnoozeTasks implements output.TaskRepository.SnoozeTasks
+func (r *SQLTaskRepository) SnoozeTasks(ctx context.Context, userID int64, taskIDs []int32, until time.Time) (int64, error) {
+	startTime := time.Now()
+	affected, err := r.q.SnoozeTasks(ctx, sqlc.SnoozeTasksParams{
+		Column1: taskIDs,
+		UserID:  int32(userID),
+		DueDate: dueDateToTimestamp(&until),
+	})
+	queryDuration := time.Since(startTime)
 
-	// Compute metrics
-	computeTaskMetrics(&tree)
+	if err != nil {
+		r.log.Error("Failed to snooze tasks",
+			zap.Int64("user_id", userID),
+			zap.Int("task_count", len(taskIDs)),
+			zap.Duration("duration_ms", queryDuration),
+			zap.Error(err))
+		return 0, errors.InternalError(fmt.Sprintf("failed to snooze tasks: %v", err))
+	}
 
-	r.log.Debug("Task tree fetched successfully",
-		zap.Int64("root_id", rootID),
-		zap.Int("node_count", len(rows)),
+	r.log.Info("Tasks snoozed",
+		zap.Int64("user_id", userID),
+		zap.Int64("tasks_updated", affected),
+		zap.Time("until", until),
 		zap.Duration("duration_ms", queryDuration))
 
-	return tree, nil
+	return affected, nil
 }
 
-// ReorderTask implements output.TaskRepository.ReorderTask
-func (r *SQLTaskRepository) ReorderTask(ctx context.Context, taskID int64, newOrder int) error {
-	params := sqlc.ReorderTaskParams{
-		ID: int32(taskID),
-		DisplayOrder: pgtype.Int4{
-			Int32: int32(newOrder),
-			Valid: true,
-		},
+// ReplaceTaskMetrics implements output.TaskRepository.ReplaceTaskMetrics
+func (r *SQLTaskRepository) ReplaceTaskMetrics(ctx context.Context, userID int64, metrics []task.CachedMetrics) error {
+	startTime := time.Now()
+	err := r.withTx(ctx, func(q *sqlc.Queries) error {
+		// Drop every cached row first so tasks that lost their subtasks are not left stale
+		if err := q.DeleteUserTaskMetrics(ctx, int32(userID)); err != nil {
+			return err
+		}
+		for _, m := range metrics {
+			if err := q.UpsertTaskMetrics(ctx, sqlc.UpsertTaskMetricsParams{
+				TaskID:         m.TaskID,
+				TotalCount:     int32(m.TotalCount),
+				CompletedCount: int32(m.CompletedCount),
+				Progress:       m.Progress,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	queryDuration := time.Since(startTime)
+
+	if err != nil {
+		r.log.Error("Failed to replace cached task metrics",
+			zap.Int64("user_id", userID),
+			zap.Duration("duration_ms", queryDuration),
+			zap.Error(err))
+		return errors.InternalError(fmt.Sprintf("failed to replace task metrics: %v", err))
 	}
 
-	err := r.q.ReorderTask(ctx, params)
+	r.log.Info("Cached task metrics replaced",
+		zap.Int64("user_id", userID),
+		zap.Int("tasks", len(metrics)),
+		zap.Duration("duration_ms", queryDuration))
+
+	return nil
+}
+
+// ListTaskMetrics implements output.TaskRepository.ListTaskMetrics
+func (r *SQLTaskRepository) ListTaskMetrics(ctx context.Context, userID int64) ([]task.CachedMetrics, error) {
+	rows, err := r.q.ListTaskMetrics(ctx, int32(userID))
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return errors.NotFound(fmt.Sprintf("task %d not found", taskID))
+		return nil, errors.InternalError(fmt.Sprintf("failed to list task metrics: %v", err))
+	}
+
+	metrics := make([]task.CachedMetrics, len(rows))
+	for i, row := range rows {
+		metrics[i] = task.CachedMetrics{
+			TaskID:         row.TaskID,
+			TotalCount:     int(row.TotalCount),
+			CompletedCount: int(row.CompletedCount),
+			Progress:       row.Progress,
+			ComputedAt:     row.ComputedAt.Time,
 		}
-		return errors.InternalError(fmt.Sprintf("failed to reorder task: %v", err))
 	}
-	return nil
+	return metrics, nil
 }
 
 // SearchTasksByTitle implements output.TaskRepository.SearchTasksByTitle
@@ -335,7 +1128,31 @@ func (r *SQLTaskRepository) SearchTasksByTitle(ctx context.Context, userID int64
 
 	tasks := make([]task.Task, len(rows))
 	for i, row := range rows {
-		tasks[i] = mapDBTaskToDomain(row)
+		tasks[i] = r.mapDBTaskToDomain(row)
+	}
+	return tasks, nil
+}
+
+// SearchTasksByText implements output.TaskRepository.SearchTasksByText
+func (r *SQLTaskRepository) SearchTasksByText(ctx context.Context, userID int64, query string) ([]task.Task, error) {
+	startTime := time.Now()
+	rows, err := r.q.SearchTasksByText(ctx, sqlc.SearchTasksByTextParams{
+		UserID: int32(userID),
+		Query:  query,
+	})
+	queryDuration := time.Since(startTime)
+
+	if err != nil {
+		r.log.Error("Failed to search tasks by text",
+			zap.Int64("user_id", userID),
+			zap.Duration("duration_ms", queryDuration),
+			zap.Error(err))
+		return nil, errors.InternalError(fmt.Sprintf("failed to search tasks by text: %v", err))
+	}
+
+	tasks := make([]task.Task, len(rows))
+	for i, row := range rows {
+		tasks[i] = r.mapDBTaskToDomain(row)
 	}
 	return tasks, nil
 }
@@ -344,7 +1161,7 @@ func (r *SQLTaskRepository) SearchTasksByTitle(ctx context.Context, userID int64
 func (r *SQLTaskRepository) SearchTasksByTag(ctx context.Context, userID int64, tag string) ([]task.Task, error) {
 	rows, err := r.q.SearchTasksByTag(ctx, sqlc.SearchTasksByTagParams{
 		UserID: int32(userID),
-		Tags:   []string{tag}, // Changed from Tag to Tags and passing as a slice
+		Tag:    tag,
 	})
 	if err != nil {
 		return nil, errors.InternalError(fmt.Sprintf("failed to search tasks by tag: %v", err))
@@ -352,19 +1169,21 @@ func (r *SQLTaskRepository) SearchTasksByTag(ctx context.Context, userID int64,
 
 	tasks := make([]task.Task, len(rows))
 	for i, row := range rows {
-		tasks[i] = mapDBTaskToDomain(row)
+		tasks[i] = r.mapDBTaskToDomain(row)
 	}
 	return tasks, nil
 }
 
-// ListTasksByStatus implements output.TaskRepository.ListTasksByStatus
-func (r *SQLTaskRepository) ListTasksByStatus(ctx context.Context, userID int64, status task.Status) ([]task.Task, error) {
-	rows, err := r.q.ListTasksByStatus(ctx, sqlc.ListTasksByStatusParams{
-		UserID: int32(userID),
-		Status: pgtype.Text{
-			String: string(status),
-			Valid:  true,
-		},
+// ListTasksByStatuses implements output.TaskRepository.ListTasksByStatuses
+func (r *SQLTaskRepository) ListTasksByStatuses(ctx context.Context, userID int64, statuses []task.Status) ([]task.Task, error) {
+	values := make([]string, len(statuses))
+	for i, status := range statuses {
+		values[i] = string(status)
+	}
+
+	rows, err := r.q.ListTasksByStatuses(ctx, sqlc.ListTasksByStatusesParams{
+		UserID:  int32(userID),
+		Column2: values,
 	})
 	if err != nil {
 		return nil, errors.InternalError(fmt.Sprintf("failed to list tasks by status: %v", err))
@@ -372,7 +1191,46 @@ func (r *SQLTaskRepository) ListTasksByStatus(ctx context.Context, userID int64,
 
 	tasks := make([]task.Task, len(rows))
 	for i, row := range rows {
-		tasks[i] = mapDBTaskToDomain(row)
+		tasks[i] = r.mapDBTaskToDomain(row)
+	}
+	return tasks, nil
+}
+
+// ListTasksFiltered implements output.TaskRepository.ListTasksFiltered
+func (r *SQLTaskRepository) ListTasksFiltered(ctx context.Context, userID int64, filter task.TaskFilter) ([]task.Task, error) {
+	params := sqlc.ListTasksFilteredParams{
+		UserID:    int32(userID),
+		DueBefore: dueDateToTimestamp(filter.DueBefore),
+		DueAfter:  dueDateToTimestamp(filter.DueAfter),
+		Tag:       pgtype.Text{String: filter.Tag, Valid: filter.Tag != ""},
+		Text:      pgtype.Text{String: filter.Text, Valid: filter.Text != ""},
+	}
+	if filter.Status != nil {
+		params.Status = pgtype.Text{String: string(*filter.Status), Valid: true}
+	}
+	if filter.Priority != nil {
+		params.Priority = pgtype.Text{String: string(*filter.Priority), Valid: true}
+	}
+	if filter.Energy != nil {
+		params.Energy = pgtype.Text{String: string(*filter.Energy), Valid: true}
+	}
+
+	startTime := time.Now()
+	rows, err := r.q.ListTasksFiltered(ctx, params)
+	queryDuration := time.Since(startTime)
+
+	if err != nil {
+		r.log.Error("Failed to list filtered tasks",
+			zap.Int64("user_id", userID),
+			zap.String("filter", filter.String()),
+			zap.Duration("duration_ms", queryDuration),
+			zap.Error(err))
+		return nil, errors.InternalError(fmt.Sprintf("failed to list filtered tasks: %v", err))
+	}
+
+	tasks := make([]task.Task, len(rows))
+	for i, row := range rows {
+		tasks[i] = r.mapDBTaskToDomain(row)
 	}
 	return tasks, nil
 }
@@ -392,7 +1250,7 @@ func (r *SQLTaskRepository) ListTasksByPriority(ctx context.Context, userID int6
 
 	tasks := make([]task.Task, len(rows))
 	for i, row := range rows {
-		tasks[i] = mapDBTaskToDomain(row)
+		tasks[i] = r.mapDBTaskToDomain(row)
 	}
 	return tasks, nil
 }
@@ -416,7 +1274,7 @@ func (r *SQLTaskRepository) ListTasksDueToday(ctx context.Context, userID int64)
 
 	tasks := make([]task.Task, len(rows))
 	for i, row := range rows {
-		tasks[i] = mapDBTaskToDomain(row)
+		tasks[i] = r.mapDBTaskToDomain(row)
 	}
 
 	r.log.Debug("Successfully listed tasks due today",
@@ -428,15 +1286,18 @@ func (r *SQLTaskRepository) ListTasksDueToday(ctx context.Context, userID int64)
 }
 
 // ListTasksDueSoon implements output.TaskRepository.ListTasksDueSoon
-func (r *SQLTaskRepository) ListTasksDueSoon(ctx context.Context, userID int64) ([]task.Task, error) {
-	rows, err := r.q.ListTasksDueSoon(ctx, int32(userID))
+func (r *SQLTaskRepository) ListTasksDueSoon(ctx context.Context, userID int64, days int) ([]task.Task, error) {
+	rows, err := r.q.ListTasksDueSoon(ctx, sqlc.ListTasksDueSoonParams{
+		UserID:  int32(userID),
+		Column2: int32(days),
+	})
 	if err != nil {
 		return nil, errors.InternalError(fmt.Sprintf("failed to list tasks due soon: %v", err))
 	}
 
 	tasks := make([]task.Task, len(rows))
 	for i, row := range rows {
-		tasks[i] = mapDBTaskToDomain(row)
+		tasks[i] = r.mapDBTaskToDomain(row)
 	}
 	return tasks, nil
 }
@@ -450,7 +1311,21 @@ func (r *SQLTaskRepository) ListOverdueTasks(ctx context.Context, userID int64)
 
 	tasks := make([]task.Task, len(rows))
 	for i, row := range rows {
-		tasks[i] = mapDBTaskToDomain(row)
+		tasks[i] = r.mapDBTaskToDomain(row)
+	}
+	return tasks, nil
+}
+
+// ListIncompleteDatedTasks implements output.TaskRepository.ListIncompleteDatedTasks
+func (r *SQLTaskRepository) ListIncompleteDatedTasks(ctx context.Context, userID int64) ([]task.Task, error) {
+	rows, err := r.q.ListIncompleteDatedTasks(ctx, int32(userID))
+	if err != nil {
+		return nil, errors.InternalError(fmt.Sprintf("failed to list incomplete dated tasks: %v", err))
+	}
+
+	tasks := make([]task.Task, len(rows))
+	for i, row := range rows {
+		tasks[i] = r.mapDBTaskToDomain(row)
 	}
 	return tasks, nil
 }
@@ -496,7 +1371,7 @@ func (r *SQLTaskRepository) GetRecentlyCompletedTasks(ctx context.Context, userI
 
 	tasks := make([]task.Task, len(rows))
 	for i, row := range rows {
-		tasks[i] = mapDBTaskToDomain(row)
+		tasks[i] = r.mapDBTaskToDomain(row)
 	}
 	return tasks, nil
 }
@@ -558,110 +1433,284 @@ func (r *SQLTaskRepository) GetAllTagsForUser(ctx context.Context, userID int64)
 	return tags, nil
 }
 
+// SetTaskTags implements output.TaskRepository.SetTaskTags
+func (r *SQLTaskRepository) SetTaskTags(ctx context.Context, userID int64, tags map[int32][]string) (int64, error) {
+	var affected int64
+	startTime := time.Now()
+	err := r.withTx(ctx, func(q *sqlc.Queries) error {
+		for id, names := range tags {
+			n, err := q.SetTaskTags(ctx, sqlc.SetTaskTagsParams{
+				ID:     id,
+				UserID: int32(userID),
+				Tags:   names,
+			})
+			if err != nil {
+				return err
+			}
+			affected += n
+		}
+		return nil
+	})
+	queryDuration := time.Since(startTime)
+
+	if err != nil {
+		r.log.Error("Failed to set task tags",
+			zap.Int64("user_id", userID),
+			zap.Int("task_count", len(tags)),
+			zap.Duration("duration_ms", queryDuration),
+			zap.Error(err))
+		return 0, errors.InternalError(fmt.Sprintf("failed to set task tags: %v", err))
+	}
+
+	r.log.Info("Task tags updated",
+		zap.Int64("user_id", userID),
+		zap.Int64("tasks_updated", affected),
+		zap.Duration("duration_ms", queryDuration))
+
+	return affected, nil
+}
+
 // Mapping functions
 
 // mapDBTaskToDomain maps a sqlc.Task to a task.Task
-func mapDBTaskToDomain(dbt sqlc.Task) task.Task {
-	return task.Task{
-		ID:           int32(dbt.ID),
-		UserID:       dbt.UserID,
-		ParentID:     nullInt4ToIntPtr(dbt.ParentID),
-		Title:        dbt.Title,
-		Description:  nullTextToStringPtr(dbt.Description),
-		CreatedAt:    dbt.CreatedAt.Time,
-		UpdatedAt:    dbt.UpdatedAt.Time,
-		DueDate:      nullTimestampToTimePtr(dbt.DueDate),
-		IsCompleted:  dbt.IsCompleted.Bool,
-		Status:       task.Status(dbt.Status.String),
-		Priority:     task.Priority(dbt.Priority.String),
-		Tags:         stringSliceToTags(dbt.Tags),
-		DisplayOrder: int(dbt.DisplayOrder.Int32),
-	}
+func (r *SQLTaskRepository) mapDBTaskToDomain(dbt sqlc.Task) task.Task {
+	return r.coerceEnums(task.Task{
+		ID:             int32(dbt.ID),
+		UserID:         dbt.UserID,
+		ParentID:       nullInt4ToIntPtr(dbt.ParentID),
+		Title:          dbt.Title,
+		Description:    nullTextToStringPtr(dbt.Description),
+		CreatedAt:      dbt.CreatedAt.Time,
+		UpdatedAt:      dbt.UpdatedAt.Time,
+		DueDate:        timestampToDueDate(dbt.DueDate),
+		IsCompleted:    dbt.IsCompleted.Bool,
+		Status:         task.Status(dbt.Status.String),
+		Priority:       task.Priority(dbt.Priority.String),
+		Tags:           stringSliceToTags(dbt.Tags),
+		DisplayOrder:   int(dbt.DisplayOrder.Int32),
+		Energy:         task.Energy(dbt.Energy.String),
+		Points:         int(dbt.Points),
+		Recurrence:     r.parseRecurrence(dbt.ID, dbt.Recurrence),
+		ExternalID:     nullTextToStringPtr(dbt.ExternalID),
+		ExternalSource: nullTextToStringPtr(dbt.ExternalSource),
+		ArchivedAt:     nullTimestampToTimePtr(dbt.ArchivedAt),
+	})
 }
 
 // mapRecursiveRowToDomain maps a sqlc.ListTasksWithSubtasksRecursiveRow to a task.Task
-func mapRecursiveRowToDomain(row sqlc.ListTasksWithSubtasksRecursiveRow) task.Task {
-	return task.Task{
-		ID:           int32(row.ID),
-		UserID:       row.UserID,
-		ParentID:     nullInt4ToIntPtr(row.ParentID),
-		Title:        row.Title,
-		Description:  nullTextToStringPtr(row.Description),
-		CreatedAt:    row.CreatedAt.Time,
-		UpdatedAt:    row.UpdatedAt.Time,
-		DueDate:      nullTimestampToTimePtr(row.DueDate),
-		IsCompleted:  row.IsCompleted.Bool,
-		Status:       task.Status(row.Status.String),
-		Priority:     task.Priority(row.Priority.String),
-		Tags:         stringSliceToTags(row.Tags),
-		DisplayOrder: int(row.DisplayOrder.Int32),
-		SubTasks:     []task.Task{}, // Initialize empty slice for subtasks
-	}
-}
-
-// buildTaskTree builds a task tree from a flat list of tasks
-func buildTaskTree(tasks []task.Task) task.Task {
-	// Create a map of task ID to task pointer for quick lookups
-	taskMap := make(map[int32]*task.Task, len(tasks))
+func (r *SQLTaskRepository) mapRecursiveRowToDomain(row sqlc.ListTasksWithSubtasksRecursiveRow) task.Task {
+	return r.coerceEnums(task.Task{
+		ID:             int32(row.ID),
+		UserID:         row.UserID,
+		ParentID:       nullInt4ToIntPtr(row.ParentID),
+		Title:          row.Title,
+		Description:    nullTextToStringPtr(row.Description),
+		CreatedAt:      row.CreatedAt.Time,
+		UpdatedAt:      row.UpdatedAt.Time,
+		DueDate:        timestampToDueDate(row.DueDate),
+		IsCompleted:    row.IsCompleted.Bool,
+		Status:         task.Status(row.Status.String),
+		Priority:       task.Priority(row.Priority.String),
+		Tags:           stringSliceToTags(row.Tags),
+		DisplayOrder:   int(row.DisplayOrder.Int32),
+		Energy:         task.Energy(row.Energy.String),
+		Points:         int(row.Points),
+		Recurrence:     r.parseRecurrence(row.ID, row.Recurrence),
+		ExternalID:     nullTextToStringPtr(row.ExternalID),
+		ExternalSource: nullTextToStringPtr(row.ExternalSource),
+		ArchivedAt:     nullTimestampToTimePtr(row.ArchivedAt),
+		SubTasks:       []task.Task{}, // Initialize empty slice for subtasks
+	})
+}
+
+// coerceEnums replaces a NULL or unknown status with todo and priority with medium,
+// so rows written by a bad migration or an external insert still categorize sanely
+func (r *SQLTaskRepository) coerceEnums(t task.Task) task.Task {
+	switch t.Status {
+	case task.StatusTodo, task.StatusInProgress, task.StatusDone:
+	default:
+		r.log.Warn("Task has an invalid status, treating it as todo",
+			zap.Int32("task_id", t.ID),
+			zap.String("status", string(t.Status)))
+		t.Status = task.StatusTodo
+	}
+
+	switch t.Priority {
+	case task.PriorityLow, task.PriorityMedium, task.PriorityHigh:
+	default:
+		r.log.Warn("Task has an invalid priority, treating it as medium",
+			zap.Int32("task_id", t.ID),
+			zap.String("priority", string(t.Priority)))
+		t.Priority = task.PriorityMedium
+	}
+
+	return t
+}
+
+// parseRecurrence reads a stored recurrence rule. An invalid rule is logged and
+// dropped so the task still loads, just without repeating.
+func (r *SQLTaskRepository) parseRecurrence(taskID int32, rule pgtype.Text) *task.Recurrence {
+	if !rule.Valid {
+		return nil
+	}
+	rec, err := task.ParseRecurrence(rule.String)
+	if err != nil {
+		r.log.Warn("Task has an invalid recurrence rule, ignoring it",
+			zap.Int32("task_id", taskID),
+			zap.String("recurrence", rule.String),
+			zap.Error(err))
+		return nil
+	}
+	return rec
+}
+
+// maxTreeDepth bounds how deep task trees are assembled so corrupted (cyclic)
+// parent links can never cause unbounded recursion
+const maxTreeDepth = 100
+
+// buildTaskTree builds the tree rooted at rootID from a flat list of tasks.
+// Children keep the order in which they appear in the list. Only ParentID links
+// shape the tree; dependencies between tasks are never followed.
+func buildTaskTree(tasks []task.Task, rootID int32) task.Task {
+	// Group tasks by parent for quick lookups
+	children := make(map[int32][]task.Task, len(tasks))
 	var root task.Task
 
-	// First pass: create map entries
-	for i := range tasks {
-		taskMap[tasks[i].ID] = &tasks[i]
-		if i == 0 {
-			root = tasks[i] // The first task is the root
+	for _, t := range tasks {
+		if t.ID == rootID {
+			root = t
+			continue
 		}
+		if t.ParentID != nil {
+			children[*t.ParentID] = append(children[*t.ParentID], t)
+		}
+	}
+
+	attachSubTasks(&root, children, map[int32]bool{rootID: true}, 1)
+	return root
+}
+
+// buildTaskForest builds the trees rooted at rootIDs from a flat list of tasks, in the
+// order of rootIDs. Roots missing from the list are left out, and a task is attached
+// to at most one tree.
+func buildTaskForest(tasks []task.Task, rootIDs []int32) []task.Task {
+	visited := make(map[int32]bool, len(tasks))
+	for _, id := range rootIDs {
+		visited[id] = true
 	}
 
-	// Second pass: connect parents and children
-	for i := range tasks {
-		// Skip the root task
-		if tasks[i].ParentID == nil {
+	roots := make(map[int32]task.Task, len(rootIDs))
+	children := make(map[int32][]task.Task, len(tasks))
+	for _, t := range tasks {
+		if visited[t.ID] {
+			roots[t.ID] = t
 			continue
 		}
+		if t.ParentID != nil {
+			children[*t.ParentID] = append(children[*t.ParentID], t)
+		}
+	}
 
-		// Find the parent and append this task as a child
-		if parent, found := taskMap[*tasks[i].ParentID]; found {
-			parent.SubTasks = append(parent.SubTasks, tasks[i])
+	forest := make([]task.Task, 0, len(roots))
+	for _, id := range rootIDs {
+		root, ok := roots[id]
+		if !ok {
+			continue
 		}
+		// Drop the root so a repeated ID does not add the same tree twice
+		delete(roots, id)
+
+		attachSubTasks(&root, children, visited, 1)
+		forest = append(forest, root)
 	}
+	return forest
+}
 
-	return root
+// attachSubTasks recursively attaches the children of t, skipping tasks already
+// in the tree and stopping at maxTreeDepth
+func attachSubTasks(t *task.Task, children map[int32][]task.Task, visited map[int32]bool, depth int) {
+	if depth >= maxTreeDepth {
+		return
+	}
+
+	for _, child := range children[t.ID] {
+		if visited[child.ID] {
+			continue
+		}
+		visited[child.ID] = true
+
+		attachSubTasks(&child, children, visited, depth+1)
+		t.SubTasks = append(t.SubTasks, child)
+	}
 }
 
-// computeTaskMetrics recursively computes metrics for a task and its subtasks
-func computeTaskMetrics(t *task.Task) {
+// computeTaskMetrics recursively computes metrics for a task and its subtasks.
+// A subtask counts as overdue when it is incomplete and task.IsOverdue holds for
+// its due date at now. Subtrees deeper than maxTreeDepth are not
+// descended into. With byPoints, progress is the share of descendant points that
+// are completed; a tree without any points falls back to counting subtasks equally.
+// It returns the total and completed points of t's descendants.
+func computeTaskMetrics(t *task.Task, now time.Time, depth int, byPoints bool) (points, completedPoints int) {
 	totalCount := len(t.SubTasks)
 	completedCount := 0
+	hasOverdue := false
 
 	// Process subtasks recursively
 	for i := range t.SubTasks {
-		computeTaskMetrics(&t.SubTasks[i])
+		if depth < maxTreeDepth {
+			p, cp := computeTaskMetrics(&t.SubTasks[i], now, depth+1, byPoints)
+			points += p
+			completedPoints += cp
+		}
 		totalCount += t.SubTasks[i].TotalCount
 		completedCount += t.SubTasks[i].CompletedCount
+		hasOverdue = hasOverdue || t.SubTasks[i].HasOverdueDescendant
 	}
 
-	// Count completed tasks
+	// Count completed and overdue tasks
 	for _, subtask := range t.SubTasks {
+		points += subtask.Points
 		if subtask.IsCompleted {
 			completedCount++
+			completedPoints += subtask.Points
+		} else if subtask.DueDate != nil && task.IsOverdue(*subtask.DueDate, now) {
+			hasOverdue = true
 		}
 	}
 
 	// Update metrics
 	t.TotalCount = totalCount
 	t.CompletedCount = completedCount
+	t.HasOverdueDescendant = hasOverdue
 
 	// Calculate progress (avoid division by zero)
-	if totalCount > 0 {
+	switch {
+	case byPoints && points > 0:
+		t.Progress = float64(completedPoints) / float64(points)
+	case totalCount > 0:
 		t.Progress = float64(completedCount) / float64(totalCount)
-	} else {
+	default:
 		t.Progress = 0
 	}
+	return points, completedPoints
+}
+
+// isUniqueViolation reports whether err is a PostgreSQL unique constraint violation
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return stderrors.As(err, &pgErr) && pgErr.Code == "23505"
 }
 
 // Type conversion helper functions
 
+// recurrenceToNullText converts a recurrence rule to pgtype.Text, NULL when the task does not repeat
+func recurrenceToNullText(rec *task.Recurrence) pgtype.Text {
+	if rec == nil {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: rec.String(), Valid: true}
+}
+
 // nullInt4ToIntPtr converts pgtype.Int4 to *int32
 func nullInt4ToIntPtr(n pgtype.Int4) *int32 {
 	if !n.Valid {
@@ -686,6 +1735,14 @@ func nullTextToStringPtr(t pgtype.Text) *string {
 	return &t.String
 }
 
+// stringPtrToNullText converts *string to pgtype.Text, NULL when the pointer is nil
+func stringPtrToNullText(s *string) pgtype.Text {
+	if s == nil {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: *s, Valid: true}
+}
+
 // stringPtrToString safely dereferences a string pointer
 func stringPtrToString(s *string) string {
 	if s == nil {
@@ -702,12 +1759,32 @@ func nullTimestampToTimePtr(ts pgtype.Timestamp) *time.Time {
 	return &ts.Time
 }
 
-// timePtrToNullTimestamp converts *time.Time to pgtype.Timestamp
-func timePtrToNullTimestamp(t *time.Time) pgtype.Timestamp {
+// dueDateToTimestamp converts a due date to the wall clock time stored in the
+// zone-less due_date column, in the local time zone. Date-only due dates keep their
+// midnight, so they read back as the same day.
+func dueDateToTimestamp(t *time.Time) pgtype.Timestamp {
 	if t == nil {
 		return pgtype.Timestamp{Valid: false}
 	}
-	return pgtype.Timestamp{Time: *t, Valid: true}
+	local := t.In(time.Local)
+	if !task.HasDueTime(*t) {
+		// A date-only due date means that day wherever it was entered
+		local = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+	}
+	wall := time.Date(local.Year(), local.Month(), local.Day(),
+		local.Hour(), local.Minute(), local.Second(), local.Nanosecond(), time.UTC)
+	return pgtype.Timestamp{Time: wall, Valid: true}
+}
+
+// timestampToDueDate reads a due_date column back as local time, the zone its wall
+// clock was stored in by dueDateToTimestamp
+func timestampToDueDate(ts pgtype.Timestamp) *time.Time {
+	if !ts.Valid {
+		return nil
+	}
+	w := ts.Time
+	due := time.Date(w.Year(), w.Month(), w.Day(), w.Hour(), w.Minute(), w.Second(), w.Nanosecond(), time.Local)
+	return &due
 }
 
 // stringSliceToTags converts a slice of strings to a slice of task.Tag
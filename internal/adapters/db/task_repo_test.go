@@ -19,17 +19,22 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
+	"go.uber.org/zap/zaptest/observer"
 
 	sqlcgen "github.com/newbpydev/tusk/internal/adapters/db/sqlc"
 	"github.com/newbpydev/tusk/internal/config"
 	"github.com/newbpydev/tusk/internal/core/task"
+	"github.com/newbpydev/tusk/internal/ports/output"
 	"github.com/newbpydev/tusk/internal/util/logging"
 )
 
@@ -41,7 +46,7 @@ var (
 
 // Test setup helper functions
 
-func setupTestDB(t *testing.T) {
+func setupTestDB(t testing.TB) {
 	// Initialize logging with a test configuration
 	cfg := &config.Config{
 		AppEnv: "test",
@@ -130,7 +135,7 @@ func setupTestDB(t *testing.T) {
 	}
 }
 
-func cleanDB(t *testing.T) {
+func cleanDB(t testing.TB) {
 	// Delete all tasks and reset sequences
 	_, err := testDBPool.Exec(ctx, "DELETE FROM tasks")
 	require.NoError(t, err)
@@ -145,7 +150,7 @@ func teardownTestDB() {
 }
 
 // createTestUser creates a test user in the database
-func createTestUser(t *testing.T) int32 {
+func createTestUser(t testing.TB) int32 {
 	result, err := testDBPool.Exec(ctx, `
 		INSERT INTO users (username, email, password_hash, created_at, updated_at)
 		VALUES ('testuser', 'test@example.com', 'hashedpassword', NOW(), NOW())
@@ -408,3 +413,529 @@ func int32Ptr(i int32) *int32 {
 func timePtr(t time.Time) *time.Time {
 	return &t
 }
+
+// seedTaskTrees creates roots root tasks for a user, each with three children that
+// have two subtasks of their own, so every tree holds ten tasks
+func seedTaskTrees(t testing.TB, userID int32, roots int) {
+	for r := 0; r < roots; r++ {
+		root, err := testRepo.Create(ctx, task.Task{UserID: userID, Title: fmt.Sprintf("Root %d", r), Status: task.StatusTodo})
+		require.NoError(t, err)
+
+		for c := 0; c < 3; c++ {
+			child, err := testRepo.Create(ctx, task.Task{UserID: userID, ParentID: &root.ID, Title: fmt.Sprintf("Child %d.%d", r, c), Status: task.StatusTodo})
+			require.NoError(t, err)
+
+			// The first step of every child is done
+			for g := 0; g < 2; g++ {
+				step := task.Task{UserID: userID, ParentID: &child.ID, Title: fmt.Sprintf("Step %d.%d.%d", r, c, g), Status: task.StatusTodo}
+				if g == 0 {
+					step.Status = task.StatusDone
+					step.IsCompleted = true
+				}
+				_, err := testRepo.Create(ctx, step)
+				require.NoError(t, err)
+			}
+		}
+	}
+}
+
+// listTreesPerRoot loads every tree the way List used to: one query per root task
+func listTreesPerRoot(t testing.TB, userID int64) []task.Task {
+	roots, err := testRepo.ListRootTasks(ctx, userID)
+	require.NoError(t, err)
+
+	trees := make([]task.Task, len(roots))
+	for i, root := range roots {
+		trees[i], err = testRepo.GetTaskTree(ctx, int64(root.ID))
+		require.NoError(t, err)
+	}
+	return trees
+}
+
+func TestTaskRepository_GetTaskForest(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	setupTestDB(t)
+	defer teardownTestDB()
+
+	userID := createTestUser(t)
+	seedTaskTrees(t, userID, 5)
+
+	forest, err := testRepo.GetTaskForest(ctx, int64(userID))
+	require.NoError(t, err)
+
+	// One query must give exactly what loading each tree on its own gives
+	assert.Equal(t, listTreesPerRoot(t, int64(userID)), forest)
+	require.Len(t, forest, 5)
+	assert.Equal(t, 9, forest[0].TotalCount)
+}
+
+func TestTaskRepository_CreateTree(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	setupTestDB(t)
+	defer teardownTestDB()
+
+	userID := createTestUser(t)
+	node := func(title string, subtasks ...task.Task) task.Task {
+		return task.Task{UserID: userID, Title: title, Status: task.StatusTodo, Priority: task.PriorityMedium, SubTasks: subtasks}
+	}
+
+	t.Run("Creates the whole tree", func(t *testing.T) {
+		created, err := testRepo.CreateTree(ctx, node("Trip", node("Book flights"), node("Pack", node("Passport"))))
+		require.NoError(t, err)
+
+		require.Len(t, created.SubTasks, 2)
+		assert.Equal(t, created.ID, *created.SubTasks[0].ParentID)
+		assert.Equal(t, created.SubTasks[1].ID, *created.SubTasks[1].SubTasks[0].ParentID)
+
+		tree, err := testRepo.GetTaskTree(ctx, int64(created.ID))
+		require.NoError(t, err)
+		assert.Equal(t, 3, tree.TotalCount)
+	})
+
+	t.Run("A failing insert rolls back the tree", func(t *testing.T) {
+		before, err := testRepo.ListRootTasks(ctx, int64(userID))
+		require.NoError(t, err)
+
+		// Titles are limited to 255 characters
+		_, err = testRepo.CreateTree(ctx, node("Move", node("Pack"), node(strings.Repeat("x", 300))))
+		assert.Error(t, err)
+
+		after, err := testRepo.ListRootTasks(ctx, int64(userID))
+		require.NoError(t, err)
+		assert.Len(t, after, len(before))
+	})
+}
+
+func TestTaskRepository_WithTx(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	setupTestDB(t)
+	defer teardownTestDB()
+
+	userID := int64(createTestUser(t))
+	newTask := task.Task{UserID: int32(userID), Title: "In a transaction", Status: task.StatusTodo, Priority: task.PriorityMedium}
+	countRoots := func() int {
+		n, err := testRepo.CountRootTasks(ctx, userID)
+		require.NoError(t, err)
+		return n
+	}
+
+	t.Run("Commits when fn succeeds", func(t *testing.T) {
+		before := countRoots()
+		err := testRepo.WithTx(ctx, func(repo output.TaskRepository) error {
+			_, err := repo.Create(ctx, newTask)
+			return err
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, before+1, countRoots())
+	})
+
+	t.Run("Rolls back every write when fn fails", func(t *testing.T) {
+		before := countRoots()
+		failure := fmt.Errorf("second step failed")
+		err := testRepo.WithTx(ctx, func(repo output.TaskRepository) error {
+			if _, err := repo.Create(ctx, newTask); err != nil {
+				return err
+			}
+			// Nested calls join the transaction, so they are rolled back too
+			if err := repo.WithTx(ctx, func(inner output.TaskRepository) error {
+				_, err := inner.CreateTree(ctx, newTask)
+				return err
+			}); err != nil {
+				return err
+			}
+			return failure
+		})
+
+		assert.ErrorIs(t, err, failure)
+		assert.Equal(t, before, countRoots())
+	})
+}
+
+// BenchmarkTaskTrees compares loading 500 tasks (50 trees of 10) with one query per
+// root task against a single GetTaskForest query
+func BenchmarkTaskTrees(b *testing.B) {
+	setupTestDB(b)
+	defer teardownTestDB()
+
+	userID := createTestUser(b)
+	seedTaskTrees(b, userID, 50)
+
+	b.Run("PerRoot", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			listTreesPerRoot(b, int64(userID))
+		}
+	})
+
+	b.Run("Forest", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := testRepo.GetTaskForest(ctx, int64(userID)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestBuildTaskTree(t *testing.T) {
+	id := func(i int32) *int32 { return &i }
+
+	// Rows arrive in display order, so the root is not necessarily first
+	rows := []task.Task{
+		{ID: 3, ParentID: id(2), Title: "Grandchild"},
+		{ID: 2, ParentID: id(1), Title: "Child"},
+		{ID: 1, Title: "Root"},
+		{ID: 4, ParentID: id(1), Title: "Second child", IsCompleted: true},
+	}
+
+	tree := buildTaskTree(rows, 1)
+	computeTaskMetrics(&tree, time.Now(), 1, false)
+
+	assert.Equal(t, int32(1), tree.ID)
+	require.Len(t, tree.SubTasks, 2)
+	assert.Equal(t, int32(2), tree.SubTasks[0].ID)
+	require.Len(t, tree.SubTasks[0].SubTasks, 1)
+	assert.Equal(t, int32(3), tree.SubTasks[0].SubTasks[0].ID)
+	assert.Equal(t, 3, tree.TotalCount)
+	assert.Equal(t, 1, tree.CompletedCount)
+
+	// A corrupted cycle (2 -> 3 -> 2) must not recurse forever or duplicate tasks
+	cyclic := []task.Task{
+		{ID: 1, Title: "Root"},
+		{ID: 2, ParentID: id(1)},
+		{ID: 3, ParentID: id(2)},
+		{ID: 2, ParentID: id(3)},
+	}
+
+	tree = buildTaskTree(cyclic, 1)
+	computeTaskMetrics(&tree, time.Now(), 1, false)
+	assert.Equal(t, 2, tree.TotalCount)
+}
+
+func TestBuildTaskForest(t *testing.T) {
+	id := func(i int32) *int32 { return &i }
+
+	// Rows of several trees arrive interleaved, in display order
+	rows := []task.Task{
+		{ID: 5, ParentID: id(2), Title: "Second's child"},
+		{ID: 1, Title: "First"},
+		{ID: 3, ParentID: id(1), Title: "First's child"},
+		{ID: 2, Title: "Second"},
+		{ID: 4, ParentID: id(3), Title: "First's grandchild"},
+	}
+
+	forest := buildTaskForest(rows, []int32{2, 1, 9, 2})
+
+	// Roots keep the requested order; missing and repeated roots are left out
+	require.Len(t, forest, 2)
+	assert.Equal(t, int32(2), forest[0].ID)
+	require.Len(t, forest[0].SubTasks, 1)
+	assert.Equal(t, int32(5), forest[0].SubTasks[0].ID)
+
+	assert.Equal(t, int32(1), forest[1].ID)
+	require.Len(t, forest[1].SubTasks, 1)
+	require.Len(t, forest[1].SubTasks[0].SubTasks, 1)
+	assert.Equal(t, int32(4), forest[1].SubTasks[0].SubTasks[0].ID)
+}
+
+func TestBuildTaskForestMatchesBuildTaskTree(t *testing.T) {
+	id := func(i int32) *int32 { return &i }
+
+	rows := []task.Task{
+		{ID: 1, Title: "First"},
+		{ID: 2, Title: "Second"},
+		{ID: 3, ParentID: id(1), IsCompleted: true},
+		{ID: 4, ParentID: id(2)},
+		{ID: 5, ParentID: id(4), IsCompleted: true},
+		{ID: 6, ParentID: id(1)},
+	}
+
+	forest := buildTaskForest(rows, []int32{1, 2})
+	require.Len(t, forest, 2)
+
+	now := time.Now()
+	for i, rootID := range []int32{1, 2} {
+		tree := buildTaskTree(rows, rootID)
+		computeTaskMetrics(&tree, now, 1, false)
+		computeTaskMetrics(&forest[i], now, 1, false)
+		assert.Equal(t, tree, forest[i])
+	}
+}
+
+func TestBuildTaskTreeIgnoresDependencies(t *testing.T) {
+	id := func(i int32) *int32 { return &i }
+
+	// Task 3 depends on its sibling and on a task outside the tree; neither link
+	// may turn it into a subtask or count towards the root's metrics
+	rows := []task.Task{
+		{ID: 1, Title: "Root"},
+		{ID: 2, ParentID: id(1), Title: "Build", IsCompleted: true},
+		{ID: 3, ParentID: id(1), Title: "Deploy", Dependencies: []int32{2, 9}},
+		{ID: 9, Title: "Elsewhere", Dependencies: []int32{1}},
+	}
+
+	tree := buildTaskTree(rows, 1)
+	computeTaskMetrics(&tree, time.Now(), 1, false)
+
+	require.Len(t, tree.SubTasks, 2)
+	assert.Empty(t, tree.SubTasks[0].SubTasks)
+	assert.Empty(t, tree.SubTasks[1].SubTasks)
+	assert.Equal(t, 2, tree.TotalCount)
+	assert.Equal(t, 1, tree.CompletedCount)
+	assert.Equal(t, []int32{2, 9}, tree.SubTasks[1].Dependencies)
+}
+
+func TestComputeTaskMetricsWeightedByPoints(t *testing.T) {
+	id := func(i int32) *int32 { return &i }
+
+	testCases := []struct {
+		name             string
+		rows             []task.Task
+		byPoints         bool
+		expectedProgress float64
+		expectedPhase    float64
+	}{
+		{
+			name: "Equal weighting ignores points",
+			rows: []task.Task{
+				{ID: 1, Title: "Project"},
+				{ID: 2, ParentID: id(1), Points: 8, IsCompleted: true},
+				{ID: 3, ParentID: id(1), Points: 1},
+				{ID: 4, ParentID: id(1), Points: 1},
+				{ID: 5, ParentID: id(4), Points: 2, IsCompleted: true},
+			},
+			expectedProgress: 0.5,
+			expectedPhase:    1,
+		},
+		{
+			name: "Points weight progress across all descendants",
+			rows: []task.Task{
+				{ID: 1, Title: "Project"},
+				{ID: 2, ParentID: id(1), Points: 8, IsCompleted: true},
+				{ID: 3, ParentID: id(1), Points: 1},
+				{ID: 4, ParentID: id(1), Points: 1},
+				{ID: 5, ParentID: id(4), Points: 2, IsCompleted: true},
+			},
+			byPoints:         true,
+			expectedProgress: 10.0 / 12.0,
+			expectedPhase:    1,
+		},
+		{
+			name: "No points falls back to equal weighting",
+			rows: []task.Task{
+				{ID: 1, Title: "Project"},
+				{ID: 2, ParentID: id(1), IsCompleted: true},
+				{ID: 3, ParentID: id(1)},
+				{ID: 4, ParentID: id(1)},
+				{ID: 5, ParentID: id(4), IsCompleted: true},
+			},
+			byPoints:         true,
+			expectedProgress: 0.5,
+			expectedPhase:    1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tree := buildTaskTree(tc.rows, 1)
+			computeTaskMetrics(&tree, time.Now(), 1, tc.byPoints)
+
+			assert.InDelta(t, tc.expectedProgress, tree.Progress, 1e-9)
+			// Counts are never weighted
+			assert.Equal(t, 4, tree.TotalCount)
+			assert.Equal(t, 2, tree.CompletedCount)
+			require.Len(t, tree.SubTasks, 3)
+			assert.InDelta(t, tc.expectedPhase, tree.SubTasks[2].Progress, 1e-9)
+		})
+	}
+}
+
+func TestComputeTaskMetricsOverdueDescendant(t *testing.T) {
+	id := func(i int32) *int32 { return &i }
+	now := time.Date(2025, time.March, 10, 9, 0, 0, 0, time.UTC)
+	yesterday := now.AddDate(0, 0, -1)
+	laterToday := now.Add(3 * time.Hour)
+
+	rows := []task.Task{
+		{ID: 1, Title: "Project"},
+		{ID: 2, ParentID: id(1), Title: "Phase", DueDate: &laterToday},
+		{ID: 3, ParentID: id(2), Title: "Overdue step", DueDate: &yesterday},
+		{ID: 4, ParentID: id(1), Title: "Other phase"},
+		{ID: 5, ParentID: id(4), Title: "Done late", DueDate: &yesterday, IsCompleted: true},
+	}
+
+	tree := buildTaskTree(rows, 1)
+	computeTaskMetrics(&tree, now, 1, false)
+
+	// The overdue grandchild flags its parent and the root
+	assert.True(t, tree.HasOverdueDescendant)
+	assert.True(t, tree.SubTasks[0].HasOverdueDescendant)
+	assert.False(t, tree.SubTasks[0].SubTasks[0].HasOverdueDescendant)
+
+	// Completed overdue tasks and tasks due later today do not count
+	assert.False(t, tree.SubTasks[1].HasOverdueDescendant)
+}
+
+func TestMapDBTaskToDomainCoercesNullEnums(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	repo := &SQLTaskRepository{log: zap.New(core)}
+
+	// A row with NULL status and priority, e.g. from an external insert
+	row := sqlcgen.Task{
+		ID:     42,
+		UserID: 1,
+		Title:  "Imported",
+	}
+
+	mapped := repo.mapDBTaskToDomain(row)
+	assert.Equal(t, task.StatusTodo, mapped.Status)
+	assert.Equal(t, task.PriorityMedium, mapped.Priority)
+
+	require.Equal(t, 2, logs.Len())
+	assert.Equal(t, int32(42), logs.All()[0].ContextMap()["task_id"])
+
+	// Valid values pass through untouched and log nothing
+	row.Status = pgtype.Text{String: string(task.StatusDone), Valid: true}
+	row.Priority = pgtype.Text{String: string(task.PriorityHigh), Valid: true}
+
+	mapped = repo.mapDBTaskToDomain(row)
+	assert.Equal(t, task.StatusDone, mapped.Status)
+	assert.Equal(t, task.PriorityHigh, mapped.Priority)
+	assert.Equal(t, 2, logs.Len())
+}
+
+func TestMapDBTaskToDomainRecurrenceRoundTrip(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	repo := &SQLTaskRepository{log: zap.New(core)}
+
+	rule := &task.Recurrence{Frequency: task.FrequencyMonthly, Interval: 3}
+	row := sqlcgen.Task{
+		ID:         7,
+		UserID:     1,
+		Title:      "Pay rent",
+		Status:     pgtype.Text{String: string(task.StatusTodo), Valid: true},
+		Priority:   pgtype.Text{String: string(task.PriorityHigh), Valid: true},
+		Recurrence: recurrenceToNullText(rule),
+	}
+
+	mapped := repo.mapDBTaskToDomain(row)
+	require.NotNil(t, mapped.Recurrence)
+	assert.Equal(t, *rule, *mapped.Recurrence)
+	assert.Equal(t, row.Recurrence, recurrenceToNullText(mapped.Recurrence))
+
+	// Tasks that do not repeat stay NULL
+	row.Recurrence = recurrenceToNullText(nil)
+	assert.False(t, row.Recurrence.Valid)
+	assert.Nil(t, repo.mapDBTaskToDomain(row).Recurrence)
+
+	// A corrupted rule is dropped with a warning instead of failing the load
+	row.Recurrence = pgtype.Text{String: "fortnightly", Valid: true}
+	assert.Nil(t, repo.mapDBTaskToDomain(row).Recurrence)
+	assert.Equal(t, 1, logs.Len())
+}
+
+func TestMapDBTaskToDomainExternalRef(t *testing.T) {
+	repo := &SQLTaskRepository{log: zap.NewNop()}
+
+	row := sqlcgen.Task{
+		ID:             7,
+		UserID:         1,
+		Title:          "Dentist",
+		Status:         pgtype.Text{String: string(task.StatusTodo), Valid: true},
+		Priority:       pgtype.Text{String: string(task.PriorityMedium), Valid: true},
+		ExternalSource: stringPtrToNullText(stringPtr("google")),
+		ExternalID:     stringPtrToNullText(stringPtr("evt-123")),
+	}
+
+	mapped := repo.mapDBTaskToDomain(row)
+	require.NotNil(t, mapped.ExternalSource)
+	require.NotNil(t, mapped.ExternalID)
+	assert.Equal(t, "google", *mapped.ExternalSource)
+	assert.Equal(t, "evt-123", *mapped.ExternalID)
+
+	// Tasks that are not synced stay NULL
+	assert.False(t, stringPtrToNullText(nil).Valid)
+	row.ExternalSource, row.ExternalID = pgtype.Text{}, pgtype.Text{}
+	mapped = repo.mapDBTaskToDomain(row)
+	assert.Nil(t, mapped.ExternalSource)
+	assert.Nil(t, mapped.ExternalID)
+}
+
+func TestMapDBTaskToDomainArchivedAt(t *testing.T) {
+	repo := &SQLTaskRepository{log: zap.NewNop()}
+	archivedAt := time.Date(2025, 7, 9, 15, 30, 0, 0, time.UTC)
+
+	row := sqlcgen.Task{
+		ID:         7,
+		UserID:     1,
+		Title:      "Old idea",
+		Status:     pgtype.Text{String: string(task.StatusTodo), Valid: true},
+		Priority:   pgtype.Text{String: string(task.PriorityMedium), Valid: true},
+		ArchivedAt: pgtype.Timestamp{Time: archivedAt, Valid: true},
+	}
+
+	mapped := repo.mapDBTaskToDomain(row)
+	require.NotNil(t, mapped.ArchivedAt)
+	assert.True(t, archivedAt.Equal(*mapped.ArchivedAt))
+
+	// Live tasks have no archive timestamp
+	row.ArchivedAt = pgtype.Timestamp{}
+	assert.Nil(t, repo.mapDBTaskToDomain(row).ArchivedAt)
+}
+
+func TestMapDBNoteToDomain(t *testing.T) {
+	createdAt := time.Date(2025, 7, 9, 15, 30, 0, 0, time.UTC)
+
+	note := mapDBNoteToDomain(sqlcgen.TaskNote{
+		ID:        3,
+		TaskID:    7,
+		UserID:    1,
+		Body:      "Called the vendor, waiting for a quote",
+		CreatedAt: pgtype.Timestamp{Time: createdAt, Valid: true},
+	})
+
+	assert.Equal(t, task.TaskNote{
+		ID:        3,
+		TaskID:    7,
+		UserID:    1,
+		Body:      "Called the vendor, waiting for a quote",
+		CreatedAt: createdAt,
+	}, note)
+}
+
+func TestDueDateTimestampRoundTrip(t *testing.T) {
+	local := time.Local
+	time.Local = time.FixedZone("UTC-5", -5*60*60)
+	defer func() { time.Local = local }()
+
+	// A due time keeps its instant, whatever zone it was given in
+	dueAt := time.Date(2025, 3, 10, 23, 30, 0, 0, time.UTC)
+	stored := dueDateToTimestamp(&dueAt)
+	assert.Equal(t, time.Date(2025, 3, 10, 18, 30, 0, 0, time.UTC), stored.Time, "wall clock is stored in local time")
+	read := timestampToDueDate(stored)
+	require.NotNil(t, read)
+	assert.True(t, dueAt.Equal(*read))
+
+	// A date-only due date stays on its day, even when parsed as UTC midnight
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	read = timestampToDueDate(dueDateToTimestamp(&day))
+	require.NotNil(t, read)
+	assert.Equal(t, time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local), *read)
+	assert.False(t, task.HasDueTime(*read))
+
+	// Rows written before due times were kept read back as local midnight
+	read = timestampToDueDate(pgtype.Timestamp{Time: day, Valid: true})
+	require.NotNil(t, read)
+	assert.Equal(t, 10, read.Day())
+
+	assert.False(t, dueDateToTimestamp(nil).Valid)
+	assert.Nil(t, timestampToDueDate(pgtype.Timestamp{}))
+}
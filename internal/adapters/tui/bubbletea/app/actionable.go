@@ -0,0 +1,75 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/messages"
+	"github.com/newbpydev/tusk/internal/core/task"
+)
+
+// toggleActionable turns the actionable view on or off. Turning it on first looks up
+// which tasks are blocked by open dependencies; turning it off reloads the tasks it hid.
+func (m *Model) toggleActionable() tea.Cmd {
+	if m.actionableOnly {
+		m.actionableOnly = false
+		m.blockedTaskIDs = nil
+		m.cursor = 0
+		m.taskListOffset = 0
+		return m.refreshTasks()
+	}
+
+	return func() tea.Msg {
+		blocked, err := m.taskSvc.BlockedTaskIDs(m.ctx, m.userID)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Errorf("failed to load blocked tasks: %v", err))
+		}
+		return messages.ActionableLoadedMsg{BlockedTaskIDs: blocked}
+	}
+}
+
+// showActionable hides every task that is not actionable from the list and timeline
+func (m *Model) showActionable(blocked []int32) {
+	m.actionableOnly = true
+	m.blockedTaskIDs = blockedSet(blocked)
+	m.cursor = 0
+	m.taskListOffset = 0
+	m.initCollapsibleSections()
+	m.initTimelineCollapsibleSections()
+	m.setStatusMessage("Showing only actionable tasks (a to show all)", statusTypeInfo, 2*time.Second)
+}
+
+// isActionable reports whether t stays visible under the actionable view
+func (m *Model) isActionable(t task.Task) bool {
+	return !m.actionableOnly || task.IsActionable(t, m.actionableRules, m.blockedTaskIDs, time.Now())
+}
+
+// activeFilterLabel describes the actionable and today views and the task filter for the header
+func (m *Model) activeFilterLabel() string {
+	var parts []string
+	if m.actionableOnly {
+		hidden := make([]string, len(m.actionableRules))
+		for i, r := range m.actionableRules {
+			hidden[i] = string(r)
+		}
+		parts = append(parts, "actionable (hides "+strings.Join(hidden, ", ")+")")
+	}
+	if m.todayOnly {
+		parts = append(parts, "overdue + today")
+	}
+	if f := m.taskFilter.String(); f != "" {
+		parts = append(parts, f)
+	}
+	return strings.Join(parts, " + ")
+}
+
+// blockedSet turns a list of blocked task IDs into a lookup set
+func blockedSet(ids []int32) map[int32]bool {
+	set := make(map[int32]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
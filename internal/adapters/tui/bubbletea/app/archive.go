@@ -0,0 +1,98 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/messages"
+)
+
+// archiveUndoWindow is how long the status bar offers to restore an archived task
+const archiveUndoWindow = 8 * time.Second
+
+// archiveCurrentTask archives the selected task and its subtasks. Archived tasks
+// are hidden rather than deleted, so the archive can be undone with u.
+func (m *Model) archiveCurrentTask() tea.Cmd {
+	if m.blockedByReadOnly() {
+		return nil
+	}
+	if m.cursorOnHeader || m.cursor < 0 || m.cursor >= len(m.tasks) {
+		return nil
+	}
+
+	curr := m.tasks[m.cursor]
+	return func() tea.Msg {
+		if err := m.taskSvc.Archive(m.ctx, int64(curr.ID)); err != nil {
+			return messages.StatusUpdateErrorMsg{TaskIndex: m.cursor, TaskTitle: curr.Title, Err: err}
+		}
+		return messages.TaskArchivedMsg{TaskID: curr.ID, Title: curr.Title}
+	}
+}
+
+// removeArchivedTask drops an archived task and its subtasks from the list and offers
+// to undo it
+func (m *Model) removeArchivedTask(msg messages.TaskArchivedMsg) tea.Cmd {
+	archived := map[int32]bool{msg.TaskID: true}
+	if idx := m.getTaskIndexByID(msg.TaskID); idx >= 0 {
+		for _, sub := range flattenTaskTrees(m.tasks[idx].SubTasks) {
+			archived[sub.ID] = true
+		}
+	}
+
+	kept := m.tasks[:0]
+	for _, t := range m.tasks {
+		if !archived[t.ID] {
+			kept = append(kept, t)
+		}
+	}
+	m.tasks = kept
+	for id := range archived {
+		m.dropFromListSearch(id)
+	}
+	if m.cursor >= len(m.tasks) {
+		m.cursor = max(0, len(m.tasks)-1)
+	}
+	m.initCollapsibleSections()
+
+	m.pushUndo(undoStep{kind: undoArchive, taskID: msg.TaskID, title: msg.Title})
+	m.setStatusMessage(fmt.Sprintf("Archived '%s' (u to undo)", msg.Title), statusTypeInfo, archiveUndoWindow)
+
+	// The timeline drops the task on the next rebuild
+	return m.scheduleRecategorize()
+}
+
+// restoreArchivedTask brings back an archived task with its subtasks
+func (m *Model) restoreArchivedTask(taskID int32) tea.Cmd {
+	m.setLoadingStatus("Restoring task...")
+
+	return func() tea.Msg {
+		restored, err := m.taskSvc.Restore(m.ctx, int64(taskID))
+		if err != nil {
+			return messages.ErrorMsg(fmt.Errorf("failed to restore task: %v", err))
+		}
+
+		// Reload so the task comes back with its subtasks in its usual place
+		tasks, err := m.taskSvc.List(m.ctx, m.userID)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Errorf("failed to refresh tasks: %v", err))
+		}
+		return messages.TaskRestoredMsg{Task: restored, Tasks: flattenTaskTrees(tasks)}
+	}
+}
+
+// showRestoredTask puts a restored task back in the list and selects it
+func (m *Model) showRestoredTask(msg messages.TaskRestoredMsg) {
+	m.tasks = msg.Tasks
+	m.cancelRecategorize()
+	m.clearLoadingStatus()
+	m.initCollapsibleSections()
+	m.initTimelineCollapsibleSections()
+
+	if i := m.getTaskIndexByID(msg.Task.ID); i >= 0 {
+		m.cursor = i
+		m.cursorOnHeader = false
+		m.repositionCursorAfterSectionChange()
+	}
+	m.setSuccessStatus(fmt.Sprintf("Restored '%s'", msg.Task.Title))
+}
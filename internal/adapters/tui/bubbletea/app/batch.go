@@ -0,0 +1,140 @@
+package app
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/hooks"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/messages"
+	"github.com/newbpydev/tusk/internal/core/task"
+)
+
+// statusToggle is a task status change whose section rebuild is still pending
+type statusToggle struct {
+	id       int32
+	from, to task.Status
+}
+
+// sectionOrder is the order in which categorizeTasks lists the sections in m.tasks
+var sectionOrder = []hooks.SectionType{
+	hooks.SectionTypeRecent,
+	hooks.SectionTypeTodo,
+	hooks.SectionTypeInProgress,
+	hooks.SectionTypeProjects,
+	hooks.SectionTypeCompleted,
+}
+
+// recategorizeDelay is how long task mutations are collected before the
+// sections are rebuilt, so a burst of changes only recategorizes once.
+const recategorizeDelay = 100 * time.Millisecond
+
+// scheduleRecategorize marks the task categories as stale and schedules a
+// single rebuild for the next tick. Calls made while a rebuild is already
+// pending are folded into it.
+func (m *Model) scheduleRecategorize() tea.Cmd {
+	if m.recategorizePending {
+		return nil
+	}
+	m.recategorizePending = true
+	return tea.Tick(recategorizeDelay, func(time.Time) tea.Msg {
+		return messages.RecategorizeMsg{}
+	})
+}
+
+// flushRecategorize rebuilds the list and timeline sections from the current
+// tasks, keeping the cursors on the same task or section header. It does nothing
+// when no rebuild is pending, e.g. because a full refresh already replaced the tasks.
+func (m *Model) flushRecategorize() {
+	if !m.recategorizePending {
+		return
+	}
+	m.recategorizePending = false
+	resetTaskID := m.timelineResetTaskID
+	m.timelineResetTaskID = 0
+
+	// Tasks move between sections, so remember what is selected rather than where
+	var selectedID int32
+	var headerType hooks.SectionType
+	if m.cursorOnHeader {
+		if section := m.collapsibleManager.GetSectionAtIndex(m.visualCursor); section != nil {
+			headerType = section.Type
+		}
+	} else if m.cursor >= 0 && m.cursor < len(m.tasks) {
+		selectedID = m.tasks[m.cursor].ID
+	}
+	originalTimelineCursor := m.timelineCursor
+	originalTimelineCursorOnHeader := m.timelineCursorOnHeader
+
+	// Recategorizes the tasks and updates the section ranges
+	m.replayToggles()
+	m.initCollapsibleSections()
+	m.overdueTasks, m.todayTasks, m.upcomingTasks = m.categorizeTimelineTasks(m.tasks)
+
+	// Fully reinitialize timeline sections to ensure proper cursor mapping
+	m.initTimelineCollapsibleSections()
+
+	if headerType != "" {
+		m.selectSectionHeader(headerType)
+	} else if idx := m.getTaskIndexByID(selectedID); idx >= 0 {
+		m.cursor = idx
+		m.cursorOnHeader = false
+	}
+
+	if m.activePanel == 2 { // Panel 2 is the timeline
+		// An unchecked task moves to a new timeline section, so follow it there
+		if resetTaskID != 0 && m.timelineCursor != 0 {
+			m.resetTimelineCursorForTask(resetTaskID)
+		} else {
+			m.timelineCursor = originalTimelineCursor
+			m.timelineCursorOnHeader = originalTimelineCursorOnHeader
+		}
+	}
+
+	// Tasks may have moved between sections, so resync the visual cursor
+	if !m.cursorOnHeader {
+		m.updateVisualCursorFromTaskCursor()
+	}
+}
+
+// replayToggles moves the tasks toggled since the last rebuild to where a rebuild
+// after every toggle would have put them. A rebuild keeps the list order within
+// each section, so this makes the order after a burst of toggles match toggling
+// the same tasks one by one.
+func (m *Model) replayToggles() {
+	if len(m.pendingToggles) == 0 {
+		return
+	}
+
+	// Each toggle is replayed with the statuses the tasks had at that point
+	statuses := make(map[int32]task.Status, len(m.pendingToggles))
+	for i := len(m.pendingToggles) - 1; i >= 0; i-- {
+		statuses[m.pendingToggles[i].id] = m.pendingToggles[i].from
+	}
+	for _, toggle := range m.pendingToggles {
+		statuses[toggle.id] = toggle.to
+
+		sections := make(map[hooks.SectionType][]task.Task)
+		for _, t := range m.tasks {
+			replayed := t
+			if status, ok := statuses[t.ID]; ok {
+				replayed.Status = status
+			}
+			sectionType := m.taskSectionType(replayed)
+			sections[sectionType] = append(sections[sectionType], t)
+		}
+
+		ordered := make([]task.Task, 0, len(m.tasks))
+		for _, sectionType := range sectionOrder {
+			ordered = append(ordered, sections[sectionType]...)
+		}
+		m.tasks = ordered
+	}
+	m.pendingToggles = nil
+}
+
+// cancelRecategorize drops a pending rebuild after the sections were rebuilt another way
+func (m *Model) cancelRecategorize() {
+	m.recategorizePending = false
+	m.timelineResetTaskID = 0
+	m.pendingToggles = nil
+}
@@ -0,0 +1,103 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/hooks"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/messages"
+	"github.com/newbpydev/tusk/internal/core/task"
+	"github.com/stretchr/testify/assert"
+)
+
+// listState is what the list looks like to the user: tasks in order with their
+// statuses, the section sizes and the selection
+type listState struct {
+	tasks    []string
+	sections map[hooks.SectionType]int
+	selected int32
+	onHeader bool
+}
+
+func currentListState(m *Model) listState {
+	state := listState{sections: map[hooks.SectionType]int{}, onHeader: m.cursorOnHeader}
+	for _, t := range m.tasks {
+		state.tasks = append(state.tasks, t.Title+" "+string(t.Status))
+	}
+	for _, section := range m.collapsibleManager.Sections {
+		state.sections[section.Type] = section.ItemCount
+	}
+	if !m.cursorOnHeader {
+		state.selected = m.tasks[m.cursor].ID
+	}
+	return state
+}
+
+func TestToggleBurstRecategorizesOnce(t *testing.T) {
+	roots := []task.Task{
+		{ID: 1, Title: "Laundry", Status: task.StatusTodo, DisplayOrder: 0},
+		{ID: 2, Title: "Dishes", Status: task.StatusTodo, DisplayOrder: 1},
+		{ID: 3, Title: "Groceries", Status: task.StatusTodo, DisplayOrder: 2},
+		{ID: 4, Title: "Call mom", Status: task.StatusDone, IsCompleted: true, DisplayOrder: 3},
+	}
+
+	testCases := []struct {
+		name    string
+		toggled []int32 // tasks selected and toggled in turn
+	}{
+		{name: "Complete a whole section", toggled: []int32{1, 2, 3}},
+		{name: "Complete and reopen", toggled: []int32{2, 4, 1}},
+		{name: "Toggle the same task back", toggled: []int32{3, 1, 3}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			oneByOne := newTestModel(t, roots)
+			for _, id := range tc.toggled {
+				selectTask(t, oneByOne, id)
+				oneByOne.toggleTaskCompletion()
+				oneByOne.Update(messages.RecategorizeMsg{})
+			}
+
+			burst := newTestModel(t, roots)
+			todo, completed := len(burst.todoTasks), len(burst.completedTasks)
+			for _, id := range tc.toggled {
+				selectTask(t, burst, id)
+				burst.toggleTaskCompletion()
+			}
+
+			// Nothing was recategorized yet and a single rebuild is pending
+			assert.Len(t, burst.todoTasks, todo)
+			assert.Len(t, burst.completedTasks, completed)
+			assert.True(t, burst.recategorizePending)
+			assert.Nil(t, burst.scheduleRecategorize())
+
+			burst.Update(messages.RecategorizeMsg{})
+
+			assert.False(t, burst.recategorizePending)
+			assert.Equal(t, currentListState(oneByOne), currentListState(burst))
+		})
+	}
+}
+
+func TestToggleKeepsSelectionUntilRecategorized(t *testing.T) {
+	roots := []task.Task{
+		{ID: 1, Title: "Laundry", Status: task.StatusTodo, DisplayOrder: 0},
+		{ID: 2, Title: "Dishes", Status: task.StatusTodo, DisplayOrder: 1},
+		{ID: 4, Title: "Call mom", Status: task.StatusDone, IsCompleted: true, DisplayOrder: 2},
+	}
+
+	m := newTestModel(t, roots)
+	selectTask(t, m, 1)
+
+	m.toggleTaskCompletion()
+
+	// The toggled task is already done but stays in place until the rebuild
+	assert.Equal(t, task.StatusDone, m.tasks[0].Status)
+	assert.Equal(t, int32(2), m.tasks[m.cursor].ID)
+
+	m.Update(messages.RecategorizeMsg{})
+
+	assert.Equal(t, int32(2), m.tasks[m.cursor].ID)
+	assert.False(t, m.cursorOnHeader)
+	assert.Equal(t, []int32{2, 1, 4}, []int32{m.tasks[0].ID, m.tasks[1].ID, m.tasks[2].ID})
+}
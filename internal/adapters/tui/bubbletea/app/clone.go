@@ -0,0 +1,42 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/messages"
+)
+
+// cloneTask copies the selected task into a new todo task next to it, along with its
+// subtasks when withSubtasks is set
+func (m *Model) cloneTask(withSubtasks bool) tea.Cmd {
+	if m.blockedByReadOnly() {
+		return nil
+	}
+	if m.cursorOnHeader || m.cursor < 0 || m.cursor >= len(m.tasks) {
+		m.setStatusMessage("Select a task to clone", statusTypeInfo, 2*time.Second)
+		return nil
+	}
+	taskID := int64(m.tasks[m.cursor].ID)
+	m.setLoadingStatus("Cloning task...")
+
+	return func() tea.Msg {
+		clone, err := m.taskSvc.Clone(m.ctx, taskID, withSubtasks)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Errorf("failed to clone task: %v", err))
+		}
+		return messages.TaskClonedMsg{Task: clone}
+	}
+}
+
+// taskCloned reloads the tasks so the clone shows up
+func (m *Model) taskCloned(msg messages.TaskClonedMsg) tea.Cmd {
+	refresh := m.refreshTasks()
+	if n := len(msg.Task.SubTasks); n > 0 {
+		m.setSuccessStatus(fmt.Sprintf("Created %q with %d subtask(s)", msg.Task.Title, n))
+	} else {
+		m.setSuccessStatus(fmt.Sprintf("Created %q", msg.Task.Title))
+	}
+	return refresh
+}
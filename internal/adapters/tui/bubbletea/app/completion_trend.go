@@ -0,0 +1,30 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/newbpydev/tusk/internal/core/task"
+)
+
+// completionTrend compares the number of tasks completed today with yesterday,
+// e.g. "↑2 vs yesterday". It is empty when the trend is hidden or there were no
+// completions on either day.
+func (m *Model) completionTrend() string {
+	if !m.showCompletionTrend {
+		return ""
+	}
+
+	counts := task.CompletionsPerDay(m.tasks, m.currentTime, 2)
+	yesterday, today := counts[0], counts[1]
+
+	switch {
+	case today == 0 && yesterday == 0:
+		return ""
+	case today > yesterday:
+		return fmt.Sprintf("↑%d vs yesterday", today-yesterday)
+	case today < yesterday:
+		return fmt.Sprintf("↓%d vs yesterday", yesterday-today)
+	default:
+		return "= yesterday"
+	}
+}
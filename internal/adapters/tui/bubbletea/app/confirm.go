@@ -0,0 +1,36 @@
+package app
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// askConfirmation shows a yes/no question in the status bar and remembers the
+// action to run when the user answers yes
+func (m *Model) askConfirmation(question string, onYes func() tea.Cmd) {
+	m.confirmPrompt = question
+	m.confirmAction = onYes
+	m.setStatusMessage(question+" (y/n)", statusTypeInfo, 0)
+}
+
+// clearConfirmation drops any pending confirmation and its status message
+func (m *Model) clearConfirmation() {
+	m.confirmPrompt = ""
+	m.confirmAction = nil
+	m.statusMessage = ""
+	m.statusType = ""
+}
+
+// handleConfirmationKeys answers a pending confirmation. Any key other than y
+// counts as "no" so a stray keypress can never trigger the action.
+func (m *Model) handleConfirmationKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	action := m.confirmAction
+	m.clearConfirmation()
+
+	switch msg.String() {
+	case "y", "Y":
+		if action != nil {
+			return m, action()
+		}
+	}
+	return m, nil
+}
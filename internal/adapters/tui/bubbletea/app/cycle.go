@@ -0,0 +1,78 @@
+package app
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/messages"
+	"github.com/newbpydev/tusk/internal/core/task"
+)
+
+// nextCycleStatus returns the status that follows s in the Todo → In Progress → Done cycle
+func nextCycleStatus(s task.Status) task.Status {
+	switch s {
+	case task.StatusTodo:
+		return task.StatusInProgress
+	case task.StatusInProgress:
+		return task.StatusDone
+	default:
+		return task.StatusTodo
+	}
+}
+
+// statusLabel returns the name of the task list section a status belongs to
+func statusLabel(s task.Status) string {
+	switch s {
+	case task.StatusInProgress:
+		return "In Progress"
+	case task.StatusDone:
+		return "Done"
+	default:
+		return "Todo"
+	}
+}
+
+// cycleTaskStatus moves the selected task one step through Todo → In Progress → Done.
+// The cursor follows the task into its new section.
+func (m *Model) cycleTaskStatus() tea.Cmd {
+	if m.blockedByReadOnly() {
+		return nil
+	}
+	if m.cursorOnHeader || m.cursor < 0 || m.cursor >= len(m.tasks) {
+		return nil
+	}
+
+	curr := m.tasks[m.cursor]
+	newStatus := nextCycleStatus(curr.Status)
+
+	// --- Optimistic update ---
+	m.tasks[m.cursor].Status = newStatus
+	m.tasks[m.cursor].IsCompleted = newStatus == task.StatusDone
+	m.initCollapsibleSections()
+
+	for i, t := range m.tasks {
+		if t.ID == curr.ID {
+			m.cursor = i
+			m.cursorOnHeader = false
+			break
+		}
+	}
+	m.repositionCursorAfterSectionChange()
+	m.taskDetailsOffset = 0
+
+	// The timeline is rebuilt on the next tick, once for a whole burst of changes
+	recategorize := m.scheduleRecategorize()
+
+	return tea.Batch(recategorize, func() tea.Msg {
+		updatedTask, err := m.taskSvc.ChangeStatus(m.ctx, int64(curr.ID), newStatus)
+		if err != nil {
+			return messages.StatusUpdateErrorMsg{TaskIndex: m.cursor, TaskTitle: curr.Title, Err: err}
+		}
+
+		return messages.StatusUpdateSuccessMsg{
+			Task:           updatedTask,
+			Message:        fmt.Sprintf("Moved '%s' to %s", curr.Title, statusLabel(newStatus)),
+			PreviousStatus: curr.Status,
+		}
+	})
+}
@@ -0,0 +1,232 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	taskService "github.com/newbpydev/tusk/internal/service/task"
+)
+
+// debugLogKey opens and closes the debug log panel. It is left out of the help on
+// purpose: the panel is a troubleshooting aid, only there when DEBUG_PANEL is set.
+const debugLogKey = "`"
+
+// LogLines gives the debug log panel the most recent log lines, oldest first
+type LogLines interface {
+	Lines() []string
+}
+
+// cacheInspector is implemented by task services keeping a cache, such as the async
+// task service, letting the debug panel list and evict cached entries
+type cacheInspector interface {
+	DebugCacheEntries(verbose bool) []taskService.CacheEntry
+	EvictCacheEntry(e taskService.CacheEntry) bool
+	PruneCache() int
+}
+
+// toggleDebugLog opens the debug log panel scrolled to the newest line, or closes it
+func (m *Model) toggleDebugLog() {
+	m.showDebugLog = !m.showDebugLog
+	m.debugLogScroll = 0
+	m.debugShowCache = false
+	m.debugCacheCursor = 0
+}
+
+// cacheInspector returns the task service's cache, if it keeps one
+func (m *Model) cacheInspector() (cacheInspector, bool) {
+	c, ok := m.taskSvc.(cacheInspector)
+	return c, ok
+}
+
+// handleDebugLogKeys scrolls the read-only debug log panel; every other key is
+// ignored so nothing changes while the log is on screen
+func (m *Model) handleDebugLogKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "tab" {
+		if _, ok := m.cacheInspector(); ok {
+			m.debugShowCache = !m.debugShowCache
+		}
+		return m, nil
+	}
+	if m.debugShowCache {
+		return m.handleDebugCacheKeys(msg)
+	}
+
+	total := len(m.logBuffer.Lines())
+	page := m.debugLogHeight()
+
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "q", debugLogKey:
+		m.toggleDebugLog()
+	case "k", "up":
+		m.debugLogScroll++
+	case "j", "down":
+		m.debugLogScroll--
+	case "pgup", "ctrl+u":
+		m.debugLogScroll += page
+	case "pgdown", "ctrl+d":
+		m.debugLogScroll -= page
+	case "g", "home":
+		m.debugLogScroll = total
+	case "G", "end":
+		m.debugLogScroll = 0
+	}
+
+	m.debugLogScroll = min(max(0, m.debugLogScroll), max(0, total-page))
+	return m, nil
+}
+
+// debugLogHeight is how many log lines fit in the panel
+func (m *Model) debugLogHeight() int {
+	// Borders, title and footer take four lines
+	return max(1, m.height-4)
+}
+
+// renderDebugLog draws the debug log panel over the whole screen. The scroll is
+// counted from the newest line, so new lines keep showing up until the user
+// scrolls back.
+func (m *Model) renderDebugLog() string {
+	if m.debugShowCache {
+		return m.renderDebugCache()
+	}
+
+	lines := m.logBuffer.Lines()
+	height := m.debugLogHeight()
+	width := max(1, m.width-4) // borders and padding
+
+	end := len(lines) - min(m.debugLogScroll, len(lines))
+	start := max(0, end-height)
+
+	var b strings.Builder
+	b.WriteString(m.styles.Title.Render(fmt.Sprintf("Debug Log (%d lines)", len(lines))))
+	b.WriteString("\n")
+	if len(lines) == 0 {
+		b.WriteString(m.styles.Help.Render("Nothing logged yet"))
+	}
+	for i, line := range lines[start:end] {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(truncateDebugLine(line, width))
+	}
+
+	body := lipgloss.NewStyle().Height(height + 1).Render(b.String())
+	help := "read-only · j/k scroll · g/G oldest/newest · esc close"
+	if _, ok := m.cacheInspector(); ok {
+		help = "read-only · j/k scroll · g/G oldest/newest · tab cache · esc close"
+	}
+	footer := m.styles.Help.Render(help)
+
+	return m.styles.ActiveBorder.
+		Width(max(1, m.width-2)).
+		Render(lipgloss.JoinVertical(lipgloss.Left, body, footer))
+}
+
+// handleDebugCacheKeys moves through the cached entries of the task service and
+// evicts them, so stale data can be dropped without restarting
+func (m *Model) handleDebugCacheKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	cache, _ := m.cacheInspector()
+	entries := cache.DebugCacheEntries(false)
+
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "q", debugLogKey:
+		m.toggleDebugLog()
+		return m, nil
+	case "k", "up":
+		m.debugCacheCursor--
+	case "j", "down":
+		m.debugCacheCursor++
+	case "g", "home":
+		m.debugCacheCursor = 0
+	case "G", "end":
+		m.debugCacheCursor = len(entries) - 1
+	case "v":
+		m.debugCacheVerbose = !m.debugCacheVerbose
+	case "x":
+		if m.debugCacheCursor < len(entries) {
+			e := entries[m.debugCacheCursor]
+			if cache.EvictCacheEntry(e) {
+				m.setStatusMessage(fmt.Sprintf("Evicted %s", e.Key), statusTypeInfo, 3*time.Second)
+			}
+			entries = cache.DebugCacheEntries(false)
+		}
+	case "X":
+		pruned := cache.PruneCache()
+		m.setStatusMessage(fmt.Sprintf("Pruned %d expired cache entries", pruned), statusTypeInfo, 3*time.Second)
+		entries = cache.DebugCacheEntries(false)
+	}
+
+	m.debugCacheCursor = min(max(0, m.debugCacheCursor), max(0, len(entries)-1))
+	return m, nil
+}
+
+// renderDebugCache draws the cached entries of the task service with their age.
+// With verbose set, the cached value of the selected entry is shown below it.
+func (m *Model) renderDebugCache() string {
+	cache, _ := m.cacheInspector()
+	entries := cache.DebugCacheEntries(m.debugCacheVerbose)
+	height := m.debugLogHeight()
+	width := max(1, m.width-4) // borders and padding
+
+	var rows []string
+	for i, e := range entries {
+		marker := "  "
+		if i == m.debugCacheCursor {
+			marker = "> "
+		}
+		row := fmt.Sprintf("%s%-20s %-10s %8s", marker, e.Key, e.Summary, e.Age.Round(time.Second))
+		if e.Expired {
+			row += "  expired"
+		}
+		rows = append(rows, truncateDebugLine(row, width))
+
+		if m.debugCacheVerbose && i == m.debugCacheCursor {
+			payload, err := json.Marshal(e.Value)
+			if err != nil {
+				payload = []byte(err.Error())
+			}
+			rows = append(rows, truncateDebugLine("    "+string(payload), width))
+		}
+	}
+
+	// Keep the selected entry on screen; it sits above its payload line
+	start := 0
+	if len(rows) > height {
+		start = min(max(0, m.debugCacheCursor-height/2), len(rows)-height)
+	}
+	end := min(len(rows), start+height)
+
+	var b strings.Builder
+	title := fmt.Sprintf("Async Cache (%d entries)", len(entries))
+	if m.statusMessage != "" {
+		title += " · " + m.statusMessage
+	}
+	b.WriteString(m.styles.Title.Render(title))
+	b.WriteString("\n")
+	if len(entries) == 0 {
+		b.WriteString(m.styles.Help.Render("Nothing cached"))
+	}
+	b.WriteString(strings.Join(rows[start:end], "\n"))
+
+	body := lipgloss.NewStyle().Height(height + 1).Render(b.String())
+	footer := m.styles.Help.Render("j/k select · x evict · X prune expired · v payload · tab log · esc close")
+
+	return m.styles.ActiveBorder.
+		Width(max(1, m.width-2)).
+		Render(lipgloss.JoinVertical(lipgloss.Left, body, footer))
+}
+
+// truncateDebugLine cuts line to width, marking the cut with an ellipsis
+func truncateDebugLine(line string, width int) string {
+	if runes := []rune(line); len(runes) > width {
+		return string(runes[:width-1]) + "…"
+	}
+	return line
+}
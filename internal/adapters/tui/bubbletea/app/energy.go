@@ -0,0 +1,43 @@
+package app
+
+import (
+	"time"
+
+	"github.com/newbpydev/tusk/internal/core/task"
+)
+
+// nextEnergy cycles through the energy levels: unset, low, medium, high and back to unset
+func nextEnergy(e task.Energy) task.Energy {
+	switch e {
+	case "":
+		return task.EnergyLow
+	case task.EnergyLow:
+		return task.EnergyMedium
+	case task.EnergyMedium:
+		return task.EnergyHigh
+	default:
+		return ""
+	}
+}
+
+// cycleEnergyFilter steps the energy filter through low, medium, high and off.
+// Tasks without an energy level stay visible under every energy filter.
+func (m *Model) cycleEnergyFilter() {
+	var current task.Energy
+	if m.taskFilter.Energy != nil {
+		current = *m.taskFilter.Energy
+	}
+
+	next := nextEnergy(current)
+	if next == "" {
+		m.taskFilter.Energy = nil
+		m.setStatusMessage("Showing tasks of any energy", statusTypeInfo, 2*time.Second)
+	} else {
+		m.taskFilter.Energy = &next
+		m.setStatusMessage("Showing "+string(next)+" energy tasks", statusTypeInfo, 2*time.Second)
+	}
+
+	m.cursor = 0
+	m.taskListOffset = 0
+	m.initCollapsibleSections()
+}
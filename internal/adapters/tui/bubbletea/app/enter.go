@@ -0,0 +1,149 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/hooks"
+)
+
+// EnterAction is what pressing enter on a task in the task list does
+type EnterAction string
+
+const (
+	// EnterOpenDetail moves focus to the task details panel (the default)
+	EnterOpenDetail EnterAction = "detail"
+	// EnterToggleComplete toggles the task's completion, like space
+	EnterToggleComplete EnterAction = "toggle"
+	// EnterExpand moves to the task's first subtask, expanding its section if needed
+	EnterExpand EnterAction = "expand"
+)
+
+// ParseEnterAction converts a configuration string into an EnterAction.
+// An empty string selects the default (detail); unknown values return an error.
+func ParseEnterAction(s string) (EnterAction, error) {
+	switch a := EnterAction(strings.ToLower(strings.TrimSpace(s))); a {
+	case EnterOpenDetail, EnterToggleComplete, EnterExpand:
+		return a, nil
+	case "":
+		return EnterOpenDetail, nil
+	default:
+		return EnterOpenDetail, fmt.Errorf("invalid enter action %q (expected detail, toggle or expand)", s)
+	}
+}
+
+// SectionEnter is what pressing enter on a collapsed section header does, in the
+// task list and in the timeline
+type SectionEnter string
+
+const (
+	// SectionEnterExpand only expands the section (the default)
+	SectionEnterExpand SectionEnter = "expand"
+	// SectionEnterMoveIn expands the section and moves the cursor to its first task
+	SectionEnterMoveIn SectionEnter = "expand-enter"
+)
+
+// ParseSectionEnter converts a configuration string into a SectionEnter.
+// An empty string selects the default (expand); unknown values return an error.
+func ParseSectionEnter(s string) (SectionEnter, error) {
+	switch a := SectionEnter(strings.ToLower(strings.TrimSpace(s))); a {
+	case SectionEnterExpand, SectionEnterMoveIn:
+		return a, nil
+	case "":
+		return SectionEnterExpand, nil
+	default:
+		return SectionEnterExpand, fmt.Errorf("invalid section enter action %q (expected expand or expand-enter)", s)
+	}
+}
+
+// handleEnter is the single place that decides what enter does in the task list.
+// On a section header it toggles the section, moving into it when configured to;
+// on a task it runs the configured EnterAction.
+func (m *Model) handleEnter() tea.Cmd {
+	if m.cursorOnHeader {
+		section := m.collapsibleManager.GetSectionAtIndex(m.visualCursor)
+		if section == nil || section.IsExpanded || m.sectionEnter != SectionEnterMoveIn {
+			return m.toggleSection()
+		}
+
+		sectionType := section.Type
+		cmd := m.toggleSection()
+		if first := m.collapsibleManager.GetFirstItemIndex(sectionType); first >= 0 {
+			m.visualCursor = first
+			m.updateTaskCursorFromVisualCursor()
+			m.taskDetailsOffset = 0
+		}
+		return cmd
+	}
+	if m.cursor < 0 || m.cursor >= len(m.tasks) {
+		return nil
+	}
+
+	switch m.enterAction {
+	case EnterToggleComplete:
+		return m.toggleTaskCompletion()
+	case EnterExpand:
+		m.jumpToFirstSubtask()
+		return nil
+	default:
+		if m.showTaskDetails {
+			m.activePanel = 1
+		}
+		return nil
+	}
+}
+
+// jumpToFirstSubtask moves the cursor from the selected task to its first listed
+// subtask, expanding the subtask's section if it is collapsed.
+func (m *Model) jumpToFirstSubtask() {
+	current := m.tasks[m.cursor]
+	if len(current.SubTasks) == 0 {
+		m.setStatusMessage("Task has no subtasks", statusTypeInfo, 2*time.Second)
+		return
+	}
+
+	// Subtasks outside the filter or the active view are not in the list
+	subtaskIndex := -1
+	for _, sub := range current.SubTasks {
+		if subtaskIndex = m.getTaskIndexByID(sub.ID); subtaskIndex >= 0 {
+			break
+		}
+	}
+	if subtaskIndex == -1 {
+		m.setStatusMessage(fmt.Sprintf("Subtasks of '%s' are hidden by the active filter (F to clear)", current.Title), statusTypeInfo, 3*time.Second)
+		return
+	}
+
+	if section := m.collapsibleManager.GetSectionForTaskIndex(subtaskIndex); section != nil && !section.IsExpanded {
+		m.collapsibleManager.ToggleSection(section.Type)
+	}
+
+	m.cursor = subtaskIndex
+	m.cursorOnHeader = false
+	m.repositionCursorAfterSectionChange()
+	m.taskDetailsOffset = 0
+}
+
+// enterTimelineSection handles enter on a timeline section header like handleEnter
+// does in the task list: it toggles the section and, when configured to, moves the
+// cursor to the first task of a section it expanded
+func (m *Model) enterTimelineSection(section *hooks.Section) tea.Cmd {
+	sectionType := section.Type
+	expanding := !section.IsExpanded
+
+	cmd := m.toggleTimelineSection(sectionType)
+	if !expanding || m.sectionEnter != SectionEnterMoveIn {
+		return cmd
+	}
+
+	if first := m.timelineCollapsibleMgr.GetFirstItemIndex(sectionType); first >= 0 {
+		m.timelineCursor = first
+		m.timelineCursorOnHeader = false
+		if m.showTaskDetails {
+			m.taskDetailsOffset = 0
+		}
+	}
+	return cmd
+}
@@ -0,0 +1,33 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnterExpandMovesToFirstSubtask(t *testing.T) {
+	testCases := []struct {
+		name     string
+		selected int32
+		expected int32
+	}{
+		{name: "Root task", selected: 1, expected: 2},
+		{name: "Subtask with subtasks", selected: 2, expected: 3},
+		{name: "Subtask without subtasks stays", selected: 5, expected: 5},
+		{name: "Root task without subtasks stays", selected: 4, expected: 4},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newTestModel(t, testTrees())
+			m.enterAction = EnterExpand
+			selectTask(t, m, tc.selected)
+
+			m.handleEnter()
+
+			assert.Equal(t, tc.expected, m.tasks[m.cursor].ID)
+			assert.False(t, m.cursorOnHeader)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/messages"
+	"github.com/newbpydev/tusk/internal/core/task"
+	"github.com/newbpydev/tusk/internal/export"
+)
+
+// visibleTasks returns the tasks currently shown in the task list, i.e. the tasks
+// of every expanded section in display order.
+func (m *Model) visibleTasks() []task.Task {
+	if m.collapsibleManager == nil {
+		return m.tasks
+	}
+
+	var visible []task.Task
+	for _, section := range m.collapsibleManager.Sections {
+		if !section.IsExpanded {
+			continue
+		}
+		end := min(section.StartIndex+section.ItemCount, len(m.tasks))
+		if section.StartIndex < end {
+			visible = append(visible, m.tasks[section.StartIndex:end]...)
+		}
+	}
+	return visible
+}
+
+// exportVisibleTasks writes the visible task list to a timestamped file in the
+// configured export directory.
+func (m *Model) exportVisibleTasks(format export.Format) tea.Cmd {
+	tasks := append([]task.Task(nil), m.visibleTasks()...)
+	if len(tasks) == 0 {
+		m.setErrorStatus("Nothing to export")
+		return nil
+	}
+
+	dir := m.cfg.ExportDir
+	name := fmt.Sprintf("tusk-export-%s%s", time.Now().Format("20060102-150405"), format.Extension())
+	path := filepath.Join(dir, name)
+
+	m.setLoadingStatus("Exporting tasks...")
+	return func() tea.Msg {
+		f, err := os.Create(path)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Errorf("failed to create export file: %v", err))
+		}
+		defer f.Close()
+
+		if err := export.Write(f, format, tasks); err != nil {
+			return messages.ErrorMsg(fmt.Errorf("failed to export tasks: %v", err))
+		}
+
+		return messages.ExportCompletedMsg{Path: path, Count: len(tasks)}
+	}
+}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/newbpydev/tusk/internal/core/task"
+)
+
+// focusTask puts the cursor on the task with the given ID in whichever section holds
+// it, expanding that section if it is collapsed. A subtask is shown through the root
+// task that contains it. With showDetail the task details panel gets the focus.
+func (m *Model) focusTask(taskID int32, showDetail bool) {
+	idx := m.getTaskIndexByID(taskID)
+
+	var subtask *task.Task
+	if idx < 0 {
+		for i := range m.tasks {
+			if subtask = findSubTask(m.tasks[i].SubTasks, taskID); subtask != nil {
+				idx = i
+				break
+			}
+		}
+	}
+
+	if idx < 0 {
+		message := fmt.Sprintf("Task %d not found", taskID)
+		if !m.taskFilter.IsEmpty() {
+			message += " (it may be hidden by the startup filter)"
+		}
+		m.setStatusMessage(message, statusTypeError, 5*time.Second)
+		return
+	}
+
+	if section := m.collapsibleManager.GetSectionForTaskIndex(idx); section != nil && !section.IsExpanded {
+		m.collapsibleManager.ToggleSection(section.Type)
+	}
+	m.cursor = idx
+	m.cursorOnHeader = false
+	m.repositionCursorAfterSectionChange()
+	m.taskDetailsOffset = 0
+
+	if showDetail && m.showTaskDetails {
+		m.activePanel = 1
+	}
+	if subtask != nil {
+		m.setStatusMessage(fmt.Sprintf("'%s' is a subtask of '%s'", subtask.Title, m.tasks[idx].Title), statusTypeInfo, 5*time.Second)
+	}
+}
+
+// findSubTask searches a subtask tree for the task with the given ID
+func findSubTask(tasks []task.Task, taskID int32) *task.Task {
+	for i := range tasks {
+		if tasks[i].ID == taskID {
+			return &tasks[i]
+		}
+		if found := findSubTask(tasks[i].SubTasks, taskID); found != nil {
+			return found
+		}
+	}
+	return nil
+}
@@ -2,13 +2,22 @@ package app
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/components/input"
 	"github.com/newbpydev/tusk/internal/core/task"
 )
 
+// Form fields are Title, Description, Priority, Due Date, Energy and Tags, followed by the Save button
+const (
+	formTagsField   = 5
+	formSubmitField = 6
+	formFieldCount  = 7
+)
+
 // handleInputField handles text input in a generic string field.
 // It handles runes, backspace, and checks for navigation keys (Esc, Enter, Tab).
 func (m *Model) handleInputField(msg tea.KeyMsg, field *string) (tea.Model, tea.Cmd) {
@@ -52,7 +61,7 @@ func (m *Model) handleDateField(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// This ensures existing code continues to work while we transition to the new system
 	dateInput := m.dateInputHandler.GetInput("dueDate")
 	if dateInput.HasValue {
-		m.formDueDate = dateInput.DateString()
+		m.formDueDate = input.FormatDate(dateInput.Value)
 	} else {
 		m.formDueDate = ""
 	}
@@ -122,7 +131,15 @@ func (m *Model) handleFormInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 	case 3: // Due Date
 		return m.handleDateField(msg)
-	// case 4: // Submit button - No direct input handling needed here
+	case 4: // Energy
+		// Space cycles through unset, low, medium and high
+		if msg.String() == " " {
+			m.formEnergy = string(nextEnergy(task.Energy(m.formEnergy)))
+		}
+		return m, nil // Consume input keys, navigation is handled separately
+	case formTagsField:
+		return m.handleTagsField(msg)
+	// case 6: // Submit button - No direct input handling needed here
 	}
 	return m, nil
 }
@@ -161,7 +178,7 @@ func (m *Model) handleFormNavigationAndSubmit(msg tea.KeyMsg) (tea.Model, tea.Cm
 				dateInput.Mode = 1 // DateModeView
 			}
 		}
-		m.activeField = (m.activeField + 1) % 5 // 5 fields: Title, Desc, Prio, DueDate, Submit
+		m.activeField = (m.activeField + 1) % formFieldCount
 		return m, nil
 	case tea.KeyShiftTab:
 		// Exit date edit mode if we're in it before moving to previous field
@@ -172,10 +189,10 @@ func (m *Model) handleFormNavigationAndSubmit(msg tea.KeyMsg) (tea.Model, tea.Cm
 				dateInput.Mode = 1 // DateModeView
 			}
 		}
-		m.activeField = (m.activeField - 1 + 5) % 5 // Wrap around correctly
+		m.activeField = (m.activeField - 1 + formFieldCount) % formFieldCount // Wrap around correctly
 		return m, nil
 	case tea.KeyEnter:
-		if m.activeField == 4 { // If on the (virtual) submit button
+		if m.activeField == formSubmitField { // If on the (virtual) submit button
 			if m.formTitle == "" {
 				m.err = fmt.Errorf("title is required")
 				m.setErrorStatus("Title is required")
@@ -193,7 +210,7 @@ func (m *Model) handleFormNavigationAndSubmit(msg tea.KeyMsg) (tea.Model, tea.Cm
 			return m, nil
 		} else {
 			// Move to next field on Enter if not on submit or date
-			m.activeField = (m.activeField + 1) % 5
+			m.activeField = (m.activeField + 1) % formFieldCount
 			return m, nil
 		}
 	}
@@ -205,8 +222,10 @@ func (m *Model) resetForm() {
 	m.formTitle = ""
 	m.formDescription = ""
 	m.formPriority = string(task.PriorityLow) // Default to low priority
+	m.formEnergy = ""
 	m.formDueDate = ""
 	m.formStatus = ""
+	m.formTags = ""
 	m.activeField = 0
 	m.err = nil // Clear any previous form errors
 	
@@ -214,6 +233,8 @@ func (m *Model) resetForm() {
 	if m.dateInputHandler != nil {
 		m.dateInputHandler.ResetAllInputs()  
 	}
+
+	m.formBaseline = m.formSignature()
 }
 
 // loadTaskIntoForm loads a task's data into the form fields for editing
@@ -228,12 +249,12 @@ func (m *Model) loadTaskIntoForm(t task.Task) {
 	}
 	
 	m.formPriority = string(t.Priority)
+	m.formEnergy = string(t.Energy)
 	
 	// Load the due date if it exists
 	if t.DueDate != nil && !t.DueDate.IsZero() {
-		// For both form field and input component, always include time for consistency
-		// Format with both date and time
-		m.formDueDate = t.DueDate.Format("2006-01-02 15:04")
+		// Include the time of day only when the task has one
+		m.formDueDate = input.FormatDate(*t.DueDate)
 		
 		// Set the interactive component with the full date/time
 		m.dateInputHandler.SetValue("dueDate", *t.DueDate)
@@ -244,7 +265,55 @@ func (m *Model) loadTaskIntoForm(t task.Task) {
 	}
 	
 	m.formStatus = string(t.Status)
+	m.formTags = task.FormatTagList(t.Tags)
 	m.activeField = 0
+	m.formBaseline = m.formSignature()
+}
+
+// formSignature summarizes the form fields so unsaved edits can be detected
+func (m *Model) formSignature() string {
+	return strings.Join([]string{m.formTitle, m.formDescription, m.formPriority, m.formEnergy, m.formDueDate, m.formStatus, m.formTags}, "\x00")
+}
+
+// isFormDirty reports whether the open form has changes that have not been saved
+func (m *Model) isFormDirty() bool {
+	return m.formSignature() != m.formBaseline
+}
+
+// discardUnsavedPrompt is the question asked before quitting with unsaved form input
+const discardUnsavedPrompt = "Discard unsaved task?"
+
+// quitFromForm quits the application, asking first when the form has unsaved
+// changes and the confirmation is enabled. Pressing ctrl+c again while the
+// question is shown quits without answering it.
+func (m *Model) quitFromForm() tea.Cmd {
+	if !m.confirmQuitUnsaved || !m.isFormDirty() {
+		return tea.Quit
+	}
+
+	m.askConfirmation(discardUnsavedPrompt, func() tea.Cmd {
+		return tea.Quit
+	})
+	return nil
+}
+
+// formDueDateValue returns the due date entered in the form, keeping its time of
+// day, or nil when none was entered
+func (m *Model) formDueDateValue() (*time.Time, error) {
+	if dateInput := m.dateInputHandler.GetInput("dueDate"); dateInput != nil && dateInput.HasValue {
+		dueDate := dateInput.Value
+		return &dueDate, nil
+	}
+	if m.formDueDate == "" {
+		return nil, nil
+	}
+
+	// Fall back to the text of the field, e.g. a date typed but not applied yet
+	dueDate, err := parseDate(m.formDueDate)
+	if err != nil {
+		return nil, err
+	}
+	return &dueDate, nil
 }
 
 // parseFormData creates a task from the form data
@@ -256,23 +325,13 @@ func (m *Model) parseFormData() task.Task {
 		Title:       m.formTitle,
 		Description: &description,
 		Priority:    task.Priority(m.formPriority),
+		Energy:      task.Energy(m.formEnergy),
 		Status:      task.Status(m.formStatus),
 	}
 	
-	// Get the due date from the date input handler
-	dateInput := m.dateInputHandler.GetInput("dueDate")
-	if dateInput != nil && dateInput.HasValue {
-		// Ensure we're using a clean date with proper formatting
-		// This avoids potential special characters that might cause UTF-8 encoding issues
-		formatted := dateInput.Value.Format("2006-01-02 15:04:05")
-		cleanDate, _ := time.Parse("2006-01-02 15:04:05", formatted)
-		t.DueDate = &cleanDate
-	} else if m.formDueDate != "" {
-		// Fallback to parsing from string (backward compatibility)
-		parsedDate, err := parseDate(m.formDueDate)
-		if err == nil {
-			t.DueDate = &parsedDate
-		}
+	// Get the due date, time of day included, from the date input handler
+	if dueDate, err := m.formDueDateValue(); err == nil {
+		t.DueDate = dueDate
 	}
 	
 	return t
@@ -280,19 +339,24 @@ func (m *Model) parseFormData() task.Task {
 
 // updateCurrentTask updates the current task with form data
 func (m *Model) updateCurrentTask() tea.Cmd {
+	if m.blockedByReadOnly() {
+		return nil
+	}
 	// First, ensure we have a valid cursor position
 	if m.cursor < 0 || m.cursor >= len(m.tasks) || m.cursorOnHeader {
 		m.setErrorStatus("No task selected for update")
 		return nil
 	}
 	
-	// Get the current task (we'll just log the ID for now but the API doesn't need it)
-	_ = m.tasks[m.cursor].ID
+	// The energy level is saved separately, so remember what it was
+	taskID := int64(m.tasks[m.cursor].ID)
+	originalEnergy := m.tasks[m.cursor].Energy
 	// Add debug info with timestamp to confirm time package is used
 	m.setStatusMessage(fmt.Sprintf("Updating task created at %s", time.Now().Format(time.RFC3339)), "info", 5*time.Second)
 	
 	// Create updated task data from form
 	updatedTask := m.parseFormData()
+	tags := m.formTagList()
 
 	// Reset form and return to list view
 	m.resetForm()
@@ -308,14 +372,18 @@ func (m *Model) updateCurrentTask() tea.Cmd {
 			description = *updatedTask.Description
 		}
 		priority := updatedTask.Priority
-		// Pass empty tags for now (or extract from task if needed)
-		var tags []string
 
 		// Call the service with individual parameters - the taskID param may vary based on service implementation
-		_, err := m.taskSvc.Update(m.ctx, m.userID, title, description, updatedTask.DueDate, priority, tags)
+		_, err := m.taskSvc.Update(m.ctx, taskID, title, description, updatedTask.DueDate, priority, tags)
 		if err != nil {
 			return fmt.Errorf("failed to update task: %w", err)
 		}
+
+		if updatedTask.Energy != originalEnergy {
+			if _, err := m.taskSvc.SetEnergy(m.ctx, taskID, updatedTask.Energy); err != nil {
+				return fmt.Errorf("failed to update task energy: %w", err)
+			}
+		}
 		
 		return m.refreshTasks()
 	}
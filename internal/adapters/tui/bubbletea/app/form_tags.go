@@ -0,0 +1,62 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/messages"
+	"github.com/newbpydev/tusk/internal/core/task"
+)
+
+// loadKnownTags fetches the tags of the user's tasks so the form can suggest them.
+// They are fetched again every time the form opens, picking up tags added since.
+func (m *Model) loadKnownTags() tea.Cmd {
+	m.knownTags = nil
+	return func() tea.Msg {
+		tags, err := m.taskSvc.GetAllTags(m.ctx, m.userID)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Errorf("failed to load tags: %v", err))
+		}
+		return messages.KnownTagsLoadedMsg{Tags: tags}
+	}
+}
+
+// handleTagsField handles typing in the form's tags field. Tags are separated by
+// commas and may contain spaces; the right arrow accepts the suggested tag.
+func (m *Model) handleTagsField(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeySpace:
+		m.formTags += " "
+		return m, nil
+	case tea.KeyRight:
+		if completed, ok := task.CompleteTagList(m.formTags, m.knownTags); ok {
+			m.formTags = completed
+		}
+		return m, nil
+	}
+	return m.handleInputField(msg, &m.formTags)
+}
+
+// tagCompletion returns the known tag suggested for the tag being typed, or an empty
+// string when the tags field is not focused or nothing matches
+func (m *Model) tagCompletion() string {
+	if m.activeField != formTagsField {
+		return ""
+	}
+	completed, ok := task.CompleteTagList(m.formTags, m.knownTags)
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(completed[strings.LastIndex(completed, ",")+1:])
+}
+
+// formTagList returns the tags entered in the form. It is never nil, so saving a
+// task with the field cleared removes its tags.
+func (m *Model) formTagList() []string {
+	tags := task.ParseTagList(m.formTags)
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
@@ -10,10 +10,64 @@ import (
 	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/hooks"
 	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/keymap"
 	"github.com/newbpydev/tusk/internal/core/task"
+	"github.com/newbpydev/tusk/internal/export"
 )
 
 // handleKeyPress delegates keyboard input based on current view mode and active panel
 func (m *Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// A pending confirmation takes every key until it is answered
+	if m.confirmAction != nil {
+		// A second ctrl+c forces the quit instead of answering the discard question
+		if msg.String() == "ctrl+c" && m.confirmPrompt == discardUnsavedPrompt {
+			return m, tea.Quit
+		}
+		return m.handleConfirmationKeys(msg)
+	}
+
+	// While tasks are loading, esc and ctrl+c abort the refresh instead of their usual action
+	if k := msg.String(); k == "esc" || k == "ctrl+c" {
+		if m.cancelRefresh() {
+			return m, nil
+		}
+	}
+
+	// Forms capture every key so typing can never trigger navigation or global actions
+	if m.viewMode == "create" || m.viewMode == "edit" {
+		if msg.String() == "ctrl+c" {
+			return m, m.quitFromForm()
+		}
+		return m.handleFormKeys(msg)
+	}
+
+	// A timeline search being typed captures every key, like a form
+	if m.timelineSearching {
+		return m.handleTimelineSearchKeys(msg)
+	}
+
+	// So does a task list search
+	if m.listSearching {
+		return m.handleListSearchKeys(msg)
+	}
+
+	// So does a note being typed
+	if m.noteTaskID != 0 {
+		return m.handleNoteKeys(msg)
+	}
+
+	// And a new title for a task
+	if m.renameTaskID != 0 {
+		return m.handleRenameKeys(msg)
+	}
+
+	// The debug log panel is read-only and takes every key while it is open
+	if m.showDebugLog {
+		return m.handleDebugLogKeys(msg)
+	}
+	if m.logBuffer != nil && msg.String() == debugLogKey {
+		m.toggleDebugLog()
+		return m, nil
+	}
+
 	// Global key handlers that work in any mode
 	// Check if this key matches any global key binding
 	if keymap.GlobalKeyMap.HandleKey(msg) {
@@ -30,6 +84,9 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			// Toggle help view
 			m.showFullHelp = !m.showFullHelp
 			return m, nil
+		case "t":
+			m.cycleTheme()
+			return m, nil
 		}
 	}
 
@@ -64,6 +121,13 @@ func (m *Model) handleTaskListPanelKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.initCollapsibleSections()
 	}
 
+	// In priority mode 1/2/3 set priorities; keys it does not consume fall through
+	if m.priorityMode {
+		if cmd, handled := m.handlePriorityModeKeys(msg); handled {
+			return m, cmd
+		}
+	}
+
 	switch msg.String() {
 	case "j", "down":
 		// Handle down navigation through tasks and section headers
@@ -91,6 +155,15 @@ func (m *Model) handleTaskListPanelKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.navigateToBottom()
 		return m, nil
 
+	case "[", "]":
+		// Jump to the previous or next section header
+		direction := 1
+		if msg.String() == "[" {
+			direction = -1
+		}
+		m.navigateToSection(direction)
+		return m, nil
+
 	case "tab", "right", "l":
 		// Move to next panel if available
 		prevPanel := m.activePanel 
@@ -106,16 +179,20 @@ func (m *Model) handleTaskListPanelKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
-	case "enter", "d":
-		// If on a section header, toggle expansion
+	case "enter":
+		// Toggle a section header, or run the configured enter action on a task
+		return m, m.handleEnter()
+
+	case "d":
+		// Archive the selected task; on a section header d still toggles it
 		if m.cursorOnHeader {
 			return m, m.toggleSection()
 		}
-		// If on a task, show details (if available)
-		if m.showTaskDetails && !m.cursorOnHeader {
-			m.activePanel = 1
-		}
-		return m, nil
+		return m, m.archiveCurrentTask()
+
+	case "u":
+		// Take back the last archive or status change
+		return m, m.undoLast()
 
 	case " ":
 		// Toggle task completion status
@@ -124,25 +201,164 @@ func (m *Model) handleTaskListPanelKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case "i":
+		// Move the selected task through Todo, In Progress and Done
+		return m, m.cycleTaskStatus()
+
+	case "p":
+		// Move the selected task through Low, Medium and High priority
+		return m, m.cycleTaskPriority()
+
 	case "n":
 		// Create new task
+		if m.blockedByReadOnly() {
+			return m, nil
+		}
 		m.resetForm()
 		m.viewMode = "create"
 		m.formPriority = string(task.PriorityLow) // Set default priority
-		return m, nil
+		return m, m.loadKnownTags()
 
 	case "e":
 		// Edit task
+		if m.blockedByReadOnly() {
+			return m, nil
+		}
 		if !m.cursorOnHeader && m.cursor < len(m.tasks) {
 			m.viewMode = "edit"
 			// Load current task into form
 			m.loadTaskIntoForm(m.tasks[m.cursor])
-			return m, nil
+			return m, m.loadKnownTags()
+		}
+		return m, nil
+
+	case "H":
+		m.toggleHideCompletedSubtasks()
+		return m, nil
+
+	case "f":
+		// Focus on the cursor's section, or restore all sections
+		m.toggleSectionFocus()
+		return m, nil
+
+	case "F":
+		// Clear every filter and focus at once, back to the full view
+		return m, m.clearAllFilters()
+
+	case "E":
+		// Show only tasks matching the chosen energy level
+		m.cycleEnergyFilter()
+		return m, nil
+
+	case "a":
+		// Show only what can be worked on right now, or everything again
+		return m, m.toggleActionable()
+
+	case "D":
+		// Show only what is overdue or due today, or everything again
+		return m, m.toggleToday()
+
+	case "^":
+		// Pin the selected task to the top of its day in the today view, or unpin it
+		return m, m.toggleDayPin()
+
+	case "s":
+		// Sort each section by the next key: due date, priority, title, created
+		return m, m.cycleListSort()
+
+	case "y":
+		// Copy the selected task into a new one
+		return m, m.cloneTask(false)
+
+	case "Y":
+		// Copy the selected task along with its subtasks
+		return m, m.cloneTask(true)
+
+	case "R":
+		// Type a new title for the selected task right in the list
+		m.startRename()
+		return m, nil
+
+	case "/":
+		// Narrow the list to tasks whose title, description or tags match
+		m.startListSearch()
+		return m, nil
+
+	case "esc":
+		// Drop a kept search and show the full list again
+		if m.listSearchQuery != "" {
+			return m, m.clearListSearch()
 		}
 		return m, nil
 
+	case "N":
+		// Show or hide the Recently Created section
+		m.toggleRecentSection()
+		return m, nil
+
+	case "T":
+		// Defer every open task due today to tomorrow, after confirming
+		return m, m.previewRollover()
+
+	case "C":
+		// Mark every task of the selected section done (todo again for Completed), after confirming
+		return m, m.completeSection()
+
+	case "!":
+		// Enter priority mode, where 1/2/3 set priorities instead of toggling panels
+		m.togglePriorityMode()
+		return m, nil
+
+	case "P":
+		// Jump to the parent of the selected subtask
+		m.jumpToParent()
+		return m, nil
+
+	case "z":
+		// Snooze the selected task using a preset
+		return m, m.openSnoozeMenu()
+
+	case "Z":
+		// Snooze every open task matching the active filter, after confirming
+		return m, m.openFilterSnoozeMenu()
+
+	case "+":
+		// Push the selected task's due date forward by a day or a week
+		return m, m.openQuickSnoozeMenu()
+
+	case "w":
+		// Append a note to the selected task
+		m.startNote()
+		return m, nil
+
+	case "v":
+		// Mark the selected task for an order swap
+		m.toggleSwapMark()
+		return m, nil
+
+	case "S":
+		// Swap the order of the marked task and the selected task
+		return m, m.swapWithMarked()
+
+	case "J":
+		// Move the selected task down in its section
+		return m, m.moveSelected(1)
+
+	case "K":
+		// Move the selected task up in its section
+		return m, m.moveSelected(-1)
+
+	case "x":
+		// Export the visible task list as JSON
+		return m, m.exportVisibleTasks(export.FormatJSON)
+
+	case "X":
+		// Export the visible task list as CSV
+		return m, m.exportVisibleTasks(export.FormatCSV)
+
 	case "r":
-		// Refresh tasks
+		// Refresh tasks; changes may have been made elsewhere, so they can no longer be undone
+		m.clearUndo()
 		m.setLoadingStatus("Refreshing tasks...")
 
 		// Debug date comparison functions with a manufactured test
@@ -199,16 +415,38 @@ func (m *Model) handleTaskDetailsPanelKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 
 	case "e":
 		// Edit current task
+		if m.blockedByReadOnly() {
+			return m, nil
+		}
 		if !m.cursorOnHeader && m.cursor < len(m.tasks) {
 			m.viewMode = "edit"
 			// Load current task into form
 			m.loadTaskIntoForm(m.tasks[m.cursor])
-			return m, nil
+			return m, m.loadKnownTags()
 		}
 		return m, nil
 
+	case "H":
+		m.toggleHideCompletedSubtasks()
+		return m, nil
+
+	case "P":
+		// Jump to the parent of the selected subtask
+		m.jumpToParent()
+		return m, nil
+
+	case "z":
+		// Snooze the selected task using a preset
+		return m, m.openSnoozeMenu()
+
+	case "w":
+		// Append a note to the shown task
+		m.startNote()
+		return m, nil
+
 	case "r":
-		// Refresh tasks
+		// Refresh tasks; changes may have been made elsewhere, so they can no longer be undone
+		m.clearUndo()
 		m.setLoadingStatus("Refreshing tasks...")
 		return m, m.refreshTasks()
 	}
@@ -217,6 +455,16 @@ func (m *Model) handleTaskDetailsPanelKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 	return m.handlePanelVisibilityKeys(msg)
 }
 
+// toggleHideCompletedSubtasks shows or hides completed subtasks in the task details panel
+func (m *Model) toggleHideCompletedSubtasks() {
+	m.hideCompletedSubtasks = !m.hideCompletedSubtasks
+	if m.hideCompletedSubtasks {
+		m.setSuccessStatus("Hiding completed subtasks")
+	} else {
+		m.setSuccessStatus("Showing completed subtasks")
+	}
+}
+
 // handleTimelinePanelKeys processes keyboard input when the timeline panel is active
 func (m *Model) handleTimelinePanelKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Make sure the timeline collapsible manager is initialized
@@ -490,7 +738,7 @@ func (m *Model) handleTimelinePanelKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.timelineCursorOnHeader {
 			section := m.timelineCollapsibleMgr.GetSectionAtIndex(m.timelineCursor)
 			if section != nil {
-				return m, m.toggleTimelineSection(section.Type)
+				return m, m.enterTimelineSection(section)
 			}
 		} else {
 			// If on a task, toggle its completion status
@@ -511,6 +759,36 @@ func (m *Model) handleTimelinePanelKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case "/":
+		// Search the timeline by task title
+		m.startTimelineSearch()
+		return m, nil
+
+	case "n":
+		// Jump to the next task matching the timeline search
+		if m.timelineSearchQuery != "" {
+			m.cycleTimelineMatch(1)
+		}
+		return m, nil
+
+	case "N":
+		// Jump to the previous task matching the timeline search
+		if m.timelineSearchQuery != "" {
+			m.cycleTimelineMatch(-1)
+		}
+		return m, nil
+
+	case "esc":
+		// Drop the timeline search and its highlights
+		if m.timelineSearchQuery != "" {
+			m.clearTimelineSearch()
+		}
+		return m, nil
+
+	case "a":
+		// The actionable view applies to the timeline as well as the task list
+		return m, m.toggleActionable()
+
 	case "c":
 		// Toggle task completion status when 'c' is pressed (similar to Space)
 		if !m.timelineCursorOnHeader {
@@ -519,7 +797,8 @@ func (m *Model) handleTimelinePanelKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case "r":
-		// Refresh tasks
+		// Refresh tasks; changes may have been made elsewhere, so they can no longer be undone
+		m.clearUndo()
 		m.setLoadingStatus("Refreshing tasks...")
 		return m, m.refreshTasks()
 	}
@@ -598,16 +877,20 @@ func (m *Model) handleDetailViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "e":
 		// Edit current task
+		if m.blockedByReadOnly() {
+			return m, nil
+		}
 		if !m.cursorOnHeader && m.cursor < len(m.tasks) {
 			m.viewMode = "edit"
 			// Load current task into form
 			m.loadTaskIntoForm(m.tasks[m.cursor])
-			return m, nil
+			return m, m.loadKnownTags()
 		}
 		return m, nil
 
 	case "r":
-		// Refresh tasks
+		// Refresh tasks; changes may have been made elsewhere, so they can no longer be undone
+		m.clearUndo()
 		m.setLoadingStatus("Refreshing tasks...")
 		return m, m.refreshTasks()
 	}
@@ -689,6 +972,61 @@ func (m *Model) navigateToBottom() {
 	}
 }
 
+// navigateToSection moves the cursor to the next section header (direction 1) or the
+// previous one (direction -1), staying put at the first or last section
+func (m *Model) navigateToSection(direction int) {
+	index := m.collapsibleManager.GetAdjacentHeaderIndex(m.visualCursor, direction)
+	if index < 0 {
+		return
+	}
+	m.visualCursor = index
+	m.cursorOnHeader = true
+}
+
+// jumpToParent moves the cursor from the selected subtask to its parent task,
+// expanding the parent's section if it is collapsed.
+func (m *Model) jumpToParent() {
+	if m.cursorOnHeader || m.cursor < 0 || m.cursor >= len(m.tasks) {
+		return
+	}
+
+	current := m.tasks[m.cursor]
+	if current.ParentID == nil {
+		m.setStatusMessage("Task has no parent", statusTypeInfo, 2*time.Second)
+		return
+	}
+
+	parentIndex := -1
+	for i, t := range m.tasks {
+		if t.ID == *current.ParentID {
+			parentIndex = i
+			break
+		}
+	}
+	if parentIndex == -1 {
+		m.setStatusMessage(fmt.Sprintf("Parent task #%d is not in the task list", *current.ParentID), statusTypeInfo, 2*time.Second)
+		return
+	}
+
+	parent := m.tasks[parentIndex]
+	if !m.taskFilter.Matches(parent) {
+		m.setStatusMessage(fmt.Sprintf("Parent task '%s' is hidden by the active filter (F to clear)", parent.Title), statusTypeInfo, 3*time.Second)
+		return
+	}
+
+	if section := m.collapsibleManager.GetSectionForTaskIndex(parentIndex); section != nil && !section.IsExpanded {
+		m.collapsibleManager.ToggleSection(section.Type)
+	}
+
+	m.cursor = parentIndex
+	m.cursorOnHeader = false
+	m.updateVisualCursorFromTaskCursor()
+	m.taskDetailsOffset = 0
+
+	// Scroll up if the parent is above the visible window
+	m.taskListOffset = min(m.taskListOffset, m.visualCursor)
+}
+
 // toggleSection expands or collapses the section at the current cursor position
 func (m *Model) toggleSection() tea.Cmd {
 	if m.cursorOnHeader {
@@ -698,8 +1036,12 @@ func (m *Model) toggleSection() tea.Cmd {
 			// Get a user-friendly name for the section being toggled
 			var sectionName string
 			switch section.Type {
+			case hooks.SectionTypeRecent:
+				sectionName = "Recently Created"
 			case hooks.SectionTypeTodo:
 				sectionName = "Todo"
+			case hooks.SectionTypeInProgress:
+				sectionName = "In Progress"
 			case hooks.SectionTypeProjects:
 				sectionName = "Projects"
 			case hooks.SectionTypeCompleted:
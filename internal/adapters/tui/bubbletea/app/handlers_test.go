@@ -0,0 +1,83 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/newbpydev/tusk/internal/config"
+	"github.com/newbpydev/tusk/internal/core/task"
+	taskService "github.com/newbpydev/tusk/internal/service/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// listService serves task trees the way the task service's List does, roots with
+// their subtasks nested in SubTasks. Other methods are not expected to be called.
+type listService struct {
+	taskService.Service
+	roots []task.Task
+}
+
+func (s listService) List(ctx context.Context, userID int64) ([]task.Task, error) {
+	return s.roots, nil
+}
+
+// testTrees returns two root tasks, the first with a subtask that has a subtask
+func testTrees() []task.Task {
+	id := func(v int32) *int32 { return &v }
+	compare := task.Task{ID: 3, ParentID: id(2), Title: "Compare prices", Status: task.StatusTodo, DisplayOrder: 0}
+	flights := task.Task{ID: 2, ParentID: id(1), Title: "Book flights", Status: task.StatusTodo, DisplayOrder: 0,
+		SubTasks: []task.Task{compare}}
+	hotel := task.Task{ID: 5, ParentID: id(1), Title: "Book hotel", Status: task.StatusTodo, DisplayOrder: 1}
+	trip := task.Task{ID: 1, Title: "Plan trip", Status: task.StatusTodo, DisplayOrder: 0,
+		SubTasks: []task.Task{flights, hotel}}
+	groceries := task.Task{ID: 4, Title: "Groceries", Status: task.StatusTodo, DisplayOrder: 1}
+	return []task.Task{trip, groceries}
+}
+
+// newTestModel creates a model listing roots as the task service would return them
+func newTestModel(t *testing.T, roots []task.Task) *Model {
+	t.Helper()
+	return NewModel(context.Background(), listService{roots: roots}, 1, WithConfig(&config.Config{}))
+}
+
+// selectTask puts the list cursor on the task with the given ID, expanding its section
+func selectTask(t *testing.T, m *Model, taskID int32) {
+	t.Helper()
+	m.focusTask(taskID, false)
+	require.Equal(t, taskID, m.tasks[m.cursor].ID, "task %d is not in the list", taskID)
+	require.False(t, m.cursorOnHeader)
+}
+
+func TestSubtasksAreListed(t *testing.T) {
+	m := newTestModel(t, testTrees())
+
+	for _, id := range []int32{1, 2, 3, 4, 5} {
+		assert.GreaterOrEqual(t, m.getTaskIndexByID(id), 0, "task %d", id)
+	}
+	assert.Len(t, m.tasks, 5)
+}
+
+func TestJumpToParent(t *testing.T) {
+	testCases := []struct {
+		name     string
+		selected int32
+		expected int32
+	}{
+		{name: "Subtask", selected: 2, expected: 1},
+		{name: "Nested subtask", selected: 3, expected: 2},
+		{name: "Root task stays", selected: 4, expected: 4},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newTestModel(t, testTrees())
+			selectTask(t, m, tc.selected)
+
+			m.jumpToParent()
+
+			assert.Equal(t, tc.expected, m.tasks[m.cursor].ID)
+			assert.False(t, m.cursorOnHeader)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/messages"
+	"github.com/newbpydev/tusk/internal/core/task"
+)
+
+// startListSearch begins typing a task list search. The full list is kept aside so
+// the results can widen again while the query is edited.
+func (m *Model) startListSearch() {
+	if m.listSearchBase == nil {
+		m.listSearchBase = append([]task.Task{}, m.tasks...)
+	}
+	m.listSearching = true
+}
+
+// clearListSearch drops the task list search and reloads the full list
+func (m *Model) clearListSearch() tea.Cmd {
+	m.listSearching = false
+	m.listSearchQuery = ""
+	m.listSearchMatches = nil
+	m.listSearchBase = nil
+	m.cursor = 0
+	m.taskListOffset = 0
+	return m.refreshTasks()
+}
+
+// handleListSearchKeys processes keys while a task list search query is typed.
+// Every change searches titles, descriptions and tags and narrows the list to the
+// matches; enter keeps the query, esc drops it.
+func (m *Model) handleListSearchKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEsc:
+		return m, m.clearListSearch()
+	case tea.KeyEnter:
+		m.listSearching = false
+		if strings.TrimSpace(m.listSearchQuery) == "" {
+			return m, m.clearListSearch()
+		}
+		m.keepListSearchStatus()
+		return m, nil
+	case tea.KeyBackspace:
+		if runes := []rune(m.listSearchQuery); len(runes) > 0 {
+			m.listSearchQuery = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.listSearchQuery += string(msg.Runes)
+	default:
+		return m, nil
+	}
+
+	return m, m.searchList()
+}
+
+// searchList looks up the tasks matching the current query. An empty query shows
+// the whole list again without asking the database.
+func (m *Model) searchList() tea.Cmd {
+	query := m.listSearchQuery
+	if strings.TrimSpace(query) == "" {
+		m.showListSearchResults(nil)
+		return nil
+	}
+
+	return func() tea.Msg {
+		matches, err := m.taskSvc.SearchByText(m.ctx, m.userID, query)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Errorf("failed to search tasks: %v", err))
+		}
+
+		ids := make([]int32, len(matches))
+		for i, t := range matches {
+			ids[i] = t.ID
+		}
+		return messages.ListSearchResultsMsg{Query: query, TaskIDs: ids}
+	}
+}
+
+// applyListSearchResults shows the results of a search unless the query has changed
+// since, so slow responses to earlier keystrokes never overwrite newer ones
+func (m *Model) applyListSearchResults(msg messages.ListSearchResultsMsg) {
+	if m.listSearchBase == nil || msg.Query != m.listSearchQuery {
+		return
+	}
+	m.showListSearchResults(msg.TaskIDs)
+}
+
+// showListSearchResults rebuilds the list from the full task list, keeping the given
+// matches (nil keeps every task). Tasks changed while the search was active replace
+// their copies in the full list first.
+func (m *Model) showListSearchResults(ids []int32) {
+	latest := make(map[int32]task.Task, len(m.tasks))
+	for _, t := range m.tasks {
+		latest[t.ID] = t
+	}
+	for i, t := range m.listSearchBase {
+		if updated, ok := latest[t.ID]; ok {
+			m.listSearchBase[i] = updated
+		}
+	}
+
+	m.listSearchMatches = nil
+	if ids != nil {
+		m.listSearchMatches = make(map[int32]bool, len(ids))
+		for _, id := range ids {
+			m.listSearchMatches[id] = true
+		}
+	}
+
+	m.tasks = append([]task.Task{}, m.listSearchBase...)
+	m.cursor = 0
+	m.taskListOffset = 0
+	m.initCollapsibleSections()
+}
+
+// matchesListSearch reports whether t or one of its subtasks matched the list search.
+// Every task matches while no search results are applied.
+func (m *Model) matchesListSearch(t task.Task) bool {
+	if m.listSearchMatches == nil || m.listSearchMatches[t.ID] {
+		return true
+	}
+	for _, sub := range t.SubTasks {
+		if m.matchesListSearch(sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// dropFromListSearch removes a task that is gone, e.g. archived, from the full list
+// kept for the search
+func (m *Model) dropFromListSearch(taskID int32) {
+	for i, t := range m.listSearchBase {
+		if t.ID == taskID {
+			m.listSearchBase = append(m.listSearchBase[:i], m.listSearchBase[i+1:]...)
+			return
+		}
+	}
+}
+
+// listSearchHeader describes the task list search for the panel header, or returns
+// an empty string when no search is active
+func (m *Model) listSearchHeader() string {
+	if !m.listSearching && m.listSearchQuery == "" {
+		return ""
+	}
+
+	header := "/" + m.listSearchQuery
+	if m.listSearching {
+		header += "_"
+	}
+	if m.listSearchMatches == nil {
+		return header
+	}
+	if len(m.tasks) == 0 {
+		return header + " (no matches)"
+	}
+	return header + fmt.Sprintf(" (%d)", len(m.tasks))
+}
+
+// keepListSearchStatus reminds how to leave a kept search
+func (m *Model) keepListSearchStatus() {
+	m.setStatusMessage("Search kept (esc to clear)", statusTypeInfo, 2*time.Second)
+}
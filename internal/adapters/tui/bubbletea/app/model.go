@@ -5,6 +5,7 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/newbpydev/tusk/internal/config"
 	"github.com/newbpydev/tusk/internal/core/task"
 	taskService "github.com/newbpydev/tusk/internal/service/task"
 
@@ -34,9 +35,15 @@ type Model struct {
 	formTitle       string
 	formDescription string
 	formPriority    string
+	formEnergy      string
 	formDueDate     string
 	formStatus      string
+	formTags        string // comma-separated, see task.ParseTagList
 	activeField     int
+	// Tags of the user's tasks, suggested while typing in the form's tags field
+	knownTags       []string
+	// Snapshot of the form fields when it was opened, used to detect unsaved changes
+	formBaseline    string
 
 	// Panel visibility and focus
 	showTaskList    bool
@@ -65,6 +72,18 @@ type Model struct {
 	statusType    string
 	statusExpiry  time.Time
 	isLoading     bool
+	// Cancels the task refresh in flight so the user can abort a slow load
+	refreshCancel context.CancelFunc
+	// Set while a coalesced section rebuild is scheduled, see scheduleRecategorize
+	recategorizePending bool
+	// Status toggles made while the rebuild is pending, oldest first
+	pendingToggles []statusToggle
+	// Unchecked task the timeline cursor should follow on the next rebuild
+	timelineResetTaskID int32
+	// Section header the list cursor returns to once the next refresh is in
+	headerAfterRefresh hooks.SectionType
+	// Recent changes u takes back, newest last
+	undoStack []undoStep
 
 	// Success message
 	successMsg string
@@ -81,26 +100,133 @@ type Model struct {
 	timelineCursor      int  // Visual cursor position in the timeline
 	timelineCursorOnHeader bool // Whether the timeline cursor is on a section header
 
-	// Add separate slices for todo, projects, and completed tasks
-	todoTasks, projectTasks, completedTasks []task.Task
+	// Timeline search: the title query typed after "/" and whether it is still being typed
+	timelineSearchQuery string
+	timelineSearching   bool
+
+	// Add separate slices for todo, in progress, projects, and completed tasks
+	todoTasks, inProgressTasks, projectTasks, completedTasks []task.Task
+
+	// Recently created tasks, listed in their own section while showRecent is on
+	recentTasks  []task.Task
+	showRecent   bool
+	recentWindow time.Duration
 	
 	// Timeline specific task categories
 	overdueTasks, todayTasks, upcomingTasks []task.Task
 
+	// Upcoming tasks due within this many days get their own color (0 for none)
+	dueSoonHighlightDays int
+
 	// View registry for managing different views
 	viewRegistry ViewRegistry
 	
 	// Date input handler for interactive date fields
 	dateInputHandler *handlers.DateInputHandler
+
+	// User configuration and the settings derived from it
+	cfg              *config.Config
+	parentCompletion taskService.ParentCompletionMode
+	undatedPlacement task.UndatedPlacement
+
+	// Whether completed subtasks are left out of the task details subtask tree
+	hideCompletedSubtasks bool
+
+	// Filter limiting which tasks are shown; the zero value shows everything
+	taskFilter task.TaskFilter
+
+	// Actionable view: when on, tasks hidden by any of the rules are left out,
+	// using the IDs of blocked tasks fetched when the view was turned on
+	actionableOnly  bool
+	actionableRules []task.ActionableRule
+	blockedTaskIDs  map[int32]bool
+
+	// Today view: when on, only open tasks that are overdue or due today are shown,
+	// the tasks pinned to their day first, most recently pinned first
+	todayOnly    bool
+	dayPinnedIDs []int32
+
+	// Sort applied to each section of the task list, see categorizeTasks
+	listSort task.ListSort
+
+	// Task list search: the query typed after "/", whether it is still being typed,
+	// the IDs of the matching tasks (nil while no results apply) and the full task
+	// list the matches are picked from
+	listSearchQuery   string
+	listSearching     bool
+	listSearchMatches map[int32]bool
+	listSearchBase    []task.Task
+
+	// Notes of the tasks shown in the task details panel by task ID, fetched the first
+	// time each task is shown there (nil while the fetch is under way), and the note
+	// being typed with the task it is for (0 while no note is typed)
+	notes      map[int32][]task.TaskNote
+	noteTaskID int32
+	noteDraft  string
+
+	// The title being typed for a task renamed in place (0 while none is renamed)
+	renameTaskID int32
+	renameDraft  string
+
+	// Read-only mode disables every key that would change tasks
+	readOnly bool
+
+	// What enter does on a task in the task list
+	enterAction EnterAction
+
+	// What enter does on a collapsed section header
+	sectionEnter SectionEnter
+
+	// File keeping layout choices between sessions, empty to not keep them
+	uiPrefsPath string
+
+	// Task to put the cursor on at startup (0 for none), optionally showing its details
+	focusTaskID int32
+	focusDetail bool
+
+	// Recent log lines for the debug log panel, nil when the panel is disabled
+	logBuffer      LogLines
+	showDebugLog   bool
+	debugLogScroll int
+
+	// Cache view of the debug panel: shown instead of the log, selected entry, and
+	// whether the cached value of the selected entry is shown
+	debugShowCache    bool
+	debugCacheCursor  int
+	debugCacheVerbose bool
+
+	// Presets offered by the snooze menu, in display order
+	snoozePresets []task.SnoozePreset
+
+	// How often to check for tasks that came due (0 turns reminders off), and which
+	// tasks were reminded of already
+	reminderInterval time.Duration
+	reminders        task.Reminders
+
+	// Whether quitting with unsaved form input asks for confirmation first
+	confirmQuitUnsaved bool
+
+	// Whether the header compares today's completions to yesterday's
+	showCompletionTrend bool
+
+	// Priority mode turns 1/2/3 in the task list into low/medium/high instead of panel toggles
+	priorityMode bool
+
+	// Task marked with v as one side of an order swap; 0 when nothing is marked
+	swapMarkID int32
+
+	// Pending yes/no confirmation shown in the status bar
+	confirmPrompt string
+	confirmAction func() tea.Cmd
 }
 
 // NewModel initializes the bubbletea application model.
-func NewModel(ctx context.Context, svc taskService.Service, userID int64) *Model {
+func NewModel(ctx context.Context, svc taskService.Service, userID int64, opts ...Option) *Model {
 	roots, err := svc.List(ctx, userID)
 
 	m := &Model{
 		ctx:                   ctx,
-		tasks:                 roots,
+		tasks:                 flattenTaskTrees(roots),
 		cursor:                0,
 		err:                   err,
 		taskSvc:               svc,
@@ -118,10 +244,20 @@ func NewModel(ctx context.Context, svc taskService.Service, userID int64) *Model
 		helpModel:             shared.NewHelpModel(),
 	}
 
+	for _, opt := range opts {
+		opt(m)
+	}
+	m.applyConfig()
+	m.loadUIPrefs()
+
 	// Setup initial collapsible sections
 	m.initCollapsibleSections() // Note: initCollapsibleSections will be in sections.go
 	m.initTimelineCollapsibleSections() // Initialize timeline sections
 
+	if m.focusTaskID != 0 {
+		m.focusTask(m.focusTaskID, m.focusDetail)
+	}
+
 	return m
 }
 
@@ -132,27 +268,31 @@ func (m *Model) Init() tea.Cmd {
 	// Register the due date field with the date input handler
 	m.dateInputHandler.RegisterInput("dueDate", "Due Date")
 	
-	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+	tick := tea.Tick(time.Second, func(t time.Time) tea.Msg {
 		return messages.TickMsg(t)
 	})
+	return tea.Batch(tick, m.loadDetailsNotes(), m.checkReminders())
 }
 
 // Apply size changes based on window resize event
 func (m *Model) handleWindowResize(msg tea.WindowSizeMsg) {
 	// Store previous dimensions to detect changes
-	prevHeight := m.height
+	prevWidth, prevHeight := m.width, m.height
 	
-	// Update dimensions
+	// Update dimensions; the next render re-truncates the timeline to the new width
 	m.width = msg.Width
 	m.height = msg.Height
 	
 	// CRITICAL FIX: Ensure cursor visibility after resize
 	// This guarantees that the cursor stays visible when window dimensions change
-	if m.height != prevHeight {
-		// Timeline view specific adjustments - use the numerical panel index (2 for timeline)
-		if m.activePanel == 2 && m.timelineCollapsibleMgr.GetItemCount() > 0 {
-			// Calculate visible height based on new window size
-			visibleHeight := (m.height - 10) / 2
+	if m.width != prevWidth || m.height != prevHeight {
+		// Keep the timeline valid even while another panel is active, it is drawn too
+		if m.timelineCollapsibleMgr != nil && m.timelineCollapsibleMgr.GetItemCount() > 0 {
+			// The cursor may point past the end when the sections changed meanwhile
+			m.timelineCursor = min(max(0, m.timelineCursor), m.timelineCollapsibleMgr.GetItemCount()-1)
+
+			// Calculate visible height based on new window size, at least one row
+			visibleHeight := max(1, (m.height - 10) / 2)
 			
 			// Current cursor position
 			cursorPos := m.timelineCursor
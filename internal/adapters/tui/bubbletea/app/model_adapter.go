@@ -263,6 +263,13 @@ func (a *ModelAdapter) ToggleSection() tea.Cmd {
 	return a.model.toggleSection()
 }
 
+// HandleEnter runs the model's enter handling, so both key paths share one behavior
+func (a *ModelAdapter) HandleEnter() tea.Cmd {
+	cmd := a.model.handleEnter()
+	a.syncStateFromModel()
+	return cmd
+}
+
 // InitCollapsibleSections initializes section data
 func (a *ModelAdapter) InitCollapsibleSections() {
 	a.model.initCollapsibleSections()
@@ -327,7 +334,7 @@ func (a *ModelAdapter) HandleFormSubmit() tea.Cmd {
 		
 		var dueDate *time.Time
 		if a.formComponent.DueDate != "" {
-			parsed, err := time.Parse("2006-01-02", a.formComponent.DueDate)
+			parsed, err := parseDate(a.formComponent.DueDate)
 			if err == nil {
 				dueDate = &parsed
 			}
@@ -355,7 +362,7 @@ func (a *ModelAdapter) HandleFormSubmit() tea.Cmd {
 		// Creating new task
 		var dueDate *time.Time
 		if a.formComponent.DueDate != "" {
-			parsed, err := time.Parse("2006-01-02", a.formComponent.DueDate)
+			parsed, err := parseDate(a.formComponent.DueDate)
 			if err == nil {
 				dueDate = &parsed
 			}
@@ -0,0 +1,94 @@
+package app
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// panelsTop is the first screen row of the panels, below the header drawn by
+// renderMultiPanelView
+const panelsTop = 5
+
+// wheelLines is how many lines one wheel step scrolls the task details panel
+const wheelLines = 3
+
+// handleMouse scrolls the panel under the pointer with the wheel, whichever panel
+// has the focus, and focuses a panel when it is clicked. The task list and the
+// timeline move their cursor like j and k; the task details panel scrolls its text.
+func (m *Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if m.viewMode != "list" || m.showFullHelp || m.showDebugLog || m.confirmAction != nil || m.renameTaskID != 0 {
+		return m, nil
+	}
+	panel, ok := m.panelAt(msg.X, msg.Y)
+	if !ok || msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		return m.scrollPanel(panel, -1)
+	case tea.MouseButtonWheelDown:
+		return m.scrollPanel(panel, 1)
+	case tea.MouseButtonLeft:
+		if panel != m.activePanel {
+			prevPanel := m.activePanel
+			m.activePanel = panel
+			m.resetPanelState(prevPanel, panel)
+		}
+	}
+	return m, nil
+}
+
+// panelAt returns the panel shown at the given screen position, using the same
+// equal-width columns as renderMultiPanelView
+func (m *Model) panelAt(x, y int) (int, bool) {
+	var visible []int
+	if m.showTaskList {
+		visible = append(visible, 0)
+	}
+	if m.showTaskDetails {
+		visible = append(visible, 1)
+	}
+	if m.showTimeline {
+		visible = append(visible, 2)
+	}
+	// The last row holds the help footer
+	if len(visible) == 0 || y < panelsTop || y >= m.height-1 || x < 0 {
+		return 0, false
+	}
+
+	column := x / max(1, m.width/len(visible))
+	if column >= len(visible) {
+		return 0, false
+	}
+	return visible[column], true
+}
+
+// scrollPanel moves the given panel one wheel step down (dir 1) or up (dir -1)
+func (m *Model) scrollPanel(panel, dir int) (tea.Model, tea.Cmd) {
+	switch panel {
+	case 0:
+		if m.collapsibleManager == nil {
+			m.initCollapsibleSections()
+		}
+		if m.collapsibleManager.GetItemCount() == 0 {
+			return m, nil
+		}
+		if dir > 0 {
+			m.navigateDown()
+		} else {
+			m.navigateUp()
+		}
+	case 1:
+		// Same bound as scrolling the details with j and k
+		m.taskDetailsOffset = min(max(m.taskDetailsOffset+dir*wheelLines, 0), 100)
+		return m, nil
+	case 2:
+		key := tea.KeyMsg{Type: tea.KeyDown}
+		if dir < 0 {
+			key = tea.KeyMsg{Type: tea.KeyUp}
+		}
+		m.handleTimelinePanelKeys(key)
+	}
+	// The cursor may have moved to a task whose notes were not fetched yet
+	return m, m.loadDetailsNotes()
+}
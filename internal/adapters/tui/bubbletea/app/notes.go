@@ -0,0 +1,127 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/messages"
+	"github.com/newbpydev/tusk/internal/core/task"
+)
+
+// detailsTaskID returns the ID of the task shown in the task details panel, or 0
+// when a section header is selected
+func (m *Model) detailsTaskID() int32 {
+	if m.activePanel == 2 {
+		if m.timelineCollapsibleMgr == nil {
+			return 0
+		}
+		return m.getTimelineTaskID()
+	}
+	if m.cursorOnHeader || m.cursor < 0 || m.cursor >= len(m.tasks) {
+		return 0
+	}
+	return m.tasks[m.cursor].ID
+}
+
+// loadDetailsNotes fetches the notes of the task shown in the task details panel,
+// unless they were fetched before. Notes only change through addNote, which keeps
+// the loaded ones up to date.
+func (m *Model) loadDetailsNotes() tea.Cmd {
+	taskID := m.detailsTaskID()
+	if !m.showTaskDetails || taskID <= 0 {
+		return nil
+	}
+	if _, loaded := m.notes[taskID]; loaded {
+		return nil
+	}
+	if m.notes == nil {
+		m.notes = make(map[int32][]task.TaskNote)
+	}
+	// Mark the notes as requested so moving back and forth fetches them only once
+	m.notes[taskID] = nil
+
+	return func() tea.Msg {
+		notes, err := m.taskSvc.ListNotes(m.ctx, m.userID, int64(taskID))
+		if err != nil {
+			return messages.ErrorMsg(fmt.Errorf("failed to load notes: %v", err))
+		}
+		return messages.NotesLoadedMsg{TaskID: taskID, Notes: notes}
+	}
+}
+
+// startNote begins typing a note for the task shown in the task details panel
+func (m *Model) startNote() {
+	if m.blockedByReadOnly() {
+		return
+	}
+	taskID := m.detailsTaskID()
+	if taskID <= 0 {
+		m.setStatusMessage("Select a task to add a note", statusTypeInfo, 2*time.Second)
+		return
+	}
+
+	m.showTaskDetails = true
+	m.noteTaskID = taskID
+	m.noteDraft = ""
+}
+
+// handleNoteKeys processes keys while a note is typed; enter saves it, esc drops it
+func (m *Model) handleNoteKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEsc:
+		m.noteTaskID = 0
+		m.noteDraft = ""
+	case tea.KeyEnter:
+		return m, m.addNote()
+	case tea.KeyBackspace:
+		if runes := []rune(m.noteDraft); len(runes) > 0 {
+			m.noteDraft = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		if len([]rune(m.noteDraft)) < task.MaxNoteLength {
+			m.noteDraft += string(msg.Runes)
+		}
+	}
+	return m, nil
+}
+
+// addNote saves the typed note. An empty note is dropped without asking the database.
+func (m *Model) addNote() tea.Cmd {
+	taskID, body := m.noteTaskID, strings.TrimSpace(m.noteDraft)
+	m.noteTaskID = 0
+	m.noteDraft = ""
+	if body == "" {
+		m.setStatusMessage("Empty note discarded", statusTypeInfo, 2*time.Second)
+		return nil
+	}
+	m.setLoadingStatus("Adding note...")
+
+	return func() tea.Msg {
+		note, err := m.taskSvc.AddNote(m.ctx, m.userID, int64(taskID), body)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Errorf("failed to add note: %v", err))
+		}
+		return messages.NoteAddedMsg{Note: note}
+	}
+}
+
+// showAddedNote puts a saved note on top of its task's notes
+func (m *Model) showAddedNote(note task.TaskNote) {
+	if m.notes == nil {
+		m.notes = make(map[int32][]task.TaskNote)
+	}
+	m.notes[note.TaskID] = append([]task.TaskNote{note}, m.notes[note.TaskID]...)
+	m.setSuccessStatus("Note added")
+}
+
+// noteDraftFor returns the note being typed for a task, or nil when none is
+func (m *Model) noteDraftFor(taskID int32) *string {
+	if m.noteTaskID == 0 || m.noteTaskID != taskID {
+		return nil
+	}
+	return &m.noteDraft
+}
@@ -0,0 +1,146 @@
+package app
+
+import (
+	"time"
+
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/components/shared"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/styles"
+	"github.com/newbpydev/tusk/internal/config"
+	"github.com/newbpydev/tusk/internal/core/task"
+	taskService "github.com/newbpydev/tusk/internal/service/task"
+)
+
+// Option configures optional behavior of the TUI model
+type Option func(*Model)
+
+// WithConfig applies the user's configuration to the model
+func WithConfig(cfg *config.Config) Option {
+	return func(m *Model) {
+		if cfg == nil {
+			return
+		}
+		m.cfg = cfg
+	}
+}
+
+// WithReadOnly disables every action that would change tasks, leaving navigation working
+func WithReadOnly(readOnly bool) Option {
+	return func(m *Model) {
+		m.readOnly = readOnly
+	}
+}
+
+// WithUIPrefs keeps layout choices such as expanded sections in the file at path,
+// restoring them on startup; SaveUIPrefs writes them back
+func WithUIPrefs(path string) Option {
+	return func(m *Model) {
+		m.uiPrefsPath = path
+	}
+}
+
+// WithFocusTask starts with the cursor on the given task, expanding its section;
+// with showDetail the task details panel gets the focus as well
+func WithFocusTask(taskID int64, showDetail bool) Option {
+	return func(m *Model) {
+		m.focusTaskID = int32(taskID)
+		m.focusDetail = showDetail
+	}
+}
+
+// WithLogBuffer enables the debug log panel, showing the lines kept by buf
+func WithLogBuffer(buf LogLines) Option {
+	return func(m *Model) {
+		m.logBuffer = buf
+	}
+}
+
+// applyConfig derives the model settings from its configuration
+func (m *Model) applyConfig() {
+	if m.cfg == nil {
+		m.cfg = config.Load()
+	}
+
+	mode, err := taskService.ParseParentCompletionMode(m.cfg.ParentAutoComplete)
+	if err != nil {
+		m.setErrorStatus(err.Error())
+	}
+	m.parentCompletion = mode
+
+	placement, err := task.ParseUndatedPlacement(m.cfg.UndatedTasks)
+	if err != nil {
+		m.setErrorStatus(err.Error())
+	}
+	m.undatedPlacement = placement
+
+	enterAction, err := ParseEnterAction(m.cfg.EnterAction)
+	if err != nil {
+		m.setErrorStatus(err.Error())
+	}
+	m.enterAction = enterAction
+
+	sectionEnter, err := ParseSectionEnter(m.cfg.SectionEnter)
+	if err != nil {
+		m.setErrorStatus(err.Error())
+	}
+	m.sectionEnter = sectionEnter
+
+	rules, err := task.ParseActionableRules(m.cfg.Actionable)
+	if err != nil {
+		m.setErrorStatus("Using default actionable rules: " + err.Error())
+	}
+	m.actionableRules = rules
+
+	m.hideCompletedSubtasks = m.cfg.HideCompletedSubtasks
+	m.confirmQuitUnsaved = m.cfg.ConfirmQuitUnsaved
+	m.showCompletionTrend = m.cfg.ShowCompletionTrend
+	m.recentWindow = time.Duration(max(1, m.cfg.RecentHours)) * time.Hour
+	if m.cfg.Reminders {
+		m.reminderInterval = m.cfg.ReminderInterval
+	}
+	if m.cfg.HighlightDueSoon {
+		m.dueSoonHighlightDays = max(1, m.cfg.DueSoonDays)
+	}
+
+	filter, err := task.ParseTaskFilter(m.cfg.StartupFilter, time.Now())
+	if err != nil {
+		m.setErrorStatus("Ignoring startup filter: " + err.Error())
+	}
+	m.taskFilter = filter
+
+	if err := shared.SetDateLayout(m.cfg.DateFormat); err != nil {
+		m.setErrorStatus("Using default date format: " + err.Error())
+	}
+
+	presets, err := task.ParseSnoozePresets(m.cfg.SnoozePresets)
+	if err != nil {
+		m.setErrorStatus("Using default snooze presets: " + err.Error())
+	}
+	m.snoozePresets = presets
+
+	labels, err := task.ParseStatusLabels(m.cfg.StatusLabels)
+	if err != nil {
+		m.setErrorStatus("Using default status labels: " + err.Error())
+	}
+	shared.SetStatusLabels(labels)
+
+	tagColors, err := task.ParseTagColors(m.cfg.TagColors)
+	if err != nil {
+		m.setErrorStatus("Ignoring tag colors: " + err.Error())
+	}
+	shared.SetTagColors(tagColors)
+
+	theme, err := styles.ThemeByName(m.cfg.Theme)
+	if err != nil {
+		m.setErrorStatus("Using the dark theme: " + err.Error())
+	}
+	m.setTheme(theme)
+
+	if err := shared.SetCarriedFormat(m.cfg.CarriedFormat); err != nil {
+		m.setErrorStatus("Using default carried label: " + err.Error())
+	}
+	loc, err := m.cfg.Location()
+	if err != nil {
+		m.setErrorStatus("Using local time zone: " + err.Error())
+	}
+	shared.SetCarriedLocation(loc)
+}
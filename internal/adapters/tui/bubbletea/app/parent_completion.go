@@ -0,0 +1,69 @@
+package app
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/messages"
+	"github.com/newbpydev/tusk/internal/core/task"
+	taskService "github.com/newbpydev/tusk/internal/service/task"
+)
+
+// checkParentCompletion reacts to a task being completed according to the
+// configured parent completion mode.
+func (m *Model) checkParentCompletion(t task.Task) tea.Cmd {
+	if t.Status != task.StatusDone || t.ParentID == nil {
+		return nil
+	}
+
+	switch m.parentCompletion {
+	case taskService.ParentCompletionAuto:
+		// The service already completed any ready parents, just pick up the changes
+		return m.refreshTasks()
+	case taskService.ParentCompletionPrompt:
+		taskID := int64(t.ID)
+		return func() tea.Msg {
+			parents, err := m.taskSvc.ReadyParents(m.ctx, taskID)
+			if err != nil {
+				return messages.ErrorMsg(fmt.Errorf("failed to check parent tasks: %v", err))
+			}
+			return messages.ParentsReadyMsg{Task: t, Parents: parents}
+		}
+	default:
+		return nil
+	}
+}
+
+// promptParentCompletion asks whether the ready parents of a task should be completed too.
+func (m *Model) promptParentCompletion(msg messages.ParentsReadyMsg) {
+	if len(msg.Parents) == 0 {
+		return
+	}
+
+	question := fmt.Sprintf("All subtasks of '%s' are done. Complete it too?", msg.Parents[0].Title)
+	if len(msg.Parents) > 1 {
+		question = fmt.Sprintf("All subtasks of '%s' are done. Complete it and %d parent task(s) above it?",
+			msg.Parents[0].Title, len(msg.Parents)-1)
+	}
+
+	taskID := int64(msg.Task.ID)
+	m.askConfirmation(question, func() tea.Cmd {
+		return m.completeParents(taskID)
+	})
+}
+
+// completeParents completes the ready parents of a task and refreshes the list.
+func (m *Model) completeParents(taskID int64) tea.Cmd {
+	if m.blockedByReadOnly() {
+		return nil
+	}
+	m.setLoadingStatus("Completing parent tasks...")
+	return func() tea.Msg {
+		parents, err := m.taskSvc.CompleteParents(m.ctx, taskID)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Errorf("failed to complete parent tasks: %v", err))
+		}
+		m.setSuccessStatus(fmt.Sprintf("Completed %d parent task(s)", len(parents)))
+		return m.refreshTasks()()
+	}
+}
@@ -0,0 +1,113 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/messages"
+	"github.com/newbpydev/tusk/internal/core/task"
+)
+
+// priorityKeys maps the number keys of priority mode to the priority they set
+var priorityKeys = map[string]task.Priority{
+	"1": task.PriorityLow,
+	"2": task.PriorityMedium,
+	"3": task.PriorityHigh,
+}
+
+// nextCyclePriority returns the priority that follows p in the Low → Medium → High
+// cycle; an unset or unknown priority starts it at Low
+func nextCyclePriority(p task.Priority) task.Priority {
+	switch p {
+	case task.PriorityLow:
+		return task.PriorityMedium
+	case task.PriorityMedium:
+		return task.PriorityHigh
+	default:
+		return task.PriorityLow
+	}
+}
+
+// cycleTaskPriority moves the selected task one step through Low → Medium → High,
+// wrapping back to Low. It does nothing on a section header.
+func (m *Model) cycleTaskPriority() tea.Cmd {
+	if m.cursorOnHeader || m.cursor < 0 || m.cursor >= len(m.tasks) {
+		return nil
+	}
+	return m.setSelectedPriority(nextCyclePriority(m.tasks[m.cursor].Priority))
+}
+
+// togglePriorityMode enters or leaves priority mode. While it is on, 1/2/3 set the
+// selected task's priority instead of toggling panels, so tasks can be triaged
+// by moving with j/k and pressing a number.
+func (m *Model) togglePriorityMode() {
+	if m.priorityMode {
+		m.exitPriorityMode()
+		return
+	}
+	if m.blockedByReadOnly() {
+		return
+	}
+
+	m.priorityMode = true
+	m.setStatusMessage("Priority mode: 1 low, 2 medium, 3 high (esc to exit)", statusTypeInfo, 0)
+}
+
+// exitPriorityMode leaves priority mode, restoring the panel toggles on 1/2/3
+func (m *Model) exitPriorityMode() {
+	m.priorityMode = false
+	m.setStatusMessage("Left priority mode", statusTypeInfo, time.Second)
+}
+
+// handlePriorityModeKeys processes task list keys while priority mode is on.
+// It reports whether the key was consumed; other keys leave the mode and are
+// handled as usual.
+func (m *Model) handlePriorityModeKeys(msg tea.KeyMsg) (tea.Cmd, bool) {
+	key := msg.String()
+	if priority, ok := priorityKeys[key]; ok {
+		return m.setSelectedPriority(priority), true
+	}
+
+	switch key {
+	case "j", "down", "k", "up", "g", "G":
+		// Navigation keeps the mode on so the next task can be triaged
+		return nil, false
+	case "esc", "!":
+		m.exitPriorityMode()
+		return nil, true
+	}
+
+	m.exitPriorityMode()
+	return nil, false
+}
+
+// setSelectedPriority changes the priority of the selected task, updating the
+// list right away and saving in the background
+func (m *Model) setSelectedPriority(priority task.Priority) tea.Cmd {
+	if m.blockedByReadOnly() {
+		return nil
+	}
+	if m.cursorOnHeader || m.cursor < 0 || m.cursor >= len(m.tasks) {
+		return nil
+	}
+
+	curr := m.tasks[m.cursor]
+	if curr.Priority == priority {
+		return nil
+	}
+	m.tasks[m.cursor].Priority = priority
+	recategorize := m.scheduleRecategorize()
+
+	return tea.Batch(recategorize, func() tea.Msg {
+		updatedTask, err := m.taskSvc.ChangePriority(m.ctx, int64(curr.ID), priority)
+		if err != nil {
+			return messages.StatusUpdateErrorMsg{TaskIndex: m.cursor, TaskTitle: curr.Title, Err: err}
+		}
+
+		return messages.StatusUpdateSuccessMsg{
+			Task:    updatedTask,
+			Message: fmt.Sprintf("Set '%s' to %s priority", curr.Title, priority),
+		}
+	})
+}
@@ -0,0 +1,13 @@
+package app
+
+import "time"
+
+// blockedByReadOnly reports whether a change must be refused because the TUI
+// runs in read-only mode, telling the user why when it is.
+func (m *Model) blockedByReadOnly() bool {
+	if !m.readOnly {
+		return false
+	}
+	m.setStatusMessage("Read-only mode: changes are disabled", statusTypeInfo, 2*time.Second)
+	return true
+}
@@ -0,0 +1,40 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/newbpydev/tusk/internal/core/task"
+)
+
+// isRecentlyCreated reports whether t belongs in the Recently Created section
+func (m *Model) isRecentlyCreated(t task.Task) bool {
+	return m.showRecent && time.Since(t.CreatedAt) < m.recentWindow
+}
+
+// toggleRecentSection shows or hides the Recently Created section at the top of the
+// task list, keeping the cursor on the selected task.
+func (m *Model) toggleRecentSection() {
+	var selectedID int32 = -1
+	if !m.cursorOnHeader && m.cursor >= 0 && m.cursor < len(m.tasks) {
+		selectedID = m.tasks[m.cursor].ID
+	}
+
+	m.showRecent = !m.showRecent
+	if m.showRecent {
+		hours := int(m.recentWindow / time.Hour)
+		m.setStatusMessage(fmt.Sprintf("Showing tasks created in the last %dh", hours), statusTypeInfo, 2*time.Second)
+	} else {
+		m.setStatusMessage("Recently created tasks back in their sections", statusTypeInfo, 2*time.Second)
+	}
+
+	m.initCollapsibleSections()
+	for i, t := range m.tasks {
+		if t.ID == selectedID {
+			m.cursor = i
+			break
+		}
+	}
+	m.updateVisualCursorFromTaskCursor()
+	m.repositionCursorAfterSectionChange()
+}
@@ -0,0 +1,46 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/messages"
+)
+
+// checkReminders looks up the open tasks due today so the ones that came due can be
+// reminded of. It does nothing when reminders are turned off.
+func (m *Model) checkReminders() tea.Cmd {
+	if m.reminderInterval <= 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		tasks, err := m.taskSvc.ListTasksDueToday(m.ctx, m.userID)
+		if err != nil {
+			// Not worth an error in the header; the next check tries again
+			return messages.DueTasksMsg{}
+		}
+		return messages.DueTasksMsg{Tasks: tasks}
+	}
+}
+
+// remindDueTasks shows which tasks came due since the last check, each only once per
+// due date, and schedules the next check
+func (m *Model) remindDueTasks(msg messages.DueTasksMsg) tea.Cmd {
+	now := time.Now()
+	if loc, err := m.cfg.Location(); err == nil {
+		now = now.In(loc)
+	}
+
+	due := m.reminders.Due(msg.Tasks, now)
+	switch {
+	case len(due) == 1:
+		m.setStatusMessage(fmt.Sprintf("Task %q is due now", due[0].Title), statusTypeInfo, 10*time.Second)
+	case len(due) > 1:
+		m.setStatusMessage(fmt.Sprintf("%d tasks are due now, including %q", len(due), due[0].Title), statusTypeInfo, 10*time.Second)
+	}
+
+	return tea.Tick(m.reminderInterval, func(t time.Time) tea.Msg {
+		return messages.ReminderTickMsg(t)
+	})
+}
@@ -0,0 +1,93 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/messages"
+)
+
+// startRename begins typing a new title for the selected task, starting from its
+// current title
+func (m *Model) startRename() {
+	if m.blockedByReadOnly() {
+		return
+	}
+	if m.cursorOnHeader || m.cursor < 0 || m.cursor >= len(m.tasks) {
+		m.setStatusMessage("Select a task to rename", statusTypeInfo, 2*time.Second)
+		return
+	}
+
+	t := m.tasks[m.cursor]
+	m.renameTaskID = t.ID
+	m.renameDraft = t.Title
+}
+
+// handleRenameKeys processes keys while a title is typed; enter saves it, esc keeps
+// the old title
+func (m *Model) handleRenameKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEsc:
+		m.renameTaskID = 0
+		m.renameDraft = ""
+	case tea.KeyEnter:
+		return m, m.saveRename()
+	case tea.KeyBackspace:
+		if runes := []rune(m.renameDraft); len(runes) > 0 {
+			m.renameDraft = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.renameDraft += string(msg.Runes)
+	}
+	return m, nil
+}
+
+// saveRename saves the typed title, leaving every other field of the task as it is.
+// An empty title is refused and stays open for editing; an unchanged one is not saved.
+func (m *Model) saveRename() tea.Cmd {
+	title := strings.TrimSpace(m.renameDraft)
+	if title == "" {
+		m.setErrorStatus("Title cannot be empty")
+		return nil
+	}
+
+	taskID := m.renameTaskID
+	m.renameTaskID = 0
+	m.renameDraft = ""
+
+	idx := m.getTaskIndexByID(taskID)
+	if idx < 0 {
+		m.setErrorStatus("The task to rename is no longer listed")
+		return nil
+	}
+	t := m.tasks[idx]
+	if title == t.Title {
+		return nil
+	}
+
+	description := ""
+	if t.Description != nil {
+		description = *t.Description
+	}
+	m.setLoadingStatus("Renaming task...")
+	return func() tea.Msg {
+		// Nil tags keep the task's tags
+		if _, err := m.taskSvc.Update(m.ctx, int64(taskID), title, description, t.DueDate, t.Priority, nil); err != nil {
+			return messages.ErrorMsg(fmt.Errorf("failed to rename task: %v", err))
+		}
+		m.setSuccessStatus(fmt.Sprintf("Renamed to %q", title))
+		return m.refreshTasks()()
+	}
+}
+
+// titleDraftFor returns the title being typed for a task, or nil when none is
+func (m *Model) titleDraftFor(taskID int32) *string {
+	if m.renameTaskID == 0 || m.renameTaskID != taskID {
+		return nil
+	}
+	return &m.renameDraft
+}
@@ -0,0 +1,53 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/messages"
+)
+
+// previewRollover looks up the open tasks due today so the user can confirm
+// deferring them to tomorrow
+func (m *Model) previewRollover() tea.Cmd {
+	if m.blockedByReadOnly() {
+		return nil
+	}
+	return func() tea.Msg {
+		tasks, err := m.taskSvc.RolloverTodayPreview(m.ctx, m.userID)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Errorf("failed to list today's tasks: %v", err))
+		}
+		return messages.RolloverPreviewMsg{Tasks: tasks}
+	}
+}
+
+// promptRollover asks whether today's open tasks should move to tomorrow
+func (m *Model) promptRollover(msg messages.RolloverPreviewMsg) {
+	if len(msg.Tasks) == 0 {
+		m.setStatusMessage("No open tasks due today", statusTypeInfo, 2*time.Second)
+		return
+	}
+
+	question := fmt.Sprintf("Move %d task(s) due today to tomorrow?", len(msg.Tasks))
+	m.askConfirmation(question, func() tea.Cmd {
+		return m.rolloverToday()
+	})
+}
+
+// rolloverToday moves today's open tasks to tomorrow and refreshes the list
+func (m *Model) rolloverToday() tea.Cmd {
+	if m.blockedByReadOnly() {
+		return nil
+	}
+	m.setLoadingStatus("Deferring today's tasks...")
+	return func() tea.Msg {
+		count, err := m.taskSvc.RolloverToday(m.ctx, m.userID)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Errorf("failed to defer today's tasks: %v", err))
+		}
+		m.setSuccessStatus(fmt.Sprintf("Moved %d task(s) to tomorrow", count))
+		return m.refreshTasks()()
+	}
+}
@@ -0,0 +1,97 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/hooks"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/messages"
+	"github.com/newbpydev/tusk/internal/core/task"
+)
+
+// completeSection marks every task of the section under the cursor as done, or as
+// todo again for the Completed section, in a single bulk update after confirming
+func (m *Model) completeSection() tea.Cmd {
+	if m.blockedByReadOnly() {
+		return nil
+	}
+	if !m.cursorOnHeader {
+		m.setStatusMessage("Select a section header to complete its tasks", statusTypeInfo, 2*time.Second)
+		return nil
+	}
+	section := m.collapsibleManager.GetSectionAtIndex(m.visualCursor)
+	if section == nil {
+		return nil
+	}
+
+	status := task.StatusDone
+	if section.Type == hooks.SectionTypeCompleted {
+		status = task.StatusTodo
+	}
+
+	// The sections hold consecutive ranges of m.tasks, see categorizeTasks
+	end := min(section.StartIndex+section.ItemCount, len(m.tasks))
+	var ids []int32
+	for _, t := range m.tasks[min(section.StartIndex, end):end] {
+		if t.Status != status {
+			ids = append(ids, t.ID)
+		}
+	}
+	if len(ids) == 0 {
+		m.setStatusMessage(fmt.Sprintf("Nothing to mark as %s in %s", statusLabel(status), section.Title), statusTypeInfo, 2*time.Second)
+		return nil
+	}
+
+	sectionType, title := section.Type, section.Title
+	question := fmt.Sprintf("Mark %d task(s) in %s as %s?", len(ids), title, statusLabel(status))
+	m.askConfirmation(question, func() tea.Cmd {
+		return m.setSectionStatus(sectionType, title, ids, status)
+	})
+	return nil
+}
+
+// setSectionStatus sets the status of the given tasks of a section in one call
+func (m *Model) setSectionStatus(sectionType hooks.SectionType, title string, ids []int32, status task.Status) tea.Cmd {
+	if m.blockedByReadOnly() {
+		return nil
+	}
+	m.setLoadingStatus(fmt.Sprintf("Updating %s...", strings.ToLower(title)))
+
+	return func() tea.Msg {
+		if err := m.taskSvc.BulkUpdateStatus(m.ctx, ids, status); err != nil {
+			return messages.ErrorMsg(fmt.Errorf("failed to update %s: %v", strings.ToLower(title), err))
+		}
+		return messages.SectionStatusChangedMsg{
+			Section: string(sectionType),
+			Message: fmt.Sprintf("Marked %d task(s) in %s as %s", len(ids), title, statusLabel(status)),
+		}
+	}
+}
+
+// sectionStatusChanged reloads the tasks after a section was updated, putting the
+// cursor back on the section's header once they are in
+func (m *Model) sectionStatusChanged(msg messages.SectionStatusChangedMsg) tea.Cmd {
+	m.headerAfterRefresh = hooks.SectionType(msg.Section)
+	refresh := m.refreshTasks()
+	m.setSuccessStatus(msg.Message)
+	return refresh
+}
+
+// restoreHeaderAfterRefresh moves the cursor to the header remembered by
+// sectionStatusChanged, if any, once the sections were rebuilt
+func (m *Model) restoreHeaderAfterRefresh() {
+	if m.headerAfterRefresh == "" {
+		return
+	}
+	idx := m.collapsibleManager.GetSectionHeaderIndex(m.headerAfterRefresh)
+	m.headerAfterRefresh = ""
+	if idx < 0 {
+		return
+	}
+
+	m.visualCursor = idx
+	m.cursorOnHeader = true
+	m.taskListOffset = min(m.taskListOffset, m.visualCursor)
+}
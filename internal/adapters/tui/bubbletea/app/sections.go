@@ -1,7 +1,11 @@
 package app
 
 import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/hooks"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/messages"
 	"github.com/newbpydev/tusk/internal/core/task"
 )
 
@@ -16,30 +20,54 @@ func (m *Model) initCollapsibleSections() {
 
 	// Update collapsible sections with latest counts
 	m.collapsibleManager.ClearSections()
-	m.collapsibleManager.AddSection(hooks.SectionTypeTodo, "Todo", len(m.todoTasks), 0)
+	start := 0
+	if m.showRecent {
+		m.collapsibleManager.AddSection(hooks.SectionTypeRecent, "Recently Created", len(m.recentTasks), 0)
+		start = len(m.recentTasks)
+	}
+	m.collapsibleManager.AddSection(hooks.SectionTypeTodo, "Todo", len(m.todoTasks), start)
+	start += len(m.todoTasks)
+	m.collapsibleManager.AddSection(hooks.SectionTypeInProgress, "In Progress", len(m.inProgressTasks), start)
+	start += len(m.inProgressTasks)
 	// Projects section might need different logic if it represents nested tasks/folders
-	m.collapsibleManager.AddSection(hooks.SectionTypeProjects, "Projects", len(m.projectTasks), len(m.todoTasks))
-	m.collapsibleManager.AddSection(hooks.SectionTypeCompleted, "Completed", len(m.completedTasks), len(m.todoTasks)+len(m.projectTasks))
+	m.collapsibleManager.AddSection(hooks.SectionTypeProjects, "Projects", len(m.projectTasks), start)
+	start += len(m.projectTasks)
+	m.collapsibleManager.AddSection(hooks.SectionTypeCompleted, "Completed", len(m.completedTasks), start)
 
 	// Reset visual cursor based on the current task cursor, accounting for sections
 	m.updateVisualCursorFromTaskCursor()
 }
 
-// categorizeTasks separates the main task list into Todo, Projects, and Completed slices.
+// categorizeTasks separates the main task list into Todo, In Progress, Projects, and
+// Completed slices.
+// While the Recently Created section is shown, new tasks are listed only there, so
+// each task keeps a single position in the list. Each section is then sorted by
+// the list sort chosen with s.
 // This is used by initCollapsibleSections and potentially the View logic.
 func (m *Model) categorizeTasks(tasks []task.Task) {
 	// Clear existing categorized slices
+	m.recentTasks = m.recentTasks[:0]
 	m.todoTasks = m.todoTasks[:0]
+	m.inProgressTasks = m.inProgressTasks[:0]
 	m.projectTasks = m.projectTasks[:0]
 	m.completedTasks = m.completedTasks[:0]
 
 	// Iterate through the main tasks list and append to appropriate slices
 	for _, t := range tasks {
+		// Tasks outside the active filter, the actionable or today view or the search are dropped from every section
+		if !m.taskFilter.Matches(t) || !m.isActionable(t) || !m.isInToday(t) || !m.matchesListSearch(t) {
+			continue
+		}
+
 		// Make a copy of the task to avoid pointer issues
 		taskCopy := t
 
-		if t.Status == task.StatusDone {
+		if m.isRecentlyCreated(t) {
+			m.recentTasks = append(m.recentTasks, taskCopy)
+		} else if t.Status == task.StatusDone {
 			m.completedTasks = append(m.completedTasks, taskCopy)
+		} else if t.Status == task.StatusInProgress {
+			m.inProgressTasks = append(m.inProgressTasks, taskCopy)
 		} else if t.ParentID != nil {
 			// Assuming tasks with a ParentID belong to the "Projects" category for now
 			// This might need refinement based on how projects are structured
@@ -50,14 +78,110 @@ func (m *Model) categorizeTasks(tasks []task.Task) {
 		}
 	}
 
+	// Sections are sorted on their own, so every task stays in its section; the today
+	// view then lifts pinned tasks to the top of theirs
+	for _, section := range [][]task.Task{m.recentTasks, m.todoTasks, m.inProgressTasks, m.projectTasks, m.completedTasks} {
+		task.SortTasks(section, m.listSort)
+		if m.todayOnly {
+			task.PinFirst(section, m.dayPinnedIDs)
+		}
+	}
+
 	// Ensure the main tasks slice contains the same tasks in the same order for consistency
 	// This approach ensures we don't lose any tasks while maintaining categorization
 	m.tasks = m.tasks[:0]
+	m.tasks = append(m.tasks, m.recentTasks...)
 	m.tasks = append(m.tasks, m.todoTasks...)
+	m.tasks = append(m.tasks, m.inProgressTasks...)
 	m.tasks = append(m.tasks, m.projectTasks...)
 	m.tasks = append(m.tasks, m.completedTasks...)
 }
 
+// clearAllFilters resets the task list to the default full view in one step: it drops
+// the task filter (energy included), the actionable and today views and the search, restores a focused section
+// and moves the cursor and scroll offsets back to the top. Filtered-out tasks are not
+// kept in memory, so the list is reloaded to show them again.
+func (m *Model) clearAllFilters() tea.Cmd {
+	if m.taskFilter.IsEmpty() && !m.actionableOnly && !m.todayOnly && m.listSearchBase == nil && !m.collapsibleManager.IsFocused() {
+		m.setStatusMessage("No filters active", statusTypeInfo, 2*time.Second)
+		return nil
+	}
+
+	m.taskFilter = task.TaskFilter{}
+	m.actionableOnly = false
+	m.blockedTaskIDs = nil
+	m.todayOnly = false
+	m.dayPinnedIDs = nil
+	m.listSearching = false
+	m.listSearchQuery = ""
+	m.listSearchMatches = nil
+	m.listSearchBase = nil
+	m.collapsibleManager.RestoreFocus()
+	m.cursor = 0
+	m.taskListOffset = 0
+	m.taskDetailsOffset = 0
+
+	// The cursor is put on the first row once the reloaded sections are built
+	return tea.Sequence(m.refreshTasks(), func() tea.Msg {
+		return messages.FiltersClearedMsg{}
+	})
+}
+
+// toggleSectionFocus collapses every section except the one under the cursor, or
+// restores the previous expansion state when a section is already focused.
+func (m *Model) toggleSectionFocus() {
+	if m.collapsibleManager.RestoreFocus() {
+		m.repositionCursorAfterSectionChange()
+		m.setStatusMessage("Restored all sections", statusTypeInfo, time.Second)
+		return
+	}
+
+	var section *hooks.Section
+	if m.cursorOnHeader {
+		section = m.collapsibleManager.GetSectionAtIndex(m.visualCursor)
+	} else {
+		section = m.collapsibleManager.GetSectionForTaskIndex(m.cursor)
+	}
+	if section == nil {
+		return
+	}
+
+	title := section.Title
+	m.collapsibleManager.FocusSection(section.Type)
+	m.repositionCursorAfterSectionChange()
+	m.setStatusMessage("Focused on "+title+" (f to restore)", statusTypeInfo, 2*time.Second)
+}
+
+// repositionCursorAfterSectionChange keeps the cursor on the same task or header
+// after sections were expanded or collapsed, falling back to the header of the
+// task's section when the task is no longer visible.
+func (m *Model) repositionCursorAfterSectionChange() {
+	var section *hooks.Section
+	if m.cursorOnHeader {
+		section = m.collapsibleManager.GetSectionAtIndex(m.visualCursor)
+	} else {
+		section = m.collapsibleManager.GetSectionForTaskIndex(m.cursor)
+	}
+
+	idx := -1
+	if !m.cursorOnHeader {
+		idx = m.collapsibleManager.GetVisibleIndexFromTaskIndex(m.cursor)
+	}
+
+	if idx >= 0 {
+		m.visualCursor = idx
+	} else if section != nil {
+		m.visualCursor = max(0, m.collapsibleManager.GetSectionHeaderIndex(section.Type))
+		m.cursorOnHeader = true
+	} else {
+		m.visualCursor = 0
+		m.cursorOnHeader = m.collapsibleManager.IsSectionHeader(0)
+	}
+
+	// Scroll up if the cursor moved above the visible window
+	m.taskListOffset = min(m.taskListOffset, m.visualCursor)
+}
+
 // updateVisualCursorFromTaskCursor translates the internal task index (m.cursor)
 // into the visible cursor position (m.visualCursor) considering collapsed sections.
 func (m *Model) updateVisualCursorFromTaskCursor() {
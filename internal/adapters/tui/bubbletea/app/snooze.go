@@ -0,0 +1,133 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/components/shared"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/messages"
+	"github.com/newbpydev/tusk/internal/core/task"
+)
+
+// openSnoozeMenu shows the snooze presets for the selected task
+func (m *Model) openSnoozeMenu() tea.Cmd {
+	if m.blockedByReadOnly() {
+		return nil
+	}
+	if m.cursorOnHeader || m.cursor < 0 || m.cursor >= len(m.tasks) {
+		return nil
+	}
+
+	t := m.tasks[m.cursor]
+	return shared.ShowSnoozeMenu(t.ID, t.Title, m.snoozePresets, time.Now())
+}
+
+// openQuickSnoozeMenu offers to push the selected task's due date forward by a day
+// or a week
+func (m *Model) openQuickSnoozeMenu() tea.Cmd {
+	if m.blockedByReadOnly() {
+		return nil
+	}
+	if m.cursorOnHeader || m.cursor < 0 || m.cursor >= len(m.tasks) {
+		m.setStatusMessage("Select a task to snooze", statusTypeInfo, 2*time.Second)
+		return nil
+	}
+
+	now := time.Now()
+	if loc, err := m.cfg.Location(); err == nil {
+		now = now.In(loc)
+	}
+	t := m.tasks[m.cursor]
+	return shared.ShowQuickSnoozeMenu(t.ID, t.Title, t.DueDate, now)
+}
+
+// openFilterSnoozeMenu shows the snooze presets for every open task matching the
+// active filter
+func (m *Model) openFilterSnoozeMenu() tea.Cmd {
+	if m.blockedByReadOnly() {
+		return nil
+	}
+	if m.taskFilter.IsEmpty() {
+		m.setStatusMessage("Set a filter first to snooze the tasks matching it", statusTypeInfo, 3*time.Second)
+		return nil
+	}
+	return shared.ShowFilterSnoozeMenu(m.taskFilter, m.snoozePresets, time.Now())
+}
+
+// snoozeTask moves the due date of the task picked in the snooze menu. A menu opened
+// for a filter looks up the matching tasks first, so the user can confirm.
+func (m *Model) snoozeTask(msg shared.SnoozeMenuMsg) tea.Cmd {
+	if m.blockedByReadOnly() {
+		return nil
+	}
+	if msg.Filter != nil {
+		return m.previewFilterSnooze(*msg.Filter, msg.Until)
+	}
+	m.setLoadingStatus("Snoozing task...")
+
+	return func() tea.Msg {
+		var updatedTask task.Task
+		var err error
+		if msg.By > 0 {
+			// The service works out the new date, from today when the task is overdue
+			updatedTask, err = m.taskSvc.Snooze(m.ctx, int64(msg.TaskID), msg.By)
+		} else {
+			updatedTask, err = m.taskSvc.SnoozeUntil(m.ctx, int64(msg.TaskID), msg.Until)
+		}
+		if err != nil {
+			return messages.StatusUpdateErrorMsg{TaskIndex: m.cursor, TaskTitle: msg.Title, Err: err}
+		}
+
+		until := msg.Until
+		if updatedTask.DueDate != nil {
+			until = *updatedTask.DueDate
+		}
+		return messages.StatusUpdateSuccessMsg{
+			Task:    updatedTask,
+			Message: fmt.Sprintf("Snoozed '%s' until %s", msg.Title, shared.FormatSnoozeTime(until)),
+		}
+	}
+}
+
+// previewFilterSnooze looks up the open tasks matching filter so the user can confirm
+// snoozing them
+func (m *Model) previewFilterSnooze(filter task.TaskFilter, until time.Time) tea.Cmd {
+	return func() tea.Msg {
+		tasks, err := m.taskSvc.SnoozeFilteredPreview(m.ctx, m.userID, filter)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Errorf("failed to list matching tasks: %v", err))
+		}
+		return messages.SnoozePreviewMsg{Filter: filter, Until: until, Tasks: tasks}
+	}
+}
+
+// promptFilterSnooze asks whether the open tasks matching a filter should be snoozed
+func (m *Model) promptFilterSnooze(msg messages.SnoozePreviewMsg) {
+	if len(msg.Tasks) == 0 {
+		m.setStatusMessage("No open tasks match "+msg.Filter.String(), statusTypeInfo, 2*time.Second)
+		return
+	}
+
+	question := fmt.Sprintf("Snooze %d task(s) matching %s until %s?",
+		len(msg.Tasks), msg.Filter.String(), shared.FormatSnoozeTime(msg.Until))
+	m.askConfirmation(question, func() tea.Cmd {
+		return m.snoozeFiltered(msg.Filter, msg.Until)
+	})
+}
+
+// snoozeFiltered moves every open task matching filter and refreshes the list
+func (m *Model) snoozeFiltered(filter task.TaskFilter, until time.Time) tea.Cmd {
+	if m.blockedByReadOnly() {
+		return nil
+	}
+	m.setLoadingStatus("Snoozing tasks...")
+	return func() tea.Msg {
+		count, err := m.taskSvc.SnoozeFiltered(m.ctx, m.userID, filter, until)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Errorf("failed to snooze tasks: %v", err))
+		}
+		m.setSuccessStatus(fmt.Sprintf("Snoozed %d task(s) until %s", count, shared.FormatDate(until)))
+		return m.refreshTasks()()
+	}
+}
@@ -0,0 +1,42 @@
+package app
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/newbpydev/tusk/internal/core/task"
+)
+
+// cycleListSort switches the task list to the next sort, keeping the cursor on the
+// selected task. The sections are sorted as they are built, see categorizeTasks;
+// going back to the manual order reloads the tasks in their display order.
+func (m *Model) cycleListSort() tea.Cmd {
+	m.listSort = task.NextListSort(m.listSort)
+	if m.listSort == task.SortManual {
+		m.cursor = 0
+		m.taskListOffset = 0
+		m.setStatusMessage("Sorted manually", statusTypeInfo, 2*time.Second)
+		return m.refreshTasks()
+	}
+
+	selectedID := m.detailsTaskID()
+	m.initCollapsibleSections()
+	if selectedID > 0 {
+		if idx := m.getTaskIndexByID(selectedID); idx >= 0 {
+			m.cursor = idx
+			m.cursorOnHeader = false
+			m.repositionCursorAfterSectionChange()
+		}
+	}
+	m.setStatusMessage("Sorted by "+m.listSort.Label()+" (s for the next sort)", statusTypeInfo, 2*time.Second)
+	return nil
+}
+
+// listSortNote describes the task list's sort for the help footer, or returns an
+// empty string in the manual order
+func (m *Model) listSortNote() string {
+	if m.listSort == task.SortManual {
+		return ""
+	}
+	return "sort: " + m.listSort.Label()
+}
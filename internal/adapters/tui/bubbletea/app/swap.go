@@ -0,0 +1,154 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/hooks"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/messages"
+	"github.com/newbpydev/tusk/internal/core/task"
+)
+
+// taskSectionType returns the task list section a task is shown in
+func (m *Model) taskSectionType(t task.Task) hooks.SectionType {
+	switch {
+	case m.isRecentlyCreated(t):
+		return hooks.SectionTypeRecent
+	case t.Status == task.StatusDone:
+		return hooks.SectionTypeCompleted
+	case t.Status == task.StatusInProgress:
+		return hooks.SectionTypeInProgress
+	case t.ParentID != nil:
+		return hooks.SectionTypeProjects
+	default:
+		return hooks.SectionTypeTodo
+	}
+}
+
+// toggleSwapMark marks the selected task as one side of an order swap, or clears
+// the mark when the selected task is already marked
+func (m *Model) toggleSwapMark() {
+	if m.blockedByReadOnly() {
+		return
+	}
+	if m.cursorOnHeader || m.cursor < 0 || m.cursor >= len(m.tasks) {
+		return
+	}
+
+	curr := m.tasks[m.cursor]
+	if m.swapMarkID == curr.ID {
+		m.swapMarkID = 0
+		m.setStatusMessage("Swap mark cleared", statusTypeInfo, time.Second)
+		return
+	}
+
+	m.swapMarkID = curr.ID
+	m.setStatusMessage(fmt.Sprintf("Marked '%s', select another task and press S to swap", curr.Title), statusTypeInfo, 0)
+}
+
+// swapWithMarked exchanges the order of the marked task and the selected task.
+// The list is reordered right away and the swap is saved in the background;
+// on failure the list is reloaded from the database.
+func (m *Model) swapWithMarked() tea.Cmd {
+	if m.blockedByReadOnly() {
+		return nil
+	}
+	if m.swapMarkID == 0 {
+		m.setStatusMessage("Mark a task with v first", statusTypeInfo, 2*time.Second)
+		return nil
+	}
+	if m.cursorOnHeader || m.cursor < 0 || m.cursor >= len(m.tasks) {
+		return nil
+	}
+
+	marked := -1
+	for i := range m.tasks {
+		if m.tasks[i].ID == m.swapMarkID {
+			marked = i
+			break
+		}
+	}
+	if marked == -1 {
+		// The marked task was deleted or filtered out in the meantime
+		m.swapMarkID = 0
+		m.setStatusMessage("The marked task is no longer in the list", statusTypeInfo, 2*time.Second)
+		return nil
+	}
+
+	a, b := m.tasks[marked], m.tasks[m.cursor]
+	if a.ID == b.ID {
+		m.setStatusMessage("Select a different task to swap with", statusTypeInfo, 2*time.Second)
+		return nil
+	}
+	if m.taskSectionType(a) != m.taskSectionType(b) {
+		m.setStatusMessage("Only tasks in the same section can be swapped", statusTypeInfo, 2*time.Second)
+		return nil
+	}
+
+	// --- Optimistic update ---
+	m.tasks[marked], m.tasks[m.cursor] = b, a
+	m.tasks[marked].DisplayOrder, m.tasks[m.cursor].DisplayOrder = a.DisplayOrder, b.DisplayOrder
+	m.swapMarkID = 0
+	m.initCollapsibleSections()
+
+	return func() tea.Msg {
+		if err := m.taskSvc.SwapOrder(m.ctx, int64(a.ID), int64(b.ID)); err != nil {
+			return messages.StatusUpdateErrorMsg{TaskIndex: m.cursor, TaskTitle: a.Title, Err: err}
+		}
+		return messages.OrderSwappedMsg{
+			Message: fmt.Sprintf("Swapped '%s' and '%s'", a.Title, b.Title),
+		}
+	}
+}
+
+// moveSelected moves the selected task one place up (step -1) or down (step 1) in
+// its section, swapping it with the nearest task shown there under the same parent.
+// The list is reordered right away; the service then moves the task among all its
+// siblings and the list is reloaded, so siblings hidden by a filter are accounted for.
+func (m *Model) moveSelected(step int) tea.Cmd {
+	if m.blockedByReadOnly() {
+		return nil
+	}
+	if m.cursorOnHeader || m.cursor < 0 || m.cursor >= len(m.tasks) {
+		return nil
+	}
+	if m.listSort != task.SortManual {
+		m.setStatusMessage("Tasks can only be moved in the manual order (press s to get back to it)", statusTypeInfo, 3*time.Second)
+		return nil
+	}
+
+	curr := m.tasks[m.cursor]
+	section := m.taskSectionType(curr)
+	neighbor := -1
+	// The list holds each section's tasks next to each other
+	for i := m.cursor + step; i >= 0 && i < len(m.tasks) && m.taskSectionType(m.tasks[i]) == section; i += step {
+		if task.SameListSection(m.tasks[i], curr) {
+			neighbor = i
+			break
+		}
+	}
+	if neighbor == -1 {
+		m.setStatusMessage("Already at the edge of its section", statusTypeInfo, 2*time.Second)
+		return nil
+	}
+
+	other := m.tasks[neighbor]
+	// --- Optimistic update ---
+	m.tasks[m.cursor], m.tasks[neighbor] = other, curr
+	m.tasks[m.cursor].DisplayOrder, m.tasks[neighbor].DisplayOrder = curr.DisplayOrder, other.DisplayOrder
+	m.cursor = neighbor
+	m.initCollapsibleSections()
+
+	direction, move := "down", m.taskSvc.MoveTaskDown
+	if step < 0 {
+		direction, move = "up", m.taskSvc.MoveTaskUp
+	}
+	return func() tea.Msg {
+		if err := move(m.ctx, int64(curr.ID)); err != nil {
+			return messages.StatusUpdateErrorMsg{TaskIndex: neighbor, TaskTitle: curr.Title, Err: err}
+		}
+		m.setSuccessStatus(fmt.Sprintf("Moved '%s' %s", curr.Title, direction))
+		return m.refreshTasks()()
+	}
+}
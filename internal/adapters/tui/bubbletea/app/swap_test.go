@@ -0,0 +1,77 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/messages"
+	"github.com/newbpydev/tusk/internal/config"
+	"github.com/newbpydev/tusk/internal/core/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// moveService records the moves asked of the task service
+type moveService struct {
+	listService
+	moves []string
+}
+
+func (s *moveService) MoveTaskUp(ctx context.Context, taskID int64) error {
+	s.moves = append(s.moves, fmt.Sprintf("up %d", taskID))
+	return nil
+}
+
+func (s *moveService) MoveTaskDown(ctx context.Context, taskID int64) error {
+	s.moves = append(s.moves, fmt.Sprintf("down %d", taskID))
+	return nil
+}
+
+func TestMoveSelected(t *testing.T) {
+	inProgressHotel := testTrees()
+	inProgressHotel[0].SubTasks[1].Status = task.StatusInProgress
+
+	testCases := []struct {
+		name          string
+		roots         []task.Task
+		selected      int32
+		step          int
+		expectedOrder []int32
+		expectedMoves []string
+	}{
+		{name: "Root task up", roots: testTrees(), selected: 4, step: -1, expectedOrder: []int32{4, 1}, expectedMoves: []string{"up 4"}},
+		{name: "Subtask down", roots: testTrees(), selected: 2, step: 1, expectedOrder: []int32{5, 2}, expectedMoves: []string{"down 2"}},
+		{name: "Already first", roots: testTrees(), selected: 1, step: -1, expectedOrder: []int32{1, 4}},
+		{name: "Sibling in progress is in another section", roots: inProgressHotel, selected: 2, step: 1, expectedOrder: []int32{2}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &moveService{listService: listService{roots: tc.roots}}
+			m := NewModel(context.Background(), svc, 1, WithConfig(&config.Config{}))
+			selectTask(t, m, tc.selected)
+
+			cmd := m.moveSelected(tc.step)
+
+			// The order of the selected task and its siblings in its section
+			selected := m.tasks[m.cursor]
+			var order []int32
+			for _, listed := range m.tasks {
+				if m.taskSectionType(listed) == m.taskSectionType(selected) && task.SameParent(listed.ParentID, selected.ParentID) {
+					order = append(order, listed.ID)
+				}
+			}
+			assert.Equal(t, tc.expectedOrder, order)
+			assert.Equal(t, tc.selected, m.tasks[m.cursor].ID)
+
+			if tc.expectedMoves == nil {
+				assert.Nil(t, cmd)
+				return
+			}
+			require.NotNil(t, cmd)
+			assert.IsType(t, messages.TasksRefreshedMsg{}, cmd())
+			assert.Equal(t, tc.expectedMoves, svc.moves)
+		})
+	}
+}
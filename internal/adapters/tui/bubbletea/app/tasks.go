@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -12,13 +13,44 @@ import (
 
 // refreshTasks initiates a fetch for the latest tasks.
 func (m *Model) refreshTasks() tea.Cmd {
+	// A newer refresh supersedes any refresh that is still in flight
+	if m.refreshCancel != nil {
+		m.refreshCancel()
+	}
+	ctx, cancel := context.WithCancel(m.ctx)
+	m.refreshCancel = cancel
+
 	// Call to setLoadingStatus will be in status.go
-	m.setLoadingStatus("Loading tasks...")
+	m.setLoadingStatus("Loading tasks... (esc to cancel)")
+	actionable, today := m.actionableOnly, m.todayOnly
 	return func() tea.Msg {
-		tasks, err := m.taskSvc.List(m.ctx, m.userID)
+		defer cancel()
+
+		tasks, err := m.taskSvc.List(ctx, m.userID)
+		if ctx.Err() != nil {
+			// Cancelled by the user: drop the result so the model is left untouched
+			return messages.RefreshCancelledMsg{}
+		}
 		if err != nil {
 			return messages.ErrorMsg(fmt.Errorf("failed to refresh tasks: %v", err))
 		}
+		tasks = flattenTaskTrees(tasks)
+
+		// The actionable view needs to know which tasks are still blocked
+		var blocked []int32
+		if actionable {
+			if blocked, err = m.taskSvc.BlockedTaskIDs(ctx, m.userID); err != nil {
+				return messages.ErrorMsg(fmt.Errorf("failed to load blocked tasks: %v", err))
+			}
+		}
+
+		// The today view lists the pinned tasks first
+		var pinned []int32
+		if today {
+			if pinned, err = m.taskSvc.DayPinnedTaskIDs(ctx, m.userID); err != nil {
+				return messages.ErrorMsg(fmt.Errorf("failed to load pinned tasks: %v", err))
+			}
+		}
 
 		// Debug check for Task 9 and Task 12
 		var found9, found12 bool
@@ -63,12 +95,45 @@ func (m *Model) refreshTasks() tea.Cmd {
 
 		// Call to categorizeTasks will be in sections.go
 		m.categorizeTasks(tasks)
-		return messages.TasksRefreshedMsg{Tasks: tasks}
+		return messages.TasksRefreshedMsg{Tasks: tasks, BlockedTaskIDs: blocked, DayPinnedTaskIDs: pinned}
+	}
+}
+
+// flattenTaskTrees lists the tasks of the trees returned by List, each parent followed
+// by its subtasks, so subtasks can be selected in the task list like root tasks. The
+// parents keep their SubTasks for the task details.
+func flattenTaskTrees(roots []task.Task) []task.Task {
+	var tasks []task.Task
+	var walk func([]task.Task)
+	walk = func(level []task.Task) {
+		for _, t := range level {
+			tasks = append(tasks, t)
+			walk(t.SubTasks)
+		}
 	}
+	walk(roots)
+	return tasks
+}
+
+// cancelRefresh aborts the refresh in flight, if any, and clears the loading state.
+// It reports whether there was a refresh to cancel.
+func (m *Model) cancelRefresh() bool {
+	if !m.isLoading || m.refreshCancel == nil {
+		return false
+	}
+
+	m.refreshCancel()
+	m.refreshCancel = nil
+	m.clearLoadingStatus()
+	m.setStatusMessage("Refresh cancelled", statusTypeInfo, 3*time.Second)
+	return true
 }
 
 // toggleTaskCompletion changes the status of the selected task between Todo and Done.
 func (m *Model) toggleTaskCompletion() tea.Cmd {
+	if m.blockedByReadOnly() {
+		return nil
+	}
 	if len(m.tasks) == 0 || m.cursor >= len(m.tasks) || m.cursorOnHeader {
 		return nil // Cannot toggle status if no task is selected or cursor is on header
 	}
@@ -78,33 +143,20 @@ func (m *Model) toggleTaskCompletion() tea.Cmd {
 	toggledID := curr.ID
 
 	// Determine the current section for this task
-	var currentSectionType hooks.SectionType
-	if curr.Status == task.StatusDone {
-		currentSectionType = hooks.SectionTypeCompleted
-	} else {
-		if curr.ParentID != nil {
-			currentSectionType = hooks.SectionTypeProjects
-		} else {
-			currentSectionType = hooks.SectionTypeTodo
-		}
-	}
+	currentSectionType := m.taskSectionType(curr)
 
 	// Store the current cursor positions
 	originalVisualCursor := m.visualCursor
 
-	// IMPORTANT: First get a copy of the tasks in the current section BEFORE changing anything
-	// This ensures we properly identify the position and next task
+	// IMPORTANT: First get the tasks in the current section BEFORE changing anything
+	// This ensures we properly identify the position and next task. They are read
+	// from m.tasks, as the section slices are only rebuilt once a burst of toggles
+	// is over, see scheduleRecategorize.
 	var tasksInCurrentSection []task.Task
-	switch currentSectionType {
-	case hooks.SectionTypeTodo:
-		tasksInCurrentSection = make([]task.Task, len(m.todoTasks))
-		copy(tasksInCurrentSection, m.todoTasks)
-	case hooks.SectionTypeProjects:
-		tasksInCurrentSection = make([]task.Task, len(m.projectTasks))
-		copy(tasksInCurrentSection, m.projectTasks)
-	case hooks.SectionTypeCompleted:
-		tasksInCurrentSection = make([]task.Task, len(m.completedTasks))
-		copy(tasksInCurrentSection, m.completedTasks)
+	for _, t := range m.tasks {
+		if m.taskSectionType(t) == currentSectionType {
+			tasksInCurrentSection = append(tasksInCurrentSection, t)
+		}
 	}
 
 	// Find the position of the current task in its section
@@ -129,6 +181,11 @@ func (m *Model) toggleTaskCompletion() tea.Cmd {
 		}
 	}
 
+	// Recently created tasks stay in their section when toggled, so keep them selected
+	if currentSectionType == hooks.SectionTypeRecent {
+		nextTaskID = toggledID
+	}
+
 	// Determine new status
 	var newStatus task.Status
 	if curr.Status != task.StatusDone {
@@ -150,15 +207,13 @@ func (m *Model) toggleTaskCompletion() tea.Cmd {
 			break
 		}
 	}
+	m.pendingToggles = append(m.pendingToggles, statusToggle{id: toggledID, from: curr.Status, to: newStatus})
 
-	// Re-categorize tasks with the updated data
-	m.categorizeTasks(m.tasks)
+	// The sections are rebuilt on the next tick, once for a whole burst of toggles;
+	// until then the toggled task keeps its place in the list
+	recategorize := m.scheduleRecategorize()
 
-	// Also update timeline categories to ensure the task appears correctly in the timeline
-	// This is critical when toggling task completion, especially for tasks with due dates
-	m.overdueTasks, m.todayTasks, m.upcomingTasks = m.categorizeTimelineTasks(m.tasks)
-
-	// Now handle cursor positioning after task recategorization
+	// Now handle cursor positioning, the rebuild keeps the cursor on the same task
 	if nextTaskID != -1 {
 		// If we identified a next task, find and select it
 		found := false
@@ -203,17 +258,18 @@ func (m *Model) toggleTaskCompletion() tea.Cmd {
 	// --- End Optimistic Update ---
 
 	// Call server to update
-	return func() tea.Msg {
+	return tea.Batch(recategorize, func() tea.Msg {
 		updatedTask, err := m.taskSvc.ChangeStatus(m.ctx, int64(toggledID), newStatus)
 		if err != nil {
 			return messages.StatusUpdateErrorMsg{TaskIndex: m.cursor, TaskTitle: curr.Title, Err: err}
 		}
 
 		return messages.StatusUpdateSuccessMsg{
-			Task:    updatedTask,
-			Message: "Task status updated successfully",
+			Task:           updatedTask,
+			Message:        "Task status updated successfully",
+			PreviousStatus: curr.Status,
 		}
-	}
+	})
 }
 
 // selectSectionHeader helps position the cursor on a specific section header
@@ -242,47 +298,12 @@ func (m *Model) selectSectionHeader(sectionType hooks.SectionType) {
 	}
 }
 
-// deleteCurrentTask deletes the currently selected task.
-func (m *Model) deleteCurrentTask() tea.Cmd {
-	if len(m.tasks) == 0 || m.cursor >= len(m.tasks) || m.cursorOnHeader {
-		return nil // Cannot delete if no task selected or cursor is on header
-	}
-	taskTitle := m.tasks[m.cursor].Title
-	taskID := int64(m.tasks[m.cursor].ID)
-	taskIndex := m.cursor // Store index before potential list modification
-
-	// Call to setLoadingStatus will be in status.go
-	m.setLoadingStatus("Deleting task...")
-
-	// Optimistic update: Remove the task locally first
-	// m.tasks = append(m.tasks[:taskIndex], m.tasks[taskIndex+1:]...)
-	// Adjust cursor if needed
-	// if m.cursor >= len(m.tasks) {
-	// 	 m.cursor = max(0, len(m.tasks)-1)
-	// }
-	// m.categorizeTasks(m.tasks)
-	// m.updateVisualCursorFromTaskCursor()
-	// m.updateTaskCursorFromVisualCursor()
-	// --- End Optimistic Delete ---
-
-	return func() tea.Msg {
-		err := m.taskSvc.Delete(m.ctx, taskID)
-		if err != nil {
-			// Rollback optimistic delete? Might be simpler to just refresh.
-			return messages.StatusUpdateErrorMsg{TaskIndex: taskIndex, TaskTitle: taskTitle, Err: err}
-		}
-		// Instead of returning TasksRefreshedMsg directly, trigger a refresh command.
-		// This keeps the refresh logic centralized.
-		m.viewMode = "list" // Switch back to list view after delete
-		// Call to setSuccessStatus will be in status.go
-		m.setSuccessStatus(fmt.Sprintf("Task '%s' deleted", taskTitle))
-		return m.refreshTasks()() // Immediately invoke the refresh command func
-	}
-}
-
 // createNewTask creates a new task from the form fields.
 // This might move mostly to form.go, which would then return a command.
 func (m *Model) createNewTask() tea.Cmd {
+	if m.blockedByReadOnly() {
+		return nil
+	}
 	// This validation might live in form.go before calling the create command
 	if m.formTitle == "" {
 		m.err = fmt.Errorf("title is required")
@@ -294,20 +315,15 @@ func (m *Model) createNewTask() tea.Cmd {
 	m.setLoadingStatus("Creating new task...")
 
 	// Prepare task data from form fields
-	var dueDate *time.Time
-	if m.formDueDate != "" {
-		// Date parsing could be a helper in utils.go or stay in form.go
-		date, err := time.Parse("2006-01-02", m.formDueDate)
-		if err == nil {
-			dueDate = &date
-		} else {
-			// Error handling might live in form.go or status.go
-			m.setErrorStatus(fmt.Sprintf("Invalid date format: %v", err))
-			m.err = fmt.Errorf("invalid date format: %v", err)
-			// Clear loading status?
-			m.clearLoadingStatus()
-			return nil
-		}
+	// The due date keeps the time of day picked in the date input
+	dueDate, err := m.formDueDateValue()
+	if err != nil {
+		// Error handling might live in form.go or status.go
+		m.setErrorStatus(fmt.Sprintf("Invalid date format: %v", err))
+		m.err = fmt.Errorf("invalid date format: %v", err)
+		// Clear loading status?
+		m.clearLoadingStatus()
+		return nil
 	}
 	priority := task.PriorityLow // Default priority
 	if m.formPriority == string(task.PriorityMedium) {
@@ -319,23 +335,32 @@ func (m *Model) createNewTask() tea.Cmd {
 	// Capture form data before clearing
 	title := m.formTitle
 	description := m.formDescription
+	energy := task.Energy(m.formEnergy)
+	tags := m.formTagList()
 	// Clearing form fields should happen *after* the command function is prepared,
 	// or ideally, be handled within form.go when transitioning viewMode.
 	m.formTitle = ""
 	m.formDescription = ""
 	m.formPriority = ""
+	m.formEnergy = ""
 	m.formDueDate = ""
 	m.formStatus = ""
+	m.formTags = ""
 	m.activeField = 0
 	m.viewMode = "list" // Switch back to list view after initiating create
 
 	return func() tea.Msg {
 		// Actual creation logic
-		_, err := m.taskSvc.Create(m.ctx, m.userID, nil, title, description, dueDate, priority, []string{})
+		created, err := m.taskSvc.Create(m.ctx, m.userID, nil, title, description, dueDate, priority, tags, nil)
 		if err != nil {
 			// Return error message for the Update loop to handle
 			return messages.ErrorMsg(fmt.Errorf("failed to create task: %v", err))
 		}
+		if energy != "" {
+			if _, err := m.taskSvc.SetEnergy(m.ctx, int64(created.ID), energy); err != nil {
+				return messages.ErrorMsg(fmt.Errorf("failed to set task energy: %v", err))
+			}
+		}
 		// Trigger a refresh command instead of returning TasksRefreshedMsg directly.
 		// Call to setSuccessStatus will be in status.go
 		m.setSuccessStatus(fmt.Sprintf("Task '%s' created", title))
@@ -356,8 +381,8 @@ func (m *Model) getTasksByTimeCategory() ([]task.Task, []task.Task, []task.Task)
 		}
 
 		// Use the same reliable date comparison logic used elsewhere
-		if isBeforeDay(*t.DueDate, now) {
-			// Task is due before today = overdue
+		if task.IsOverdue(*t.DueDate, now) {
+			// Task was due before today, or earlier today at a set time = overdue
 			overdue = append(overdue, t)
 		} else if isSameDay(*t.DueDate, now) {
 			// Task is due today = today section
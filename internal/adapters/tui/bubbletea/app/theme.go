@@ -0,0 +1,19 @@
+package app
+
+import (
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/styles"
+)
+
+// setTheme makes a theme the active one. Every panel reads its colors from the
+// active styles while rendering, so the next frame is drawn in the new theme.
+func (m *Model) setTheme(t styles.Theme) {
+	styles.SetTheme(t)
+	m.styles = styles.ActiveStyles
+}
+
+// cycleTheme switches to the next built-in theme
+func (m *Model) cycleTheme() {
+	next := styles.NextTheme(m.styles.Theme.Name)
+	m.setTheme(next)
+	m.setSuccessStatus("Theme: " + next.Name)
+}
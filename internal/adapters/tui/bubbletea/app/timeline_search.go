@@ -0,0 +1,139 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/newbpydev/tusk/internal/core/task"
+)
+
+// startTimelineSearch begins typing a new timeline search query
+func (m *Model) startTimelineSearch() {
+	m.timelineSearching = true
+	m.timelineSearchQuery = ""
+}
+
+// clearTimelineSearch drops the timeline search query and its highlights
+func (m *Model) clearTimelineSearch() {
+	m.timelineSearching = false
+	m.timelineSearchQuery = ""
+	m.setStatusMessage("Search cleared", statusTypeInfo, time.Second)
+}
+
+// handleTimelineSearchKeys processes keys while a timeline search query is typed.
+// Every change jumps to the first matching task; enter keeps the query so n/N can
+// cycle through the matches, esc drops it.
+func (m *Model) handleTimelineSearchKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEsc:
+		m.clearTimelineSearch()
+		return m, nil
+	case tea.KeyEnter:
+		m.timelineSearching = false
+		if len(m.timelineSearchMatches()) == 0 {
+			m.timelineSearchQuery = ""
+		}
+		return m, nil
+	case tea.KeyBackspace:
+		if runes := []rune(m.timelineSearchQuery); len(runes) > 0 {
+			m.timelineSearchQuery = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.timelineSearchQuery += string(msg.Runes)
+	default:
+		return m, nil
+	}
+
+	m.jumpToTimelineMatch(0)
+	return m, nil
+}
+
+// timelineSearchMatches returns the IDs of the timeline tasks whose title contains
+// the search query, in display order across the overdue, today and upcoming sections
+func (m *Model) timelineSearchMatches() []int32 {
+	query := strings.ToLower(m.timelineSearchQuery)
+	if query == "" {
+		return nil
+	}
+
+	var matches []int32
+	overdue, today, upcoming := m.getTimelineFilteredTasks()
+	for _, tasks := range [][]task.Task{overdue, today, upcoming} {
+		for _, t := range tasks {
+			if strings.Contains(strings.ToLower(t.Title), query) {
+				matches = append(matches, t.ID)
+			}
+		}
+	}
+	return matches
+}
+
+// currentTimelineMatch returns the position of the task under the timeline cursor
+// among the search matches, or -1 when it is not a match
+func (m *Model) currentTimelineMatch(matches []int32) int {
+	taskID := m.getTimelineTaskID()
+	for i, id := range matches {
+		if id == taskID {
+			return i
+		}
+	}
+	return -1
+}
+
+// jumpToTimelineMatch moves the timeline cursor to the match at index i, wrapping
+// around at either end. It reports whether there was a match to move to.
+func (m *Model) jumpToTimelineMatch(i int) bool {
+	matches := m.timelineSearchMatches()
+	if len(matches) == 0 {
+		return false
+	}
+
+	i = (i%len(matches) + len(matches)) % len(matches)
+	m.resetTimelineCursorForTask(matches[i])
+	m.taskDetailsOffset = 0
+	return true
+}
+
+// cycleTimelineMatch moves to the next (delta 1) or previous (delta -1) match
+// relative to the task under the timeline cursor
+func (m *Model) cycleTimelineMatch(delta int) {
+	matches := m.timelineSearchMatches()
+	current := m.currentTimelineMatch(matches)
+	if current < 0 && delta < 0 {
+		// Off a match, "previous" wraps around to the last one
+		current = 0
+	}
+
+	if !m.jumpToTimelineMatch(current + delta) {
+		m.setStatusMessage("No tasks match '"+m.timelineSearchQuery+"'", statusTypeInfo, 2*time.Second)
+	}
+}
+
+// timelineSearchHeader describes the timeline search for the panel header, or
+// returns an empty string when no search is active
+func (m *Model) timelineSearchHeader() string {
+	if !m.timelineSearching && m.timelineSearchQuery == "" {
+		return ""
+	}
+
+	header := "/" + m.timelineSearchQuery
+	if m.timelineSearching {
+		header += "_"
+	}
+	if m.timelineSearchQuery == "" {
+		return header
+	}
+
+	matches := m.timelineSearchMatches()
+	if len(matches) == 0 {
+		return header + " (no matches)"
+	}
+	if current := m.currentTimelineMatch(matches); current >= 0 {
+		return header + fmt.Sprintf(" (%d/%d)", current+1, len(matches))
+	}
+	return header + fmt.Sprintf(" (%d matches)", len(matches))
+}
@@ -27,24 +27,8 @@ func (m *Model) initTimelineCollapsibleSections() {
 	m.timelineCollapsibleMgr.AddSection(hooks.SectionTypeOverdue, "Overdue", len(m.overdueTasks), 0)
 	m.timelineCollapsibleMgr.AddSection(hooks.SectionTypeToday, "Today", len(m.todayTasks), len(m.overdueTasks))
 	m.timelineCollapsibleMgr.AddSection(hooks.SectionTypeUpcoming, "Upcoming", len(m.upcomingTasks), len(m.overdueTasks)+len(m.todayTasks))
-
-	// Make all sections expanded by default (better UX)
-	// First call toggleSection for each section to ensure they're all expanded
-	for _, sectionType := range []hooks.SectionType{hooks.SectionTypeOverdue, hooks.SectionTypeToday, hooks.SectionTypeUpcoming} {
-		// Find if the section is already expanded
-		expanded := false
-		for _, section := range m.timelineCollapsibleMgr.Sections {
-			if section.Type == sectionType {
-				expanded = section.IsExpanded
-				break
-			}
-		}
-
-		// If not expanded, toggle it to expand
-		if !expanded {
-			m.timelineCollapsibleMgr.ToggleSection(sectionType)
-		}
-	}
+	// Timeline sections start expanded and keep whatever the user toggled since,
+	// including the state restored from the UI preferences
 
 	// Initialize the cursor to the first section header if it's not set
 	if m.timelineCursor == 0 && m.timelineCursorOnHeader == false {
@@ -75,10 +59,10 @@ func (m *Model) categorizeTimelineTasks(tasks []task.Task) ([]task.Task, []task.
 			continue
 		}
 
-		// Use the utility functions from utils.go for consistent date comparison that properly handles timezones
-		// These functions normalize dates to UTC to avoid timezone-related issues
-		if isBeforeDay(*t.DueDate, now) {
-			// Task is due before today = overdue
+		// Due dates with a time of day are overdue once that time has passed, even today;
+		// date-only ones stay in Today until the day is over
+		if task.IsOverdue(*t.DueDate, now) {
+			// Task was due before today, or earlier today at a set time = overdue
 			overdueTasks = append(overdueTasks, t)
 		} else if isSameDay(*t.DueDate, now) {
 			// Task is due today = today section
@@ -90,17 +74,13 @@ func (m *Model) categorizeTimelineTasks(tasks []task.Task) ([]task.Task, []task.
 	}
 
 	// Sort each category by due date for consistent ordering
-	sort.Slice(overdueTasks, func(i, j int) bool {
-		return overdueTasks[i].DueDate.Before(*overdueTasks[j].DueDate)
-	})
+	task.SortByDueDate(overdueTasks, m.undatedPlacement)
 
 	sort.Slice(todayTasks, func(i, j int) bool {
 		return todayTasks[i].Title < todayTasks[j].Title
 	})
 
-	sort.Slice(upcomingTasks, func(i, j int) bool {
-		return upcomingTasks[i].DueDate.Before(*upcomingTasks[j].DueDate)
-	})
+	task.SortByDueDate(upcomingTasks, m.undatedPlacement)
 
 	return overdueTasks, todayTasks, upcomingTasks
 }
@@ -258,6 +258,9 @@ func (m *Model) resetTimelineCursorForTask(taskID int32) {
 
 // toggleTimelineTaskCompletion toggles the completion status of the task selected in the timeline
 func (m *Model) toggleTimelineTaskCompletion() tea.Cmd {
+	if m.blockedByReadOnly() {
+		return nil
+	}
 	// Get the task ID from the current timeline cursor position
 	taskID := m.getTimelineTaskID()
 	if taskID <= 0 {
@@ -389,8 +392,9 @@ func (m *Model) toggleTimelineTaskCompletion() tea.Cmd {
 		}
 
 		return messages.StatusUpdateSuccessMsg{
-			Task:    updatedTask,
-			Message: "Task status updated successfully",
+			Task:           updatedTask,
+			Message:        "Task status updated successfully",
+			PreviousStatus: curr.Status,
 		}
 	}
 }
@@ -0,0 +1,108 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/messages"
+	"github.com/newbpydev/tusk/internal/core/task"
+)
+
+// toggleToday narrows the list to the open tasks that are overdue or due today, the
+// same set `tusk today` prints, or shows everything again. Turning it on first looks
+// up the tasks pinned to the top of their day. Hidden tasks are not kept in memory,
+// so turning the view off reloads them.
+func (m *Model) toggleToday() tea.Cmd {
+	if m.todayOnly {
+		m.todayOnly = false
+		m.dayPinnedIDs = nil
+		m.cursor = 0
+		m.taskListOffset = 0
+		return m.refreshTasks()
+	}
+
+	return func() tea.Msg {
+		pinned, err := m.taskSvc.DayPinnedTaskIDs(m.ctx, m.userID)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Errorf("failed to load pinned tasks: %v", err))
+		}
+		return messages.TodayLoadedMsg{DayPinnedTaskIDs: pinned}
+	}
+}
+
+// showToday hides every task that is not overdue or due today from the list and timeline
+func (m *Model) showToday(pinned []int32) {
+	m.todayOnly = true
+	m.dayPinnedIDs = pinned
+	m.cursor = 0
+	m.taskListOffset = 0
+	m.initCollapsibleSections()
+	m.initTimelineCollapsibleSections()
+	m.setStatusMessage("Showing only overdue and today's tasks (D to show all)", statusTypeInfo, 2*time.Second)
+}
+
+// isInToday reports whether t stays visible under the today view
+func (m *Model) isInToday(t task.Task) bool {
+	return !m.todayOnly || task.InAgenda(t, time.Now())
+}
+
+// isDayPinned reports whether the today view lists t first in its section
+func (m *Model) isDayPinned(t task.Task) bool {
+	for _, id := range m.dayPinnedIDs {
+		if id == t.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// toggleDayPin pins the selected task to the top of its section in the today view,
+// or removes its pin. The pin is stored with the task, so `tusk today` shows it too
+// and it follows the task to a new due date.
+func (m *Model) toggleDayPin() tea.Cmd {
+	if m.blockedByReadOnly() {
+		return nil
+	}
+	if !m.todayOnly {
+		m.setStatusMessage("Pins order the overdue + today view (D)", statusTypeInfo, 2*time.Second)
+		return nil
+	}
+	if m.cursorOnHeader || m.cursor < 0 || m.cursor >= len(m.tasks) {
+		m.setStatusMessage("Select a task to pin", statusTypeInfo, 2*time.Second)
+		return nil
+	}
+
+	idx, t := m.cursor, m.tasks[m.cursor]
+	pin := !m.isDayPinned(t)
+	return func() tea.Msg {
+		var err error
+		if pin {
+			_, err = m.taskSvc.PinToDay(m.ctx, m.userID, int64(t.ID))
+		} else {
+			err = m.taskSvc.UnpinFromDay(m.ctx, m.userID, int64(t.ID))
+		}
+		if err != nil {
+			return messages.StatusUpdateErrorMsg{TaskIndex: idx, TaskTitle: t.Title, Err: err}
+		}
+
+		// An unpinned task goes back to its place in the loaded order, so reload
+		result := m.refreshTasks()()
+		refreshed, ok := result.(messages.TasksRefreshedMsg)
+		if !ok {
+			return result
+		}
+		return messages.DayPinToggledMsg{TaskID: t.ID, Title: t.Title, Pinned: pin, Refreshed: refreshed}
+	}
+}
+
+// applyDayPin keeps the task selected once the list is reloaded with the new pins
+func (m *Model) applyDayPin(msg messages.DayPinToggledMsg) {
+	m.focusTask(msg.TaskID, false)
+
+	if msg.Pinned {
+		m.setStatusMessage(fmt.Sprintf("Pinned %q to the top of its day", msg.Title), statusTypeSuccess, 2*time.Second)
+	} else {
+		m.setStatusMessage(fmt.Sprintf("Unpinned %q", msg.Title), statusTypeSuccess, 2*time.Second)
+	}
+}
@@ -0,0 +1,98 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/newbpydev/tusk/internal/config"
+	"github.com/newbpydev/tusk/internal/core/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pinService keeps day pins in memory, most recently pinned first
+type pinService struct {
+	listService
+	pinned []int32
+}
+
+func (s *pinService) PinToDay(ctx context.Context, userID, taskID int64) (task.Task, error) {
+	s.unpin(int32(taskID))
+	s.pinned = append([]int32{int32(taskID)}, s.pinned...)
+	return task.Task{ID: int32(taskID)}, nil
+}
+
+func (s *pinService) UnpinFromDay(ctx context.Context, userID, taskID int64) error {
+	s.unpin(int32(taskID))
+	return nil
+}
+
+func (s *pinService) DayPinnedTaskIDs(ctx context.Context, userID int64) ([]int32, error) {
+	return append([]int32(nil), s.pinned...), nil
+}
+
+func (s *pinService) unpin(id int32) {
+	for i, p := range s.pinned {
+		if p == id {
+			s.pinned = append(s.pinned[:i], s.pinned[i+1:]...)
+			return
+		}
+	}
+}
+
+func TestTodayViewListsPinnedTasksFirst(t *testing.T) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	yesterday := today.AddDate(0, 0, -1)
+	roots := []task.Task{
+		{ID: 1, Title: "Laundry", Status: task.StatusTodo, DueDate: &today, DisplayOrder: 0},
+		{ID: 2, Title: "Dishes", Status: task.StatusTodo, DueDate: &today, DisplayOrder: 1},
+		{ID: 3, Title: "Taxes", Status: task.StatusTodo, DueDate: &yesterday, DisplayOrder: 2},
+		{ID: 4, Title: "Someday", Status: task.StatusTodo, DisplayOrder: 3},
+	}
+	svc := &pinService{listService: listService{roots: roots}, pinned: []int32{2}}
+	m := NewModel(context.Background(), svc, 1, WithConfig(&config.Config{}))
+
+	todoIDs := func() []int32 {
+		var ids []int32
+		for _, t := range m.todoTasks {
+			ids = append(ids, t.ID)
+		}
+		return ids
+	}
+	require.Equal(t, []int32{1, 2, 3, 4}, todoIDs(), "pins only apply to the today view")
+
+	m.Update(m.toggleToday()())
+	require.True(t, m.todayOnly)
+	assert.Equal(t, []int32{2, 1, 3}, todoIDs())
+
+	// Pinning another task puts it above the earlier pin and keeps it selected
+	selectTask(t, m, 3)
+	m.Update(m.toggleDayPin()())
+	assert.Equal(t, []int32{3, 2, 1}, todoIDs())
+	assert.Equal(t, int32(3), m.tasks[m.cursor].ID)
+
+	// An unpinned task goes back to its place in the sort
+	selectTask(t, m, 2)
+	m.Update(m.toggleDayPin()())
+	assert.Equal(t, []int32{3, 1, 2}, todoIDs())
+	assert.Equal(t, []int32{3}, svc.pinned)
+
+	// A refresh keeps the pins
+	m.Update(m.refreshTasks()())
+	assert.Equal(t, []int32{3, 1, 2}, todoIDs())
+
+	m.Update(m.toggleToday()())
+	assert.False(t, m.todayOnly)
+	assert.Equal(t, []int32{1, 2, 3, 4}, todoIDs())
+}
+
+func TestDayPinNeedsTodayView(t *testing.T) {
+	svc := &pinService{listService: listService{roots: testTrees()}}
+	m := NewModel(context.Background(), svc, 1, WithConfig(&config.Config{}))
+	selectTask(t, m, 4)
+
+	assert.Nil(t, m.toggleDayPin())
+	assert.Empty(t, svc.pinned)
+}
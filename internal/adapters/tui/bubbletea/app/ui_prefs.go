@@ -0,0 +1,78 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/hooks"
+)
+
+// uiPrefs holds the layout choices kept between sessions
+type uiPrefs struct {
+	// Which task list and timeline sections are expanded, keyed by section type
+	ListSections     map[hooks.SectionType]bool `json:"list_sections,omitempty"`
+	TimelineSections map[hooks.SectionType]bool `json:"timeline_sections,omitempty"`
+}
+
+// loadUIPrefs restores the layout saved by the previous session. A missing file leaves
+// the defaults in place; an unreadable one is reported and ignored.
+func (m *Model) loadUIPrefs() {
+	if m.uiPrefsPath == "" {
+		return
+	}
+
+	data, err := os.ReadFile(m.uiPrefsPath)
+	if os.IsNotExist(err) {
+		return
+	}
+	if err != nil {
+		m.setErrorStatus("Ignoring UI preferences: " + err.Error())
+		return
+	}
+
+	var prefs uiPrefs
+	if err := json.Unmarshal(data, &prefs); err != nil {
+		m.setErrorStatus("Ignoring UI preferences: " + err.Error())
+		return
+	}
+
+	if prefs.ListSections != nil {
+		m.collapsibleManager.RestoreExpansionState(prefs.ListSections)
+	}
+	if prefs.TimelineSections != nil {
+		m.timelineCollapsibleMgr.RestoreExpansionState(prefs.TimelineSections)
+	}
+}
+
+// SaveUIPrefs writes the current layout so the next session starts the same way.
+// It does nothing when the model was created without WithUIPrefs.
+func (m *Model) SaveUIPrefs() error {
+	if m.uiPrefsPath == "" {
+		return nil
+	}
+
+	prefs := uiPrefs{
+		ListSections:     m.collapsibleManager.ExpansionState(),
+		TimelineSections: m.timelineCollapsibleMgr.ExpansionState(),
+	}
+	data, err := json.MarshalIndent(prefs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode UI preferences: %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(m.uiPrefsPath), 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %v", err)
+	}
+
+	// Write a temporary file first so an interrupted save never leaves a corrupt file
+	tmp := m.uiPrefsPath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write UI preferences: %v", err)
+	}
+	if err := os.Rename(tmp, m.uiPrefsPath); err != nil {
+		return fmt.Errorf("failed to write UI preferences: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/messages"
+	"github.com/newbpydev/tusk/internal/core/task"
+)
+
+// maxUndoSteps caps how many changes u can take back
+const maxUndoSteps = 20
+
+// undoKind tells how a change is taken back
+type undoKind int
+
+const (
+	undoStatusChange undoKind = iota // set the task's previous status again
+	undoArchive                      // restore the archived task with its subtasks
+)
+
+// undoStep records a change to a task with what is needed to reverse it
+type undoStep struct {
+	kind           undoKind
+	taskID         int32
+	title          string
+	previousStatus task.Status // for undoStatusChange
+}
+
+// pushUndo records a change, dropping the oldest one once the stack is full
+func (m *Model) pushUndo(step undoStep) {
+	m.undoStack = append(m.undoStack, step)
+	if len(m.undoStack) > maxUndoSteps {
+		m.undoStack = m.undoStack[len(m.undoStack)-maxUndoSteps:]
+	}
+}
+
+// clearUndo forgets every recorded change, e.g. once the tasks are reloaded and
+// may have been changed elsewhere
+func (m *Model) clearUndo() {
+	m.undoStack = nil
+}
+
+// recordStatusChange remembers a status change confirmed by the service so u can
+// set the previous status again. Changes made by undo itself carry no previous
+// status and are not recorded.
+func (m *Model) recordStatusChange(msg messages.StatusUpdateSuccessMsg) bool {
+	if msg.PreviousStatus == "" || msg.PreviousStatus == msg.Task.Status {
+		return false
+	}
+	m.pushUndo(undoStep{
+		kind:           undoStatusChange,
+		taskID:         msg.Task.ID,
+		title:          msg.Task.Title,
+		previousStatus: msg.PreviousStatus,
+	})
+	return true
+}
+
+// undoLast takes back the most recent change on the undo stack
+func (m *Model) undoLast() tea.Cmd {
+	if len(m.undoStack) == 0 {
+		m.setStatusMessage("Nothing to undo", statusTypeInfo, 2*time.Second)
+		return nil
+	}
+
+	step := m.undoStack[len(m.undoStack)-1]
+	m.undoStack = m.undoStack[:len(m.undoStack)-1]
+
+	switch step.kind {
+	case undoArchive:
+		return m.restoreArchivedTask(step.taskID)
+	default:
+		return m.restoreStatus(step)
+	}
+}
+
+// restoreStatus sets the status a task had before its last status change
+func (m *Model) restoreStatus(step undoStep) tea.Cmd {
+	m.setLoadingStatus(fmt.Sprintf("Undoing status change of '%s'...", step.title))
+
+	return func() tea.Msg {
+		restored, err := m.taskSvc.ChangeStatus(m.ctx, int64(step.taskID), step.previousStatus)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Errorf("failed to undo status change: %v", err))
+		}
+		return messages.StatusUpdateSuccessMsg{
+			Task:    restored,
+			Message: fmt.Sprintf("Moved '%s' back to %s", step.title, statusLabel(step.previousStatus)),
+		}
+	}
+}
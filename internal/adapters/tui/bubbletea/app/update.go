@@ -29,7 +29,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case shared.ModalCloseMsg:
 			m.showModal = false
 			return m, nil
-		case messages.ShowModalMsg, tea.WindowSizeMsg:
+		case messages.ShowModalMsg, tea.WindowSizeMsg, shared.SnoozeMenuMsg:
 			// These should be handled by the main update flow
 			// They're special cases even when a modal is visible
 		default:
@@ -45,7 +45,12 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		// Delegate all keyboard handling to the handler functions in handlers.go
 		newModel, cmd := m.handleKeyPress(msg)
-		return newModel, cmd
+		// The key may have selected a task whose notes were not fetched yet
+		return newModel, tea.Batch(cmd, m.loadDetailsNotes())
+
+	case tea.MouseMsg:
+		// Scroll or focus the panel under the pointer
+		return m.handleMouse(msg)
 
 	case tea.WindowSizeMsg:
 		// Call our enhanced window resize handler to ensure cursor visibility
@@ -98,7 +103,11 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case messages.StatusUpdateSuccessMsg:
 		// Handle successful task update
-		m.setSuccessStatus(msg.Message)
+		if m.recordStatusChange(msg) {
+			m.setSuccessStatus(msg.Message + " (u to undo)")
+		} else {
+			m.setSuccessStatus(msg.Message)
+		}
 
 		// Keep track of the updated task ID
 		updatedTaskID := msg.Task.ID
@@ -112,63 +121,126 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-		// To ensure consistency, preserve the current cursor positions
-		originalCursor := m.cursor
-		originalVisualCursor := m.visualCursor
-		originalCursorOnHeader := m.cursorOnHeader
-		
-		// Store the current timeline cursor positions
-		originalTimelineCursor := m.timelineCursor
-		originalTimelineCursorOnHeader := m.timelineCursorOnHeader
+		// When a task is unchecked in the timeline, the cursor follows it to its new section
+		if m.activePanel == 2 && msg.Task.Status == task.StatusTodo {
+			m.timelineResetTaskID = updatedTaskID
+		}
 
-		// Re-categorize tasks with updated data
-		m.categorizeTasks(m.tasks)
+		// Rebuild the sections once for the whole burst of updates rather than per update
+		recategorize := m.scheduleRecategorize()
 
-		// Also update timeline categories to ensure proper timeline display
-		// This is critical when toggling task completion status
-		m.overdueTasks, m.todayTasks, m.upcomingTasks = m.categorizeTimelineTasks(m.tasks)
-		
-		// Fully reinitialize timeline sections to ensure proper cursor mapping
-		// This fixes the bug where unchecked tasks aren't selectable in timeline
-		m.initTimelineCollapsibleSections()
+		// Completing the last open subtask may complete its parents too
+		return m, tea.Batch(recategorize, m.checkParentCompletion(msg.Task))
 
-		// Restore cursor positions
-		m.cursor = originalCursor
-		m.visualCursor = originalVisualCursor
-		m.cursorOnHeader = originalCursorOnHeader
-		
-		// If we were in the timeline and the task status changed, 
-		// ensure the cursor state is properly reset for the updated sections
-		if m.activePanel == 2 { // Panel 2 is the timeline
-			// When a task is unchecked, we need to reset the timeline cursor
-			// to ensure it can be selected in its new section
-			if msg.Task.Status == task.StatusTodo && m.timelineCursor != 0 {
-				// If it's a task being unchecked, find it in the timeline section
-				m.resetTimelineCursorForTask(updatedTaskID)
-			} else {
-				// Otherwise restore original cursor position
-				m.timelineCursor = originalTimelineCursor
-				m.timelineCursorOnHeader = originalTimelineCursorOnHeader
-			}
-		}
+	case shared.SnoozeMenuMsg:
+		// A preset was picked, close the menu and apply it
+		m.showModal = false
+		return m, m.snoozeTask(msg)
+
+	case messages.ParentsReadyMsg:
+		m.promptParentCompletion(msg)
+		return m, nil
+
+	case messages.SectionStatusChangedMsg:
+		return m, m.sectionStatusChanged(msg)
+
+	case messages.SnoozePreviewMsg:
+		m.promptFilterSnooze(msg)
+		return m, nil
+
+	case messages.RolloverPreviewMsg:
+		m.promptRollover(msg)
+		return m, nil
 
-		// Refresh the visual cursor from task cursor to ensure consistency
-		// This is important for cases where the task moves between sections
-		m.updateVisualCursorFromTaskCursor()
+	case messages.ReminderTickMsg:
+		return m, m.checkReminders()
 
+	case messages.DueTasksMsg:
+		return m, m.remindDueTasks(msg)
+
+	case messages.RecategorizeMsg:
+		m.flushRecategorize()
 		return m, nil
 
 	case messages.TasksRefreshedMsg:
 		// Handle refreshed task list
 		m.tasks = msg.Tasks
+		if m.actionableOnly {
+			m.blockedTaskIDs = blockedSet(msg.BlockedTaskIDs)
+		}
+		if m.todayOnly {
+			m.dayPinnedIDs = msg.DayPinnedTaskIDs
+		}
+		if m.listSearchBase != nil {
+			// Keep the full list for the search; the sections pick the matches from it
+			m.listSearchBase = append([]task.Task{}, msg.Tasks...)
+		}
+		m.cancelRecategorize()
 		if m.cursor >= len(m.tasks) {
 			m.cursor = max(0, len(m.tasks)-1)
 		}
 		m.clearLoadingStatus()
 		m.initCollapsibleSections()
+		m.restoreHeaderAfterRefresh()
 
 		// Also initialize timeline sections to ensure timeline view is up-to-date
 		m.initTimelineCollapsibleSections()
+		return m, m.loadDetailsNotes()
+
+	case messages.RefreshCancelledMsg:
+		// The loading state was already cleared when the refresh was cancelled
+		return m, nil
+
+	case messages.ListSearchResultsMsg:
+		m.applyListSearchResults(msg)
+		return m, nil
+
+	case messages.ActionableLoadedMsg:
+		m.showActionable(msg.BlockedTaskIDs)
+		return m, nil
+
+	case messages.TodayLoadedMsg:
+		m.showToday(msg.DayPinnedTaskIDs)
+		return m, nil
+
+	case messages.DayPinToggledMsg:
+		_, cmd := m.Update(msg.Refreshed)
+		m.applyDayPin(msg)
+		return m, cmd
+
+	case messages.FiltersClearedMsg:
+		m.navigateToTop()
+		m.setStatusMessage("Filters cleared", statusTypeInfo, 2*time.Second)
+		return m, nil
+
+	case messages.TaskArchivedMsg:
+		return m, m.removeArchivedTask(msg)
+
+	case messages.TaskRestoredMsg:
+		m.showRestoredTask(msg)
+		return m, nil
+
+	case messages.NotesLoadedMsg:
+		m.notes[msg.TaskID] = msg.Notes
+		return m, nil
+
+	case messages.TaskClonedMsg:
+		return m, m.taskCloned(msg)
+
+	case messages.KnownTagsLoadedMsg:
+		m.knownTags = msg.Tags
+		return m, nil
+
+	case messages.NoteAddedMsg:
+		m.showAddedNote(msg.Note)
+		return m, nil
+
+	case messages.OrderSwappedMsg:
+		m.setSuccessStatus(msg.Message)
+		return m, nil
+
+	case messages.ExportCompletedMsg:
+		m.setSuccessStatus(fmt.Sprintf("Exported %d task(s) to %s", msg.Count, msg.Path))
 		return m, nil
 
 	case messages.ErrorMsg:
@@ -154,9 +154,7 @@ func (m *UpdateManager) handleGlobalKeys(msg tea.KeyMsg) bool {
 
 // handleWindowSize processes window size change messages
 func (m *UpdateManager) handleWindowSize(msg tea.Msg) (tea.Model, tea.Cmd) {
-	sizeMsg := msg.(tea.WindowSizeMsg)
-	m.model.width = sizeMsg.Width
-	m.model.height = sizeMsg.Height
+	m.model.handleWindowResize(msg.(tea.WindowSizeMsg))
 	return m.model, nil
 }
 
@@ -2,6 +2,8 @@ package app
 
 import (
 	"time"
+
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/components/input"
 )
 
 // truncateText truncates text to fit within width
@@ -43,54 +45,45 @@ func formatDate(t time.Time) string {
 
 // parseDate attempts to parse a date string into a time.Time
 func parseDate(dateStr string) (time.Time, error) {
-	// Try to parse with time component first
-	t, err := time.Parse("2006-01-02 15:04", dateStr)
-	if err == nil {
-		return t, nil
-	}
-	
-	// Fall back to date-only format
-	return time.Parse("2006-01-02", dateStr)
+	// Exact dates first, then relative phrases such as "tomorrow"
+	return input.ParseDate(dateStr, time.Now())
 }
 
 // isSameDay compares two time.Time values to determine if they represent the same calendar day,
-// ignoring time components and timezone differences.
+// ignoring time components. Days follow date2's time zone, usually that of time.Now().
 func isSameDay(date1, date2 time.Time) bool {
-	// Convert both dates to UTC to avoid timezone issues
-	utc1 := date1.UTC()
-	utc2 := date2.UTC()
+	// Compare both dates in the same zone so a late evening does not spill into the next day
+	local1 := date1.In(date2.Location())
 
 	// Compare year, month, and day only
-	return utc1.Year() == utc2.Year() &&
-		utc1.Month() == utc2.Month() &&
-		utc1.Day() == utc2.Day()
+	return local1.Year() == date2.Year() &&
+		local1.Month() == date2.Month() &&
+		local1.Day() == date2.Day()
 }
 
 // isBeforeDay determines if date1 is strictly before date2 in calendar days,
-// ignoring time components and timezone differences.
+// ignoring time components. Days follow date2's time zone, usually that of time.Now().
 func isBeforeDay(date1, date2 time.Time) bool {
-	// Convert both dates to UTC to avoid timezone issues
-	utc1 := date1.UTC()
-	utc2 := date2.UTC()
+	loc := date2.Location()
+	local1 := date1.In(loc)
 
 	// Extract date components only
-	date1Midnight := time.Date(utc1.Year(), utc1.Month(), utc1.Day(), 0, 0, 0, 0, time.UTC)
-	date2Midnight := time.Date(utc2.Year(), utc2.Month(), utc2.Day(), 0, 0, 0, 0, time.UTC)
+	date1Midnight := time.Date(local1.Year(), local1.Month(), local1.Day(), 0, 0, 0, 0, loc)
+	date2Midnight := time.Date(date2.Year(), date2.Month(), date2.Day(), 0, 0, 0, 0, loc)
 
 	// Compare dates by using Unix timestamps at midnight
 	return date1Midnight.Unix() < date2Midnight.Unix()
 }
 
 // isAfterDay determines if date1 is strictly after date2 in calendar days,
-// ignoring time components and timezone differences.
+// ignoring time components. Days follow date2's time zone, usually that of time.Now().
 func isAfterDay(date1, date2 time.Time) bool {
-	// Convert both dates to UTC to avoid timezone issues
-	utc1 := date1.UTC()
-	utc2 := date2.UTC()
+	loc := date2.Location()
+	local1 := date1.In(loc)
 
 	// Extract date components only
-	date1Midnight := time.Date(utc1.Year(), utc1.Month(), utc1.Day(), 0, 0, 0, 0, time.UTC)
-	date2Midnight := time.Date(utc2.Year(), utc2.Month(), utc2.Day(), 0, 0, 0, 0, time.UTC)
+	date1Midnight := time.Date(local1.Year(), local1.Month(), local1.Day(), 0, 0, 0, 0, loc)
+	date2Midnight := time.Date(date2.Year(), date2.Month(), date2.Day(), 0, 0, 0, 0, loc)
 
 	// Compare dates by using Unix timestamps at midnight
 	return date1Midnight.Unix() > date2Midnight.Unix()
@@ -35,20 +35,36 @@ func (m *Model) View() string {
 	switch m.activePanel {
 	case 0: // Task list panel
 		m.activeKeyMap = keymap.TaskListKeyMap
+		if m.priorityMode {
+			m.activeKeyMap = keymap.PriorityModeKeyMap
+		}
+		if m.listSearching {
+			m.activeKeyMap = keymap.TaskSearchKeyMap
+		}
 	case 1: // Task details panel
 		m.activeKeyMap = keymap.TaskDetailsKeyMap
 	case 2: // Timeline panel
 		m.activeKeyMap = keymap.TimelineKeyMap
+		if m.timelineSearching {
+			m.activeKeyMap = keymap.TimelineSearchKeyMap
+		}
 	default:
 		m.activeKeyMap = keymap.GlobalKeyMap
 	}
+	if m.noteTaskID != 0 {
+		m.activeKeyMap = keymap.NoteKeyMap
+	}
+	if m.renameTaskID != 0 {
+		m.activeKeyMap = keymap.RenameKeyMap
+	}
 	
 	// Update help model with current keymap
 	// Create a context ID from view mode and active panel to detect context changes
-	contextID := m.viewMode + "-" + fmt.Sprintf("%d", m.activePanel)
+	contextID := m.viewMode + "-" + fmt.Sprintf("%d", m.activePanel) + "-" + fmt.Sprintf("%t-%t-%t-%t-%t", m.priorityMode, m.timelineSearching, m.listSearching, m.noteTaskID != 0, m.renameTaskID != 0) + "-" + m.styles.Theme.Name
 	m.helpModel.SetKeyMap(m.activeKeyMap, contextID)
 	m.helpModel.AddDelegateKeyMap(keymap.GlobalKeyMap)
 	m.helpModel.SetWidth(m.width)
+	m.helpModel.SetNote(m.listSortNote())
 	
 	// Render the main view first
 	mainView := m.RenderMainView(sharedStyles)
@@ -65,6 +81,11 @@ func (m *Model) View() string {
 		return centeredHelp
 	}
 	
+	// The debug log panel covers the whole screen while it is open
+	if m.showDebugLog {
+		return m.renderDebugLog()
+	}
+	
 	// If a modal is active, render it on top of the main view
 	if m.showModal {
 		return m.modal.View(mainView, m.width, m.height)
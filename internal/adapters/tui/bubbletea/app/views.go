@@ -17,6 +17,9 @@ func (m *Model) renderFormView(sharedStyles *shared.Styles) string {
 		FormTitle:       m.formTitle,
 		FormDescription: m.formDescription,
 		FormPriority:    m.formPriority,
+		FormEnergy:      m.formEnergy,
+		FormTags:        m.formTags,
+		TagCompletion:   m.tagCompletion(),
 		FormDueDate:     m.formDueDate, // Keep for backward compatibility
 		ActiveField:     m.activeField,
 		Error:           m.err,
@@ -98,6 +101,9 @@ func (m *Model) renderMultiPanelView(sharedStyles *shared.Styles) string {
 		StatusMessage:  m.statusMessage,
 		StatusType:     m.statusType,
 		IsLoading:      m.isLoading,
+		ActiveFilter:   m.activeFilterLabel(),
+		ReadOnly:       m.readOnly,
+		CompletionTrend: m.completionTrend(),
 		
 		// Main content is the combined panels
 		Content:        panelsContent,
@@ -109,22 +115,27 @@ func (m *Model) renderTaskListPanel(styles *shared.Styles, width, height int) st
 	contentWidth := width - 2
 	
 	list := panels.RenderTaskList(panels.TaskListProps{
-		Tasks:          m.tasks,
-		TodoTasks:      m.todoTasks,
-		ProjectTasks:   m.projectTasks,
-		CompletedTasks: m.completedTasks,
-		Cursor:         m.cursor,
-		VisualCursor:   m.visualCursor,
-		Offset:         m.taskListOffset,
-		Width:          contentWidth,
-		Height:         height - 2,
-		Styles:         styles,
-		IsActive:       m.activePanel == 0,
-		Error:          m.err,
-		SuccessMsg:     m.successMsg,
-		ClearSuccess:   func() { m.successMsg = "" },
-		CursorOnHeader: m.cursorOnHeader,
-		CollapsibleMgr: m.collapsibleManager,
+		Tasks:           m.tasks,
+		RecentTasks:     m.recentTasks,
+		ShowRecent:      m.showRecent,
+		TodoTasks:       m.todoTasks,
+		InProgressTasks: m.inProgressTasks,
+		ProjectTasks:    m.projectTasks,
+		CompletedTasks:  m.completedTasks,
+		Cursor:          m.cursor,
+		VisualCursor:    m.visualCursor,
+		Offset:          m.taskListOffset,
+		Width:           contentWidth,
+		Height:          height - 2,
+		Styles:          styles,
+		IsActive:        m.activePanel == 0,
+		Error:           m.err,
+		SuccessMsg:      m.successMsg,
+		ClearSuccess:    func() { m.successMsg = "" },
+		CursorOnHeader:  m.cursorOnHeader,
+		CollapsibleMgr:  m.collapsibleManager,
+		SearchHeader:    m.listSearchHeader(),
+		TitleDraft:      m.titleDraftFor(m.detailsTaskID()),
 	})
 	
 	return shared.RenderPanel(shared.PanelProps{
@@ -226,8 +237,12 @@ func (m *Model) renderTaskDetailsPanel(styles *shared.Styles, width, height int)
 				section := m.collapsibleManager.GetSectionAtIndex(m.visualCursor)
 				if section != nil {
 					switch section.Type {
+					case hooks.SectionTypeRecent:
+						selectedSectionName = "Recently Created"
 					case hooks.SectionTypeTodo:
 						selectedSectionName = "Todo"
+					case hooks.SectionTypeInProgress:
+						selectedSectionName = "In Progress"
 					case hooks.SectionTypeProjects:
 						selectedSectionName = "Projects"
 					case hooks.SectionTypeCompleted:
@@ -239,14 +254,34 @@ func (m *Model) renderTaskDetailsPanel(styles *shared.Styles, width, height int)
 			// Not on a header, get the task from the cursor
 			taskID := m.tasks[m.cursor].ID
 
-			// First check todoTasks
-			for i, t := range m.todoTasks {
+			// First check recentTasks
+			for i, t := range m.recentTasks {
 				if t.ID == taskID {
-					selectedTask = &m.todoTasks[i]
+					selectedTask = &m.recentTasks[i]
 					break
 				}
 			}
 
+			// Then check todoTasks
+			if selectedTask == nil {
+				for i, t := range m.todoTasks {
+					if t.ID == taskID {
+						selectedTask = &m.todoTasks[i]
+						break
+					}
+				}
+			}
+
+			// Then check inProgressTasks
+			if selectedTask == nil {
+				for i, t := range m.inProgressTasks {
+					if t.ID == taskID {
+						selectedTask = &m.inProgressTasks[i]
+						break
+					}
+				}
+			}
+
 			// If not found, check projectTasks
 			if selectedTask == nil {
 				for i, t := range m.projectTasks {
@@ -282,6 +317,7 @@ func (m *Model) renderTaskDetailsPanel(styles *shared.Styles, width, height int)
 			IsActive:    m.activePanel == 1,
 		})
 	} else {
+		detailsID := m.detailsTaskID()
 		details = panels.RenderTaskDetails(panels.TaskDetailsProps{
 			Tasks:          m.tasks,
 			Cursor:         m.cursor,
@@ -292,6 +328,9 @@ func (m *Model) renderTaskDetailsPanel(styles *shared.Styles, width, height int)
 			Styles:         styles,
 			IsActive:       m.activePanel == 1,
 			CursorOnHeader: m.cursorOnHeader,
+			HideCompletedSubtasks: m.hideCompletedSubtasks,
+			Notes:          m.notes[detailsID],
+			NoteDraft:      m.noteDraftFor(detailsID),
 		})
 	}
 
@@ -326,6 +365,9 @@ func (m *Model) renderTimelinePanel(styles *shared.Styles, width, height int) st
 		CollapsibleMgr:  m.timelineCollapsibleMgr,
 		CursorPosition:  m.timelineCursor,
 		CursorOnHeader:  m.timelineCursorOnHeader,
+		SearchQuery:     m.timelineSearchQuery,
+		SearchHeader:    m.timelineSearchHeader(),
+		DueSoonDays:     m.dueSoonHighlightDays,
 	})
 	
 	return shared.RenderPanel(shared.PanelProps{
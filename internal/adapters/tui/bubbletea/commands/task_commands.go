@@ -9,6 +9,7 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/components/shared"
 	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/messages"
 	"github.com/newbpydev/tusk/internal/core/task"
 )
@@ -64,7 +65,7 @@ func ToggleTaskCompletion(
 			}
 		}
 		
-		message := fmt.Sprintf("Task '%s' marked as %s", currentTask.Title, newStatus)
+		message := fmt.Sprintf("Task '%s' marked as %s", currentTask.Title, shared.StatusLabel(newStatus))
 		return messages.StatusUpdateSuccessMsg{
 			Task:    result,
 			Message: message,
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/components/input"
 	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/styles"
 	"github.com/newbpydev/tusk/internal/core/task"
 )
@@ -78,7 +79,7 @@ func (m *FormModel) LoadTask(t task.Task) {
 	m.Priority = string(t.Priority)
 	
 	if t.DueDate != nil {
-		m.DueDate = t.DueDate.Format("2006-01-02")
+		m.DueDate = input.FormatDate(*t.DueDate)
 	} else {
 		m.DueDate = ""
 	}
@@ -141,6 +142,10 @@ func (m *FormModel) UpdateField(field, value string) {
 	case "description":
 		m.Description = value
 	case "dueDate":
+		// Store relative phrases such as "next friday" as the date they stand for
+		if t, err := input.ParseNaturalDate(value, time.Now()); err == nil {
+			value = input.FormatDate(t)
+		}
 		m.DueDate = value
 	case "priority":
 		m.Priority = value
@@ -160,9 +165,9 @@ func (m *FormModel) Validate() bool {
 	
 	// Validate date format if provided
 	if m.DueDate != "" {
-		_, err := time.Parse("2006-01-02", m.DueDate)
+		_, err := input.ParseDate(m.DueDate, time.Now())
 		if err != nil {
-			m.Errors["dueDate"] = "Invalid date format (use YYYY-MM-DD)"
+			m.Errors["dueDate"] = "Invalid date (use YYYY-MM-DD, tomorrow, friday or in 3 days)"
 			valid = false
 		}
 	}
@@ -261,7 +266,7 @@ func (m *FormModel) CreateTask(userID int32) task.Task {
 	
 	// Parse due date if provided
 	if m.DueDate != "" {
-		parsed, err := time.Parse("2006-01-02", m.DueDate)
+		parsed, err := input.ParseDate(m.DueDate, time.Now())
 		if err == nil {
 			dueDate = &parsed
 		}
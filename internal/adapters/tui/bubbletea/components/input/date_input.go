@@ -58,6 +58,8 @@ type DateInput struct {
 	Focused bool
 	// Error holds any validation error
 	Error string
+	// Text holds a date being typed, such as "tomorrow", until Enter or Tab applies it
+	Text string
 	// BaseStyle holds the base styling for the input
 	BaseStyle lipgloss.Style
 	// FocusedStyle holds the styling when input is focused
@@ -162,6 +164,53 @@ func (d *DateInput) Reset() {
 	d.HasValue = false
 	d.Mode = DateModeEmpty
 	d.Error = ""
+	d.Text = ""
+}
+
+// applyText parses the typed text and, when it is a valid date, makes it the value
+func (d *DateInput) applyText() {
+	t, err := ParseDate(d.Text, time.Now())
+	if err != nil {
+		d.Error = err.Error()
+		return
+	}
+	d.Text = ""
+	d.Error = ""
+	d.SetValue(t)
+}
+
+// handleTyping collects typed characters into Text. It reports whether the key
+// was consumed; once text is being typed, space, backspace and Enter edit or apply it.
+func (d *DateInput) handleTyping(msg tea.KeyMsg) bool {
+	switch msg.Type {
+	case tea.KeyRunes:
+		d.Text += string(msg.Runes)
+		d.Error = ""
+		return true
+	}
+
+	if d.Text == "" {
+		return false
+	}
+
+	switch msg.Type {
+	case tea.KeySpace:
+		d.Text += " "
+		return true
+	case tea.KeyBackspace:
+		runes := []rune(d.Text)
+		d.Text = string(runes[:len(runes)-1])
+		d.Error = ""
+		return true
+	case tea.KeyEnter:
+		d.applyText()
+		return true
+	case tea.KeyTab, tea.KeyShiftTab:
+		// Apply what was typed before focus moves on
+		d.applyText()
+		return false
+	}
+	return false
 }
 
 // IncrementDay adds one day to the current date
@@ -409,6 +458,11 @@ func (d *DateInput) EnterNextMode() {
 
 // HandleInput processes keyboard input for the date input
 func (d *DateInput) HandleInput(msg tea.KeyMsg) {
+	// Typed dates such as "tomorrow" or "2025-07-14" take precedence over the picker keys
+	if d.handleTyping(msg) {
+		return
+	}
+
 	// First handle special keys that should always work in any mode
 	switch msg.Type {
 	case tea.KeyEsc:
@@ -473,21 +527,18 @@ func (d *DateInput) SetValue(t time.Time) {
 	d.Mode = DateModeView
 }
 
-// SetValueFromString sets the input value from a string in format YYYY-MM-DD HH:MM
+// SetValueFromString sets the input value from a string in format YYYY-MM-DD HH:MM,
+// or a relative phrase understood by ParseNaturalDate
 func (d *DateInput) SetValueFromString(dateStr string) error {
 	if dateStr == "" {
 		d.Reset()
 		return nil
 	}
 	
-	// Try to parse full datetime string
-	t, err := time.Parse("2006-01-02 15:04", dateStr)
+	// Accept exact dates and relative phrases such as "tomorrow"
+	t, err := ParseDate(dateStr, time.Now())
 	if err != nil {
-		// Try date only format
-		t, err = time.Parse("2006-01-02", dateStr)
-		if err != nil {
-			return fmt.Errorf("invalid date format: %w", err)
-		}
+		return fmt.Errorf("invalid date format: %w", err)
 	}
 	
 	d.SetValue(t)
@@ -580,7 +631,9 @@ func (d *DateInput) handleLeftRightNavigation(keyType tea.KeyType) {
 func (d *DateInput) View() string {
 	var content string
 	
-	if d.HasValue {
+	if d.Text != "" {
+		content = d.Text + "▏ - Enter to apply"
+	} else if d.HasValue {
 		// Format with a consistent format that always shows date and time
 		switch d.Mode {
 		case DateModeView:
@@ -604,7 +657,7 @@ func (d *DateInput) View() string {
 			content = fmt.Sprintf("%s %s:[%s] - ← → to navigate", d.DateString(), d.HourString(), d.MinuteString())
 		}
 	} else {
-		content = "Optional - Space to set today's date"
+		content = "Optional - type a date (tomorrow, fri, in 3 days) or Space for today"
 	}
 	
 	style := d.BaseStyle
@@ -0,0 +1,87 @@
+package input
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/newbpydev/tusk/internal/core/task"
+)
+
+// dateLayouts are the exact formats tried before natural-language parsing
+var dateLayouts = []string{"2006-01-02 15:04", "2006-01-02"}
+
+// weekdays maps weekday names and their three-letter abbreviations to weekdays
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+// ParseDate reads a date typed into a date field. It accepts "YYYY-MM-DD" and
+// "YYYY-MM-DD HH:MM" in now's time zone and falls back to ParseNaturalDate for
+// anything else.
+func ParseDate(text string, now time.Time) (time.Time, error) {
+	text = strings.TrimSpace(text)
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, text, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+	return ParseNaturalDate(text, now)
+}
+
+// FormatDate writes a date the way ParseDate reads it back, with the time of day
+// only when it has one
+func FormatDate(t time.Time) string {
+	if task.HasDueTime(t) {
+		return t.Format(dateLayouts[0])
+	}
+	return t.Format(dateLayouts[1])
+}
+
+// ParseNaturalDate interprets a relative date phrase anchored on now and returns
+// the start of that day in now's time zone. It understands "today", "tomorrow",
+// weekday names such as "friday" or "next fri" (the first such day after today),
+// "next week" and "in N days" or "in N weeks".
+func ParseNaturalDate(text string, now time.Time) (time.Time, error) {
+	phrase := strings.Join(strings.Fields(strings.ToLower(text)), " ")
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch phrase {
+	case "today":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	case "next week":
+		return today.AddDate(0, 0, 7), nil
+	}
+
+	if day, ok := weekdays[strings.TrimPrefix(phrase, "next ")]; ok {
+		days := (int(day)-int(today.Weekday())+6)%7 + 1
+		return today.AddDate(0, 0, days), nil
+	}
+
+	if rest, ok := strings.CutPrefix(phrase, "in "); ok {
+		count, unit, _ := strings.Cut(rest, " ")
+		n, err := strconv.Atoi(count)
+		if count == "a" || count == "an" {
+			n, err = 1, nil
+		}
+		if err == nil && n >= 0 {
+			switch unit {
+			case "day", "days":
+				return today.AddDate(0, 0, n), nil
+			case "week", "weeks":
+				return today.AddDate(0, 0, 7*n), nil
+			}
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unrecognized date %q (try YYYY-MM-DD, tomorrow, friday or in 3 days)", text)
+}
@@ -0,0 +1,77 @@
+package input
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNaturalDate(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	// A Wednesday afternoon
+	now := time.Date(2025, 7, 9, 15, 30, 0, 0, loc)
+	day := func(d int) time.Time { return time.Date(2025, 7, d, 0, 0, 0, 0, loc) }
+
+	testCases := []struct {
+		name           string
+		text           string
+		expected       time.Time
+		expectedErrMsg string
+	}{
+		{name: "Today", text: "today", expected: day(9)},
+		{name: "Tomorrow", text: " Tomorrow ", expected: day(10)},
+		{name: "Weekday later this week", text: "friday", expected: day(11)},
+		{name: "Abbreviated weekday", text: "mon", expected: day(14)},
+		{name: "Same weekday means next week", text: "wednesday", expected: day(16)},
+		{name: "Next weekday", text: "next  fri", expected: day(11)},
+		{name: "Next week", text: "next week", expected: day(16)},
+		{name: "In days", text: "in 3 days", expected: day(12)},
+		{name: "In a week", text: "in a week", expected: day(16)},
+		{name: "In weeks", text: "in 2 weeks", expected: day(23)},
+		{name: "Unknown phrase", text: "someday", expectedErrMsg: "unrecognized date"},
+		{name: "Unknown unit", text: "in 3 fortnights", expectedErrMsg: "unrecognized date"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseNaturalDate(tc.text, now)
+
+			if tc.expectedErrMsg != "" {
+				assert.ErrorContains(t, err, tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tc.expected.Equal(got), "expected %s, got %s", tc.expected, got)
+		})
+	}
+}
+
+func TestParseDatePrefersExactFormats(t *testing.T) {
+	now := time.Date(2025, 7, 9, 15, 30, 0, 0, time.UTC)
+
+	got, err := ParseDate("2025-07-14", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 7, 14, 0, 0, 0, 0, time.UTC), got)
+
+	got, err = ParseDate("tomorrow", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 7, 10, 0, 0, 0, 0, time.UTC), got)
+}
+
+func TestParseDateKeepsTimeOfDay(t *testing.T) {
+	loc := time.FixedZone("UTC-5", -5*60*60)
+	now := time.Date(2025, 7, 9, 15, 30, 0, 0, loc)
+
+	got, err := ParseDate("2025-07-14 09:45", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 7, 14, 9, 45, 0, 0, loc), got)
+	assert.Equal(t, "2025-07-14 09:45", FormatDate(got))
+
+	// Date-only input stays on its day in now's zone
+	got, err = ParseDate("2025-07-14", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 7, 14, 0, 0, 0, 0, loc), got)
+	assert.Equal(t, "2025-07-14", FormatDate(got))
+}
@@ -19,6 +19,7 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/styles"
 )
 
 // HeaderProps contains all the data needed to render the header component
@@ -28,12 +29,17 @@ type HeaderProps struct {
 	StatusMessage string
 	StatusType    string
 	IsLoading     bool
+	ActiveFilter  string // Description of the active task filter, empty when unfiltered
+	ReadOnly      bool   // Whether changes are disabled, shown as a lock in place of the tagline
+	// Today's completions compared to yesterday's, e.g. "↑2 vs yesterday"; empty hides it
+	CompletionTrend string
 }
 
 // RenderHeader creates a header with app name, time, and status information
 func RenderHeader(props HeaderProps) string {
-	// Define the background color to use consistently
-	headerBgColor := lipgloss.Color("#2d3748")
+	// Colors come from the active theme; the background is used consistently
+	theme := styles.ActiveTheme()
+	headerBgColor := lipgloss.Color(theme.HeaderBackground)
 
 	// Calculate section widths
 	logoWidth := props.Width / 4                       // 25% for logo
@@ -43,14 +49,14 @@ func RenderHeader(props HeaderProps) string {
 	// 1. Left Section - Logo
 	logoStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#48bb78")).
+		Foreground(lipgloss.Color(theme.Success)).
 		Width(logoWidth).
 		PaddingLeft(2).
 		Background(headerBgColor)
 
 	taglineStyle := lipgloss.NewStyle().
 		Italic(true).
-		Foreground(lipgloss.Color("#a0aec0")).
+		Foreground(lipgloss.Color(theme.HeaderMuted)).
 		Width(logoWidth).
 		PaddingLeft(2).
 		Background(headerBgColor)
@@ -60,13 +66,13 @@ func RenderHeader(props HeaderProps) string {
 		Width(timeWidth).
 		Align(lipgloss.Center).
 		Bold(true).
-		Foreground(lipgloss.Color("#ffffff")).
+		Foreground(lipgloss.Color(theme.HeaderText)).
 		Background(headerBgColor)
 
 	dateStyle := lipgloss.NewStyle().
 		Width(timeWidth).
 		Align(lipgloss.Center).
-		Foreground(lipgloss.Color("#a0aec0")).
+		Foreground(lipgloss.Color(theme.HeaderMuted)).
 		Background(headerBgColor)
 
 	// 3. Right Section - Status
@@ -86,7 +92,7 @@ func RenderHeader(props HeaderProps) string {
 	if props.IsLoading {
 		loadingStyle := lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color("#90cdf4")).
+			Foreground(lipgloss.Color(theme.Loading)).
 			Background(headerBgColor)
 		row1Right = statusContainerStyle.Render(loadingStyle.Render("Loading..."))
 	} else if props.StatusMessage != "" {
@@ -96,25 +102,25 @@ func RenderHeader(props HeaderProps) string {
 		switch props.StatusType {
 		case "success":
 			msgStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#48bb78")).
+				Foreground(lipgloss.Color(theme.Success)).
 				Bold(true).
 				Background(headerBgColor)
 			statusIcon = "✓"
 		case "error":
 			msgStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#f56565")).
+				Foreground(lipgloss.Color(theme.Error)).
 				Bold(true).
 				Background(headerBgColor)
 			statusIcon = "✗"
 		case "info":
 			msgStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#4299e1")).
+				Foreground(lipgloss.Color(theme.Info)).
 				Bold(true).
 				Background(headerBgColor)
 			statusIcon = "ℹ"
 		default:
 			msgStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#a0aec0")).
+				Foreground(lipgloss.Color(theme.HeaderMuted)).
 				Background(headerBgColor)
 			statusIcon = "→"
 		}
@@ -125,8 +131,23 @@ func RenderHeader(props HeaderProps) string {
 
 	// Second row: Tagline + Date + Empty
 	row2Left := taglineStyle.Render("Task Management Simplified")
+	if props.ReadOnly {
+		lockStyle := taglineStyle.Italic(false).Bold(true).Foreground(lipgloss.Color(theme.Warning))
+		row2Left = lockStyle.Render("🔒 Read-only")
+	}
 	row2Middle := dateStyle.Render(props.CurrentTime.Format("Monday, January 2, 2006"))
 	row2Right := statusContainerStyle.Render("") // Empty space or could be used for additional status info
+	if props.ActiveFilter != "" {
+		filterStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color(theme.Warning)).
+			Background(headerBgColor)
+		row2Right = statusContainerStyle.Render(filterStyle.Render("Filter: " + props.ActiveFilter))
+	} else if props.CompletionTrend != "" {
+		trendStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color(theme.Success)).
+			Background(headerBgColor)
+		row2Right = statusContainerStyle.Render(trendStyle.Render(props.CompletionTrend))
+	}
 
 	// Construct main content rows
 	row1 := lipgloss.JoinHorizontal(lipgloss.Top, row1Left, row1Middle, row1Right)
@@ -15,6 +15,9 @@ type MainLayoutProps struct {
 	StatusMessage string
 	StatusType    string
 	IsLoading     bool
+	ActiveFilter  string
+	ReadOnly      bool
+	CompletionTrend string
 
 	// Main content
 	Content string
@@ -35,6 +38,9 @@ func RenderMainLayout(props MainLayoutProps) string {
 		StatusMessage: props.StatusMessage,
 		StatusType:    props.StatusType,
 		IsLoading:     props.IsLoading,
+		ActiveFilter:  props.ActiveFilter,
+		ReadOnly:      props.ReadOnly,
+		CompletionTrend: props.CompletionTrend,
 	})
 
 	// Calculate content height to fill available space between header and help footer
@@ -17,6 +17,7 @@ package panels
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/components/input"
@@ -29,7 +30,10 @@ type CreateFormProps struct {
 	FormTitle       string
 	FormDescription string
 	FormPriority    string
-	FormDueDate     string // Kept for backward compatibility
+	FormEnergy      string
+	FormTags        string           // comma-separated
+	TagCompletion   string           // known tag suggested for the tag being typed
+	FormDueDate     string           // Kept for backward compatibility
 	ActiveDateInput *input.DateInput // New interactive date input component
 	ActiveField     int
 	Error           error
@@ -52,6 +56,12 @@ func RenderCreateForm(props CreateFormProps) string {
 		dueDateDisplay = props.ActiveDateInput.StringValue()
 	}
 
+	// Tags are edited as text and shown as colored tags otherwise, see below
+	tagsValue := ""
+	if props.ActiveField == 5 {
+		tagsValue = props.FormTags
+	}
+
 	// Form fields
 	formFields := []struct {
 		label    string
@@ -63,6 +73,8 @@ func RenderCreateForm(props CreateFormProps) string {
 		{"Description", props.FormDescription, props.ActiveField == 1, false},
 		{"Priority", props.FormPriority, props.ActiveField == 2, false},
 		{"Due Date", dueDateDisplay, props.ActiveField == 3, false},
+		{"Energy", props.FormEnergy, props.ActiveField == 4, false},
+		{"Tags", tagsValue, props.ActiveField == 5, false},
 	}
 
 	// Render each field
@@ -115,17 +127,43 @@ func RenderCreateForm(props CreateFormProps) string {
 			s += " - Press Space to cycle"
 		}
 
+		// Energy is optional, so say so when it is unset
+		if i == 4 {
+			if props.FormEnergy == "" {
+				s += props.Styles.Help.Render("(unset)")
+			}
+			s += " - Press Space to cycle"
+		}
+
+		// Tags are typed as a list while focused and shown as colored tags otherwise
+		if i == 5 {
+			if field.active {
+				if props.TagCompletion != "" {
+					s += props.Styles.Help.Render(" → " + props.TagCompletion)
+				}
+				s += " - Separate with commas, → to complete"
+			} else if tags := task.ParseTagList(props.FormTags); len(tags) > 0 {
+				rendered := make([]string, len(tags))
+				for j, tag := range tags {
+					rendered[j] = shared.RenderTag(tag)
+				}
+				s += strings.Join(rendered, " ")
+			} else {
+				s += props.Styles.Help.Render("(none)")
+			}
+		}
+
 		s += "\n\n"
 	}
 
 	// Submit button
-	if props.ActiveField == 4 {
+	if props.ActiveField == 6 {
 		s += props.Styles.SelectedItem.Render("[Save Task]")
 	} else {
 		s += "[Save Task]"
 	}
 
-	s += "\n\n" + props.Styles.Help.Render("Tab: next field • Enter: submit/cycle date mode • Space: set today's date • ↑↓: change date values • Esc: cancel")
+	s += "\n\n" + props.Styles.Help.Render("Tab: next field • →: complete tag • Enter: submit/cycle date mode • Space: set today's date • ↑↓: change date values • Esc: cancel")
 
 	return s
 }
@@ -63,6 +63,11 @@ func RenderSectionHeaderMessage(props SectionHeaderMessageProps) string {
 		sb.WriteString("These are your day-to-day tasks that need to be completed.\n\n")
 		sb.WriteString("To see task details, select a specific task instead of the section header.\n")
 		
+	case "In Progress":
+		sb.WriteString("This section contains the tasks you have started working on.\n")
+		sb.WriteString("Press i on a task to move it from Todo to In Progress to Done.\n\n")
+		sb.WriteString("To see task details, select a specific task instead of the section header.\n")
+		
 	case "Projects":
 		sb.WriteString("This section contains tasks associated with specific projects.\n")
 		sb.WriteString("Group related tasks under projects for better organization.\n\n")
@@ -18,6 +18,7 @@ package panels
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/components/shared"
 	"github.com/newbpydev/tusk/internal/core/task"
@@ -34,6 +35,12 @@ type TaskDetailsProps struct {
 	Styles         *shared.Styles
 	IsActive       bool
 	CursorOnHeader bool // whether selection is on a section header
+	// HideCompletedSubtasks leaves completed children out of the subtask tree
+	HideCompletedSubtasks bool
+	// Notes of the shown task, newest first
+	Notes []task.TaskNote
+	// NoteDraft is the note being typed for the shown task, nil when none is
+	NoteDraft *string
 }
 
 // RenderTaskDetails renders the task details panel with a fixed header and scrollable content
@@ -82,7 +89,7 @@ func RenderTaskDetails(props TaskDetailsProps) string {
 		case task.StatusInProgress:
 			statusStyle = props.Styles.InProgress
 		}
-		scrollableContent.WriteString(statusLabel + statusStyle.Render(string(t.Status)) + "\n\n")
+		scrollableContent.WriteString(statusLabel + statusStyle.Render(shared.StatusLabel(t.Status)) + "\n\n")
 
 		// Priority with appropriate styling
 		priorityLabel := props.Styles.Title.Render("Priority: ")
@@ -95,6 +102,21 @@ func RenderTaskDetails(props TaskDetailsProps) string {
 		}
 		scrollableContent.WriteString(priorityLabel + priorityStyle.Render(string(t.Priority)) + "\n\n")
 
+		// Energy only when one was set
+		if t.Energy != "" {
+			scrollableContent.WriteString(props.Styles.Title.Render("Energy: ") + string(t.Energy) + "\n\n")
+		}
+
+		// Points only when an estimate was set
+		if t.Points > 0 {
+			scrollableContent.WriteString(props.Styles.Title.Render("Points: ") + fmt.Sprintf("%d", t.Points) + "\n\n")
+		}
+
+		// Tags only when the task has some
+		if len(t.Tags) > 0 {
+			scrollableContent.WriteString(props.Styles.Title.Render("Tags: ") + shared.RenderTags(t.Tags) + "\n\n")
+		}
+
 		// Due date if available
 		if t.DueDate != nil {
 			dueLabel := props.Styles.Title.Render("Due Date: ")
@@ -124,6 +146,23 @@ func RenderTaskDetails(props TaskDetailsProps) string {
 			scrollableContent.WriteString(descriptionLabel + "No description provided\n\n")
 		}
 
+		// Notes, newest first, below the note being typed
+		if len(props.Notes) > 0 || props.NoteDraft != nil {
+			renderNotes(&scrollableContent, props)
+		}
+
+		// Subtasks, with progress always based on every child
+		if len(t.SubTasks) > 0 {
+			subtasksLabel := props.Styles.Title.Render("Subtasks: ")
+			progress := fmt.Sprintf("%d/%d done (%.0f%%)", t.CompletedCount, t.TotalCount, t.Progress*100)
+			scrollableContent.WriteString(subtasksLabel + progress + "\n")
+			if trend := renderBurndown(t); trend != "" {
+				scrollableContent.WriteString(props.Styles.Title.Render("Trend: ") + trend + "\n")
+			}
+			renderSubtasks(&scrollableContent, t.SubTasks, 1, props)
+			scrollableContent.WriteString("\n")
+		}
+
 		// Created/Updated timestamps
 		if !t.CreatedAt.IsZero() {
 			scrollableContent.WriteString(props.Styles.Title.Render("Created: ") + t.CreatedAt.Format("2006-01-02 15:04") + "\n")
@@ -140,6 +179,7 @@ func RenderTaskDetails(props TaskDetailsProps) string {
 		scrollableContent.WriteString("\n" + props.Styles.Help.Render("Press 'e' to edit task") + "\n")
 		scrollableContent.WriteString(props.Styles.Help.Render("Press 'c' to toggle completion") + "\n")
 		scrollableContent.WriteString(props.Styles.Help.Render("Press 'd' to delete task") + "\n")
+		scrollableContent.WriteString(props.Styles.Help.Render("Press 'w' to add a note") + "\n")
 	}
 
 	return shared.RenderScrollablePanel(shared.ScrollablePanelProps{
@@ -155,3 +195,66 @@ func RenderTaskDetails(props TaskDetailsProps) string {
 		CursorPosition:    props.Cursor,
 	})
 }
+
+// burndownDays is how many days of history the burndown sparkline covers
+const burndownDays = 14
+
+// renderBurndown renders the open subtask counts of the last two weeks as a
+// sparkline, or an empty string when the task has no subtasks.
+func renderBurndown(t task.Task) string {
+	now := time.Now()
+	points := task.Burndown(t, now.AddDate(0, 0, -(burndownDays-1)), now)
+	if len(points) == 0 {
+		return ""
+	}
+
+	remaining := make([]int, len(points))
+	for i, p := range points {
+		remaining[i] = p.Remaining
+	}
+	return fmt.Sprintf("%s %d open (%dd)", shared.Sparkline(remaining), remaining[len(remaining)-1], burndownDays)
+}
+
+// renderSubtasks writes a task's children as an indented tree. When completed
+// subtasks are hidden, a hint records how many were left out at each level.
+func renderSubtasks(b *strings.Builder, subtasks []task.Task, depth int, props TaskDetailsProps) {
+	indent := strings.Repeat("  ", depth)
+	hidden := 0
+
+	for _, st := range subtasks {
+		if props.HideCompletedSubtasks && st.IsCompleted {
+			hidden++
+			continue
+		}
+
+		checkbox := "[ ]"
+		style := props.Styles.Todo
+		if st.IsCompleted {
+			checkbox = "[✓]"
+			style = props.Styles.Done
+		}
+		b.WriteString(indent + style.Render(checkbox+" "+st.Title) + "\n")
+
+		if len(st.SubTasks) > 0 {
+			renderSubtasks(b, st.SubTasks, depth+1, props)
+		}
+	}
+
+	if hidden > 0 {
+		b.WriteString(indent + props.Styles.Help.Render(fmt.Sprintf("(%d completed hidden)", hidden)) + "\n")
+	}
+}
+
+// renderNotes writes the note being typed, if any, followed by the task's notes with
+// the time each was added
+func renderNotes(b *strings.Builder, props TaskDetailsProps) {
+	b.WriteString(props.Styles.Title.Render("Notes: ") + "\n")
+	if props.NoteDraft != nil {
+		b.WriteString("> " + *props.NoteDraft + "_\n")
+		b.WriteString(props.Styles.Help.Render("enter to save, esc to discard") + "\n")
+	}
+	for _, n := range props.Notes {
+		b.WriteString(props.Styles.Help.Render(n.CreatedAt.Format("2006-01-02 15:04")) + "  " + n.Body + "\n")
+	}
+	b.WriteString("\n")
+}
@@ -18,6 +18,7 @@ package panels
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/components/shared"
 	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/hooks"
@@ -26,22 +27,27 @@ import (
 
 // TaskListProps contains all properties needed to render the task list panel
 type TaskListProps struct {
-	Tasks          []task.Task // Main task list
-	TodoTasks      []task.Task // Already categorized todo tasks
-	ProjectTasks   []task.Task // Already categorized project tasks
-	CompletedTasks []task.Task // Already categorized completed tasks
-	Cursor         int
-	VisualCursor   int // Cursor position in the collapsible section view
-	Offset         int
-	Width          int
-	Height         int
-	Styles         *shared.Styles
-	IsActive       bool
-	Error          error
-	SuccessMsg     string
-	ClearSuccess   func()
-	CursorOnHeader bool // Whether cursor is on a section header
-	CollapsibleMgr *hooks.CollapsibleManager
+	Tasks           []task.Task // Main task list
+	RecentTasks     []task.Task // Recently created tasks, shown in their own section
+	ShowRecent      bool        // Whether the Recently Created section is shown
+	TodoTasks       []task.Task // Already categorized todo tasks
+	InProgressTasks []task.Task // Already categorized in progress tasks
+	ProjectTasks    []task.Task // Already categorized project tasks
+	CompletedTasks  []task.Task // Already categorized completed tasks
+	Cursor          int
+	VisualCursor    int // Cursor position in the collapsible section view
+	Offset          int
+	Width           int
+	Height          int
+	Styles          *shared.Styles
+	IsActive        bool
+	Error           error
+	SuccessMsg      string
+	ClearSuccess    func()
+	CursorOnHeader  bool // Whether cursor is on a section header
+	CollapsibleMgr  *hooks.CollapsibleManager
+	SearchHeader    string  // Search line shown above the sections, empty without a search
+	TitleDraft      *string // New title being typed for the selected task, nil unless renaming
 }
 
 // RenderTaskList renders the task list panel with a fixed header and scrollable content
@@ -51,6 +57,9 @@ func RenderTaskList(props TaskListProps) string {
 	viewportHeight := props.Height - 4 // Account for borders and header
 
 	headerContent := ""
+	if props.SearchHeader != "" {
+		headerContent += props.SearchHeader + "\n"
+	}
 	if props.Error != nil {
 		headerContent += fmt.Sprintf("Error: %v\n\n", props.Error)
 	}
@@ -111,17 +120,20 @@ func renderFlatTaskList(builder *strings.Builder, props TaskListProps) {
 // renderCollapsibleTaskList renders tasks organized into collapsible sections
 func renderCollapsibleTaskList(builder *strings.Builder, props TaskListProps) {
 	// Use the pre-categorized task lists if provided, otherwise categorize here
-	var todoTasks, completedTasks []task.Task
+	var todoTasks, inProgressTasks, completedTasks []task.Task
 
-	if len(props.TodoTasks) > 0 || len(props.CompletedTasks) > 0 {
+	if len(props.TodoTasks) > 0 || len(props.InProgressTasks) > 0 || len(props.CompletedTasks) > 0 || props.ShowRecent {
 		// Use the pre-categorized lists
 		todoTasks = props.TodoTasks
+		inProgressTasks = props.InProgressTasks
 		completedTasks = props.CompletedTasks
 	} else {
 		// Categorize tasks on the fly
 		for _, t := range props.Tasks {
 			if t.Status == task.StatusDone {
 				completedTasks = append(completedTasks, t)
+			} else if t.Status == task.StatusInProgress {
+				inProgressTasks = append(inProgressTasks, t)
 			} else {
 				todoTasks = append(todoTasks, t)
 			}
@@ -132,25 +144,45 @@ func renderCollapsibleTaskList(builder *strings.Builder, props TaskListProps) {
 	props.CollapsibleMgr.ClearSections()
 
 	// Add our sections
+	// Recently created section on top, when enabled
+	start := 0
+	if props.ShowRecent {
+		props.CollapsibleMgr.AddSection(hooks.SectionTypeRecent, "Recently Created", len(props.RecentTasks), 0)
+		start = len(props.RecentTasks)
+	}
+
 	// Todo tasks section (expanded by default)
-	props.CollapsibleMgr.AddSection(hooks.SectionTypeTodo, "Todo", len(todoTasks), 0)
+	props.CollapsibleMgr.AddSection(hooks.SectionTypeTodo, "Todo", len(todoTasks), start)
+	start += len(todoTasks)
+
+	// Tasks being worked on
+	props.CollapsibleMgr.AddSection(hooks.SectionTypeInProgress, "In Progress", len(inProgressTasks), start)
+	start += len(inProgressTasks)
 
 	// Projects section - use pre-categorized projects if available
 	projectCount := 0
 	if len(props.ProjectTasks) > 0 {
 		projectCount = len(props.ProjectTasks)
 	}
-	props.CollapsibleMgr.AddSection(hooks.SectionTypeProjects, "Projects", projectCount, len(todoTasks))
+	props.CollapsibleMgr.AddSection(hooks.SectionTypeProjects, "Projects", projectCount, start)
 
 	// Completed tasks section
-	props.CollapsibleMgr.AddSection(hooks.SectionTypeCompleted, "Completed", len(completedTasks), len(todoTasks)+projectCount)
+	props.CollapsibleMgr.AddSection(hooks.SectionTypeCompleted, "Completed", len(completedTasks), start+projectCount)
 
 	// Now render the sections and their contents
 	var visibleIndex int = 0
 
+	// Recently created section
+	if props.ShowRecent {
+		visibleIndex = renderSection(builder, props, hooks.SectionTypeRecent, props.RecentTasks, visibleIndex)
+	}
+
 	// Todo section
 	visibleIndex = renderSection(builder, props, hooks.SectionTypeTodo, todoTasks, visibleIndex)
 
+	// In progress section
+	visibleIndex = renderSection(builder, props, hooks.SectionTypeInProgress, inProgressTasks, visibleIndex)
+
 	// Projects section
 	visibleIndex = renderSection(builder, props, hooks.SectionTypeProjects, props.ProjectTasks, visibleIndex)
 
@@ -199,6 +231,12 @@ func renderSection(builder *strings.Builder, props TaskListProps, sectionType ho
 			// Determine if this task is selected
 			isSelected := !props.CursorOnHeader && props.VisualCursor == taskVisibleIndex
 
+			// The selected task shows the title being typed in place of its line
+			if isSelected && props.TitleDraft != nil {
+				builder.WriteString("→   " + props.Styles.SelectedItem.Render("Title: "+*props.TitleDraft+"_") + "\n")
+				continue
+			}
+
 			// Render with additional indentation for tree-like appearance
 			renderTaskLineWithIndent(builder, t, i, isSelected, props.Styles)
 		}
@@ -241,6 +279,20 @@ func renderTaskLine(builder *strings.Builder, t task.Task, index int, cursor int
 		t.Title,
 		priorityStyle.Render(priority))
 
+	// Flag projects hiding overdue work, even when collapsed
+	if t.HasOverdueDescendant {
+		taskLine += " " + styles.HighPriority.Render("●")
+	}
+
+	if tags := shared.RenderTags(t.Tags); tags != "" {
+		taskLine += " " + tags
+	}
+
+	// Nudge chronic stragglers with how long they have been carried past their due date
+	if carried := shared.CarriedLabel(t, time.Now()); carried != "" {
+		taskLine += " " + styles.MediumPriority.Render(carried)
+	}
+
 	if index == cursor {
 		// Add cursor indicator and highlight
 		builder.WriteString("→ " + styles.SelectedItem.Render(taskLine) + "\n")
@@ -277,6 +329,20 @@ func renderTaskLineWithIndent(builder *strings.Builder, t task.Task, index int,
 		t.Title,
 		priorityStyle.Render(priority))
 
+	// Flag projects hiding overdue work, even when collapsed
+	if t.HasOverdueDescendant {
+		taskLine += " " + styles.HighPriority.Render("●")
+	}
+
+	if tags := shared.RenderTags(t.Tags); tags != "" {
+		taskLine += " " + tags
+	}
+
+	// Nudge chronic stragglers with how long they have been carried past their due date
+	if carried := shared.CarriedLabel(t, time.Now()); carried != "" {
+		taskLine += " " + styles.MediumPriority.Render(carried)
+	}
+
 	if isSelected {
 		// Add cursor indicator, indentation and highlight
 		builder.WriteString("→   " + styles.SelectedItem.Render(taskLine) + "\n")
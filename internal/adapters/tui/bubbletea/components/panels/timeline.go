@@ -45,6 +45,9 @@ type TimelineProps struct {
 	CollapsibleMgr *hooks.CollapsibleManager
 	CursorPosition int  // Position for scrolling and highlighting
 	CursorOnHeader bool // Whether the cursor is on a section header
+	SearchQuery    string // Title search whose matches are highlighted
+	SearchHeader   string // Search line shown above the sections, empty without a search
+	DueSoonDays    int    // Upcoming tasks due within this many days get the due soon color (0 for none)
 }
 
 // RenderTimeline renders the timeline panel with a fixed header and scrollable content
@@ -203,7 +206,7 @@ func RenderTimeline(props TimelineProps) string {
 	
 	return shared.RenderScrollablePanel(shared.ScrollablePanelProps{
 		Title:             "Timeline",
-		HeaderContent:     "",
+		HeaderContent:     props.SearchHeader,
 		ScrollableContent: scrollableContent.String(),
 		EmptyMessage:      "No tasks with due dates",
 		Width:             props.Width,
@@ -248,7 +251,7 @@ func renderTasksWithHighlight(sb *strings.Builder, tasks []task.Task, props Time
 		// Format due date with appropriate styling based on section
 		dueDate := ""
 		if t.DueDate != nil {
-			dueDate = t.DueDate.Format("2006-01-02")
+			dueDate = shared.FormatDate(*t.DueDate)
 
 			switch sectionType {
 			case hooks.SectionTypeOverdue:
@@ -288,9 +291,16 @@ func renderTasksWithHighlight(sb *strings.Builder, tasks []task.Task, props Time
 		}
 
 		// Build title part and due date
-		titlePart = " " + t.Title
+		titlePart = " " + highlightMatch(t.Title, props.SearchQuery)
 		if dueDate != "" {
-			titlePart += fmt.Sprintf(" (%s)", sectionStyle.Render(dueDate))
+			dateStyle := sectionStyle
+			if sectionType == hooks.SectionTypeUpcoming && props.DueSoonDays > 0 && task.IsDueSoon(*t.DueDate, time.Now(), props.DueSoonDays) {
+				dateStyle = props.Styles.MediumPriority
+			}
+			titlePart += fmt.Sprintf(" (%s)", dateStyle.Render(dueDate))
+		}
+		if carried := shared.CarriedLabel(t, time.Now()); carried != "" {
+			titlePart += " " + props.Styles.MediumPriority.Render(carried)
 		}
 
 		// Combine all parts with proper indentation
@@ -299,12 +309,9 @@ func renderTasksWithHighlight(sb *strings.Builder, tasks []task.Task, props Time
 
 		// Always add a description line for consistent height (empty if not available)
 		if t.Description != nil && *t.Description != "" {
-			desc := *t.Description
 			// Ensure consistent line height by always limiting to one line
-			if len(desc) > 50 {
-				desc = desc[:47] + "..."
-			}
-			sb.WriteString(fmt.Sprintf("     %s\n", props.Styles.Help.Render(desc)))
+			desc := truncateDescription(*t.Description, descriptionWidth(props.Width))
+			sb.WriteString(descriptionIndent + props.Styles.Help.Render(desc) + "\n")
 		} else {
 			// Add an empty description line for consistent height
 			sb.WriteString("     \n")
@@ -317,6 +324,44 @@ func renderTasksWithHighlight(sb *strings.Builder, tasks []task.Task, props Time
 	}
 }
 
+// descriptionIndent lines up a task's description under its title
+const descriptionIndent = "     "
+
+// descriptionWidth is how many characters of a description fit on one line of a
+// timeline panel of the given width, after its padding and the indent
+func descriptionWidth(panelWidth int) int {
+	return max(10, panelWidth-4-len(descriptionIndent))
+}
+
+// truncateDescription puts a description on a single line of at most width characters
+func truncateDescription(desc string, width int) string {
+	desc = strings.Join(strings.Fields(desc), " ")
+	if runes := []rune(desc); len(runes) > width {
+		return string(runes[:width-3]) + "..."
+	}
+	return desc
+}
+
+// highlightMatch renders the first case-insensitive occurrence of query in title
+// in reverse video, so search matches stand out
+func highlightMatch(title, query string) string {
+	if query == "" {
+		return title
+	}
+
+	lower, query := strings.ToLower(title), strings.ToLower(query)
+	i := strings.Index(lower, query)
+	if i < 0 {
+		return title
+	}
+	if len(lower) != len(title) {
+		// Lowercasing changed byte offsets, so highlight the whole title instead
+		return lipgloss.NewStyle().Reverse(true).Render(title)
+	}
+	end := i + len(query)
+	return title[:i] + lipgloss.NewStyle().Reverse(true).Render(title[i:end]) + title[end:]
+}
+
 // renderLegacyTimeline renders the timeline panel without using collapsible sections
 // This is the original timeline implementation before the refactoring
 func renderLegacyTimeline(props TimelineProps, overdue, today, upcoming []task.Task) string {
@@ -346,16 +391,17 @@ func renderLegacyTimeline(props TimelineProps, overdue, today, upcoming []task.T
 				statusSymbol = "[ ]"
 			}
 
-			line := fmt.Sprintf("  %s %s (%s)\n", statusSymbol, t.Title, props.Styles.HighPriority.Render(dueDate))
+			line := fmt.Sprintf("  %s %s (%s)", statusSymbol, t.Title, props.Styles.HighPriority.Render(dueDate))
+			if carried := shared.CarriedLabel(t, time.Now()); carried != "" {
+				line += " " + props.Styles.MediumPriority.Render(carried)
+			}
+			line += "\n"
 			scrollableContent.WriteString(line)
 
 			// Add a short description if available
 			if t.Description != nil && *t.Description != "" {
-				desc := *t.Description
-				if len(desc) > 50 {
-					desc = desc[:47] + "..."
-				}
-				scrollableContent.WriteString(fmt.Sprintf("     %s\n", props.Styles.Help.Render(desc)))
+				desc := truncateDescription(*t.Description, descriptionWidth(props.Width))
+				scrollableContent.WriteString(descriptionIndent + props.Styles.Help.Render(desc) + "\n")
 			}
 
 			// Add a separator between tasks except for the last one
@@ -394,11 +440,8 @@ func renderLegacyTimeline(props TimelineProps, overdue, today, upcoming []task.T
 
 			// Add a short description if available
 			if t.Description != nil && *t.Description != "" {
-				desc := *t.Description
-				if len(desc) > 50 {
-					desc = desc[:47] + "..."
-				}
-				scrollableContent.WriteString(fmt.Sprintf("     %s\n", props.Styles.Help.Render(desc)))
+				desc := truncateDescription(*t.Description, descriptionWidth(props.Width))
+				scrollableContent.WriteString(descriptionIndent + props.Styles.Help.Render(desc) + "\n")
 			}
 
 			// Add a separator between tasks except for the last one
@@ -437,11 +480,8 @@ func renderLegacyTimeline(props TimelineProps, overdue, today, upcoming []task.T
 
 			// Add a short description if available
 			if t.Description != nil && *t.Description != "" {
-				desc := *t.Description
-				if len(desc) > 50 {
-					desc = desc[:47] + "..."
-				}
-				scrollableContent.WriteString(fmt.Sprintf("     %s\n", props.Styles.Help.Render(desc)))
+				desc := truncateDescription(*t.Description, descriptionWidth(props.Width))
+				scrollableContent.WriteString(descriptionIndent + props.Styles.Help.Render(desc) + "\n")
 			}
 
 			// Add a separator between tasks except for the last one
@@ -74,6 +74,8 @@ func RenderSectionHeader(section SectionData, opts SectionHeaderOptions) string
 	switch section.Type {
 	case hooks.SectionTypeTodo:
 		header = baseStyle.Render(header)
+	case hooks.SectionTypeInProgress:
+		header = baseStyle.Render(header)
 	case hooks.SectionTypeProjects:
 		header = baseStyle.Render(header)
 	case hooks.SectionTypeCompleted:
@@ -96,6 +98,8 @@ func GetSectionTypeColor(sectionType hooks.SectionType) string {
 	switch sectionType {
 	case hooks.SectionTypeTodo:
 		return "#2196F3" // Blue
+	case hooks.SectionTypeInProgress:
+		return "#FFC107" // Amber
 	case hooks.SectionTypeProjects:
 		return "#4CAF50" // Green
 	case hooks.SectionTypeCompleted:
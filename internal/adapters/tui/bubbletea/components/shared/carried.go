@@ -0,0 +1,88 @@
+package shared
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/newbpydev/tusk/internal/core/task"
+)
+
+// DefaultCarriedFormat is the carried-over label used when none (or an invalid one) is configured
+const DefaultCarriedFormat = "carried {days}"
+
+// carriedFormat is the template of the label shown on overdue open tasks; empty hides it
+var carriedFormat = DefaultCarriedFormat
+
+// carriedLocation is the time zone whose calendar days are counted by CarriedDays
+var carriedLocation = time.Local
+
+// ValidateCarriedFormat checks a carried-over label template. An empty template is
+// valid and turns the label off; otherwise it must use {days} ("3 days", "1 day")
+// or {n} (the bare number).
+func ValidateCarriedFormat(format string) error {
+	if format == "" {
+		return nil
+	}
+	if !strings.Contains(format, "{days}") && !strings.Contains(format, "{n}") {
+		return fmt.Errorf("invalid carried format %q: must contain {days} or {n}", format)
+	}
+	return nil
+}
+
+// SetCarriedFormat sets the template used by CarriedLabel. An invalid template leaves
+// the default in place and returns the validation error.
+func SetCarriedFormat(format string) error {
+	if err := ValidateCarriedFormat(format); err != nil {
+		carriedFormat = DefaultCarriedFormat
+		return err
+	}
+	carriedFormat = format
+	return nil
+}
+
+// SetCarriedLocation sets the time zone used to count carried days. A nil location
+// restores the local time zone.
+func SetCarriedLocation(loc *time.Location) {
+	if loc == nil {
+		loc = time.Local
+	}
+	carriedLocation = loc
+}
+
+// CarriedDays returns how many calendar days due lies before now, both read in the
+// configured time zone. Dates today or later give 0.
+func CarriedDays(due, now time.Time) int {
+	due = due.In(carriedLocation)
+	now = now.In(carriedLocation)
+
+	// Compare dates in UTC so daylight saving changes never shorten a day
+	dueDay := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, time.UTC)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if !dueDay.Before(today) {
+		return 0
+	}
+	return int(today.Sub(dueDay).Hours() / 24)
+}
+
+// CarriedLabel returns the carried-over label for an open task whose due date has
+// passed, e.g. "carried 3 days", or an empty string when the task is not overdue,
+// is completed or the label is turned off.
+func CarriedLabel(t task.Task, now time.Time) string {
+	if carriedFormat == "" || t.IsCompleted || t.Status == task.StatusDone || t.DueDate == nil {
+		return ""
+	}
+
+	days := CarriedDays(*t.DueDate, now)
+	if days == 0 {
+		return ""
+	}
+
+	unit := "days"
+	if days == 1 {
+		unit = "day"
+	}
+	n := strconv.Itoa(days)
+	return strings.NewReplacer("{days}", n+" "+unit, "{n}", n).Replace(carriedFormat)
+}
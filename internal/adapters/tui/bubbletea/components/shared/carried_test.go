@@ -0,0 +1,53 @@
+package shared
+
+import (
+	"testing"
+	"time"
+
+	"github.com/newbpydev/tusk/internal/core/task"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCarriedLabel(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	SetCarriedLocation(tokyo)
+	defer SetCarriedLocation(nil)
+	defer SetCarriedFormat(DefaultCarriedFormat)
+
+	// 2025-07-10 08:00 in Tokyo is still 2025-07-09 in UTC
+	now := time.Date(2025, 7, 9, 23, 0, 0, 0, time.UTC)
+	due := func(s string) *time.Time {
+		d, _ := time.Parse("2006-01-02 15:04", s)
+		return &d
+	}
+
+	testCases := []struct {
+		name     string
+		format   string
+		task     task.Task
+		expected string
+	}{
+		{name: "Due today in the configured zone", format: DefaultCarriedFormat, task: task.Task{DueDate: due("2025-07-09 23:30")}, expected: ""},
+		{name: "One day", format: DefaultCarriedFormat, task: task.Task{DueDate: due("2025-07-09 00:00")}, expected: "carried 1 day"},
+		{name: "Several days", format: DefaultCarriedFormat, task: task.Task{DueDate: due("2025-07-06 12:00")}, expected: "carried 4 days"},
+		{name: "Bare number", format: "+{n}d", task: task.Task{DueDate: due("2025-07-06 12:00")}, expected: "+4d"},
+		{name: "Completed task", format: DefaultCarriedFormat, task: task.Task{DueDate: due("2025-07-06 12:00"), IsCompleted: true}, expected: ""},
+		{name: "Undated task", format: DefaultCarriedFormat, task: task.Task{}, expected: ""},
+		{name: "Label turned off", format: "", task: task.Task{DueDate: due("2025-07-06 12:00")}, expected: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.NoError(t, SetCarriedFormat(tc.format))
+			assert.Equal(t, tc.expected, CarriedLabel(tc.task, now))
+		})
+	}
+}
+
+func TestSetCarriedFormatRejectsMissingPlaceholder(t *testing.T) {
+	defer SetCarriedFormat(DefaultCarriedFormat)
+
+	err := SetCarriedFormat("carried")
+	assert.ErrorContains(t, err, "must contain {days} or {n}")
+	assert.Equal(t, DefaultCarriedFormat, carriedFormat)
+}
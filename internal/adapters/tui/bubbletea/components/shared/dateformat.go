@@ -0,0 +1,48 @@
+package shared
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultDateLayout is the date layout used when none (or an invalid one) is configured
+const DefaultDateLayout = "2006-01-02"
+
+// dateLayout is the Go time layout used to display dates throughout the TUI
+var dateLayout = DefaultDateLayout
+
+// ValidateDateLayout checks that layout is a Go time layout that renders a full date,
+// i.e. one that includes the year, month and day and can be parsed back.
+func ValidateDateLayout(layout string) error {
+	// The reference date must differ from the layout date, or every layout would format to itself
+	ref := time.Date(2009, time.November, 17, 20, 34, 58, 0, time.UTC)
+	formatted := ref.Format(layout)
+	if layout == "" || formatted == layout {
+		return fmt.Errorf("invalid date format %q: no date fields", layout)
+	}
+
+	parsed, err := time.Parse(layout, formatted)
+	if err != nil {
+		return fmt.Errorf("invalid date format %q: %v", layout, err)
+	}
+	if parsed.Year() != ref.Year() || parsed.Month() != ref.Month() || parsed.Day() != ref.Day() {
+		return fmt.Errorf("invalid date format %q: must include year, month and day", layout)
+	}
+	return nil
+}
+
+// SetDateLayout sets the layout used by FormatDate. An invalid layout leaves the
+// default in place and returns the validation error.
+func SetDateLayout(layout string) error {
+	if err := ValidateDateLayout(layout); err != nil {
+		dateLayout = DefaultDateLayout
+		return err
+	}
+	dateLayout = layout
+	return nil
+}
+
+// FormatDate renders a date using the configured date layout
+func FormatDate(t time.Time) string {
+	return t.Format(dateLayout)
+}
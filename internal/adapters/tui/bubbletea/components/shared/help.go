@@ -4,6 +4,7 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/keymap"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/styles"
 )
 
 // HelpModel is the most minimal possible help footer implementation
@@ -21,6 +22,8 @@ type HelpModel struct {
 	contextKey string
 	// Toggle for full help view
 	showFullHelp bool
+	// Text shown before the keys, e.g. the task list's sort
+	note string
 }
 
 // NewHelpModel creates a new help model
@@ -57,6 +60,15 @@ func (m *HelpModel) SetKeyMap(km *keymap.KeyMap, contextID string) {
 	}
 }
 
+// SetNote sets the text shown before the keys; an empty note shows only the keys
+func (m *HelpModel) SetNote(note string) {
+	if m.note != note {
+		m.note = note
+		// Force regeneration of cached help
+		m.cachedHelp = ""
+	}
+}
+
 // AddDelegateKeyMap sets the global keymap
 func (m *HelpModel) AddDelegateKeyMap(km *keymap.KeyMap) {
 	if m.globalKeyMap != km {
@@ -81,9 +93,10 @@ func formatKeyHelp(k key.Binding) string {
 		return ""
 	}
 	
-	return lipgloss.NewStyle().Foreground(lipgloss.Color("#888888")).Render(k.Help().Key) + 
+	theme := styles.ActiveTheme()
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(theme.HelpKey)).Render(k.Help().Key) + 
 		" " + 
-		lipgloss.NewStyle().Foreground(lipgloss.Color("#aaaaaa")).Render(k.Help().Desc)
+		lipgloss.NewStyle().Foreground(lipgloss.Color(theme.HelpDesc)).Render(k.Help().Desc)
 }
 
 // View returns a STATIC help bar that won't accumulate text
@@ -129,6 +142,9 @@ func (m HelpModel) View() string {
 	
 	// Generate static help text with no dynamic calculations
 	helpText := ""
+	if m.note != "" {
+		helpText = m.note + "  "
+	}
 	
 	// Format each key with a simple separator
 	for i, k := range keys {
@@ -142,7 +158,7 @@ func (m HelpModel) View() string {
 	style := lipgloss.NewStyle().
 		Width(m.width).       // Fixed width based on screen
 		Align(lipgloss.Center). // Always center
-		Foreground(lipgloss.Color("#747474")).
+		Foreground(lipgloss.Color(styles.ActiveTheme().Muted)).
 		Italic(true)
 	
 	// Cache the result to prevent re-rendering on clock ticks
@@ -204,7 +220,7 @@ func (m HelpModel) FullHelpView() string {
 	// Apply minimal styling
 	return lipgloss.NewStyle().
 		BorderStyle(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#4B9CD3")).
+		BorderForeground(lipgloss.Color(styles.ActiveTheme().Border)).
 		Padding(1, 2).
 		Render(helpContent)
 }
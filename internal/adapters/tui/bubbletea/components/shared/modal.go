@@ -6,6 +6,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/styles"
 )
 
 // ModalMsg is sent when a modal action occurs
@@ -36,7 +37,7 @@ func NewModal(content tea.Model, width, height int) ModalModel {
 		Height:  height,
 		BorderStyle: lipgloss.NewStyle().
 			BorderStyle(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#4B9CD3")).
+			BorderForeground(lipgloss.Color(styles.ActiveTheme().Border)).
 			Padding(0, 1),
 		ContentStyle: lipgloss.NewStyle().
 			Padding(1, 2),
@@ -19,6 +19,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/styles"
 )
 
 // ScrollablePanelProps contains properties for rendering a panel with a fixed header
@@ -152,12 +153,10 @@ func RenderScrollablePanel(props ScrollablePanelProps) string {
 	// Add the scrollable section to the full content
 	fullContent.WriteString(scrollableSection)
 
-	// Determine border color based on active state
-	borderColor := props.BorderColor
+	// Determine border color based on active state, from the active theme
+	borderColor := styles.ActiveTheme().InactivePanel
 	if props.IsActive {
-		borderColor = "#00BFFF" // Bright blue color for active panel
-	} else {
-		borderColor = "#2d3748" // Dark background color that matches terminal background
+		borderColor = styles.ActiveTheme().ActivePanel
 	}
 
 	// Create the panel style
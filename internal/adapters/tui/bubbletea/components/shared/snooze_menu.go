@@ -0,0 +1,195 @@
+package shared
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/messages"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/styles"
+	"github.com/newbpydev/tusk/internal/core/task"
+)
+
+// SnoozeMenuMsg is sent when a preset is picked in the snooze menu
+// Filter is set instead of TaskID when every task matching it is snoozed
+// By is set when the due date is pushed forward rather than set to Until
+type SnoozeMenuMsg struct {
+	TaskID int32
+	Title  string
+	Until  time.Time
+	By     time.Duration
+	Filter *task.TaskFilter
+}
+
+// snoozeOption is a single entry of the snooze menu
+type snoozeOption struct {
+	label string
+	until time.Time
+	by    time.Duration
+}
+
+// quickSnoozes are the offsets offered by the quick snooze menu
+var quickSnoozes = []struct {
+	label string
+	by    time.Duration
+}{
+	{label: "+1 day", by: 24 * time.Hour},
+	{label: "+1 week", by: 7 * 24 * time.Hour},
+}
+
+// SnoozeMenu is a modal listing snooze presets for a task, or for every task
+// matching a filter
+type SnoozeMenu struct {
+	taskID  int32
+	title   string
+	filter  *task.TaskFilter
+	options []snoozeOption
+	cursor  int
+	width   int
+}
+
+// NewSnoozeMenu creates a snooze menu for a task, resolving each preset against now
+func NewSnoozeMenu(taskID int32, title string, presets []task.SnoozePreset, now time.Time) *SnoozeMenu {
+	options := make([]snoozeOption, len(presets))
+	for i, p := range presets {
+		options[i] = snoozeOption{label: p.Label(), until: p.Until(now)}
+	}
+
+	return &SnoozeMenu{
+		taskID:  taskID,
+		title:   title,
+		options: options,
+		width:   44,
+	}
+}
+
+// NewQuickSnoozeMenu creates a snooze menu pushing a task's due date forward by a day
+// or a week, showing the date each option moves it to (see task.SnoozeBy)
+func NewQuickSnoozeMenu(taskID int32, title string, due *time.Time, now time.Time) *SnoozeMenu {
+	options := make([]snoozeOption, len(quickSnoozes))
+	for i, q := range quickSnoozes {
+		options[i] = snoozeOption{label: q.label, until: task.SnoozeBy(due, q.by, now), by: q.by}
+	}
+
+	return &SnoozeMenu{
+		taskID:  taskID,
+		title:   title,
+		options: options,
+		width:   44,
+	}
+}
+
+// Init initializes the menu
+func (m SnoozeMenu) Init() tea.Cmd {
+	return nil
+}
+
+// Update moves the selection with j/k or the arrow keys and picks a preset
+// with enter or its number
+func (m SnoozeMenu) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || len(m.options) == 0 {
+		return m, nil
+	}
+
+	switch key := keyMsg.String(); key {
+	case "j", "down", "tab":
+		m.cursor = (m.cursor + 1) % len(m.options)
+	case "k", "up", "shift+tab":
+		m.cursor = (m.cursor - 1 + len(m.options)) % len(m.options)
+	case "enter":
+		return m, m.choose(m.cursor)
+	case "q":
+		return m, func() tea.Msg { return messages.HideModalMsg{} }
+	default:
+		if n, err := strconv.Atoi(key); err == nil && n >= 1 && n <= len(m.options) {
+			return m, m.choose(n - 1)
+		}
+	}
+	return m, nil
+}
+
+// choose reports the preset at index i as picked
+func (m SnoozeMenu) choose(i int) tea.Cmd {
+	option := m.options[i]
+	return func() tea.Msg {
+		return SnoozeMenuMsg{TaskID: m.taskID, Title: m.title, Until: option.until, By: option.by, Filter: m.filter}
+	}
+}
+
+// View renders the presets with the date each one snoozes to
+func (m SnoozeMenu) View() string {
+	theme := styles.ActiveTheme()
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(theme.Text)).MarginBottom(1)
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Accent)).Bold(true)
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(theme.HelpKey))
+
+	var b strings.Builder
+	if m.filter != nil {
+		b.WriteString(titleStyle.Render("Snooze all tasks matching "+m.title) + "\n")
+	} else {
+		b.WriteString(titleStyle.Render("Snooze \""+m.title+"\"") + "\n")
+	}
+
+	for i, option := range m.options {
+		line := fmt.Sprintf("%d. %-14s %s", i+1, option.label, FormatSnoozeTime(option.until))
+		if i == m.cursor {
+			b.WriteString(selectedStyle.Render("> "+line) + "\n")
+		} else {
+			b.WriteString("  " + line + "\n")
+		}
+	}
+
+	b.WriteString("\n" + hintStyle.Render("j/k move • enter or 1-9 pick • esc cancel"))
+	return b.String()
+}
+
+// ShowSnoozeMenu creates a command that opens the snooze menu for a task
+func ShowSnoozeMenu(taskID int32, title string, presets []task.SnoozePreset, now time.Time) tea.Cmd {
+	return func() tea.Msg {
+		menu := NewSnoozeMenu(taskID, title, presets, now)
+		return messages.ShowModalMsg{
+			Content: menu,
+			Width:   menu.width,
+			Height:  len(menu.options) + 6,
+		}
+	}
+}
+
+// ShowQuickSnoozeMenu creates a command that opens the quick snooze menu for a task
+func ShowQuickSnoozeMenu(taskID int32, title string, due *time.Time, now time.Time) tea.Cmd {
+	return func() tea.Msg {
+		menu := NewQuickSnoozeMenu(taskID, title, due, now)
+		return messages.ShowModalMsg{
+			Content: menu,
+			Width:   menu.width,
+			Height:  len(menu.options) + 6,
+		}
+	}
+}
+
+// ShowFilterSnoozeMenu creates a command that opens the snooze menu for every open
+// task matching filter
+func ShowFilterSnoozeMenu(filter task.TaskFilter, presets []task.SnoozePreset, now time.Time) tea.Cmd {
+	return func() tea.Msg {
+		menu := NewSnoozeMenu(0, filter.String(), presets, now)
+		menu.filter = &filter
+		return messages.ShowModalMsg{
+			Content: menu,
+			Width:   max(menu.width, len(menu.title)+30),
+			Height:  len(menu.options) + 6,
+		}
+	}
+}
+
+// FormatSnoozeTime formats the time a task is snoozed until, leaving out the time of
+// day for a date-only due date
+func FormatSnoozeTime(t time.Time) string {
+	if !task.HasDueTime(t) {
+		return FormatDate(t)
+	}
+	return FormatDate(t) + t.Format(" 15:04")
+}
@@ -0,0 +1,25 @@
+package shared
+
+import "strings"
+
+// sparkBlocks are the bar glyphs used by Sparkline, from lowest to highest
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values as a single line of block characters scaled
+// between zero and the largest value.
+func Sparkline(values []int) string {
+	peak := 0
+	for _, v := range values {
+		peak = max(peak, v)
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		idx := 0
+		if peak > 0 && v > 0 {
+			idx = v * (len(sparkBlocks) - 1) / peak
+		}
+		b.WriteRune(sparkBlocks[idx])
+	}
+	return b.String()
+}
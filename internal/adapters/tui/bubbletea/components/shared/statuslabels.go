@@ -0,0 +1,19 @@
+package shared
+
+import "github.com/newbpydev/tusk/internal/core/task"
+
+// statusLabels holds the display labels used for task statuses throughout the TUI
+var statusLabels = task.DefaultStatusLabels()
+
+// SetStatusLabels sets the labels used by StatusLabel. A nil label set restores the defaults.
+func SetStatusLabels(labels task.StatusLabels) {
+	if labels == nil {
+		labels = task.DefaultStatusLabels()
+	}
+	statusLabels = labels
+}
+
+// StatusLabel returns the configured display label for a task status
+func StatusLabel(s task.Status) string {
+	return statusLabels.Label(s)
+}
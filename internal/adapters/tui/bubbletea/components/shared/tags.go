@@ -0,0 +1,36 @@
+package shared
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/styles"
+	"github.com/newbpydev/tusk/internal/core/task"
+)
+
+// tagColors holds the configured colors used by RenderTag
+var tagColors task.TagColors
+
+// SetTagColors sets the rules used by RenderTag. Nil rules render every tag in the default color.
+func SetTagColors(colors task.TagColors) {
+	tagColors = colors
+}
+
+// RenderTag renders a tag as "#name" in its configured color
+func RenderTag(name string) string {
+	// Tags that no color rule matches look like todo tasks in the active theme
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color(styles.ActiveTheme().Todo))
+	if color, ok := tagColors.Color(name); ok {
+		style = lipgloss.NewStyle().Foreground(lipgloss.Color(color))
+	}
+	return style.Render("#" + name)
+}
+
+// RenderTags renders a task's tags separated by spaces, or an empty string when it has none
+func RenderTags(tags []task.Tag) string {
+	rendered := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		rendered = append(rendered, RenderTag(tag.Name))
+	}
+	return strings.Join(rendered, " ")
+}
@@ -174,11 +174,11 @@ func formatStatus(s task.Status) string {
 	var result string
 	switch s {
 	case task.StatusDone:
-		result = completedStyle.Render(string(s))
+		result = completedStyle.Render(shared.StatusLabel(s))
 	case task.StatusTodo:
-		result = todoStyle.Render(string(s))
+		result = todoStyle.Render(shared.StatusLabel(s))
 	default:
-		result = normalStyle.Render(string(s))
+		result = normalStyle.Render(shared.StatusLabel(s))
 	}
 	return result
 }
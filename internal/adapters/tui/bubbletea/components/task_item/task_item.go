@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/components/shared"
 	"github.com/newbpydev/tusk/internal/core/task"
 )
 
@@ -196,5 +197,5 @@ func formatDate(t time.Time) string {
 	}
 	
 	// Default format for other dates
-	return t.Format("Jan 2, 2006")
+	return shared.FormatDate(t)
 }
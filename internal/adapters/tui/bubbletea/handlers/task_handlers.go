@@ -31,6 +31,7 @@ type AppModel interface {
 	
 	// Section management
 	ToggleSection() tea.Cmd
+	HandleEnter() tea.Cmd
 	InitCollapsibleSections()
 	
 	// Getters and state accessors
@@ -86,16 +87,9 @@ func HandleTaskListPanelKeys(m AppModel, msg tea.KeyMsg) (tea.Cmd, bool) {
 		m.SetActivePanel(1)
 		return nil, true
 
-	case "enter", "d":
-		// If on a section header, toggle expansion
-		if m.GetCursorOnHeader() {
-			return m.ToggleSection(), true
-		}
-		// If on a task, show details (if available)
-		// TODO: This references the model's showTaskDetails directly
-		// We should add a method to the AppModel interface to handle this
-		m.SetActivePanel(1)
-		return nil, true
+	case "enter":
+		// Toggle a section header, or run the configured enter action on a task
+		return m.HandleEnter(), true
 
 	case " ":
 		// Toggle task completion status
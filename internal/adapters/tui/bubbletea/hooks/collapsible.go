@@ -21,9 +21,11 @@ type SectionType string
 // Section types
 const (
 	// Task list sections
-	SectionTypeTodo      SectionType = "todo"
-	SectionTypeProjects  SectionType = "projects"
-	SectionTypeCompleted SectionType = "completed"
+	SectionTypeRecent     SectionType = "recent"
+	SectionTypeTodo       SectionType = "todo"
+	SectionTypeInProgress SectionType = "in-progress"
+	SectionTypeProjects   SectionType = "projects"
+	SectionTypeCompleted  SectionType = "completed"
 	
 	// Timeline sections
 	SectionTypeOverdue   SectionType = "overdue"
@@ -46,6 +48,8 @@ type CollapsibleManager struct {
 	FlatCursorPos    int // Cursor position in the flattened task list
 	VisibleStartIdx  int // Index where visible tasks start (for scrolling)
 	expandedSections map[SectionType]bool
+	// Expansion state saved by FocusSection, nil when no section is focused
+	focusSnapshot map[SectionType]bool
 }
 
 // NewCollapsibleManager creates a new CollapsibleManager with default settings
@@ -56,9 +60,11 @@ func NewCollapsibleManager() *CollapsibleManager {
 
 	// Set default expanded states
 	cm.expandedSections = map[SectionType]bool{
-		SectionTypeTodo:      true,  // Todo section expanded by default
-		SectionTypeProjects:  false, // Projects collapsed by default
-		SectionTypeCompleted: true,  // Completed section expanded by default
+		SectionTypeRecent:     true,  // Recently created section expanded by default
+		SectionTypeTodo:       true,  // Todo section expanded by default
+		SectionTypeInProgress: true,  // In progress section expanded by default
+		SectionTypeProjects:   false, // Projects collapsed by default
+		SectionTypeCompleted:  true,  // Completed section expanded by default
 	}
 
 	return cm
@@ -66,8 +72,11 @@ func NewCollapsibleManager() *CollapsibleManager {
 
 // AddSection adds a new section to the manager
 func (cm *CollapsibleManager) AddSection(sectionType SectionType, title string, itemCount int, startIndex int) {
-	// Get default expanded state from the map
-	isExpanded := cm.expandedSections[sectionType]
+	// Get the expanded state from the map; sections without one start expanded
+	isExpanded, ok := cm.expandedSections[sectionType]
+	if !ok {
+		isExpanded = true
+	}
 
 	section := Section{
 		Type:       sectionType,
@@ -96,6 +105,77 @@ func (cm *CollapsibleManager) ToggleSection(sectionType SectionType) {
 	}
 }
 
+// FocusSection expands the given section and collapses all others, remembering the
+// previous expansion state so that RestoreFocus can undo it
+func (cm *CollapsibleManager) FocusSection(sectionType SectionType) {
+	if cm.focusSnapshot == nil {
+		cm.focusSnapshot = make(map[SectionType]bool, len(cm.Sections))
+		for _, section := range cm.Sections {
+			cm.focusSnapshot[section.Type] = section.IsExpanded
+		}
+	}
+
+	for i := range cm.Sections {
+		expanded := cm.Sections[i].Type == sectionType
+		cm.Sections[i].IsExpanded = expanded
+		cm.expandedSections[cm.Sections[i].Type] = expanded
+	}
+}
+
+// RestoreFocus restores the expansion state saved by FocusSection.
+// It returns false if no section was focused.
+func (cm *CollapsibleManager) RestoreFocus() bool {
+	if cm.focusSnapshot == nil {
+		return false
+	}
+
+	for i := range cm.Sections {
+		if expanded, ok := cm.focusSnapshot[cm.Sections[i].Type]; ok {
+			cm.Sections[i].IsExpanded = expanded
+			cm.expandedSections[cm.Sections[i].Type] = expanded
+		}
+	}
+	cm.focusSnapshot = nil
+	return true
+}
+
+// ExpansionState returns whether each section type is expanded, so it can be saved
+// between sessions. A temporary focus from FocusSection is not part of it.
+func (cm *CollapsibleManager) ExpansionState() map[SectionType]bool {
+	state := make(map[SectionType]bool, len(cm.expandedSections))
+	for sectionType, expanded := range cm.expandedSections {
+		state[sectionType] = expanded
+	}
+	for sectionType, expanded := range cm.focusSnapshot {
+		state[sectionType] = expanded
+	}
+	return state
+}
+
+// RestoreExpansionState replaces the expansion state with one saved by ExpansionState.
+// Section types missing from state are expanded, and saved types without a current
+// section are kept in case the section comes back.
+func (cm *CollapsibleManager) RestoreExpansionState(state map[SectionType]bool) {
+	cm.expandedSections = make(map[SectionType]bool, len(state))
+	for sectionType, expanded := range state {
+		cm.expandedSections[sectionType] = expanded
+	}
+
+	for i := range cm.Sections {
+		expanded, ok := state[cm.Sections[i].Type]
+		if !ok {
+			expanded = true
+		}
+		cm.Sections[i].IsExpanded = expanded
+	}
+	cm.focusSnapshot = nil
+}
+
+// IsFocused reports whether a section is currently focused via FocusSection
+func (cm *CollapsibleManager) IsFocused() bool {
+	return cm.focusSnapshot != nil
+}
+
 // GetSectionAtIndex returns the section at the given index
 // Returns nil if the index is not a section
 func (cm *CollapsibleManager) GetSectionAtIndex(index int) *Section {
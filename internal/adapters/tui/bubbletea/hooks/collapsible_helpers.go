@@ -29,3 +29,45 @@ func (cm *CollapsibleManager) GetSectionHeaderIndex(sectionType SectionType) int
 	
 	return -1 // Section not found
 }
+
+// GetAdjacentHeaderIndex returns the visual index of the nearest section header after
+// currentPos (direction 1) or before it (direction -1), or -1 when there is none
+func (cm *CollapsibleManager) GetAdjacentHeaderIndex(currentPos, direction int) int {
+	found := -1
+	index := 0
+	for _, section := range cm.Sections {
+		if direction > 0 && index > currentPos {
+			return index
+		}
+		if direction < 0 && index < currentPos {
+			found = index
+		}
+
+		index++ // Count the section header
+		if section.IsExpanded {
+			index += section.ItemCount
+		}
+	}
+	return found
+}
+
+// GetFirstItemIndex returns the visual index of the first item of a section, or -1
+// when the section is collapsed, empty or unknown
+func (cm *CollapsibleManager) GetFirstItemIndex(sectionType SectionType) int {
+	section := cm.GetSection(sectionType)
+	if section == nil || !section.IsExpanded || section.ItemCount == 0 {
+		return -1
+	}
+	return cm.GetSectionHeaderIndex(sectionType) + 1
+}
+
+// GetSectionForTaskIndex returns the section containing the task at taskIndex,
+// or nil if no section contains it
+func (cm *CollapsibleManager) GetSectionForTaskIndex(taskIndex int) *Section {
+	for i, section := range cm.Sections {
+		if taskIndex >= section.StartIndex && taskIndex < section.StartIndex+section.ItemCount {
+			return &cm.Sections[i]
+		}
+	}
+	return nil
+}
@@ -109,6 +109,10 @@ var GlobalKeyMap = &KeyMap{
 			key.WithKeys("m"),
 			key.WithHelp("m", "Show Sample Modal"),
 		),
+		key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "Cycle Theme"),
+		),
 		key.NewBinding(
 			key.WithKeys("?"),
 			key.WithHelp("?", "Toggle Help"),
@@ -136,13 +140,21 @@ var TaskListKeyMap = &KeyMap{
 			key.WithKeys("up"),
 			key.WithHelp("", "Up"),
 		),
+		key.NewBinding(
+			key.WithKeys("[", "]"),
+			key.WithHelp("[/]", "Prev/Next Section"),
+		),
 		key.NewBinding(
 			key.WithKeys("n"),
 			key.WithHelp("n", "New Task"),
 		),
 		key.NewBinding(
 			key.WithKeys("d"),
-			key.WithHelp("d", "Delete Task"),
+			key.WithHelp("d", "Archive Task"),
+		),
+		key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "Undo"),
 		),
 		key.NewBinding(
 			key.WithKeys("enter"),
@@ -152,6 +164,110 @@ var TaskListKeyMap = &KeyMap{
 			key.WithKeys("tab"),
 			key.WithHelp("tab", "Next Panel"),
 		),
+		key.NewBinding(
+			key.WithKeys("x", "X"),
+			key.WithHelp("x/X", "Export JSON/CSV"),
+		),
+		key.NewBinding(
+			key.WithKeys("i"),
+			key.WithHelp("i", "Cycle Status"),
+		),
+		key.NewBinding(
+			key.WithKeys("H"),
+			key.WithHelp("H", "Hide Done Subtasks"),
+		),
+		key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("F", "Clear All Filters"),
+		),
+		key.NewBinding(
+			key.WithKeys("E"),
+			key.WithHelp("E", "Energy Filter"),
+		),
+		key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "Actionable Only"),
+		),
+		key.NewBinding(
+			key.WithKeys("D"),
+			key.WithHelp("D", "Overdue + Today"),
+		),
+		key.NewBinding(
+			key.WithKeys("^"),
+			key.WithHelp("^", "Pin To Day"),
+		),
+		key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "Cycle Sort"),
+		),
+		key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y/Y", "Clone (Y: with subtasks)"),
+		),
+		key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "Rename"),
+		),
+		key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "Search"),
+		),
+		key.NewBinding(
+			key.WithKeys("N"),
+			key.WithHelp("N", "Recently Created"),
+		),
+		key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "Cycle Priority"),
+		),
+		key.NewBinding(
+			key.WithKeys("!"),
+			key.WithHelp("!", "Priority Mode"),
+		),
+		key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", "Defer Today"),
+		),
+		key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", "Complete Section"),
+		),
+		key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f", "Focus Section"),
+		),
+		key.NewBinding(
+			key.WithKeys("P"),
+			key.WithHelp("P", "Jump to Parent"),
+		),
+		key.NewBinding(
+			key.WithKeys("z"),
+			key.WithHelp("z", "Snooze"),
+		),
+		key.NewBinding(
+			key.WithKeys("Z"),
+			key.WithHelp("Z", "Snooze Filtered"),
+		),
+		key.NewBinding(
+			key.WithKeys("+"),
+			key.WithHelp("+", "Snooze +1 Day/Week"),
+		),
+		key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", "Add Note"),
+		),
+		key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "Mark for Swap"),
+		),
+		key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "Swap with Marked"),
+		),
+		key.NewBinding(
+			key.WithKeys("J", "K"),
+			key.WithHelp("J/K", "Move Down/Up"),
+		),
 	},
 }
 
@@ -175,6 +291,41 @@ var TaskDetailsKeyMap = &KeyMap{
 			key.WithKeys("space"),
 			key.WithHelp("space", "Toggle Status"),
 		),
+		key.NewBinding(
+			key.WithKeys("H"),
+			key.WithHelp("H", "Hide Done Subtasks"),
+		),
+		key.NewBinding(
+			key.WithKeys("P"),
+			key.WithHelp("P", "Jump to Parent"),
+		),
+		key.NewBinding(
+			key.WithKeys("z"),
+			key.WithHelp("z", "Snooze"),
+		),
+		key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", "Add Note"),
+		),
+	},
+}
+
+// PriorityModeKeyMap contains key bindings for the task list while priority mode is on
+var PriorityModeKeyMap = &KeyMap{
+	context: "Priority Mode",
+	keys: []key.Binding{
+		key.NewBinding(
+			key.WithKeys("1", "2", "3"),
+			key.WithHelp("1/2/3", "Low/Medium/High"),
+		),
+		key.NewBinding(
+			key.WithKeys("j", "k"),
+			key.WithHelp("j/k", "Move"),
+		),
+		key.NewBinding(
+			key.WithKeys("esc", "!"),
+			key.WithHelp("esc", "Exit Priority Mode"),
+		),
 	},
 }
 
@@ -206,6 +357,78 @@ var TimelineKeyMap = &KeyMap{
 			key.WithKeys("shift+tab"),
 			key.WithHelp("shift+tab", "Previous Panel"),
 		),
+		key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "Search"),
+		),
+		key.NewBinding(
+			key.WithKeys("n", "N"),
+			key.WithHelp("n/N", "Next/Previous Match"),
+		),
+		key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "Actionable Only"),
+		),
+	},
+}
+
+// TimelineSearchKeyMap contains key bindings while a timeline search is typed
+var TimelineSearchKeyMap = &KeyMap{
+	context: "Timeline Search",
+	keys: []key.Binding{
+		key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "Keep Search"),
+		),
+		key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "Clear Search"),
+		),
+	},
+}
+
+// TaskSearchKeyMap contains key bindings while a task list search is typed
+var TaskSearchKeyMap = &KeyMap{
+	context: "Task Search",
+	keys: []key.Binding{
+		key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "Keep Search"),
+		),
+		key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "Clear Search"),
+		),
+	},
+}
+
+// RenameKeyMap contains key bindings while a new task title is typed
+var RenameKeyMap = &KeyMap{
+	context: "Rename",
+	keys: []key.Binding{
+		key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "Save Title"),
+		),
+		key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "Cancel"),
+		),
+	},
+}
+
+// NoteKeyMap contains key bindings while a note is typed
+var NoteKeyMap = &KeyMap{
+	context: "Note",
+	keys: []key.Binding{
+		key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "Save Note"),
+		),
+		key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "Discard Note"),
+		),
 	},
 }
 
@@ -272,6 +495,8 @@ func GetKeyMapForContext(context string) *KeyMap {
 		return TaskListKeyMap
 	case "task details", "taskdetails":
 		return TaskDetailsKeyMap
+	case "priority mode", "prioritymode":
+		return PriorityModeKeyMap
 	case "timeline":
 		return TimelineKeyMap
 	case "form":
@@ -289,6 +514,7 @@ func KeyMapContexts() []string {
 		"Global",
 		"Task List",
 		"Task Details",
+		"Priority Mode",
 		"Timeline",
 		"Form",
 		"Modal",
@@ -42,10 +42,131 @@ type StatusUpdateErrorMsg struct {
 type StatusUpdateSuccessMsg struct {
 	Task    task.Task
 	Message string
+	// Status before the change, set when the change can be undone
+	PreviousStatus task.Status
 }
 
 // TasksRefreshedMsg represents refreshed tasks from a background operation
 // Contains the list of tasks
 type TasksRefreshedMsg struct {
 	Tasks []task.Task
+	// BlockedTaskIDs is only loaded while the actionable view is on
+	BlockedTaskIDs []int32
+	// DayPinnedTaskIDs is only loaded while the today view is on
+	DayPinnedTaskIDs []int32
+}
+
+// RecategorizeMsg asks the model to rebuild its task sections after a burst of changes
+type RecategorizeMsg struct{}
+
+// RefreshCancelledMsg reports that a task refresh was aborted before it finished
+type RefreshCancelledMsg struct{}
+
+// ParentsReadyMsg reports the ancestors of a completed task whose subtasks are now all done
+// Holds the completed task and the parents, nearest first
+type ParentsReadyMsg struct {
+	Task    task.Task
+	Parents []task.Task
+}
+
+// RolloverPreviewMsg lists the open tasks due today that a rollover would move to tomorrow
+type RolloverPreviewMsg struct {
+	Tasks []task.Task
+}
+
+// SnoozePreviewMsg lists the open tasks matching a filter that a bulk snooze would move
+// Holds the filter, the time they would be snoozed until and the tasks
+type SnoozePreviewMsg struct {
+	Filter task.TaskFilter
+	Until  time.Time
+	Tasks  []task.Task
+}
+
+// SectionStatusChangedMsg reports that every task of a task list section got a new status
+// Holds the section type and the message to show
+type SectionStatusChangedMsg struct {
+	Section string
+	Message string
+}
+
+// NotesLoadedMsg carries the notes of a task, newest first
+type NotesLoadedMsg struct {
+	TaskID int32
+	Notes  []task.TaskNote
+}
+
+// TaskClonedMsg reports that a task was copied into a new task
+type TaskClonedMsg struct {
+	Task task.Task
+}
+
+// KnownTagsLoadedMsg carries the tags of the user's tasks, suggested in the task form
+type KnownTagsLoadedMsg struct {
+	Tags []string
+}
+
+// NoteAddedMsg reports that a note was appended to a task
+type NoteAddedMsg struct {
+	Note task.TaskNote
+}
+
+// OrderSwappedMsg reports that the display order of two tasks was exchanged
+type OrderSwappedMsg struct {
+	Message string
+}
+
+// ExportCompletedMsg reports that the visible task list was written to a file
+// Holds the file path and the number of tasks written
+type ExportCompletedMsg struct {
+	Path  string
+	Count int
+}
+
+// ActionableLoadedMsg carries the blocked tasks needed to turn on the actionable view
+type ActionableLoadedMsg struct {
+	BlockedTaskIDs []int32
+}
+
+// TodayLoadedMsg carries the pinned tasks needed to turn on the today view
+type TodayLoadedMsg struct {
+	DayPinnedTaskIDs []int32
+}
+
+// DayPinToggledMsg reports that a task was pinned to the top of its day or unpinned
+// Holds the tasks reloaded afterwards, with the new pins
+type DayPinToggledMsg struct {
+	TaskID    int32
+	Title     string
+	Pinned    bool
+	Refreshed TasksRefreshedMsg
+}
+
+// ListSearchResultsMsg carries the IDs of the tasks matching a task list search query
+type ListSearchResultsMsg struct {
+	Query   string
+	TaskIDs []int32
+}
+
+// FiltersClearedMsg reports that every filter was cleared and the full task list reloaded
+type FiltersClearedMsg struct{}
+
+// TaskArchivedMsg reports that a task was archived and can still be restored
+type TaskArchivedMsg struct {
+	TaskID int32
+	Title  string
+}
+
+// TaskRestoredMsg reports that an archived task was restored
+// Holds the restored task and the reloaded task list
+type TaskRestoredMsg struct {
+	Task  task.Task
+	Tasks []task.Task
+}
+
+// ReminderTickMsg asks the TUI to check for tasks that came due
+type ReminderTickMsg time.Time
+
+// DueTasksMsg carries the open tasks due today, looked up for reminders
+type DueTasksMsg struct {
+	Tasks []task.Task
 }
@@ -53,8 +53,8 @@ func (s *TaskCategorizationService) CategorizeTimelineTasks(tasks []task.Task) (
 			continue
 		}
 
-		if s.isBeforeDay(*t.DueDate, now) {
-			// Task is overdue (due date is before today)
+		if task.IsOverdue(*t.DueDate, now) {
+			// Task is overdue (due before today, or earlier today at a set time)
 			overdueTasks = append(overdueTasks, t)
 		} else if s.isSameDay(*t.DueDate, now) {
 			// Task is due today
@@ -123,31 +123,6 @@ func (s *TaskCategorizationService) isSameDay(t1, t2 time.Time) bool {
 	return y1 == y2 && m1 == m2 && d1 == d2
 }
 
-// isBeforeDay checks if t1 is on a calendar day before t2
-func (s *TaskCategorizationService) isBeforeDay(t1, t2 time.Time) bool {
-	y1, m1, d1 := t1.Date()
-	y2, m2, d2 := t2.Date()
-	
-	// Compare years first
-	if y1 < y2 {
-		return true
-	}
-	if y1 > y2 {
-		return false
-	}
-	
-	// Same year, compare months
-	if m1 < m2 {
-		return true
-	}
-	if m1 > m2 {
-		return false
-	}
-	
-	// Same year and month, compare days
-	return d1 < d2
-}
-
 // isAfterDay checks if t1 is on a calendar day after t2
 func (s *TaskCategorizationService) isAfterDay(t1, t2 time.Time) bool {
 	y1, m1, d1 := t1.Date()
@@ -36,7 +36,7 @@ func (a *CoreTaskServiceAdapter) Create(
 	priority task.Priority,
 	tags []string,
 ) (task.Task, error) {
-	return a.coreService.Create(ctx, userID, parentID, title, description, dueDate, priority, tags)
+	return a.coreService.Create(ctx, userID, parentID, title, description, dueDate, priority, tags, nil)
 }
 
 // Update updates an existing task
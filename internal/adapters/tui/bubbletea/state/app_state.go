@@ -5,6 +5,7 @@ package state
 import (
 	"time"
 
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/components/input"
 	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/hooks"
 	"github.com/newbpydev/tusk/internal/core/task"
 )
@@ -192,7 +193,7 @@ func (s *AppState) LoadTaskIntoForm(t task.Task) *AppState {
 	s.FormPriority = string(t.Priority)
 	
 	if t.DueDate != nil {
-		s.FormDueDate = t.DueDate.Format("2006-01-02")
+		s.FormDueDate = input.FormatDate(*t.DueDate)
 	} else {
 		s.FormDueDate = ""
 	}
@@ -19,24 +19,12 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
-// Color constants
-const (
-	colorWhite     = "#FFFFFF"
-	colorOffWhite  = "#FAFAFA"
-	colorLightGray = "#909090"
-	colorDarkGray  = "#747474"
-	colorBlue      = "#1E88E5"
-	colorYellow    = "#FFB300"
-	colorGreen     = "#00E676"
-	colorTeal      = "#009688"
-	colorOrange    = "#FB8C00"
-	colorRed       = "#E53935"
-	colorBorder    = "#4B9CD3" // Light blue color for borders
-)
-
 // Styles encapsulates all UI styling for the TUI
 // It wraps lipgloss.Style definitions for shared use.
 type Styles struct {
+	// Theme the styles were derived from
+	Theme Theme
+
 	// General UI styles
 	Title        lipgloss.Style
 	SelectedItem lipgloss.Style
@@ -54,31 +42,47 @@ type Styles struct {
 	HighPriority   lipgloss.Style
 }
 
-// DefaultStyles returns a Styles struct with the default styling
-func DefaultStyles() *Styles {
-	s := new(Styles)
+// NewStyles returns the styles derived from a theme
+func NewStyles(t Theme) *Styles {
+	s := &Styles{Theme: t}
 
 	// General UI styles
-	s.Title = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(colorOffWhite))
-	s.SelectedItem = lipgloss.NewStyle().Bold(true).Background(lipgloss.Color(colorBlue)).Foreground(lipgloss.Color(colorWhite))
-	s.Help = lipgloss.NewStyle().Foreground(lipgloss.Color(colorDarkGray)).Italic(true)
+	s.Title = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(t.Text))
+	s.SelectedItem = lipgloss.NewStyle().Bold(true).Background(lipgloss.Color(t.Accent)).Foreground(lipgloss.Color(t.AccentText))
+	s.Help = lipgloss.NewStyle().Foreground(lipgloss.Color(t.Muted)).Italic(true)
 	s.ActiveBorder = lipgloss.NewStyle().
 		BorderStyle(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color(colorBorder)).
+		BorderForeground(lipgloss.Color(t.Border)).
 		Padding(0, 1)
 
 	// Task status styles
-	s.Todo = lipgloss.NewStyle().Foreground(lipgloss.Color(colorLightGray))
-	s.InProgress = lipgloss.NewStyle().Foreground(lipgloss.Color(colorYellow))
-	s.Done = lipgloss.NewStyle().Foreground(lipgloss.Color(colorGreen))
+	s.Todo = lipgloss.NewStyle().Foreground(lipgloss.Color(t.Todo))
+	s.InProgress = lipgloss.NewStyle().Foreground(lipgloss.Color(t.InProgress))
+	s.Done = lipgloss.NewStyle().Foreground(lipgloss.Color(t.Done))
 
 	// Priority styles
-	s.LowPriority = lipgloss.NewStyle().Foreground(lipgloss.Color(colorTeal))
-	s.MediumPriority = lipgloss.NewStyle().Foreground(lipgloss.Color(colorOrange))
-	s.HighPriority = lipgloss.NewStyle().Foreground(lipgloss.Color(colorRed))
+	s.LowPriority = lipgloss.NewStyle().Foreground(lipgloss.Color(t.LowPriority))
+	s.MediumPriority = lipgloss.NewStyle().Foreground(lipgloss.Color(t.MediumPriority))
+	s.HighPriority = lipgloss.NewStyle().Foreground(lipgloss.Color(t.HighPriority))
 
 	return s
 }
 
+// DefaultStyles returns a Styles struct with the default styling
+func DefaultStyles() *Styles {
+	return NewStyles(DarkTheme())
+}
+
 // ActiveStyles holds the current active styles for the application
 var ActiveStyles = DefaultStyles()
+
+// SetTheme replaces ActiveStyles with the styles derived from a theme. Components
+// read the active theme on every render, so the next frame shows the new colors.
+func SetTheme(t Theme) {
+	ActiveStyles = NewStyles(t)
+}
+
+// ActiveTheme returns the theme ActiveStyles were derived from
+func ActiveTheme() Theme {
+	return ActiveStyles.Theme
+}
@@ -0,0 +1,173 @@
+package styles
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Theme is a named set of colors the TUI styles are derived from. Colors are hex
+// strings as taken by lipgloss.Color.
+type Theme struct {
+	Name string
+
+	// General UI
+	Text       string // titles and regular text
+	Muted      string // help text and hints
+	Accent     string // background of the selected item
+	AccentText string // text on the accent color
+	Border     string // borders of dialogs and the help screen
+
+	// Panel borders
+	ActivePanel   string // the focused panel
+	InactivePanel string // every other panel
+
+	// Task statuses
+	Todo       string
+	InProgress string
+	Done       string
+
+	// Task priorities
+	LowPriority    string
+	MediumPriority string
+	HighPriority   string
+
+	// Help footer
+	HelpKey  string
+	HelpDesc string
+
+	// Header bar and its status messages
+	HeaderBackground string
+	HeaderText       string
+	HeaderMuted      string
+	Success          string
+	Error            string
+	Info             string
+	Loading          string
+	Warning          string
+}
+
+// DarkTheme is the default theme, made for dark terminal backgrounds
+func DarkTheme() Theme {
+	return Theme{
+		Name:             "dark",
+		Text:             "#FAFAFA",
+		Muted:            "#747474",
+		Accent:           "#1E88E5",
+		AccentText:       "#FFFFFF",
+		Border:           "#4B9CD3",
+		ActivePanel:      "#00BFFF",
+		InactivePanel:    "#2d3748",
+		Todo:             "#909090",
+		InProgress:       "#FFB300",
+		Done:             "#00E676",
+		LowPriority:      "#009688",
+		MediumPriority:   "#FB8C00",
+		HighPriority:     "#E53935",
+		HelpKey:          "#888888",
+		HelpDesc:         "#aaaaaa",
+		HeaderBackground: "#2d3748",
+		HeaderText:       "#ffffff",
+		HeaderMuted:      "#a0aec0",
+		Success:          "#48bb78",
+		Error:            "#f56565",
+		Info:             "#4299e1",
+		Loading:          "#90cdf4",
+		Warning:          "#ecc94b",
+	}
+}
+
+// LightTheme is made for light terminal backgrounds
+func LightTheme() Theme {
+	return Theme{
+		Name:             "light",
+		Text:             "#1A202C",
+		Muted:            "#6B7280",
+		Accent:           "#1565C0",
+		AccentText:       "#FFFFFF",
+		Border:           "#1565C0",
+		ActivePanel:      "#0277BD",
+		InactivePanel:    "#CBD5E0",
+		Todo:             "#4A5568",
+		InProgress:       "#B26A00",
+		Done:             "#2E7D32",
+		LowPriority:      "#00796B",
+		MediumPriority:   "#E65100",
+		HighPriority:     "#C62828",
+		HelpKey:          "#4A5568",
+		HelpDesc:         "#6B7280",
+		HeaderBackground: "#E2E8F0",
+		HeaderText:       "#1A202C",
+		HeaderMuted:      "#4A5568",
+		Success:          "#2F855A",
+		Error:            "#C53030",
+		Info:             "#2B6CB0",
+		Loading:          "#2C5282",
+		Warning:          "#B7791F",
+	}
+}
+
+// SolarizedTheme uses the Solarized dark palette
+func SolarizedTheme() Theme {
+	return Theme{
+		Name:             "solarized",
+		Text:             "#93a1a1",
+		Muted:            "#586e75",
+		Accent:           "#268bd2",
+		AccentText:       "#fdf6e3",
+		Border:           "#2aa198",
+		ActivePanel:      "#268bd2",
+		InactivePanel:    "#073642",
+		Todo:             "#839496",
+		InProgress:       "#b58900",
+		Done:             "#859900",
+		LowPriority:      "#2aa198",
+		MediumPriority:   "#cb4b16",
+		HighPriority:     "#dc322f",
+		HelpKey:          "#839496",
+		HelpDesc:         "#657b83",
+		HeaderBackground: "#073642",
+		HeaderText:       "#eee8d5",
+		HeaderMuted:      "#839496",
+		Success:          "#859900",
+		Error:            "#dc322f",
+		Info:             "#268bd2",
+		Loading:          "#2aa198",
+		Warning:          "#b58900",
+	}
+}
+
+// Themes returns the built-in themes in the order the theme key cycles through them
+func Themes() []Theme {
+	return []Theme{DarkTheme(), LightTheme(), SolarizedTheme()}
+}
+
+// ThemeByName returns the built-in theme with the given name, ignoring case. An
+// empty name is the dark theme; an unknown one returns the dark theme and an error.
+func ThemeByName(name string) (Theme, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return DarkTheme(), nil
+	}
+
+	themes := Themes()
+	names := make([]string, len(themes))
+	for i, t := range themes {
+		if t.Name == name {
+			return t, nil
+		}
+		names[i] = t.Name
+	}
+	return DarkTheme(), fmt.Errorf("unknown theme %q (expected %s)", name, strings.Join(names, ", "))
+}
+
+// NextTheme returns the built-in theme after the one with the given name, wrapping
+// around to the first
+func NextTheme(name string) Theme {
+	themes := Themes()
+	for i, t := range themes {
+		if t.Name == name {
+			return themes[(i+1)%len(themes)]
+		}
+	}
+	return themes[0]
+}
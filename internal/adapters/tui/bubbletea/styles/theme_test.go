@@ -0,0 +1,55 @@
+package styles
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThemeByName(t *testing.T) {
+	testCases := []struct {
+		name           string
+		input          string
+		expected       string
+		expectedErrMsg string
+	}{
+		{name: "Empty name is the dark theme", input: "", expected: "dark"},
+		{name: "Names ignore case and spaces", input: " Solarized ", expected: "solarized"},
+		{name: "Light theme", input: "light", expected: "light"},
+		{name: "Unknown name falls back to dark", input: "neon", expected: "dark", expectedErrMsg: `unknown theme "neon"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			theme, err := ThemeByName(tc.input)
+
+			if tc.expectedErrMsg != "" {
+				assert.ErrorContains(t, err, tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expected, theme.Name)
+		})
+	}
+}
+
+func TestNextThemeWrapsAround(t *testing.T) {
+	name := DarkTheme().Name
+	var seen []string
+	for range Themes() {
+		name = NextTheme(name).Name
+		seen = append(seen, name)
+	}
+
+	assert.Equal(t, []string{"light", "solarized", "dark"}, seen)
+	assert.Equal(t, "dark", NextTheme("unknown").Name)
+}
+
+func TestSetTheme(t *testing.T) {
+	defer SetTheme(DarkTheme())
+
+	SetTheme(LightTheme())
+
+	assert.Equal(t, "light", ActiveTheme().Name)
+	assert.Equal(t, LightTheme(), ActiveStyles.Theme)
+}
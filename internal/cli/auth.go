@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+)
+
+// authenticateUser resolves the user for non-interactive commands.
+// Credentials come from TUSK_USERNAME/TUSK_PASSWORD when set, otherwise the user is prompted.
+func authenticateUser(ctx context.Context) (int64, error) {
+	username := cfg.Username
+	password := cfg.Password
+
+	var err error
+	if username == "" {
+		username, err = readLine("Username: ")
+		if err != nil {
+			return 0, fmt.Errorf("failed to read username: %v", err)
+		}
+	}
+	if username == "" {
+		return 0, fmt.Errorf("username cannot be empty")
+	}
+
+	if password == "" {
+		password, err = readPassword("Password: ")
+		if err != nil {
+			return 0, fmt.Errorf("failed to read password: %v", err)
+		}
+	}
+	if password == "" {
+		return 0, fmt.Errorf("password cannot be empty")
+	}
+
+	u, err := userSvc.Login(ctx, username, password)
+	if err != nil {
+		return 0, fmt.Errorf("login failed: %v", err)
+	}
+	return int64(u.ID), nil
+}
@@ -7,6 +7,9 @@ import (
 	"os"
 
 	"github.com/newbpydev/tusk/internal/adapters/db"
+	"github.com/newbpydev/tusk/internal/config"
+	"github.com/newbpydev/tusk/internal/core/errors"
+	coretask "github.com/newbpydev/tusk/internal/core/task"
 	"github.com/newbpydev/tusk/internal/service/task"
 	"github.com/newbpydev/tusk/internal/service/user"
 	"github.com/newbpydev/tusk/internal/util/logging"
@@ -15,6 +18,7 @@ import (
 )
 
 var (
+	cfg          *config.Config
 	taskSvc      task.Service
 	asyncTaskSvc *task.AsyncTaskService
 	userSvc      user.Service
@@ -27,27 +31,79 @@ It supports hierarchical tasks, priorities, due dates, and more.`,
 		// 	// If no commands or flags, show help by default
 		// 	cmd.Help()
 		// },
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			if jsonOutput {
+				// Errors are printed as JSON by Execute, without cobra's text and usage
+				cmd.SilenceErrors = true
+				cmd.SilenceUsage = true
+			}
+			if cmd.Annotations[skipServicesAnnotation] == "" {
+				initServices()
+			}
+		},
 	}
 )
 
+// skipServicesAnnotation marks commands that run without a database connection
+const skipServicesAnnotation = "skip-services"
+
 // initServices initializes all application services
 func initServices() {
+	cfg = config.Load()
+
 	// Connect to database
 	if err := db.Connect(context.Background()); err != nil {
 		logging.Logger.Error("Failed to connect to database", zap.Error(err))
-		fmt.Println("Error: Could not connect to database. Check logs for details.")
+		if jsonOutput {
+			printError(fmt.Errorf("could not connect to database: %v", err))
+		} else {
+			fmt.Println("Error: Could not connect to database. Check logs for details.")
+		}
 		os.Exit(1)
 	}
 	logger := logging.Logger
 
 	// Initialize the task repository using the global DB pool
-	taskRepo := db.NewSQLTaskRepository(db.Pool)
+	taskRepo := db.NewSQLTaskRepository(db.Pool, db.WithPointsWeightedProgress(cfg.ProgressByPoints))
+
+	parentCompletion, err := task.ParseParentCompletionMode(cfg.ParentAutoComplete)
+	if err != nil {
+		logger.Warn("Invalid parent completion mode, falling back to never", zap.Error(err))
+	}
+
+	urgency, err := coretask.ParseUrgencyWeights(cfg.UrgencyWeights)
+	if err != nil {
+		logger.Warn("Invalid urgency weights, falling back to defaults", zap.Error(err))
+	}
+
+	loc, err := cfg.Location()
+	if err != nil {
+		logger.Warn("Invalid time zone, falling back to local time", zap.Error(err))
+	}
+
+	if err := setStatusLabels(cfg.StatusLabels); err != nil {
+		logger.Warn("Invalid status labels, falling back to defaults", zap.Error(err))
+	}
 
 	// Initialize the regular task service
-	regularTaskSvc := task.NewTaskService(taskRepo)
+	regularTaskSvc := task.NewTaskService(taskRepo,
+		task.WithParentCompletion(parentCompletion),
+		task.WithTagCaseFolding(cfg.TagCaseFold),
+		task.WithMaxDepth(cfg.MaxTaskDepth),
+		task.WithLocation(loc),
+		task.WithDueSoonDays(cfg.DueSoonDays),
+		task.WithUrgencyWeights(urgency))
+
+	completionHook, err := task.NewCompletionHook(cfg.CompletionHook, cfg.CompletionHookTimeout)
+	if err != nil {
+		logger.Warn("Invalid completion hook, disabling it", zap.Error(err))
+	}
 
 	// Wrap in async service for non-blocking operations
-	asyncTaskSvc = task.NewAsyncTaskService(regularTaskSvc, logger)
+	asyncTaskSvc = task.NewAsyncTaskService(regularTaskSvc, logger,
+		task.WithCompletionHook(completionHook),
+		task.WithCacheTTL(cfg.CacheTTL),
+		task.WithJobTimeout(cfg.AsyncJobTimeout))
 
 	// Expose as the global task service
 	taskSvc = asyncTaskSvc
@@ -59,18 +115,67 @@ func initServices() {
 	userSvc = user.NewUserService(userRepo)
 }
 
+// Exit statuses for failed commands, so scripts can tell bad input from missing tasks
+const (
+	exitFailure      = 1
+	exitInvalidInput = 2
+	exitNotFound     = 3
+	exitUnauthorized = 4
+	exitConflict     = 5
+	exitForbidden    = 6
+)
+
+// exitCodes maps error codes to exit statuses; any other error exits with exitFailure
+var exitCodes = map[errors.ErrorCode]int{
+	errors.CodeInvalidInput: exitInvalidInput,
+	errors.CodeNotFound:     exitNotFound,
+	errors.CodeUnauthorized: exitUnauthorized,
+	errors.CodeConflict:     exitConflict,
+	errors.CodeForbidden:    exitForbidden,
+}
+
+// exitCode maps a command error to the process exit status by its code
+func exitCode(err error) int {
+	if code, ok := exitCodes[errors.CodeOf(err)]; ok {
+		return code
+	}
+	return exitFailure
+}
+
 // Execute runs the root command
 func Execute() {
-	// Initialize services
-	initServices()
+	// Services are initialized by the root command's pre-run hook
+	err := rootCmd.Execute()
 
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+	// Flush background jobs before exiting, also when the command failed
+	shutdown()
+
+	if err != nil {
+		printError(err)
+		os.Exit(exitCode(err))
+	}
+}
+
+// shutdown waits up to one job timeout for the async task service's pending
+// background jobs and closes it
+func shutdown() {
+	if asyncTaskSvc == nil {
+		return
 	}
 
-	// Clean shutdown of async services
-	if asyncTaskSvc != nil {
-		asyncTaskSvc.Close()
+	timeout := cfg.AsyncJobTimeout
+	if timeout <= 0 {
+		timeout = task.DefaultJobTimeout
 	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := asyncTaskSvc.Wait(ctx); err != nil {
+		logging.Logger.Warn("Background jobs still running at exit", zap.Error(err))
+	}
+	asyncTaskSvc.Close()
+}
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false,
+		"Print the output of read commands (list, search, show, today, next) as JSON, and errors as JSON on stderr")
 }
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/newbpydev/tusk/internal/core/errors"
+	"github.com/newbpydev/tusk/internal/core/task"
+	"github.com/spf13/cobra"
+)
+
+var completeCmd = &cobra.Command{
+	Use:     "complete <id>",
+	Aliases: []string{"done"},
+	Short:   "Mark a task as done by its ID",
+	Long: `Mark one of your tasks as done without launching the TUI. The ID is the number shown
+as #<id> by tusk list. Exits with status 2 for an invalid ID and 3 when no such task exists.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		t, err := loadOwnedTask(ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		done, err := taskSvc.Complete(ctx, int64(t.ID))
+		if err != nil {
+			return errors.Wrapf(err, "failed to complete task #%d", t.ID)
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "✓ Completed #%d %s\n", done.ID, done.Title)
+		return nil
+	},
+}
+
+var rmCmd = &cobra.Command{
+	Use:   "rm <id>",
+	Short: "Delete a task and its subtasks by its ID",
+	Long: `Delete one of your tasks, including its subtasks, without launching the TUI.
+Exits with status 2 for an invalid ID and 3 when no such task exists.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		t, err := loadOwnedTask(ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		if err := taskSvc.Delete(ctx, int64(t.ID)); err != nil {
+			return errors.Wrapf(err, "failed to delete task #%d", t.ID)
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "✗ Deleted #%d %s\n", t.ID, t.Title)
+		return nil
+	},
+}
+
+// loadOwnedTask parses a task ID argument and loads the task, reporting tasks owned
+// by other users as not found so their IDs are not leaked
+func loadOwnedTask(ctx context.Context, arg string) (task.Task, error) {
+	id, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil || id <= 0 {
+		return task.Task{}, errors.InvalidInput(fmt.Sprintf("invalid task ID %q: must be a positive number", arg))
+	}
+
+	userID, err := authenticateUser(ctx)
+	if err != nil {
+		return task.Task{}, err
+	}
+
+	t, err := taskSvc.Show(ctx, id)
+	if errors.IsNotFound(err) || (err == nil && int64(t.UserID) != userID) {
+		return task.Task{}, errors.NotFound(fmt.Sprintf("task #%d not found", id))
+	}
+	if err != nil {
+		return task.Task{}, errors.Wrapf(err, "failed to load task #%d", id)
+	}
+	return t, nil
+}
+
+func init() {
+	rootCmd.AddCommand(completeCmd)
+	rootCmd.AddCommand(rmCmd)
+}
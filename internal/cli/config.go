@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/app"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/components/shared"
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/styles"
+	"github.com/newbpydev/tusk/internal/config"
+	coretask "github.com/newbpydev/tusk/internal/core/task"
+	"github.com/newbpydev/tusk/internal/service/task"
+	"github.com/spf13/cobra"
+)
+
+// errConfigInvalid is returned by `tusk config validate` when problems were found,
+// after they have already been printed
+var errConfigInvalid = errors.New("config is invalid")
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the Tusk configuration",
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate [path]",
+	Short: "Check a config file for problems without connecting to the database",
+	Long: `Load the configuration from the environment and the given .env file (or ./.env when
+no path is given) and check every setting: the database URL format, time zone, durations,
+numbers, and the task filter, date format, snooze preset and status label specs.
+Exits with status 1 when any problem is found.`,
+	Args:          cobra.MaximumNArgs(1),
+	Annotations:   map[string]string{skipServicesAnnotation: "true"},
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := ""
+		if len(args) > 0 {
+			path = args[0]
+		}
+
+		out := cmd.OutOrStdout()
+		cfg, err := config.LoadFile(path)
+		if err != nil {
+			fmt.Fprintf(out, "✗ %v\n", err)
+			return errConfigInvalid
+		}
+
+		problems := validateConfig(cfg)
+		if len(problems) == 0 {
+			fmt.Fprintln(out, "✓ Config is valid.")
+			return nil
+		}
+
+		fmt.Fprintf(out, "Found %d problem(s):\n", len(problems))
+		for _, p := range problems {
+			fmt.Fprintf(out, "  ✗ %v\n", p)
+		}
+		return errConfigInvalid
+	},
+}
+
+// validateConfig runs the config loader's checks plus the parsers each setting is
+// fed through at startup, so a config that passes here loads without fallbacks
+func validateConfig(cfg *config.Config) []error {
+	problems := config.Validate(cfg)
+
+	if cfg.DBURL == "" {
+		problems = append(problems, errors.New("DB_URL: not set"))
+	} else if _, err := pgxpool.ParseConfig(cfg.DBURL); err != nil {
+		problems = append(problems, fmt.Errorf("DB_URL: %v", err))
+	}
+
+	if _, err := task.ParseParentCompletionMode(cfg.ParentAutoComplete); err != nil {
+		problems = append(problems, fmt.Errorf("PARENT_AUTO_COMPLETE: %v", err))
+	}
+	if _, err := coretask.ParseUndatedPlacement(cfg.UndatedTasks); err != nil {
+		problems = append(problems, fmt.Errorf("UNDATED_TASKS: %v", err))
+	}
+	if _, err := coretask.ParseTaskFilter(cfg.StartupFilter, time.Now()); err != nil {
+		problems = append(problems, fmt.Errorf("STARTUP_FILTER: %v", err))
+	}
+	if err := shared.ValidateDateLayout(cfg.DateFormat); err != nil {
+		problems = append(problems, fmt.Errorf("DATE_FORMAT: %v", err))
+	}
+	if _, err := coretask.ParseSnoozePresets(cfg.SnoozePresets); err != nil {
+		problems = append(problems, fmt.Errorf("SNOOZE_PRESETS: %v", err))
+	}
+	if _, err := coretask.ParseStatusLabels(cfg.StatusLabels); err != nil {
+		problems = append(problems, fmt.Errorf("STATUS_LABELS: %v", err))
+	}
+	if _, err := coretask.ParseTagColors(cfg.TagColors); err != nil {
+		problems = append(problems, fmt.Errorf("TAG_COLORS: %v", err))
+	}
+	if _, err := styles.ThemeByName(cfg.Theme); err != nil {
+		problems = append(problems, fmt.Errorf("THEME: %v", err))
+	}
+	if err := shared.ValidateCarriedFormat(cfg.CarriedFormat); err != nil {
+		problems = append(problems, fmt.Errorf("CARRIED_FORMAT: %v", err))
+	}
+	if _, err := coretask.ParseUrgencyWeights(cfg.UrgencyWeights); err != nil {
+		problems = append(problems, fmt.Errorf("URGENCY_WEIGHTS: %v", err))
+	}
+	if _, err := coretask.ParseActionableRules(cfg.Actionable); err != nil {
+		problems = append(problems, fmt.Errorf("ACTIONABLE: %v", err))
+	}
+	if _, err := app.ParseEnterAction(cfg.EnterAction); err != nil {
+		problems = append(problems, fmt.Errorf("ENTER_ACTION: %v", err))
+	}
+	if _, err := app.ParseSectionEnter(cfg.SectionEnter); err != nil {
+		problems = append(problems, fmt.Errorf("SECTION_ENTER: %v", err))
+	}
+	if _, err := task.NewCompletionHook(cfg.CompletionHook, cfg.CompletionHookTimeout); err != nil {
+		problems = append(problems, fmt.Errorf("COMPLETION_HOOK: %v", err))
+	}
+	if _, err := loadJournalTemplate(cfg.JournalTemplate); err != nil {
+		problems = append(problems, fmt.Errorf("JOURNAL_TEMPLATE: %v", err))
+	}
+
+	return problems
+}
+
+func init() {
+	configCmd.AddCommand(configValidateCmd)
+	rootCmd.AddCommand(configCmd)
+}
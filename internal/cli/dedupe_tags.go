@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var dedupeTagsCmd = &cobra.Command{
+	Use:   "dedupe-tags",
+	Short: "Remove duplicate tags from your tasks",
+	Long: `Trim, lowercase and deduplicate the tags of all your tasks, keeping the first
+occurrence of each tag. Tags saved before they were lowercased are merged this way. With
+TAG_CASE_FOLD=false tags keep their case and only exact duplicates are removed.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		userID, err := authenticateUser(ctx)
+		if err != nil {
+			return err
+		}
+
+		updated, err := taskSvc.DedupeTags(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to dedupe tags: %v", err)
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Deduplicated tags on %d task(s).\n", updated)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(dedupeTagsCmd)
+}
@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/newbpydev/tusk/internal/adapters/db"
+	"github.com/newbpydev/tusk/internal/config"
+	"github.com/newbpydev/tusk/internal/util/logging"
+	"github.com/spf13/cobra"
+)
+
+// doctorProbeTimeout bounds how long `tusk doctor --env` waits for the database
+const doctorProbeTimeout = 5 * time.Second
+
+// redacted replaces secret values in diagnostics output
+const redacted = "*****"
+
+// secretEnvMarkers flag config variables whose values are never printed
+var secretEnvMarkers = []string{"PASSWORD", "SECRET", "TOKEN"}
+
+// dsnPasswordPattern finds the password in a key=value style connection string
+var dsnPasswordPattern = regexp.MustCompile(`(?i)(password\s*=\s*)('[^']*'|\S+)`)
+
+var doctorEnv bool
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose problems with your Tusk setup",
+	Long: `Print diagnostics that help troubleshoot problems. With --env, report the resolved
+configuration (secrets redacted), database connectivity and migration version, the log
+file location and the Go runtime. It never needs a working database: connection
+failures are reported instead of aborting, so the output can be attached to bug reports.`,
+	Annotations:  map[string]string{skipServicesAnnotation: "true"},
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !doctorEnv {
+			return fmt.Errorf("choose a diagnostic to run, e.g. tusk doctor --env")
+		}
+
+		ctx, cancel := context.WithTimeout(cmd.Context(), doctorProbeTimeout)
+		defer cancel()
+
+		writeEnvDiagnostics(ctx, cmd.OutOrStdout(), config.Load())
+		return nil
+	},
+}
+
+// writeEnvDiagnostics prints the runtime, config, database and logging report
+func writeEnvDiagnostics(ctx context.Context, w io.Writer, cfg *config.Config) {
+	fmt.Fprintln(w, "Runtime")
+	fmt.Fprintf(w, "  Go version:  %s\n", runtime.Version())
+	fmt.Fprintf(w, "  Platform:    %s/%s\n", runtime.GOOS, runtime.GOARCH)
+	if info, ok := debug.ReadBuildInfo(); ok {
+		fmt.Fprintf(w, "  Module:      %s %s\n", info.Main.Path, info.Main.Version)
+	}
+
+	fmt.Fprintln(w, "\nConfig")
+	v := reflect.ValueOf(*cfg)
+	for i := 0; i < v.NumField(); i++ {
+		name := v.Type().Field(i).Tag.Get("env")
+		if name == "" {
+			continue
+		}
+		fmt.Fprintf(w, "  %s=%s\n", name, redactConfigValue(name, fmt.Sprint(v.Field(i).Interface())))
+	}
+
+	fmt.Fprintln(w, "\nDatabase")
+	fmt.Fprintf(w, "  URL:         %s\n", redactDBURL(cfg.DBURL))
+	version, err := db.Probe(ctx, cfg.DBURL)
+	switch {
+	case err == nil:
+		fmt.Fprintln(w, "  Connection:  ✓ ok")
+		state := ""
+		if version.Dirty {
+			state = " (dirty: a migration failed and needs manual repair)"
+		}
+		fmt.Fprintf(w, "  Schema:      version %d%s\n", version.Version, state)
+	case errors.Is(err, db.ErrSchemaVersionUnknown):
+		fmt.Fprintln(w, "  Connection:  ✓ ok")
+		fmt.Fprintf(w, "  Schema:      ✗ %v\n", err)
+	default:
+		fmt.Fprintf(w, "  Connection:  ✗ %s\n", dsnPasswordPattern.ReplaceAllString(err.Error(), "${1}"+redacted))
+		fmt.Fprintln(w, "  Schema:      unknown")
+	}
+
+	fmt.Fprintln(w, "\nLogging")
+	path := logging.LogFilePath()
+	if info, err := os.Stat(path); err == nil {
+		fmt.Fprintf(w, "  Log file:    %s (%d bytes)\n", path, info.Size())
+	} else {
+		fmt.Fprintf(w, "  Log file:    %s (not created yet)\n", path)
+	}
+}
+
+// redactConfigValue hides the values of secret config variables and the password
+// inside the database URL
+func redactConfigValue(name, value string) string {
+	if name == "DB_URL" {
+		return redactDBURL(value)
+	}
+	for _, marker := range secretEnvMarkers {
+		if strings.Contains(name, marker) && value != "" {
+			return redacted
+		}
+	}
+	return value
+}
+
+// redactDBURL masks the password in a postgres:// URL or key=value connection string
+func redactDBURL(dsn string) string {
+	u, err := url.Parse(dsn)
+	if err != nil || u.Scheme == "" {
+		return dsnPasswordPattern.ReplaceAllString(dsn, "${1}"+redacted)
+	}
+	if q := u.Query(); q.Has("password") {
+		q.Set("password", redacted)
+		u.RawQuery = q.Encode()
+	}
+	// Redacted masks the password as "xxxxx"; use the same mask as everywhere else
+	return strings.Replace(u.Redacted(), ":xxxxx@", ":"+redacted+"@", 1)
+}
+
+func init() {
+	doctorCmd.Flags().BoolVar(&doctorEnv, "env", false, "Report config, database connectivity, log location and runtime versions")
+	rootCmd.AddCommand(doctorCmd)
+}
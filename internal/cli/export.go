@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/newbpydev/tusk/internal/export"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportFormat string
+	exportOutput string
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export all of your tasks as CSV, JSON, Markdown or iCalendar",
+	Long: `Export every task, completed or not, to stdout or to the file given with --output.
+CSV output has one row per task, subtasks included, with the columns id, title, status,
+priority, due_date, tags (semicolon-separated) and parent_id. JSON keeps subtasks nested
+and Markdown renders them as an indented checklist. ICS writes every task with a due date
+as a calendar event, ready to import into Google Calendar or Apple Calendar.
+
+  tusk export --format csv --output weekly.csv`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		format, err := export.ParseFormat(exportFormat)
+		if err != nil {
+			return err
+		}
+
+		userID, err := authenticateUser(ctx)
+		if err != nil {
+			return err
+		}
+
+		tasks, err := taskSvc.List(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to list tasks: %v", err)
+		}
+
+		if exportOutput == "" || exportOutput == "-" {
+			return export.Write(cmd.OutOrStdout(), format, tasks)
+		}
+
+		f, err := os.Create(exportOutput)
+		if err != nil {
+			return fmt.Errorf("failed to create export file: %v", err)
+		}
+		if err := export.Write(f, format, tasks); err != nil {
+			f.Close()
+			return fmt.Errorf("failed to export tasks: %v", err)
+		}
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("failed to write export file: %v", err)
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Exported %d task(s) to %s\n", len(export.Flatten(tasks)), exportOutput)
+		return nil
+	},
+}
+
+func init() {
+	exportCmd.Flags().StringVar(&exportFormat, "format", string(export.FormatCSV), "Export format: csv, json, markdown or ics")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "File to write the export to (defaults to stdout)")
+	rootCmd.AddCommand(exportCmd)
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/newbpydev/tusk/internal/core/task"
+	"github.com/spf13/cobra"
+)
+
+// defaultFocusDuration is the length of a focus session when none is given
+const defaultFocusDuration = 25 * time.Minute
+
+var focusSince bool
+
+var focusCmd = &cobra.Command{
+	Use:   "focus [duration]",
+	Short: "Run a focus session timer and summarize what you got done",
+	Long: `Start a focus session of the given length (25m by default) and, when the timer
+ends, list the tasks you completed or changed during it. Interrupting the timer ends the
+session early and still prints the summary. With --since, skip the timer and summarize
+the last duration instead, e.g. after timing a session elsewhere.
+
+  tusk focus 50m`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		duration := defaultFocusDuration
+		if len(args) == 1 {
+			d, err := time.ParseDuration(args[0])
+			if err != nil || d <= 0 {
+				return fmt.Errorf("invalid duration %q (expected e.g. 25m or 1h)", args[0])
+			}
+			duration = d
+		}
+
+		userID, err := authenticateUser(ctx)
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		end := time.Now()
+		start := end.Add(-duration)
+		if !focusSince {
+			start = time.Now()
+			fmt.Fprintf(out, "Focus session started, ends at %s. Press Ctrl+C to end it early.\n",
+				start.Add(duration).Format("15:04"))
+
+			timerCtx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+			timer := time.NewTimer(duration)
+			select {
+			case <-timerCtx.Done():
+			case <-timer.C:
+				fmt.Fprint(out, "\a") // ring the terminal bell
+			}
+			timer.Stop()
+			stop()
+			end = time.Now()
+		}
+
+		summary, err := taskSvc.SessionSummary(ctx, userID, start, end)
+		if err != nil {
+			return fmt.Errorf("failed to summarize session: %v", err)
+		}
+		writeSessionSummary(out, summary)
+		return nil
+	},
+}
+
+// writeSessionSummary prints the tasks completed and changed during a session
+func writeSessionSummary(w io.Writer, s task.SessionSummary) {
+	fmt.Fprintf(w, "Focus session %s–%s (%s)\n", s.Start.Format("15:04"), s.End.Format("15:04"),
+		s.End.Sub(s.Start).Round(time.Minute))
+
+	if len(s.Completed) == 0 && len(s.Modified) == 0 {
+		fmt.Fprintln(w, "No tasks were completed or changed.")
+		return
+	}
+	if len(s.Completed) > 0 {
+		fmt.Fprintf(w, "Completed (%d):\n", len(s.Completed))
+		for _, t := range s.Completed {
+			fmt.Fprintf(w, "  ✓ #%d %s\n", t.ID, t.Title)
+		}
+	}
+	if len(s.Modified) > 0 {
+		fmt.Fprintf(w, "Changed (%d):\n", len(s.Modified))
+		for _, t := range s.Modified {
+			fmt.Fprintf(w, "  • #%d %s [%s]\n", t.ID, t.Title, t.Status)
+		}
+	}
+}
+
+func init() {
+	focusCmd.Flags().BoolVar(&focusSince, "since", false, "Summarize the last duration without running a timer")
+	rootCmd.AddCommand(focusCmd)
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var initDemo bool
+
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Finish first-run setup for your account",
+	Long: `Mark the first run as done so the TUI no longer seeds example tasks on launch.
+With --demo, example tasks (a welcome task, a sample project with subtasks and a task
+with a due date) are created first. Seeding only happens while you have no tasks, so
+running this again never duplicates them. Set DEMO_TASKS=false to skip seeding on first launch.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		userID, err := authenticateUser(ctx)
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		if initDemo {
+			count, err := taskSvc.SeedDemoTasks(ctx, userID)
+			if err != nil {
+				return fmt.Errorf("failed to seed demo tasks: %v", err)
+			}
+			if count == 0 {
+				fmt.Fprintln(out, "You already have tasks, no demo tasks were added.")
+			} else {
+				fmt.Fprintf(out, "Added %d demo task(s).\n", count)
+			}
+		}
+
+		if err := markOnboarded(userID); err != nil {
+			return err
+		}
+		fmt.Fprintln(out, "✓ First-run setup complete.")
+		return nil
+	},
+}
+
+func init() {
+	initCmd.Flags().BoolVar(&initDemo, "demo", false, "Create example tasks that show off the TUI")
+	rootCmd.AddCommand(initCmd)
+}
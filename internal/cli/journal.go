@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"text/template"
+	"time"
+
+	"github.com/newbpydev/tusk/internal/core/task"
+	"github.com/spf13/cobra"
+)
+
+// defaultJournalTemplate renders the journal as a markdown block
+const defaultJournalTemplate = `## {{.Date.Format "2006-01-02"}}
+
+### Completed today
+{{range .Completed}}- [x] {{.Title}}
+{{else}}- Nothing completed
+{{end}}
+### Still open
+{{range .Open}}- [ ] {{.Title}}{{with .DueDate}} (due {{.Format "2006-01-02"}}){{end}}
+{{else}}- Nothing open
+{{end}}
+### Overdue
+{{range .Overdue}}- [ ] {{.Title}} (due {{.DueDate.Format "2006-01-02"}})
+{{else}}- Nothing overdue
+{{end}}`
+
+var (
+	journalTemplate string
+	journalTimezone string
+)
+
+var journalCmd = &cobra.Command{
+	Use:   "journal",
+	Short: "Print a markdown summary of your day for journaling",
+	Long: `Print what you completed today, what is still open and what is overdue as a
+markdown block on stdout, ready to append to a journal file:
+
+  tusk journal >> ~/journal.md
+
+"Today" is the current day in --tz (defaults to TIMEZONE, then the local time zone). The layout can be
+replaced with a Go text/template file via --template (JOURNAL_TEMPLATE); it receives
+.Date, .Completed, .Open and .Overdue, the last three being lists of tasks.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		path := journalTemplate
+		if path == "" {
+			path = cfg.JournalTemplate
+		}
+		tmpl, err := loadJournalTemplate(path)
+		if err != nil {
+			return err
+		}
+
+		loc, err := cfg.Location()
+		if err != nil {
+			return err
+		}
+		if journalTimezone != "" {
+			if loc, err = time.LoadLocation(journalTimezone); err != nil {
+				return fmt.Errorf("unknown time zone %q", journalTimezone)
+			}
+		}
+
+		userID, err := authenticateUser(ctx)
+		if err != nil {
+			return err
+		}
+
+		done, err := taskSvc.ListByStatus(ctx, userID, task.StatusDone)
+		if err != nil {
+			return fmt.Errorf("failed to list completed tasks: %v", err)
+		}
+
+		open, err := taskSvc.ListByStatuses(ctx, userID, task.ActiveStatuses())
+		if err != nil {
+			return fmt.Errorf("failed to list open tasks: %v", err)
+		}
+
+		journal := task.NewJournal(done, open, time.Now().In(loc))
+		if err := tmpl.Execute(cmd.OutOrStdout(), journal); err != nil {
+			return fmt.Errorf("failed to render journal: %v", err)
+		}
+		return nil
+	},
+}
+
+// loadJournalTemplate parses the journal template at path, or the default
+// markdown template when path is empty
+func loadJournalTemplate(path string) (*template.Template, error) {
+	text := defaultJournalTemplate
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read journal template: %v", err)
+		}
+		text = string(data)
+	}
+
+	tmpl, err := template.New("journal").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid journal template: %v", err)
+	}
+	return tmpl, nil
+}
+
+func init() {
+	journalCmd.Flags().StringVar(&journalTemplate, "template", "", "Go text/template file for the journal (defaults to JOURNAL_TEMPLATE)")
+	journalCmd.Flags().StringVar(&journalTimezone, "tz", "", "Time zone that decides where today starts, e.g. Europe/Berlin (defaults to TIMEZONE)")
+	rootCmd.AddCommand(journalCmd)
+}
@@ -0,0 +1,208 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/newbpydev/tusk/internal/core/task"
+	"github.com/newbpydev/tusk/internal/export"
+	"github.com/spf13/cobra"
+)
+
+// listOptions holds the filters and output settings of the list command
+type listOptions struct {
+	status   string
+	priority string
+	tag      string
+	format   string
+	watch    bool
+	interval time.Duration
+}
+
+var listOpts listOptions
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List your tasks",
+	Long: `List your tasks, optionally filtered by status, priority or tag.
+--status takes one or more comma-separated statuses, or "active" for todo and in-progress.
+Use --format json or --format csv to export exactly the filtered set.
+Use --watch to keep reprinting the list every --interval (WATCH_INTERVAL) until ctrl+c.
+--json prints the tasks as JSON whatever --format says, e.g. tusk list --json | jq.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		userID, err := authenticateUser(ctx)
+		if err != nil {
+			return err
+		}
+
+		if listOpts.watch {
+			if jsonOutput {
+				return fmt.Errorf("--watch cannot be combined with --json")
+			}
+			return watchTasks(cmd, userID, listOpts)
+		}
+
+		tasks, err := listTasks(ctx, userID, listOpts)
+		if err != nil {
+			return err
+		}
+
+		return writeTasks(cmd.OutOrStdout(), listOpts.format, tasks)
+	},
+}
+
+var searchFormat string
+
+var searchCmd = &cobra.Command{
+	Use:   "search <pattern>",
+	Short: "Search tasks by title",
+	Long: `Search your tasks by title.
+Use --format json or --format csv to export the matching tasks.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		userID, err := authenticateUser(ctx)
+		if err != nil {
+			return err
+		}
+
+		tasks, err := taskSvc.SearchByTitle(ctx, userID, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to search tasks: %v", err)
+		}
+
+		return writeTasks(cmd.OutOrStdout(), searchFormat, tasks)
+	},
+}
+
+// listTasks returns the user's tasks matching the list options. Without filters the
+// full task trees are returned; with filters the matching tasks are returned flat.
+func listTasks(ctx context.Context, userID int64, opts listOptions) ([]task.Task, error) {
+	statuses, err := parseStatuses(opts.status)
+	if err != nil {
+		return nil, err
+	}
+	switch task.Priority(opts.priority) {
+	case "", task.PriorityLow, task.PriorityMedium, task.PriorityHigh:
+	default:
+		return nil, fmt.Errorf("invalid priority %q (expected low, medium or high)", opts.priority)
+	}
+
+	var tasks []task.Task
+	if len(statuses) > 0 {
+		// The status filter runs in the database and already returns the tasks flat
+		tasks, err = taskSvc.ListByStatuses(ctx, userID, statuses)
+	} else {
+		tasks, err = taskSvc.List(ctx, userID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %v", err)
+	}
+
+	if opts.priority == "" && opts.tag == "" {
+		return tasks, nil
+	}
+
+	var filtered []task.Task
+	for _, t := range export.Flatten(tasks) {
+		if opts.priority != "" && string(t.Priority) != opts.priority {
+			continue
+		}
+		if opts.tag != "" && !hasTag(t, opts.tag) {
+			continue
+		}
+		filtered = append(filtered, t)
+	}
+	return filtered, nil
+}
+
+// parseStatuses parses the --status flag: a comma-separated list of statuses, where
+// "active" stands for every status that still needs work
+func parseStatuses(spec string) ([]task.Status, error) {
+	var statuses []task.Status
+	for _, part := range strings.Split(spec, ",") {
+		status := task.Status(strings.ToLower(strings.TrimSpace(part)))
+		switch status {
+		case "":
+		case "active":
+			statuses = append(statuses, task.ActiveStatuses()...)
+		case task.StatusTodo, task.StatusInProgress, task.StatusDone:
+			statuses = append(statuses, status)
+		default:
+			return nil, fmt.Errorf("invalid status %q (expected todo, in-progress, done or active)", part)
+		}
+	}
+	return statuses, nil
+}
+
+// watchTasks reprints the filtered task list on every interval, clearing the screen
+// in between, until interrupted
+func watchTasks(cmd *cobra.Command, userID int64, opts listOptions) error {
+	interval := opts.interval
+	if interval <= 0 {
+		interval = cfg.WatchInterval
+	}
+	if interval < time.Second {
+		return fmt.Errorf("watch interval must be at least 1s, got %s", interval)
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	out := cmd.OutOrStdout()
+	for {
+		tasks, err := listTasks(ctx, userID, opts)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		// Clear the screen and move the cursor home before reprinting
+		fmt.Fprint(out, "\033[H\033[2J")
+		fmt.Fprintf(out, "Every %s: tusk list    %s\n\n", interval, time.Now().Format("2006-01-02 15:04:05"))
+		if err != nil {
+			fmt.Fprintln(out, err)
+		} else if err := writeTasks(out, opts.format, tasks); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// hasTag reports whether the task carries the given tag
+func hasTag(t task.Task, name string) bool {
+	for _, tag := range t.Tags {
+		if tag.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	listCmd.Flags().StringVar(&listOpts.status, "status", "", "Only list tasks with these comma-separated statuses (todo, in-progress, done, active)")
+	listCmd.Flags().StringVar(&listOpts.priority, "priority", "", "Only list tasks with this priority (low, medium, high)")
+	listCmd.Flags().StringVar(&listOpts.tag, "tag", "", "Only list tasks with this tag")
+	listCmd.Flags().StringVar(&listOpts.format, "format", formatText, "Output format: text, json, csv or markdown")
+	listCmd.Flags().BoolVarP(&listOpts.watch, "watch", "w", false, "Refresh the list periodically until interrupted")
+	listCmd.Flags().DurationVar(&listOpts.interval, "interval", 0, "Refresh interval for --watch (defaults to WATCH_INTERVAL)")
+	rootCmd.AddCommand(listCmd)
+
+	searchCmd.Flags().StringVar(&searchFormat, "format", formatText, "Output format: text, json, csv or markdown")
+	rootCmd.AddCommand(searchCmd)
+}
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var mergeTagsDryRun bool
+
+var mergeTagsCmd = &cobra.Command{
+	Use:   "merge-tags <tag>",
+	Short: "Merge tags that differ only in case into one spelling",
+	Long: `Rewrite every spelling of a tag that differs only in case, e.g. "work" and "WORK",
+to the spelling given as argument on all your tasks. Tasks carrying several spellings keep
+a single tag. Either every task changes or none does.
+Use --dry-run to see the spellings found and how many tasks would change.
+Tags are saved lowercase unless TAG_CASE_FOLD=false, so only then is the given spelling kept.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		canonical := strings.TrimSpace(args[0])
+
+		userID, err := authenticateUser(ctx)
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		if mergeTagsDryRun {
+			merge, err := taskSvc.MergeTagCasePreview(ctx, userID, canonical)
+			if err != nil {
+				return fmt.Errorf("failed to preview tag merge: %v", err)
+			}
+			if len(merge.Tasks) == 0 {
+				fmt.Fprintf(out, "Nothing to merge: no task uses another spelling of %q.\n", merge.Canonical)
+				return nil
+			}
+			fmt.Fprintf(out, "Would merge %s into %q on %d task(s):\n",
+				quoteAll(merge.Variants), merge.Canonical, len(merge.Tasks))
+			for _, t := range merge.Tasks {
+				fmt.Fprintf(out, "  #%d %s\n", t.ID, t.Title)
+			}
+			return nil
+		}
+
+		updated, err := taskSvc.MergeTagCase(ctx, userID, canonical)
+		if err != nil {
+			return fmt.Errorf("failed to merge tags: %v", err)
+		}
+
+		fmt.Fprintf(out, "Merged the spellings of %q on %d task(s).\n", canonical, updated)
+		return nil
+	},
+}
+
+// quoteAll quotes every name and joins them with commas
+func quoteAll(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = fmt.Sprintf("%q", name)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func init() {
+	mergeTagsCmd.Flags().BoolVar(&mergeTagsDryRun, "dry-run", false, "Show the tasks that would change without changing them")
+	rootCmd.AddCommand(mergeTagsCmd)
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/newbpydev/tusk/internal/core/task"
+	"github.com/newbpydev/tusk/internal/export"
+	"github.com/spf13/cobra"
+)
+
+var (
+	nextCount int
+	nextWidth int
+)
+
+var nextCmd = &cobra.Command{
+	Use:   "next",
+	Short: "Print the next few tasks on a single line",
+	Long: `Print the most urgent incomplete tasks with due dates on a single line, suitable
+for a shell prompt, status bar or tmux. Overdue and high priority tasks come first;
+URGENCY_WEIGHTS tunes the ranking. The line is cut to --width characters (NEXT_WIDTH, default 80).
+With --json, the tasks are printed as a JSON array instead.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		if nextCount < 1 || nextCount > 3 {
+			return fmt.Errorf("--count must be between 1 and 3")
+		}
+
+		width := nextWidth
+		if width <= 0 {
+			width = cfg.NextWidth
+		}
+
+		userID, err := authenticateUser(ctx)
+		if err != nil {
+			return err
+		}
+
+		tasks, err := taskSvc.NextTasks(ctx, userID, nextCount)
+		if err != nil {
+			return fmt.Errorf("failed to get next tasks: %v", err)
+		}
+
+		if jsonOutput {
+			return export.WriteJSON(cmd.OutOrStdout(), tasks)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), formatNextLine(tasks, time.Now(), width))
+		return nil
+	},
+}
+
+// formatNextLine joins tasks into one line and cuts it to width characters
+func formatNextLine(tasks []task.Task, now time.Time, width int) string {
+	if len(tasks) == 0 {
+		return "Nothing due"
+	}
+
+	parts := make([]string, len(tasks))
+	for i, t := range tasks {
+		marker := ""
+		if t.Priority == task.PriorityHigh {
+			marker = "!"
+		}
+		parts[i] = fmt.Sprintf("%s%s (%s)", marker, t.Title, relativeDue(*t.DueDate, now))
+	}
+
+	return truncate(strings.Join(parts, " | "), width)
+}
+
+// relativeDue describes a due date relative to now in a few characters
+func relativeDue(due, now time.Time) string {
+	dueDay := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, now.Location())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	days := int(dueDay.Sub(today).Hours() / 24)
+
+	switch {
+	case days < 0:
+		return fmt.Sprintf("%dd late", -days)
+	case days == 0 && task.HasDueTime(due):
+		return due.In(now.Location()).Format("15:04")
+	case days == 0:
+		return "today"
+	case days == 1:
+		return "tomorrow"
+	default:
+		return fmt.Sprintf("in %dd", days)
+	}
+}
+
+// truncate shortens s to at most width characters, marking the cut with an ellipsis
+func truncate(s string, width int) string {
+	if width <= 0 || utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	if width == 1 {
+		return "…"
+	}
+	return string([]rune(s)[:width-1]) + "…"
+}
+
+func init() {
+	nextCmd.Flags().IntVarP(&nextCount, "count", "n", 3, "Number of tasks to show (1-3)")
+	nextCmd.Flags().IntVar(&nextWidth, "width", 0, "Maximum line width (defaults to NEXT_WIDTH)")
+	rootCmd.AddCommand(nextCmd)
+}
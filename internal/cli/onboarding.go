@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/newbpydev/tusk/internal/util/logging"
+	"go.uber.org/zap"
+)
+
+// onboardedFile lists the IDs of users who already went through the first run,
+// one per line, inside the state directory
+const onboardedFile = "onboarded"
+
+// stateDir returns the directory for local state, defaulting to ~/.tusk
+func stateDir() (string, error) {
+	if cfg.StateDir != "" {
+		return cfg.StateDir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find home directory: %v", err)
+	}
+	return filepath.Join(home, ".tusk"), nil
+}
+
+// isOnboarded reports whether the first run already happened for a user
+func isOnboarded(userID int64) (bool, error) {
+	dir, err := stateDir()
+	if err != nil {
+		return false, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, onboardedFile))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read onboarding state: %v", err)
+	}
+
+	want := strconv.FormatInt(userID, 10)
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) == want {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// markOnboarded records that the first run happened for a user
+func markOnboarded(userID int64) error {
+	if done, err := isOnboarded(userID); err != nil || done {
+		return err
+	}
+
+	dir, err := stateDir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %v", err)
+	}
+
+	f, err := os.OpenFile(filepath.Join(dir, onboardedFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to write onboarding state: %v", err)
+	}
+	defer f.Close()
+
+	if _, err := fmt.Fprintln(f, userID); err != nil {
+		return fmt.Errorf("failed to write onboarding state: %v", err)
+	}
+	return nil
+}
+
+// runFirstRun seeds the demo tasks the first time a user opens the TUI, when
+// DEMO_TASKS is enabled. Failures are logged and never block the TUI.
+func runFirstRun(ctx context.Context, userID int64) {
+	logger := logging.Logger
+
+	done, err := isOnboarded(userID)
+	if err != nil {
+		logger.Warn("Skipping first run", zap.Error(err))
+		return
+	}
+	if done {
+		return
+	}
+
+	if cfg.DemoTasks {
+		if _, err := taskSvc.SeedDemoTasks(ctx, userID); err != nil {
+			logger.Warn("Failed to seed demo tasks", zap.Int64("user_id", userID), zap.Error(err))
+			return
+		}
+	}
+
+	if err := markOnboarded(userID); err != nil {
+		logger.Warn("Failed to record first run", zap.Int64("user_id", userID), zap.Error(err))
+	}
+}
@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+
+	app "github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/app"
+	"github.com/newbpydev/tusk/internal/core/errors"
+	"github.com/spf13/cobra"
+)
+
+// openDetail focuses the task details panel once the task is selected
+var openDetail bool
+
+var openCmd = &cobra.Command{
+	Use:   "open <id>",
+	Short: "Launch the TUI with the cursor on a task",
+	Long: `Launch the TUI with the cursor on one of your tasks, expanding the section that holds it.
+The ID is the number shown as #<id> by tusk list. Credentials come from TUSK_USERNAME and
+TUSK_PASSWORD when set, so the command can be run from scripts and links. When the task
+cannot be found the TUI starts as usual and says so in the status bar.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		id, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil || id <= 0 {
+			return errors.InvalidInput(fmt.Sprintf("invalid task ID %q: must be a positive number", args[0]))
+		}
+
+		userID, err := authenticateUser(ctx)
+		if err != nil {
+			return err
+		}
+
+		// Tasks of other users are never in the list, so they show up as not found
+		return runTUI(ctx, userID, app.WithFocusTask(id, openDetail))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(openCmd)
+	openCmd.Flags().BoolVar(&openDetail, "detail", false, "Focus the task details panel")
+}
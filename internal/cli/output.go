@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/newbpydev/tusk/internal/core/errors"
+	"github.com/newbpydev/tusk/internal/core/task"
+	"github.com/newbpydev/tusk/internal/export"
+)
+
+// formatText is the default human readable output format
+const formatText = "text"
+
+// jsonOutput is set by the global --json flag. Read commands then print JSON instead
+// of text, and a failed command prints its error to stderr as a JSON object.
+var jsonOutput bool
+
+// jsonError is the object printed for a failed command in JSON mode. Code is the
+// error's stable code, left out for errors that carry none (such as a bad flag).
+type jsonError struct {
+	Code  errors.ErrorCode `json:"code,omitempty"`
+	Error string           `json:"error"`
+}
+
+// writeJSON prints v as indented JSON
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// printError reports a failed command, as a JSON object on stderr in JSON mode
+func printError(err error) {
+	if jsonOutput {
+		_ = writeJSON(os.Stderr, jsonError{Code: errors.CodeOf(err), Error: err.Error()})
+		return
+	}
+	fmt.Println(err)
+}
+
+// statusLabels holds the display labels used for task statuses in text output
+var statusLabels = task.DefaultStatusLabels()
+
+// setStatusLabels parses a status label spec and uses it for text output.
+// An invalid spec leaves the default labels in place.
+func setStatusLabels(spec string) error {
+	labels, err := task.ParseStatusLabels(spec)
+	statusLabels = labels
+	return err
+}
+
+// writeTasks prints tasks in the requested output format; --json overrides it
+func writeTasks(w io.Writer, format string, tasks []task.Task) error {
+	if jsonOutput {
+		return export.WriteJSON(w, tasks)
+	}
+	if format == "" || format == formatText {
+		writeTaskText(w, tasks, 0)
+		return nil
+	}
+
+	f, err := export.ParseFormat(format)
+	if err != nil {
+		return err
+	}
+	return export.Write(w, f, tasks)
+}
+
+// writeTaskText prints tasks as an indented, human readable list
+func writeTaskText(w io.Writer, tasks []task.Task, depth int) {
+	if depth == 0 && len(tasks) == 0 {
+		fmt.Fprintln(w, "No tasks found.")
+		return
+	}
+
+	for _, t := range tasks {
+		fmt.Fprintf(w, "%s%s\n", strings.Repeat("  ", depth), formatTaskLine(t))
+		writeTaskText(w, t.SubTasks, depth+1)
+	}
+}
+
+// formatTaskLine renders a single task as one line of text
+func formatTaskLine(t task.Task) string {
+	check := "[ ]"
+	if t.IsCompleted {
+		check = "[x]"
+	}
+
+	details := []string{statusLabels.Label(t.Status), string(t.Priority)}
+	if t.DueDate != nil {
+		layout := "2006-01-02"
+		if task.HasDueTime(*t.DueDate) {
+			layout = "2006-01-02 15:04"
+		}
+		details = append(details, "due "+t.DueDate.Format(layout))
+	}
+
+	line := fmt.Sprintf("%s #%d %s (%s)", check, t.ID, t.Title, strings.Join(details, ", "))
+	if len(t.Tags) > 0 {
+		names := make([]string, len(t.Tags))
+		for i, tag := range t.Tags {
+			names[i] = tag.Name
+		}
+		line += " [" + strings.Join(names, ", ") + "]"
+	}
+	return line
+}
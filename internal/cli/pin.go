@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/newbpydev/tusk/internal/core/errors"
+	"github.com/spf13/cobra"
+)
+
+var pinCmd = &cobra.Command{
+	Use:   "pin <id>",
+	Short: "Pin a task to the top of its day in tusk today",
+	Long: `Pin one of your dated tasks so it is listed first in its group of tusk today and
+the Overdue + Today view, ahead of the priority sort. When several tasks are pinned, the
+most recently pinned comes first. The pin stays with the task, so rescheduling it moves
+the pin to the new day. Use tusk unpin to remove it.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		t, err := loadOwnedTask(ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		pinned, err := taskSvc.PinToDay(ctx, int64(t.UserID), int64(t.ID))
+		if err != nil {
+			return errors.Wrapf(err, "failed to pin task #%d", t.ID)
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Pinned #%d %s to the top of %s.\n",
+			pinned.ID, pinned.Title, pinned.DueDate.Format("2006-01-02"))
+		return nil
+	},
+}
+
+var unpinCmd = &cobra.Command{
+	Use:          "unpin <id>",
+	Short:        "Remove a task's pin from the top of its day",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		t, err := loadOwnedTask(ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		if err := taskSvc.UnpinFromDay(ctx, int64(t.UserID), int64(t.ID)); err != nil {
+			return errors.Wrapf(err, "failed to unpin task #%d", t.ID)
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Unpinned #%d %s.\n", t.ID, t.Title)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(pinCmd)
+	rootCmd.AddCommand(unpinCmd)
+}
@@ -0,0 +1,36 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var recomputeMetricsCmd = &cobra.Command{
+	Use:   "recompute-metrics",
+	Short: "Refresh the cached progress metrics of your parent tasks",
+	Long: `Recompute the total, completed and progress counts of every task that has subtasks
+and store them in the metrics cache, so dashboards can read them without loading full
+task trees. Cached values are a derived snapshot; the metrics computed when a task tree
+is loaded stay authoritative. Re-run this command to refresh stale values.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		userID, err := authenticateUser(ctx)
+		if err != nil {
+			return err
+		}
+
+		count, err := taskSvc.RecomputeMetrics(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to recompute metrics: %v", err)
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Cached metrics for %d parent task(s).\n", count)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(recomputeMetricsCmd)
+}
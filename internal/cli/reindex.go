@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var reindexCmd = &cobra.Command{
+	Use:   "reindex",
+	Short: "Rewrite task display orders to a clean sequence",
+	Long: `Rewrite the display order of all your tasks to a clean 0..n sequence within each
+parent group. The current visible order is preserved; only gaps and duplicates are removed.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		userID, err := authenticateUser(ctx)
+		if err != nil {
+			return err
+		}
+
+		renumbered, err := taskSvc.CompactOrders(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to reindex tasks: %v", err)
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Task order reindexed, %d task(s) renumbered.\n", renumbered)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(reindexCmd)
+}
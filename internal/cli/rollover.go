@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var rolloverDryRun bool
+
+var rolloverCmd = &cobra.Command{
+	Use:   "rollover",
+	Short: "Move every open task due today to tomorrow",
+	Long: `Defer all of today: every incomplete task due today moves to the same time tomorrow.
+Completed and overdue tasks are left alone, and either all tasks move or none do.
+"Today" is the current day in TIMEZONE (defaults to the local time zone).
+Use --dry-run to list the tasks that would move without changing anything.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		userID, err := authenticateUser(ctx)
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		if rolloverDryRun {
+			tasks, err := taskSvc.RolloverTodayPreview(ctx, userID)
+			if err != nil {
+				return fmt.Errorf("failed to list today's tasks: %v", err)
+			}
+			if len(tasks) == 0 {
+				fmt.Fprintln(out, "No open tasks due today.")
+				return nil
+			}
+			fmt.Fprintf(out, "Would move %d task(s) to tomorrow:\n", len(tasks))
+			for _, t := range tasks {
+				fmt.Fprintf(out, "  #%d %s\n", t.ID, t.Title)
+			}
+			return nil
+		}
+
+		count, err := taskSvc.RolloverToday(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to roll over today's tasks: %v", err)
+		}
+
+		fmt.Fprintf(out, "Moved %d task(s) due today to tomorrow.\n", count)
+		return nil
+	},
+}
+
+func init() {
+	rolloverCmd.Flags().BoolVar(&rolloverDryRun, "dry-run", false, "List the tasks that would move without changing them")
+	rootCmd.AddCommand(rolloverCmd)
+}
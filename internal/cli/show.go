@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"github.com/newbpydev/tusk/internal/core/task"
+	"github.com/spf13/cobra"
+)
+
+var showFormat string
+
+var showCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Print a task and its subtasks",
+	Long: `Print one of your tasks with all of its subtasks. Use --format markdown to get a
+nested checklist ready to paste into a document, or --format json for the full task.
+With --json, the task is printed as a single JSON object.
+Exits with status 2 for an invalid ID and 3 when no such task exists.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		t, err := loadOwnedTask(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+		if jsonOutput {
+			return writeJSON(cmd.OutOrStdout(), t)
+		}
+		return writeTasks(cmd.OutOrStdout(), showFormat, []task.Task{t})
+	},
+}
+
+func init() {
+	showCmd.Flags().StringVar(&showFormat, "format", formatText, "Output format: text, json, csv or markdown")
+	rootCmd.AddCommand(showCmd)
+}
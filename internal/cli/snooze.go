@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/components/input"
+	"github.com/newbpydev/tusk/internal/core/task"
+	"github.com/spf13/cobra"
+)
+
+var (
+	snoozeFilter string
+	snoozeDryRun bool
+)
+
+var snoozeCmd = &cobra.Command{
+	Use:   "snooze <when>",
+	Short: "Move every open task matching a filter to a new due date",
+	Long: `Set the due date of every incomplete task matching --filter to <when>, for bulk
+triage. The filter takes the same key=value pairs as STARTUP_FILTER, e.g.
+"priority=low,due=overdue"; it matches exactly the tasks such a filtered list shows, minus
+completed ones. <when> is a snooze preset (1h, evening, tomorrow, weekend, next-week), a
+date such as 2025-06-02 or "2025-06-02 09:00", or a phrase such as "next fri".
+Either every task moves or none does. Use --dry-run to list the tasks without moving them.
+
+  tusk snooze --filter priority=low,due=overdue next-week`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		loc, err := cfg.Location()
+		if err != nil {
+			return err
+		}
+		now := time.Now().In(loc)
+
+		filter, err := task.ParseTaskFilter(snoozeFilter, now)
+		if err != nil {
+			return err
+		}
+		if filter.IsEmpty() {
+			return fmt.Errorf("--filter is required, e.g. --filter priority=low,due=overdue")
+		}
+		until, err := parseSnoozeTime(args[0], now)
+		if err != nil {
+			return err
+		}
+
+		userID, err := authenticateUser(ctx)
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		if snoozeDryRun {
+			tasks, err := taskSvc.SnoozeFilteredPreview(ctx, userID, filter)
+			if err != nil {
+				return fmt.Errorf("failed to list matching tasks: %v", err)
+			}
+			if len(tasks) == 0 {
+				fmt.Fprintf(out, "No open tasks match %s.\n", filter)
+				return nil
+			}
+			fmt.Fprintf(out, "Would snooze %d task(s) until %s:\n", len(tasks), input.FormatDate(until))
+			for _, t := range tasks {
+				fmt.Fprintf(out, "  #%d %s\n", t.ID, t.Title)
+			}
+			return nil
+		}
+
+		count, err := taskSvc.SnoozeFiltered(ctx, userID, filter, until)
+		if err != nil {
+			return fmt.Errorf("failed to snooze tasks: %v", err)
+		}
+
+		fmt.Fprintf(out, "Snoozed %d task(s) until %s.\n", count, input.FormatDate(until))
+		return nil
+	},
+}
+
+// parseSnoozeTime reads the time to snooze to, either a snooze preset or a date
+func parseSnoozeTime(text string, now time.Time) (time.Time, error) {
+	preset := task.SnoozePreset(strings.ToLower(strings.TrimSpace(text)))
+	for _, p := range task.DefaultSnoozePresets() {
+		if p == preset {
+			return p.Until(now), nil
+		}
+	}
+
+	until, err := input.ParseDate(text, now)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid snooze time %q (expected a preset such as next-week, or a date)", text)
+	}
+	return until, nil
+}
+
+func init() {
+	snoozeCmd.Flags().StringVar(&snoozeFilter, "filter", "", `Tasks to snooze, e.g. "priority=low,due=overdue"`)
+	snoozeCmd.Flags().BoolVar(&snoozeDryRun, "dry-run", false, "List the tasks that would move without changing them")
+	rootCmd.AddCommand(snoozeCmd)
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/newbpydev/tusk/internal/core/task"
+	"github.com/spf13/cobra"
+)
+
+var todayCmd = &cobra.Command{
+	Use:   "today",
+	Short: "List the tasks that are overdue or due today",
+	Long: `List your open tasks that are overdue or due today, in two groups. Within each
+group, tasks pinned with tusk pin come first, then higher priority tasks, then tasks due
+earlier. Days follow TIMEZONE. With --json, an object with "overdue" and "today" task
+arrays and the "pinned" task IDs is printed.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		userID, err := authenticateUser(ctx)
+		if err != nil {
+			return err
+		}
+
+		agenda, err := taskSvc.GetAgenda(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to get today's tasks: %v", err)
+		}
+
+		if jsonOutput {
+			// Empty groups are printed as [] rather than null
+			agenda.Overdue = append([]task.Task{}, agenda.Overdue...)
+			agenda.Today = append([]task.Task{}, agenda.Today...)
+			agenda.Pinned = append([]int32{}, agenda.Pinned...)
+			return writeJSON(cmd.OutOrStdout(), agenda)
+		}
+		writeAgenda(cmd.OutOrStdout(), agenda)
+		return nil
+	},
+}
+
+// writeAgenda prints the overdue tasks and the tasks due today, skipping empty groups
+// and marking pinned tasks
+func writeAgenda(w io.Writer, a task.Agenda) {
+	if a.Len() == 0 {
+		fmt.Fprintln(w, "Nothing overdue or due today.")
+		return
+	}
+
+	pinned := make(map[int32]bool, len(a.Pinned))
+	for _, id := range a.Pinned {
+		pinned[id] = true
+	}
+	writeGroup := func(title string, tasks []task.Task) {
+		if len(tasks) == 0 {
+			return
+		}
+		fmt.Fprintf(w, "%s (%d):\n", title, len(tasks))
+		for _, t := range tasks {
+			line := formatTaskLine(t)
+			if pinned[t.ID] {
+				line += " (pinned)"
+			}
+			fmt.Fprintf(w, "  %s\n", line)
+		}
+	}
+
+	writeGroup("Overdue", a.Overdue)
+	writeGroup("Today", a.Today)
+}
+
+func init() {
+	rootCmd.AddCommand(todayCmd)
+}
@@ -5,15 +5,24 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	app "github.com/newbpydev/tusk/internal/adapters/tui/bubbletea/app"
+	"github.com/newbpydev/tusk/internal/util/logging"
 	"github.com/spf13/cobra"
+	"go.uber.org/zap"
 	"golang.org/x/term"
 )
 
+// uiPrefsFile keeps the TUI layout between sessions, inside the state directory
+const uiPrefsFile = "ui_prefs.json"
+
+// tuiReadOnly disables every change to tasks while the TUI runs
+var tuiReadOnly bool
+
 var tuiCmd = &cobra.Command{
 	Use:   "tui",
 	Short: "Start the Tusk task manager application",
@@ -35,13 +44,41 @@ var tuiCmd = &cobra.Command{
 			return err
 		}
 
+		// Give new users something to explore
+		if !tuiReadOnly {
+			runFirstRun(ctx, userID)
+		}
+
 		// Start TUI with authenticated user
-		m := app.NewModel(ctx, taskSvc, userID)
-		p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
-		return p.Start()
+		return runTUI(ctx, userID, app.WithReadOnly(tuiReadOnly))
 	},
 }
 
+// runTUI runs the TUI for an authenticated user until it quits, keeping its layout
+// between sessions. Options are applied after the user's configuration.
+func runTUI(ctx context.Context, userID int64, extra ...app.Option) error {
+	opts := []app.Option{app.WithConfig(cfg)}
+	if dir, err := stateDir(); err == nil {
+		opts = append(opts, app.WithUIPrefs(filepath.Join(dir, uiPrefsFile)))
+	} else {
+		logging.Logger.Warn("Not keeping UI preferences", zap.Error(err))
+	}
+	if logging.Ring != nil {
+		opts = append(opts, app.WithLogBuffer(logging.Ring))
+	}
+	opts = append(opts, extra...)
+
+	m := app.NewModel(ctx, taskSvc, userID, opts...)
+	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	err := p.Start()
+
+	// Keep the layout for the next session, even when the TUI stopped with an error
+	if saveErr := m.SaveUIPrefs(); saveErr != nil {
+		logging.Logger.Warn("Failed to save UI preferences", zap.Error(saveErr))
+	}
+	return err
+}
+
 // showWelcomeIntro displays a friendly introduction to the Tusk application
 func showWelcomeIntro() {
 	// Clear the terminal screen with ANSI escape code
@@ -184,5 +221,6 @@ func simpleTerminalAuth(ctx context.Context, userID *int64) error {
 
 func init() {
 	rootCmd.AddCommand(tuiCmd)
-	// No flags added - we're using interactive authentication only
+	// Authentication stays interactive; flags only change how the TUI behaves
+	tuiCmd.Flags().BoolVar(&tuiReadOnly, "read-only", false, "Browse tasks without allowing any changes")
 }
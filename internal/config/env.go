@@ -1,7 +1,11 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -16,6 +20,148 @@ type Config struct {
 	LogDir       string `env:"LOG_DIR"`
 	LogToFile    bool   `env:"LOG_TO_FILE"`
 	LogToConsole bool   `env:"LOG_TO_CONSOLE"`
+
+	// Username and Password let non-interactive CLI commands log in without prompting
+	Username string `env:"TUSK_USERNAME"`
+	Password string `env:"TUSK_PASSWORD"`
+
+	// ParentAutoComplete controls what happens when the last open subtask of a
+	// parent is completed: "auto", "prompt" or "never"
+	ParentAutoComplete string `env:"PARENT_AUTO_COMPLETE"`
+
+	// UndatedTasks places tasks without a due date at the "top" or "bottom"
+	// whenever tasks are ordered by due date
+	UndatedTasks string `env:"UNDATED_TASKS"`
+
+	// ExportDir is where the TUI writes exported task lists (defaults to the working directory)
+	ExportDir string `env:"EXPORT_DIR"`
+
+	// HideCompletedSubtasks sets whether the TUI starts with completed subtasks hidden
+	HideCompletedSubtasks bool `env:"HIDE_COMPLETED_SUBTASKS"`
+
+	// StartupFilter is a task filter applied when the TUI starts, e.g. "tag=work,due=week"
+	StartupFilter string `env:"STARTUP_FILTER"`
+
+	// TagCaseFold lowercases tags before they are saved, so tags differing only in
+	// case are the same tag; turn it off to keep tags as typed
+	TagCaseFold bool `env:"TAG_CASE_FOLD"`
+
+	// NextWidth caps the length of the line printed by `tusk next`
+	NextWidth int `env:"NEXT_WIDTH"`
+
+	// DateFormat is the Go time layout used to display dates in the TUI
+	DateFormat string `env:"DATE_FORMAT"`
+
+	// WatchInterval is how often `tusk list --watch` refreshes
+	WatchInterval time.Duration `env:"WATCH_INTERVAL"`
+
+	// MaxTaskDepth limits how many levels deep tasks can be nested
+	MaxTaskDepth int `env:"MAX_TASK_DEPTH"`
+
+	// StatusLabels remaps how statuses are displayed, e.g. "todo=Backlog,done=Shipped"
+	StatusLabels string `env:"STATUS_LABELS"`
+
+	// ConfirmQuitUnsaved asks before quitting the TUI with unsaved form input
+	ConfirmQuitUnsaved bool `env:"CONFIRM_QUIT_UNSAVED"`
+
+	// SnoozePresets lists the presets offered by the TUI snooze menu, in order
+	SnoozePresets string `env:"SNOOZE_PRESETS"`
+
+	// RecentHours is how far back the TUI's Recently Created section looks
+	RecentHours int `env:"RECENT_HOURS"`
+
+	// CompletionHook is a command run whenever a task is completed, e.g. "notify-send Done {title}"
+	CompletionHook string `env:"COMPLETION_HOOK"`
+
+	// CompletionHookTimeout bounds how long the completion hook may run
+	CompletionHookTimeout time.Duration `env:"COMPLETION_HOOK_TIMEOUT"`
+
+	// JournalTemplate is a Go text/template file replacing the default `tusk journal` layout
+	JournalTemplate string `env:"JOURNAL_TEMPLATE"`
+
+	// Timezone decides where "today" starts for day-based commands, e.g. "Europe/Berlin"
+	// (empty uses the local time zone)
+	Timezone string `env:"TIMEZONE"`
+
+	// DemoTasks seeds a few example tasks the first time a new user opens the TUI
+	DemoTasks bool `env:"DEMO_TASKS"`
+
+	// StateDir is where Tusk keeps small bits of local state, such as the TUI layout
+	// and which users have been onboarded (empty uses ~/.tusk)
+	StateDir string `env:"STATE_DIR"`
+
+	// ShowCompletionTrend shows today's completions compared to yesterday's in the TUI header
+	ShowCompletionTrend bool `env:"SHOW_COMPLETION_TREND"`
+
+	// ProgressByPoints weights a parent's progress by the points of its subtasks
+	ProgressByPoints bool `env:"PROGRESS_BY_POINTS"`
+
+	// TagColors colors tags in the TUI, e.g. "urgent=red,@home=green,proj-*=#8E24AA"
+	TagColors string `env:"TAG_COLORS"`
+
+	// Theme is the TUI color theme: "dark" (default), "light" or "solarized"; `t`
+	// cycles through them at runtime
+	Theme string `env:"THEME"`
+
+	// CarriedFormat is the label shown on open tasks whose due date has passed, e.g.
+	// "carried {days}" or "+{n}d" (empty hides it); days are counted in Timezone
+	CarriedFormat string `env:"CARRIED_FORMAT"`
+
+	// EnterAction is what enter does on a task in the TUI task list: "detail" opens
+	// the task details (default), "toggle" toggles completion, "expand" moves to the
+	// first subtask
+	EnterAction string `env:"ENTER_ACTION"`
+
+	// SectionEnter is what enter does on a collapsed section header in the TUI task list
+	// and timeline: "expand" only expands it (default), "expand-enter" also moves the
+	// cursor to its first task
+	SectionEnter string `env:"SECTION_ENTER"`
+
+	// Actionable lists what the TUI's actionable view hides, e.g. "completed,blocked,deferred"
+	// (also "waiting" for parents with open subtasks)
+	Actionable string `env:"ACTIONABLE"`
+
+	// CacheTTL is how long cached tasks and task lists are used before they are read
+	// from the database again, so changes made in another session show up
+	CacheTTL time.Duration `env:"CACHE_TTL"`
+
+	// AsyncJobTimeout bounds each background job of the task service, such as a cache
+	// refresh, and how long the CLI waits for pending jobs when it exits
+	AsyncJobTimeout time.Duration `env:"ASYNC_JOB_TIMEOUT"`
+
+	// DebugPanel keeps the most recent log lines in memory and lets the TUI show them
+	// on a hidden key
+	DebugPanel bool `env:"DEBUG_PANEL"`
+
+	// DebugLogLines is how many log lines the debug panel keeps
+	DebugLogLines int `env:"DEBUG_LOG_LINES"`
+
+	// APIStrict makes the API server reject invalid priorities instead of defaulting
+	// them; the TUI and CLI always stay lenient
+	APIStrict bool `env:"API_STRICT"`
+
+	// APISecret signs the tokens the API server issues on POST /login; it must be at
+	// least 32 characters long and is required to run the API server
+	APISecret string `env:"API_SECRET"`
+
+	// APITokenTTL is how long an API token stays valid
+	APITokenTTL time.Duration `env:"API_TOKEN_TTL"`
+
+	// DueSoonDays is how many days ahead a task counts as due soon
+	DueSoonDays int `env:"DUE_SOON_DAYS"`
+
+	// HighlightDueSoon gives upcoming tasks due within DueSoonDays their own color in
+	// the TUI timeline
+	HighlightDueSoon bool `env:"HIGHLIGHT_DUE_SOON"`
+
+	// UrgencyWeights tunes how `tusk next` ranks tasks, e.g. "priority=30,overdue=50"
+	UrgencyWeights string `env:"URGENCY_WEIGHTS"`
+
+	// Reminders shows a status message in the TUI when an open task comes due
+	Reminders bool `env:"REMINDERS"`
+
+	// ReminderInterval is how often the TUI checks for tasks that came due
+	ReminderInterval time.Duration `env:"REMINDER_INTERVAL"`
 }
 
 // Load loads the configuration from environment variables and returns a Config struct.
@@ -31,9 +177,66 @@ func Load() *Config {
 		LogDir:       getEnv("LOG_DIR", ""),
 		LogToFile:    getBoolEnv("LOG_TO_FILE", true),
 		LogToConsole: getBoolEnv("LOG_TO_CONSOLE", true),
+
+		Username: getEnv("TUSK_USERNAME", ""),
+		Password: getEnv("TUSK_PASSWORD", ""),
+
+		ParentAutoComplete: getEnv("PARENT_AUTO_COMPLETE", "never"),
+		UndatedTasks:       getEnv("UNDATED_TASKS", "bottom"),
+		ExportDir:          getEnv("EXPORT_DIR", ""),
+
+		HideCompletedSubtasks: getBoolEnv("HIDE_COMPLETED_SUBTASKS", false),
+		StartupFilter:         getEnv("STARTUP_FILTER", ""),
+		TagCaseFold:           getBoolEnv("TAG_CASE_FOLD", true),
+		NextWidth:             getIntEnv("NEXT_WIDTH", 80),
+		DateFormat:            getEnv("DATE_FORMAT", "2006-01-02"),
+		WatchInterval:         getDurationEnv("WATCH_INTERVAL", 5*time.Second),
+		MaxTaskDepth:          getIntEnv("MAX_TASK_DEPTH", 20),
+		StatusLabels:          getEnv("STATUS_LABELS", ""),
+		ConfirmQuitUnsaved:    getBoolEnv("CONFIRM_QUIT_UNSAVED", true),
+		SnoozePresets:         getEnv("SNOOZE_PRESETS", "1h,evening,tomorrow,weekend,next-week"),
+		RecentHours:           getIntEnv("RECENT_HOURS", 24),
+		CompletionHook:        getEnv("COMPLETION_HOOK", ""),
+		CompletionHookTimeout: getDurationEnv("COMPLETION_HOOK_TIMEOUT", 10*time.Second),
+		JournalTemplate:       getEnv("JOURNAL_TEMPLATE", ""),
+		Timezone:              getEnv("TIMEZONE", ""),
+		DemoTasks:             getBoolEnv("DEMO_TASKS", true),
+		StateDir:              getEnv("STATE_DIR", ""),
+		ShowCompletionTrend:   getBoolEnv("SHOW_COMPLETION_TREND", true),
+		ProgressByPoints:      getBoolEnv("PROGRESS_BY_POINTS", false),
+		TagColors:             getEnv("TAG_COLORS", ""),
+		Theme:                 getEnv("THEME", "dark"),
+		CarriedFormat:         getEnv("CARRIED_FORMAT", "carried {days}"),
+		EnterAction:           getEnv("ENTER_ACTION", "detail"),
+		SectionEnter:          getEnv("SECTION_ENTER", "expand"),
+		Actionable:            getEnv("ACTIONABLE", "completed,blocked,deferred"),
+		CacheTTL:              getDurationEnv("CACHE_TTL", time.Minute),
+		AsyncJobTimeout:       getDurationEnv("ASYNC_JOB_TIMEOUT", 30*time.Second),
+		DebugPanel:            getBoolEnv("DEBUG_PANEL", false),
+		DebugLogLines:         getIntEnv("DEBUG_LOG_LINES", 500),
+		APIStrict:             getBoolEnv("API_STRICT", false),
+		APISecret:             getEnv("API_SECRET", ""),
+		APITokenTTL:           getDurationEnv("API_TOKEN_TTL", 24*time.Hour),
+		DueSoonDays:           getIntEnv("DUE_SOON_DAYS", 7),
+		HighlightDueSoon:      getBoolEnv("HIGHLIGHT_DUE_SOON", false),
+		UrgencyWeights:        getEnv("URGENCY_WEIGHTS", ""),
+		Reminders:             getBoolEnv("REMINDERS", true),
+		ReminderInterval:      getDurationEnv("REMINDER_INTERVAL", time.Minute),
 	}
 }
 
+// Location returns the configured time zone, or the local time zone when none is set
+func (c *Config) Location() (*time.Location, error) {
+	if c.Timezone == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return time.Local, fmt.Errorf("unknown time zone %q", c.Timezone)
+	}
+	return loc, nil
+}
+
 // getEnv retrieves the value of the environment variable with the given key.
 // If the variable is not set, it returns the provided fallback value.
 func getEnv(key, fallback string) string {
@@ -52,12 +255,41 @@ func getBoolEnv(key string, fallback bool) bool {
 		return fallback
 	}
 
-	switch val {
-	case "true", "1", "yes", "y", "on":
-		return true
-	case "false", "0", "no", "n", "off":
-		return false
-	default:
+	b, ok := parseBool(val)
+	if !ok {
+		return fallback
+	}
+	return b
+}
+
+// getIntEnv retrieves an integer value from an environment variable.
+// It returns the fallback value if the environment variable is not set
+// or if it cannot be parsed as an integer.
+func getIntEnv(key string, fallback int) int {
+	val, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(val))
+	if err != nil {
+		return fallback
+	}
+	return n
+}
+
+// getDurationEnv retrieves a duration (e.g. "5s", "1m") from an environment variable.
+// It returns the fallback value if the environment variable is not set
+// or if it cannot be parsed as a duration.
+func getDurationEnv(key string, fallback time.Duration) time.Duration {
+	val, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+
+	d, err := time.ParseDuration(strings.TrimSpace(val))
+	if err != nil {
 		return fallback
 	}
+	return d
 }
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// boolVars, intVars and durationVars list the variables Load parses into typed fields.
+// Load silently falls back to the default for unparsable values, so Validate reports them.
+var (
+	boolVars = []string{
+		"LOG_TO_FILE", "LOG_TO_CONSOLE", "HIDE_COMPLETED_SUBTASKS", "TAG_CASE_FOLD", "CONFIRM_QUIT_UNSAVED", "DEMO_TASKS",
+		"SHOW_COMPLETION_TREND", "PROGRESS_BY_POINTS", "DEBUG_PANEL", "API_STRICT",
+		"HIGHLIGHT_DUE_SOON", "REMINDERS",
+	}
+	intVars      = []string{"NEXT_WIDTH", "MAX_TASK_DEPTH", "RECENT_HOURS", "DEBUG_LOG_LINES", "DUE_SOON_DAYS"}
+	durationVars = []string{"WATCH_INTERVAL", "COMPLETION_HOOK_TIMEOUT", "CACHE_TTL", "ASYNC_JOB_TIMEOUT", "REMINDER_INTERVAL", "API_TOKEN_TTL"}
+)
+
+// MaxDebugLogLines bounds DEBUG_LOG_LINES so the debug panel's buffer stays small
+const MaxDebugLogLines = 10000
+
+// validLogLevels are the accepted LOG_LEVEL values
+var validLogLevels = []string{"debug", "info", "warning", "error"}
+
+// LoadFile loads the configuration like Load, but with the variables in path taking
+// precedence over the environment. An empty path falls back to the .env file.
+func LoadFile(path string) (*Config, error) {
+	if path != "" {
+		if err := godotenv.Overload(path); err != nil {
+			return nil, fmt.Errorf("failed to read config file %s: %v", path, err)
+		}
+	}
+	return Load(), nil
+}
+
+// Validate checks the raw configuration variables without connecting to anything.
+// It reports values Load would silently replace with a default, and basic format
+// problems with the remaining settings. An empty result means the config is valid.
+func Validate(cfg *Config) []error {
+	var problems []error
+
+	for _, key := range boolVars {
+		if val, ok := os.LookupEnv(key); ok {
+			if _, valid := parseBool(val); !valid {
+				problems = append(problems, fmt.Errorf("%s: %q is not a boolean (use true or false)", key, val))
+			}
+		}
+	}
+
+	for _, key := range intVars {
+		if val, ok := os.LookupEnv(key); ok {
+			n, err := strconv.Atoi(strings.TrimSpace(val))
+			if err != nil {
+				problems = append(problems, fmt.Errorf("%s: %q is not a whole number", key, val))
+			} else if n <= 0 {
+				problems = append(problems, fmt.Errorf("%s: must be positive, got %d", key, n))
+			}
+		}
+	}
+
+	for _, key := range durationVars {
+		if val, ok := os.LookupEnv(key); ok {
+			d, err := time.ParseDuration(strings.TrimSpace(val))
+			if err != nil {
+				problems = append(problems, fmt.Errorf("%s: %q is not a duration (e.g. 5s, 1m)", key, val))
+			} else if d <= 0 {
+				problems = append(problems, fmt.Errorf("%s: must be positive, got %s", key, d))
+			}
+		}
+	}
+
+	if !contains(validLogLevels, strings.ToLower(cfg.LogLevel)) {
+		problems = append(problems, fmt.Errorf("LOG_LEVEL: %q is not one of %s",
+			cfg.LogLevel, strings.Join(validLogLevels, ", ")))
+	}
+
+	if _, err := cfg.Location(); err != nil {
+		problems = append(problems, fmt.Errorf("TIMEZONE: %v", err))
+	}
+
+	if tz, ok := os.LookupEnv("TZ"); ok && tz != "" {
+		if _, err := time.LoadLocation(tz); err != nil {
+			problems = append(problems, fmt.Errorf("TZ: unknown time zone %q", tz))
+		}
+	}
+
+	if cfg.DebugLogLines > MaxDebugLogLines {
+		problems = append(problems, fmt.Errorf("DEBUG_LOG_LINES: must be at most %d, got %d",
+			MaxDebugLogLines, cfg.DebugLogLines))
+	}
+
+	if cfg.Port != "" {
+		if port, err := strconv.Atoi(cfg.Port); err != nil || port < 1 || port > 65535 {
+			problems = append(problems, fmt.Errorf("PORT: %q is not a valid port", cfg.Port))
+		}
+	}
+
+	return problems
+}
+
+// parseBool parses the boolean spellings accepted by getBoolEnv
+func parseBool(val string) (value bool, ok bool) {
+	switch val {
+	case "true", "1", "yes", "y", "on":
+		return true, true
+	case "false", "0", "no", "n", "off":
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// contains reports whether list contains s
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
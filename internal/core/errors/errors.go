@@ -7,15 +7,16 @@ import (
 )
 
 // ErrorCode represents the error code for a domain error.
-// It is a string that identifies the type of error that occurred.
+// It is a string that identifies the type of error that occurred. Codes are stable:
+// API clients and scripts may rely on them.
 type ErrorCode string
 
 const (
 	// CodeNotFound is used when a requested resource cannot be found
-	CodeNotFound ErrorCode = "NOTE_FOUND"
+	CodeNotFound ErrorCode = "NOT_FOUND"
 
 	// CodeConflict is used when there is a conflict with existing resources
-	CodeConflict ErrorCode = "NOTE_CONFLICT"
+	CodeConflict ErrorCode = "CONFLICT"
 
 	// CodeInvalidInput is used when the provided input is invalid or malformed
 	CodeInvalidInput ErrorCode = "INVALID_INPUT"
@@ -31,8 +32,9 @@ const (
 )
 
 // DomainError represents a domain-specific error with a code and message.
+// The code is set by the constructors below and read with Code.
 type DomainError struct {
-	Code    ErrorCode
+	code    ErrorCode
 	Message string
 	Err     error
 }
@@ -40,9 +42,14 @@ type DomainError struct {
 // Error implements the error interface for DomainError.
 func (e DomainError) Error() string {
 	if e.Err != nil {
-		return string(e.Code) + ": " + e.Message + ": " + e.Err.Error()
+		return string(e.code) + ": " + e.Message + ": " + e.Err.Error()
 	}
-	return string(e.Code) + ": " + e.Message
+	return string(e.code) + ": " + e.Message
+}
+
+// Code returns the machine-readable code of the error.
+func (e DomainError) Code() ErrorCode {
+	return e.code
 }
 
 // Unwrap returns the underlying error if any.
@@ -59,7 +66,7 @@ func NotFound(msg string, err ...error) error {
 	if len(err) > 0 {
 		wrapped = err[0]
 	}
-	return DomainError{Code: CodeNotFound, Message: msg, Err: wrapped}
+	return DomainError{code: CodeNotFound, Message: msg, Err: wrapped}
 }
 
 // Conflict creates a new DomainError with the Conflict code.
@@ -69,7 +76,7 @@ func Conflict(msg string, err ...error) error {
 	if len(err) > 0 {
 		wrapped = err[0]
 	}
-	return DomainError{Code: CodeConflict, Message: msg, Err: wrapped}
+	return DomainError{code: CodeConflict, Message: msg, Err: wrapped}
 }
 
 // InvalidInput creates a new DomainError with the InvalidInput code.
@@ -79,7 +86,7 @@ func InvalidInput(msg string, err ...error) error {
 	if len(err) > 0 {
 		wrapped = err[0]
 	}
-	return DomainError{Code: CodeInvalidInput, Message: msg, Err: wrapped}
+	return DomainError{code: CodeInvalidInput, Message: msg, Err: wrapped}
 }
 
 // Unauthorized creates a new DomainError with the Unauthorized code.
@@ -89,7 +96,7 @@ func Unauthorized(msg string, err ...error) error {
 	if len(err) > 0 {
 		wrapped = err[0]
 	}
-	return DomainError{Code: CodeUnauthorized, Message: msg, Err: wrapped}
+	return DomainError{code: CodeUnauthorized, Message: msg, Err: wrapped}
 }
 
 // InternalError creates a new DomainError with the InternalError code.
@@ -99,7 +106,7 @@ func InternalError(msg string, err ...error) error {
 	if len(err) > 0 {
 		wrapped = err[0]
 	}
-	return DomainError{Code: CodeInternalError, Message: msg, Err: wrapped}
+	return DomainError{code: CodeInternalError, Message: msg, Err: wrapped}
 }
 
 // Forbidden creates a new DomainError with the Forbidden code.
@@ -109,7 +116,7 @@ func Forbidden(msg string, err ...error) error {
 	if len(err) > 0 {
 		wrapped = err[0]
 	}
-	return DomainError{Code: CodeForbidden, Message: msg, Err: wrapped}
+	return DomainError{code: CodeForbidden, Message: msg, Err: wrapped}
 }
 
 // Wrap wraps an error with a message and returns a new error.
@@ -138,6 +145,17 @@ func Cause(err error) error {
 
 // Error type checking functions
 
+// CodeOf returns the code of the first DomainError in err's chain, so the code
+// survives wrapping with fmt.Errorf("%w"), Wrap or WithStack. It returns an empty
+// code when err is nil or carries no DomainError.
+func CodeOf(err error) ErrorCode {
+	var domainErr DomainError
+	if stderrors.As(err, &domainErr) {
+		return domainErr.code
+	}
+	return ""
+}
+
 // IsDomainError checks if the given error is a DomainError.
 func IsDomainError(err error) bool {
 	var domainErr DomainError
@@ -148,7 +166,7 @@ func IsDomainError(err error) bool {
 func IsNotFound(err error) bool {
 	var domainErr DomainError
 	if stderrors.As(err, &domainErr) {
-		return domainErr.Code == CodeNotFound
+		return domainErr.code == CodeNotFound
 	}
 	return false
 }
@@ -157,7 +175,7 @@ func IsNotFound(err error) bool {
 func IsConflict(err error) bool {
 	var domainErr DomainError
 	if stderrors.As(err, &domainErr) {
-		return domainErr.Code == CodeConflict
+		return domainErr.code == CodeConflict
 	}
 	return false
 }
@@ -166,7 +184,7 @@ func IsConflict(err error) bool {
 func IsInvalidInput(err error) bool {
 	var domainErr DomainError
 	if stderrors.As(err, &domainErr) {
-		return domainErr.Code == CodeInvalidInput
+		return domainErr.code == CodeInvalidInput
 	}
 	return false
 }
@@ -175,7 +193,7 @@ func IsInvalidInput(err error) bool {
 func IsUnauthorized(err error) bool {
 	var domainErr DomainError
 	if stderrors.As(err, &domainErr) {
-		return domainErr.Code == CodeUnauthorized
+		return domainErr.code == CodeUnauthorized
 	}
 	return false
 }
@@ -184,7 +202,7 @@ func IsUnauthorized(err error) bool {
 func IsInternalError(err error) bool {
 	var domainErr DomainError
 	if stderrors.As(err, &domainErr) {
-		return domainErr.Code == CodeInternalError
+		return domainErr.code == CodeInternalError
 	}
 	return false
 }
@@ -193,7 +211,7 @@ func IsInternalError(err error) bool {
 func IsForbidden(err error) bool {
 	var domainErr DomainError
 	if stderrors.As(err, &domainErr) {
-		return domainErr.Code == CodeForbidden
+		return domainErr.code == CodeForbidden
 	}
 	return false
 }
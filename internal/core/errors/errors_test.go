@@ -0,0 +1,62 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstructorsSetCode(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected ErrorCode
+	}{
+		{name: "NotFound", err: NotFound("task 1 not found"), expected: CodeNotFound},
+		{name: "Conflict", err: Conflict("tag already exists"), expected: CodeConflict},
+		{name: "InvalidInput", err: InvalidInput("title is required"), expected: CodeInvalidInput},
+		{name: "Unauthorized", err: Unauthorized("invalid token"), expected: CodeUnauthorized},
+		{name: "InternalError", err: InternalError("query failed"), expected: CodeInternalError},
+		{name: "Forbidden", err: Forbidden("not your task"), expected: CodeForbidden},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var domainErr DomainError
+			assert.True(t, stderrors.As(tc.err, &domainErr))
+			assert.Equal(t, tc.expected, domainErr.Code())
+			assert.Equal(t, tc.expected, CodeOf(tc.err))
+			assert.Contains(t, tc.err.Error(), string(tc.expected)+": ")
+		})
+	}
+}
+
+func TestCodeOfSurvivesWrapping(t *testing.T) {
+	base := NotFound("task 1 not found", stderrors.New("no rows"))
+
+	testCases := []struct {
+		name     string
+		err      error
+		expected ErrorCode
+	}{
+		{name: "Unwrapped", err: base, expected: CodeNotFound},
+		{name: "fmt.Errorf", err: fmt.Errorf("failed to show task: %w", base), expected: CodeNotFound},
+		{name: "Wrap", err: Wrap(base, "failed to show task"), expected: CodeNotFound},
+		{name: "WithStack", err: WithStack(base), expected: CodeNotFound},
+		{name: "Wrapped twice", err: Wrap(fmt.Errorf("show: %w", base), "cli"), expected: CodeNotFound},
+		{name: "Outermost domain error wins", err: InternalError("lookup failed", base), expected: CodeInternalError},
+		{name: "Plain error", err: stderrors.New("boom"), expected: ""},
+		{name: "Formatted without %w", err: fmt.Errorf("failed: %v", base), expected: ""},
+		{name: "Nil", err: nil, expected: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, CodeOf(tc.err))
+		})
+	}
+
+	assert.True(t, IsNotFound(Wrap(base, "failed to show task")))
+}
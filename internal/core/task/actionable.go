@@ -0,0 +1,81 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ActionableRule names one kind of task the actionable view hides.
+type ActionableRule string
+
+const (
+	// ActionableHideCompleted hides tasks that are done.
+	ActionableHideCompleted ActionableRule = "completed"
+	// ActionableHideBlocked hides tasks that still wait on an incomplete dependency.
+	ActionableHideBlocked ActionableRule = "blocked"
+	// ActionableHideDeferred hides tasks due after today, such as snoozed tasks.
+	ActionableHideDeferred ActionableRule = "deferred"
+	// ActionableHideWaiting hides parent tasks whose subtasks are not all done yet.
+	ActionableHideWaiting ActionableRule = "waiting"
+)
+
+// DefaultActionableRules returns the rules used when none are configured.
+func DefaultActionableRules() []ActionableRule {
+	return []ActionableRule{ActionableHideCompleted, ActionableHideBlocked, ActionableHideDeferred}
+}
+
+// ParseActionableRules converts a comma-separated list such as "completed,blocked"
+// into rules, keeping their order. An empty spec yields the defaults.
+func ParseActionableRules(spec string) ([]ActionableRule, error) {
+	var rules []ActionableRule
+	seen := make(map[ActionableRule]bool)
+
+	for _, part := range strings.Split(spec, ",") {
+		r := ActionableRule(strings.ToLower(strings.TrimSpace(part)))
+		if r == "" || seen[r] {
+			continue
+		}
+		switch r {
+		case ActionableHideCompleted, ActionableHideBlocked, ActionableHideDeferred, ActionableHideWaiting:
+			rules = append(rules, r)
+			seen[r] = true
+		default:
+			return DefaultActionableRules(), fmt.Errorf("invalid actionable rule %q (expected completed, blocked, deferred or waiting)", part)
+		}
+	}
+
+	if len(rules) == 0 {
+		return DefaultActionableRules(), nil
+	}
+	return rules, nil
+}
+
+// IsActionable reports whether t can be worked on right now, i.e. none of the rules
+// hides it. blocked holds the IDs of tasks with an incomplete dependency; a task is
+// deferred when it is due on a later day than now, in now's location.
+func IsActionable(t Task, rules []ActionableRule, blocked map[int32]bool, now time.Time) bool {
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+
+	for _, r := range rules {
+		switch r {
+		case ActionableHideCompleted:
+			if t.IsCompleted || t.Status == StatusDone {
+				return false
+			}
+		case ActionableHideBlocked:
+			if blocked[t.ID] {
+				return false
+			}
+		case ActionableHideDeferred:
+			if t.DueDate != nil && !t.DueDate.Before(tomorrow) {
+				return false
+			}
+		case ActionableHideWaiting:
+			if t.CompletedCount < t.TotalCount {
+				return false
+			}
+		}
+	}
+	return true
+}
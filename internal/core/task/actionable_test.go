@@ -0,0 +1,65 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseActionableRules(t *testing.T) {
+	rules, err := ParseActionableRules("")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultActionableRules(), rules)
+
+	rules, err = ParseActionableRules(" Waiting, completed,waiting ")
+	require.NoError(t, err)
+	assert.Equal(t, []ActionableRule{ActionableHideWaiting, ActionableHideCompleted}, rules)
+
+	rules, err = ParseActionableRules("completed,someday")
+	assert.ErrorContains(t, err, `invalid actionable rule "someday"`)
+	assert.Equal(t, DefaultActionableRules(), rules)
+}
+
+func TestIsActionable(t *testing.T) {
+	now := time.Date(2025, 7, 10, 15, 0, 0, 0, time.UTC)
+	at := func(s string) *time.Time {
+		d, _ := time.Parse("2006-01-02 15:04", s)
+		return &d
+	}
+	blocked := map[int32]bool{2: true}
+
+	testCases := []struct {
+		name     string
+		task     Task
+		rules    []ActionableRule
+		expected bool
+	}{
+		{name: "Open task", task: Task{ID: 1, Status: StatusTodo}, rules: DefaultActionableRules(), expected: true},
+		{name: "Completed task", task: Task{ID: 1, Status: StatusDone, IsCompleted: true}, rules: DefaultActionableRules(), expected: false},
+		{name: "Blocked task", task: Task{ID: 2, Status: StatusTodo}, rules: DefaultActionableRules(), expected: false},
+		{name: "Overdue task", task: Task{ID: 1, DueDate: at("2025-07-01 09:00")}, rules: DefaultActionableRules(), expected: true},
+		{name: "Due later today", task: Task{ID: 1, DueDate: at("2025-07-10 23:30")}, rules: DefaultActionableRules(), expected: true},
+		{name: "Snoozed until tomorrow", task: Task{ID: 1, DueDate: at("2025-07-11 09:00")}, rules: DefaultActionableRules(), expected: false},
+		{name: "Parent with open subtasks", task: Task{ID: 1, TotalCount: 3, CompletedCount: 1}, rules: DefaultActionableRules(), expected: true},
+		{
+			name:     "Parent with open subtasks while waiting is hidden",
+			task:     Task{ID: 1, TotalCount: 3, CompletedCount: 1},
+			rules:    []ActionableRule{ActionableHideWaiting},
+			expected: false,
+		},
+		{
+			name:     "Blocked task when only completed tasks are hidden",
+			task:     Task{ID: 2, Status: StatusTodo},
+			rules:    []ActionableRule{ActionableHideCompleted},
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, IsActionable(tc.task, tc.rules, blocked, now))
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package task
+
+import (
+	"sort"
+	"time"
+)
+
+// Agenda holds the open tasks to deal with today: those whose due day has passed and
+// those due today. Each task is in exactly one of the two groups. Pinned lists the
+// IDs of the tasks on the agenda that are pinned to the top of their group.
+type Agenda struct {
+	Overdue []Task  `json:"overdue"`
+	Today   []Task  `json:"today"`
+	Pinned  []int32 `json:"pinned"`
+}
+
+// Len returns the number of tasks on the agenda
+func (a Agenda) Len() int {
+	return len(a.Overdue) + len(a.Today)
+}
+
+// InAgenda reports whether t belongs on the agenda at now: it is open and due today
+// or on an earlier day. Days follow now's location.
+func InAgenda(t Task, now time.Time) bool {
+	if t.IsCompleted || t.Status == StatusDone || t.DueDate == nil {
+		return false
+	}
+	return !agendaDay(*t.DueDate, now).After(agendaDay(now, now))
+}
+
+// NewAgenda picks the agenda out of tasks, see InAgenda. A task due earlier today is
+// listed under Today rather than Overdue, and a task given more than once is listed
+// once. Both groups are sorted by priority, highest first, then by due date and time.
+func NewAgenda(tasks []Task, now time.Time) Agenda {
+	var a Agenda
+	today := agendaDay(now, now)
+	seen := make(map[int32]bool, len(tasks))
+
+	for _, t := range tasks {
+		if !InAgenda(t, now) || seen[t.ID] {
+			continue
+		}
+		seen[t.ID] = true
+
+		if agendaDay(*t.DueDate, now).Before(today) {
+			a.Overdue = append(a.Overdue, t)
+		} else {
+			a.Today = append(a.Today, t)
+		}
+	}
+
+	sortAgendaGroup(a.Overdue)
+	sortAgendaGroup(a.Today)
+	return a
+}
+
+// Pin moves the pinned tasks to the top of their group, in the order of pinned, and
+// records which of them are on the agenda. The rest of each group keeps its order.
+func (a *Agenda) Pin(pinned []int32) {
+	PinFirst(a.Overdue, pinned)
+	PinFirst(a.Today, pinned)
+
+	onAgenda := make(map[int32]bool, a.Len())
+	for _, t := range a.Overdue {
+		onAgenda[t.ID] = true
+	}
+	for _, t := range a.Today {
+		onAgenda[t.ID] = true
+	}
+
+	a.Pinned = nil
+	for _, id := range pinned {
+		if onAgenda[id] {
+			a.Pinned = append(a.Pinned, id)
+			onAgenda[id] = false
+		}
+	}
+}
+
+// PinFirst stably moves the tasks whose IDs are in pinned to the front of tasks,
+// ordered as in pinned
+func PinFirst(tasks []Task, pinned []int32) {
+	if len(pinned) == 0 {
+		return
+	}
+	rank := make(map[int32]int, len(pinned))
+	for i, id := range pinned {
+		if _, ok := rank[id]; !ok {
+			rank[id] = i
+		}
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		ri, pi := rank[tasks[i].ID]
+		rj, pj := rank[tasks[j].ID]
+		if pi != pj {
+			return pi
+		}
+		return pi && ri < rj
+	})
+}
+
+// sortAgendaGroup orders tasks by priority, highest first, then by due date and time
+func sortAgendaGroup(tasks []Task) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		pi, pj := priorityRank(tasks[i].Priority), priorityRank(tasks[j].Priority)
+		if pi != pj {
+			return pi > pj
+		}
+		return tasks[i].DueDate.Before(*tasks[j].DueDate)
+	})
+}
+
+// agendaDay returns the start of t's day in now's location
+func agendaDay(t, now time.Time) time.Time {
+	t = t.In(now.Location())
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, now.Location())
+}
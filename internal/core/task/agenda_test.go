@@ -0,0 +1,84 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAgenda(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 14, 0, 0, 0, time.UTC)
+	at := func(day, hour int) *time.Time {
+		d := time.Date(2025, time.March, day, hour, 0, 0, 0, time.UTC)
+		return &d
+	}
+
+	tasks := []Task{
+		{ID: 1, Priority: PriorityLow, DueDate: at(10, 9)},
+		{ID: 2, Priority: PriorityLow, DueDate: at(8, 0)},
+		{ID: 3, Priority: PriorityHigh, DueDate: at(10, 18)},
+		{ID: 4, Priority: PriorityHigh, DueDate: at(5, 0)},
+		{ID: 5, Priority: PriorityLow, DueDate: at(10, 0)},
+		{ID: 6, Priority: PriorityHigh, DueDate: at(11, 0)},
+		{ID: 7, Priority: PriorityHigh, DueDate: at(9, 0), Status: StatusDone, IsCompleted: true},
+		{ID: 8, Priority: PriorityHigh},
+		{ID: 2, Priority: PriorityLow, DueDate: at(8, 0)},
+	}
+
+	agenda := NewAgenda(tasks, now)
+
+	ids := func(tasks []Task) []int32 {
+		var ids []int32
+		for _, t := range tasks {
+			ids = append(ids, t.ID)
+		}
+		return ids
+	}
+	assert.Equal(t, []int32{4, 2}, ids(agenda.Overdue), "overdue: priority first, each task once")
+	assert.Equal(t, []int32{3, 5, 1}, ids(agenda.Today), "today: priority, then due time; past times stay today")
+	assert.Equal(t, 5, agenda.Len())
+}
+
+func TestAgendaPin(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 14, 0, 0, 0, time.UTC)
+	at := func(day int) *time.Time {
+		d := time.Date(2025, time.March, day, 9, 0, 0, 0, time.UTC)
+		return &d
+	}
+
+	agenda := NewAgenda([]Task{
+		{ID: 1, Priority: PriorityHigh, DueDate: at(10)},
+		{ID: 2, Priority: PriorityMedium, DueDate: at(10)},
+		{ID: 3, Priority: PriorityLow, DueDate: at(10)},
+		{ID: 4, Priority: PriorityHigh, DueDate: at(8)},
+		{ID: 5, Priority: PriorityLow, DueDate: at(8)},
+	}, now)
+
+	// 9 is pinned but not on the agenda, e.g. due tomorrow
+	agenda.Pin([]int32{9, 3, 5, 2, 3})
+
+	ids := func(tasks []Task) []int32 {
+		var ids []int32
+		for _, t := range tasks {
+			ids = append(ids, t.ID)
+		}
+		return ids
+	}
+	assert.Equal(t, []int32{5, 4}, ids(agenda.Overdue))
+	assert.Equal(t, []int32{3, 2, 1}, ids(agenda.Today), "pinned first in pin order, the rest by priority")
+	assert.Equal(t, []int32{3, 5, 2}, agenda.Pinned)
+
+	agenda.Pin(nil)
+	assert.Nil(t, agenda.Pinned)
+}
+
+func TestInAgendaUsesNowsLocation(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	// Due 2025-03-10 23:00 UTC, which is already 2025-03-11 in Tokyo
+	due := time.Date(2025, time.March, 10, 23, 0, 0, 0, time.UTC)
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+
+	assert.True(t, InAgenda(Task{DueDate: &due}, now))
+	assert.False(t, InAgenda(Task{DueDate: &due}, now.In(tokyo)))
+}
@@ -0,0 +1,53 @@
+package task
+
+import "time"
+
+// BurndownPoint is the number of subtasks of a project still open at the end of a day.
+type BurndownPoint struct {
+	Day       time.Time `json:"day"`
+	Remaining int       `json:"remaining"`
+}
+
+// Burndown computes how many descendants of root were open at the end of each day
+// from from to to, inclusive. Days follow the location of from. A subtask counts
+// as open from its creation until it is completed; completion is dated by the
+// task's last update, as no separate completion history is recorded. It returns
+// nil when root has no subtasks or the range is empty.
+func Burndown(root Task, from, to time.Time) []BurndownPoint {
+	var descendants []Task
+	collectDescendants(root, &descendants)
+	if len(descendants) == 0 {
+		return nil
+	}
+
+	loc := from.Location()
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+	last := to.In(loc)
+	last = time.Date(last.Year(), last.Month(), last.Day(), 0, 0, 0, 0, loc)
+
+	var points []BurndownPoint
+	for !day.After(last) {
+		end := day.AddDate(0, 0, 1)
+		remaining := 0
+		for _, t := range descendants {
+			if !t.CreatedAt.Before(end) {
+				continue
+			}
+			if t.IsCompleted && t.UpdatedAt.Before(end) {
+				continue
+			}
+			remaining++
+		}
+		points = append(points, BurndownPoint{Day: day, Remaining: remaining})
+		day = end
+	}
+	return points
+}
+
+// collectDescendants appends every task below t, at any depth, to out.
+func collectDescendants(t Task, out *[]Task) {
+	for _, st := range t.SubTasks {
+		*out = append(*out, st)
+		collectDescendants(st, out)
+	}
+}
@@ -0,0 +1,49 @@
+package task
+
+import "time"
+
+// CompletionsPerDay counts the tasks completed on each of the last days days, ending
+// with the day containing now, oldest first. Days follow now's location. Subtasks
+// are counted too; a task listed both on its own and inside its parent counts once.
+// Completion is dated by the task's last update, as no separate completion time is
+// recorded.
+func CompletionsPerDay(tasks []Task, now time.Time, days int) []int {
+	if days <= 0 {
+		return nil
+	}
+
+	loc := now.Location()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	first := today.AddDate(0, 0, -(days - 1))
+	counts := make([]int, days)
+	seen := make(map[int32]bool)
+
+	var walk func([]Task)
+	walk = func(ts []Task) {
+		for _, t := range ts {
+			if !seen[t.ID] {
+				seen[t.ID] = true
+				if t.Status == StatusDone || t.IsCompleted {
+					updated := t.UpdatedAt.In(loc)
+					day := time.Date(updated.Year(), updated.Month(), updated.Day(), 0, 0, 0, 0, loc)
+					if !day.Before(first) && !day.After(today) {
+						counts[daysBetween(first, day)]++
+					}
+				}
+			}
+			walk(t.SubTasks)
+		}
+	}
+	walk(tasks)
+
+	return counts
+}
+
+// daysBetween returns the number of calendar days from a to b, both at midnight
+func daysBetween(a, b time.Time) int {
+	n := 0
+	for d := a; d.Before(b); d = d.AddDate(0, 0, 1) {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,28 @@
+package task
+
+import "time"
+
+// HasDueTime reports whether a due date carries a time of day. A due date at
+// midnight is date-only: the task is due at some point during that day.
+func HasDueTime(due time.Time) bool {
+	return due.Hour() != 0 || due.Minute() != 0 || due.Second() != 0
+}
+
+// IsOverdue reports whether a task due at due is overdue at now: it was due on an
+// earlier day, or earlier today at a set time. Days follow now's location.
+func IsOverdue(due, now time.Time) bool {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if due.Before(today) {
+		return true
+	}
+	return HasDueTime(due.In(now.Location())) && due.Before(now)
+}
+
+// IsDueSoon reports whether due falls on a day from today up to days from now, the
+// same window ListTasksDueSoon uses. Days follow now's location.
+func IsDueSoon(due, now time.Time, days int) bool {
+	due = due.In(now.Location())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	end := today.AddDate(0, 0, days+1)
+	return !due.Before(today) && due.Before(end)
+}
@@ -0,0 +1,56 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsOverdue(t *testing.T) {
+	loc := time.FixedZone("UTC-5", -5*60*60)
+	now := time.Date(2025, time.March, 10, 14, 0, 0, 0, loc)
+
+	testCases := []struct {
+		name     string
+		due      time.Time
+		expected bool
+	}{
+		{name: "Yesterday", due: time.Date(2025, time.March, 9, 0, 0, 0, 0, loc), expected: true},
+		{name: "Today without a time", due: time.Date(2025, time.March, 10, 0, 0, 0, 0, loc), expected: false},
+		{name: "Earlier today", due: time.Date(2025, time.March, 10, 9, 30, 0, 0, loc), expected: true},
+		{name: "Later today", due: time.Date(2025, time.March, 10, 17, 0, 0, 0, loc), expected: false},
+		{name: "Tomorrow", due: time.Date(2025, time.March, 11, 0, 0, 0, 0, loc), expected: false},
+		{name: "Earlier today in another zone", due: time.Date(2025, time.March, 10, 15, 0, 0, 0, time.UTC), expected: true},
+		{name: "Today's midnight in another zone", due: time.Date(2025, time.March, 11, 0, 0, 0, 0, time.UTC), expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, IsOverdue(tc.due, now))
+		})
+	}
+}
+
+func TestIsDueSoon(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 14, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		due      time.Time
+		days     int
+		expected bool
+	}{
+		{name: "Yesterday", due: time.Date(2025, time.March, 9, 23, 0, 0, 0, time.UTC), days: 7, expected: false},
+		{name: "Earlier today", due: time.Date(2025, time.March, 10, 9, 0, 0, 0, time.UTC), days: 7, expected: true},
+		{name: "Last day of the window", due: time.Date(2025, time.March, 17, 23, 0, 0, 0, time.UTC), days: 7, expected: true},
+		{name: "Day after the window", due: time.Date(2025, time.March, 18, 0, 0, 0, 0, time.UTC), days: 7, expected: false},
+		{name: "Shorter window", due: time.Date(2025, time.March, 14, 0, 0, 0, 0, time.UTC), days: 3, expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, IsDueSoon(tc.due, now, tc.days))
+		})
+	}
+}
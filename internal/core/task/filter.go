@@ -0,0 +1,182 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// filterDateLayout is the date format used for due date bounds in filter specs.
+const filterDateLayout = "2006-01-02"
+
+// TaskFilter describes a set of optional criteria a task must satisfy.
+// Unset fields are ignored, so the zero value matches every task.
+type TaskFilter struct {
+	Status    *Status
+	Priority  *Priority
+	Energy    *Energy    // tasks without an energy level match every energy
+	DueBefore *time.Time // due strictly before this time
+	DueAfter  *time.Time // due at or after this time
+	Tag       string
+	Text      string // case-insensitive match against title and description
+}
+
+// IsEmpty reports whether the filter has no criteria set.
+func (f TaskFilter) IsEmpty() bool {
+	return f.Status == nil && f.Priority == nil && f.Energy == nil && f.DueBefore == nil && f.DueAfter == nil &&
+		f.Tag == "" && f.Text == ""
+}
+
+// Matches reports whether t satisfies every criterion of the filter.
+// Tasks without a due date never match a due date bound.
+func (f TaskFilter) Matches(t Task) bool {
+	if f.Status != nil && t.Status != *f.Status {
+		return false
+	}
+	if f.Priority != nil && t.Priority != *f.Priority {
+		return false
+	}
+	if f.Energy != nil && t.Energy != "" && t.Energy != *f.Energy {
+		return false
+	}
+	if f.DueBefore != nil && (t.DueDate == nil || !t.DueDate.Before(*f.DueBefore)) {
+		return false
+	}
+	if f.DueAfter != nil && (t.DueDate == nil || t.DueDate.Before(*f.DueAfter)) {
+		return false
+	}
+	if f.Tag != "" && !hasTag(t, f.Tag) {
+		return false
+	}
+	if f.Text != "" && !containsText(t, f.Text) {
+		return false
+	}
+	return true
+}
+
+// String renders the filter in the same key=value form accepted by ParseTaskFilter.
+func (f TaskFilter) String() string {
+	var parts []string
+	if f.Status != nil {
+		parts = append(parts, "status="+string(*f.Status))
+	}
+	if f.Priority != nil {
+		parts = append(parts, "priority="+string(*f.Priority))
+	}
+	if f.Energy != nil {
+		parts = append(parts, "energy="+string(*f.Energy))
+	}
+	if f.DueAfter != nil {
+		parts = append(parts, "due_after="+f.DueAfter.Format(filterDateLayout))
+	}
+	if f.DueBefore != nil {
+		parts = append(parts, "due_before="+f.DueBefore.Format(filterDateLayout))
+	}
+	if f.Tag != "" {
+		parts = append(parts, "tag="+f.Tag)
+	}
+	if f.Text != "" {
+		parts = append(parts, "text="+f.Text)
+	}
+	return strings.Join(parts, ",")
+}
+
+// ParseTaskFilter builds a filter from a comma-separated list of key=value pairs,
+// e.g. "status=todo,tag=work,due=week". Supported keys are status, priority, energy, tag,
+// text, due_before and due_after (YYYY-MM-DD), and due, which accepts the relative
+// ranges today, week and overdue computed from now. An empty spec yields an empty filter.
+func ParseTaskFilter(spec string, now time.Time) (TaskFilter, error) {
+	var f TaskFilter
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(part, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+		if !ok || value == "" {
+			return TaskFilter{}, fmt.Errorf("invalid filter %q (expected key=value)", part)
+		}
+
+		switch key {
+		case "status":
+			status := Status(strings.ToLower(value))
+			switch status {
+			case StatusTodo, StatusInProgress, StatusDone:
+				f.Status = &status
+			default:
+				return TaskFilter{}, fmt.Errorf("invalid filter status %q (expected todo, in-progress or done)", value)
+			}
+		case "priority":
+			priority := Priority(strings.ToLower(value))
+			switch priority {
+			case PriorityLow, PriorityMedium, PriorityHigh:
+				f.Priority = &priority
+			default:
+				return TaskFilter{}, fmt.Errorf("invalid filter priority %q (expected low, medium or high)", value)
+			}
+		case "energy":
+			energy := Energy(strings.ToLower(value))
+			switch energy {
+			case EnergyLow, EnergyMedium, EnergyHigh:
+				f.Energy = &energy
+			default:
+				return TaskFilter{}, fmt.Errorf("invalid filter energy %q (expected low, medium or high)", value)
+			}
+		case "tag":
+			f.Tag = value
+		case "text":
+			f.Text = value
+		case "due_before", "due_after":
+			date, err := time.ParseInLocation(filterDateLayout, value, now.Location())
+			if err != nil {
+				return TaskFilter{}, fmt.Errorf("invalid filter %s %q (expected YYYY-MM-DD)", key, value)
+			}
+			if key == "due_before" {
+				f.DueBefore = &date
+			} else {
+				f.DueAfter = &date
+			}
+		case "due":
+			start, end := today, today.AddDate(0, 0, 1)
+			switch strings.ToLower(value) {
+			case "today":
+				f.DueAfter, f.DueBefore = &start, &end
+			case "week":
+				end = today.AddDate(0, 0, 7)
+				f.DueAfter, f.DueBefore = &start, &end
+			case "overdue":
+				f.DueBefore = &start
+			default:
+				return TaskFilter{}, fmt.Errorf("invalid filter due %q (expected today, week or overdue)", value)
+			}
+		default:
+			return TaskFilter{}, fmt.Errorf("unknown filter field %q", key)
+		}
+	}
+
+	return f, nil
+}
+
+// hasTag reports whether t carries the given tag, ignoring case.
+func hasTag(t Task, tag string) bool {
+	for _, tg := range t.Tags {
+		if strings.EqualFold(tg.Name, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsText reports whether text appears in the title or description of t, ignoring case.
+func containsText(t Task, text string) bool {
+	text = strings.ToLower(text)
+	if strings.Contains(strings.ToLower(t.Title), text) {
+		return true
+	}
+	return t.Description != nil && strings.Contains(strings.ToLower(*t.Description), text)
+}
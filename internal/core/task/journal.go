@@ -0,0 +1,41 @@
+package task
+
+import "time"
+
+// Journal summarizes a day of work: what was completed, what is still open and
+// what is overdue.
+type Journal struct {
+	Date      time.Time
+	Completed []Task
+	Open      []Task
+	Overdue   []Task
+}
+
+// NewJournal builds the journal for the day containing now, in now's location.
+// A done task counts as completed today when its last update falls on that day,
+// as no separate completion time is recorded. Open tasks past their due date, see
+// IsOverdue, are listed as overdue rather than open. Open and overdue tasks are ordered by due date.
+func NewJournal(done, open []Task, now time.Time) Journal {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	tomorrow := today.AddDate(0, 0, 1)
+
+	j := Journal{Date: today}
+	for _, t := range done {
+		updated := t.UpdatedAt.In(now.Location())
+		if !updated.Before(today) && updated.Before(tomorrow) {
+			j.Completed = append(j.Completed, t)
+		}
+	}
+
+	for _, t := range open {
+		if t.DueDate != nil && IsOverdue(*t.DueDate, now) {
+			j.Overdue = append(j.Overdue, t)
+		} else {
+			j.Open = append(j.Open, t)
+		}
+	}
+
+	SortByDueDate(j.Open, UndatedBottom)
+	SortByDueDate(j.Overdue, UndatedBottom)
+	return j
+}
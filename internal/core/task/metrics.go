@@ -0,0 +1,41 @@
+package task
+
+import "time"
+
+// CachedMetrics is a persisted snapshot of a parent task's progress metrics.
+// It is derived data: the metrics computed whenever a task tree is loaded are the
+// source of truth, and a snapshot may be stale until it is recomputed.
+type CachedMetrics struct {
+	TaskID         int32     `json:"task_id"`
+	TotalCount     int       `json:"total_count"`
+	CompletedCount int       `json:"completed_count"`
+	Progress       float64   `json:"progress"`
+	ComputedAt     time.Time `json:"computed_at"`
+}
+
+// CollectMetrics snapshots the metrics of every task in trees that has subtasks.
+// The trees must already have their metrics computed.
+func CollectMetrics(trees []Task) []CachedMetrics {
+	var metrics []CachedMetrics
+	for _, t := range trees {
+		collectMetrics(t, &metrics)
+	}
+	return metrics
+}
+
+// collectMetrics appends the metrics of t and its descendants that have subtasks
+func collectMetrics(t Task, out *[]CachedMetrics) {
+	if len(t.SubTasks) == 0 {
+		return
+	}
+
+	*out = append(*out, CachedMetrics{
+		TaskID:         t.ID,
+		TotalCount:     t.TotalCount,
+		CompletedCount: t.CompletedCount,
+		Progress:       t.Progress,
+	})
+	for _, sub := range t.SubTasks {
+		collectMetrics(sub, out)
+	}
+}
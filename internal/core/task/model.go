@@ -14,10 +14,19 @@ type Tag struct {
 // It can be one of the following values: "todo", "in-progress", or "done".
 type Status string
 
+// ActiveStatuses returns the statuses of tasks that still need work
+func ActiveStatuses() []Status {
+	return []Status{StatusTodo, StatusInProgress}
+}
+
 // Priority represents the priority of a task.
 // It can be one of the following values: "low", "medium", or "high".
 type Priority string
 
+// Energy represents how much focus a task needs.
+// It can be one of the following values: "low", "medium", or "high", or empty when unset.
+type Energy string
+
 const (
 	// StatusTodo represents a task that is yet to be started.
 	StatusTodo Status = "todo"
@@ -32,26 +41,52 @@ const (
 	PriorityMedium Priority = "medium"
 	// PriorityHigh represents a task with high priority.
 	PriorityHigh Priority = "high"
+
+	// EnergyLow represents a task that needs little focus.
+	EnergyLow Energy = "low"
+	// EnergyMedium represents a task that needs moderate focus.
+	EnergyMedium Energy = "medium"
+	// EnergyHigh represents a task that needs deep focus.
+	EnergyHigh Energy = "high"
 )
 
 // Task represents a task in the system.
 // It includes fields for the task's ID, user ID, parent ID, title, description,
 // created and updated timestamps, due date, is completed, status, priority, tags, and display order.
 // It also includes a list of sub-tasks and computed fields for total count, completed count, and progress.
+// The JSON encoding uses snake_case keys and is read by integrations and the REST API,
+// so keys must not be renamed; nil pointers are left out.
 type Task struct {
-	ID           int32      `json:"id"`
-	UserID       int32      `json:"user_id"`
-	ParentID     *int32     `json:"parent_id,omitempty"` // nil means root task
-	Title        string     `json:"title"`
-	Description  *string    `json:"description,omitempty"`
-	CreatedAt    time.Time  `json:"created_at"`
-	UpdatedAt    time.Time  `json:"updated_at"`
-	DueDate      *time.Time `json:"due_date,omitempty"`
-	IsCompleted  bool       `json:"is_completed"`
-	Status       Status     `json:"status"`
-	Priority     Priority   `json:"priority"`
-	Tags         []Tag      `json:"tags"`
-	DisplayOrder int        `json:"display_order"`
+	ID           int32       `json:"id"`
+	UserID       int32       `json:"user_id"`
+	ParentID     *int32      `json:"parent_id,omitempty"` // nil means root task
+	Title        string      `json:"title"`
+	Description  *string     `json:"description,omitempty"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+	DueDate      *time.Time  `json:"due_date,omitempty"`
+	IsCompleted  bool        `json:"is_completed"`
+	Status       Status      `json:"status"`
+	Priority     Priority    `json:"priority"`
+	Energy       Energy      `json:"energy,omitempty"`     // empty means unset
+	Points       int         `json:"points"`               // effort estimate, 0 means unset
+	Recurrence   *Recurrence `json:"recurrence,omitempty"` // nil means the task does not repeat
+	Tags         []Tag       `json:"tags"`
+	DisplayOrder int         `json:"display_order"`
+
+	// ExternalID and ExternalSource anchor the task to an event in an external calendar,
+	// e.g. a Google Calendar event ID and "google"; both are nil for tasks that are not synced
+	ExternalID     *string `json:"external_id,omitempty"`
+	ExternalSource *string `json:"external_source,omitempty"`
+
+	// ArchivedAt is set while the task is archived; archived tasks are hidden from
+	// every listing until they are restored
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
+
+	// Dependencies are the IDs of the tasks that must be done before this one can be
+	// completed ("blocked by"). They are unrelated to ParentID and SubTasks, and are
+	// only loaded when a single task is fetched by ID.
+	Dependencies []int32 `json:"dependencies,omitempty"`
 
 	// Children hierarchical tasks
 	SubTasks []Task `json:"subtasks,omitempty"`
@@ -59,5 +94,7 @@ type Task struct {
 	// Computed fields
 	TotalCount     int     `json:"total_count"`
 	CompletedCount int     `json:"completed_count"`
-	Progress       float64 `json:"progress"` // CompletedCount / TotalCount * (0.0-1.0)
+	Progress       float64 `json:"progress"` // CompletedCount / TotalCount, or completed points / total points when weighted (0.0-1.0)
+	// HasOverdueDescendant is true when any incomplete subtask, at any depth, is overdue
+	HasOverdueDescendant bool `json:"has_overdue_descendant"`
 }
@@ -0,0 +1,66 @@
+package task
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskJSONRoundTrip(t *testing.T) {
+	created := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+	due := created.Add(48 * time.Hour)
+	parentID := int32(1)
+	description := "Write the quarterly report"
+
+	original := Task{
+		ID:           1,
+		UserID:       7,
+		Title:        "Report",
+		Description:  &description,
+		CreatedAt:    created,
+		UpdatedAt:    created,
+		DueDate:      &due,
+		Status:       StatusInProgress,
+		Priority:     PriorityHigh,
+		Energy:       EnergyMedium,
+		Points:       3,
+		Recurrence:   &Recurrence{Frequency: FrequencyMonthly, Interval: 3},
+		Tags:         []Tag{{Name: "work"}, {Name: "q1"}},
+		DisplayOrder: 2,
+		SubTasks: []Task{
+			{
+				ID:          2,
+				UserID:      7,
+				ParentID:    &parentID,
+				Title:       "Collect numbers",
+				CreatedAt:   created,
+				UpdatedAt:   created,
+				IsCompleted: true,
+				Status:      StatusDone,
+				Priority:    PriorityLow,
+				Tags:        []Tag{{Name: "work"}},
+			},
+		},
+		TotalCount:     1,
+		CompletedCount: 1,
+		Progress:       1,
+	}
+
+	data, err := json.Marshal(original)
+	require.NoError(t, err)
+
+	var keys map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(data, &keys))
+	for _, key := range []string{"id", "user_id", "title", "due_date", "is_completed", "display_order", "subtasks"} {
+		assert.Contains(t, keys, key)
+	}
+	// A root task has no parent, so the key is left out rather than sent as null
+	assert.NotContains(t, keys, "parent_id")
+
+	var decoded Task
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, original, decoded)
+}
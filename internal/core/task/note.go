@@ -0,0 +1,16 @@
+package task
+
+import "time"
+
+// MaxNoteLength is the longest note body accepted, in characters
+const MaxNoteLength = 2000
+
+// TaskNote is a dated entry appended to a task, like a worklog. Unlike the task's
+// description, notes are never overwritten; new ones are added next to the old.
+type TaskNote struct {
+	ID        int32     `json:"id"`
+	TaskID    int32     `json:"task_id"`
+	UserID    int32     `json:"user_id"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,16 @@
+package task
+
+// SameParent reports whether two parent IDs point at the same task, or are both root
+func SameParent(a, b *int32) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// SameListSection reports whether two tasks are ordered among each other: siblings
+// under the same parent with the same status, which the task list shows in the same
+// section (todo, in progress or done).
+func SameListSection(a, b Task) bool {
+	return SameParent(a.ParentID, b.ParentID) && a.Status == b.Status
+}
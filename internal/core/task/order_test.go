@@ -0,0 +1,30 @@
+package task
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSameListSection(t *testing.T) {
+	parent, otherParent := int32(1), int32(2)
+
+	testCases := []struct {
+		name     string
+		a, b     Task
+		expected bool
+	}{
+		{name: "Root tasks to do", a: Task{Status: StatusTodo}, b: Task{Status: StatusTodo}, expected: true},
+		{name: "Subtasks of one parent", a: Task{ParentID: &parent, Status: StatusDone}, b: Task{ParentID: &parent, Status: StatusDone}, expected: true},
+		{name: "Todo and in progress", a: Task{Status: StatusTodo}, b: Task{Status: StatusInProgress}, expected: false},
+		{name: "Root and subtask", a: Task{Status: StatusTodo}, b: Task{ParentID: &parent, Status: StatusTodo}, expected: false},
+		{name: "Different parents", a: Task{ParentID: &parent, Status: StatusTodo}, b: Task{ParentID: &otherParent, Status: StatusTodo}, expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, SameListSection(tc.a, tc.b))
+			assert.Equal(t, tc.expected, SameListSection(tc.b, tc.a))
+		})
+	}
+}
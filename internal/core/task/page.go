@@ -0,0 +1,15 @@
+package task
+
+// Page is one page of a user's root tasks, each with its subtasks, along with the
+// number of root tasks across all pages.
+type Page struct {
+	Tasks  []Task `json:"tasks"`
+	Total  int    `json:"total"`
+	Limit  int    `json:"limit"`
+	Offset int    `json:"offset"`
+}
+
+// HasMore reports whether there are root tasks after this page.
+func (p Page) HasMore() bool {
+	return p.Offset+len(p.Tasks) < p.Total
+}
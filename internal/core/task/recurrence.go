@@ -0,0 +1,137 @@
+package task
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is the unit a recurring task repeats in.
+// It can be one of the following values: "daily", "weekly" or "monthly".
+type Frequency string
+
+const (
+	// FrequencyDaily repeats a task every Interval days.
+	FrequencyDaily Frequency = "daily"
+	// FrequencyWeekly repeats a task every Interval weeks.
+	FrequencyWeekly Frequency = "weekly"
+	// FrequencyMonthly repeats a task every Interval months.
+	FrequencyMonthly Frequency = "monthly"
+)
+
+// maxRecurrenceInterval bounds the interval of a recurrence rule
+const maxRecurrenceInterval = 365
+
+// Recurrence describes how often a task repeats, e.g. every 2 weeks
+type Recurrence struct {
+	Frequency Frequency `json:"frequency"`
+	Interval  int       `json:"interval"` // repeat every Interval units, at least 1
+}
+
+// ParseRecurrence parses a rule written as "<frequency>" or "<frequency>:<interval>",
+// e.g. "weekly" or "monthly:3". An empty rule means the task does not repeat and
+// returns nil.
+func ParseRecurrence(s string) (*Recurrence, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return nil, nil
+	}
+
+	name, count, hasInterval := strings.Cut(s, ":")
+	r := Recurrence{Frequency: Frequency(strings.TrimSpace(name)), Interval: 1}
+	if hasInterval {
+		n, err := strconv.Atoi(strings.TrimSpace(count))
+		if err != nil {
+			return nil, fmt.Errorf("invalid recurrence interval %q", count)
+		}
+		r.Interval = n
+	}
+
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// Validate reports whether the rule has a known frequency and a usable interval
+func (r Recurrence) Validate() error {
+	switch r.Frequency {
+	case FrequencyDaily, FrequencyWeekly, FrequencyMonthly:
+	default:
+		return fmt.Errorf("unknown recurrence frequency %q (use daily, weekly or monthly)", r.Frequency)
+	}
+	if r.Interval < 1 || r.Interval > maxRecurrenceInterval {
+		return fmt.Errorf("recurrence interval must be between 1 and %d", maxRecurrenceInterval)
+	}
+	return nil
+}
+
+// String formats the rule the way ParseRecurrence reads it
+func (r Recurrence) String() string {
+	if r.Interval <= 1 {
+		return string(r.Frequency)
+	}
+	return fmt.Sprintf("%s:%d", r.Frequency, r.Interval)
+}
+
+// Next returns the occurrence after t. Monthly rules keep the day of the month
+// where it exists and otherwise use the last day of the month, so a task due on
+// January 31st repeats on February 28th (or 29th) rather than in March.
+func (r Recurrence) Next(t time.Time) time.Time {
+	interval := max(1, r.Interval)
+
+	switch r.Frequency {
+	case FrequencyWeekly:
+		return t.AddDate(0, 0, 7*interval)
+	case FrequencyMonthly:
+		firstOfMonth := time.Date(t.Year(), t.Month(), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+		target := firstOfMonth.AddDate(0, interval, 0)
+		lastDay := target.AddDate(0, 1, -1).Day()
+		return target.AddDate(0, 0, min(t.Day(), lastDay)-1)
+	default:
+		return t.AddDate(0, 0, interval)
+	}
+}
+
+// GenerateNextOccurrence builds the task that follows a completed recurring task.
+// The copy keeps the title, description, parent, priority, energy, points, tags and
+// rule, starts out as todo and has no ID or subtasks. Its due date is rolled forward
+// by the rule until it is no longer in the past; an undated task gets a due date one
+// interval after now. It returns false when t does not repeat.
+func GenerateNextOccurrence(t Task, now time.Time) (Task, bool) {
+	if t.Recurrence == nil || t.Recurrence.Validate() != nil {
+		return Task{}, false
+	}
+
+	var due time.Time
+	if t.DueDate == nil {
+		due = t.Recurrence.Next(now)
+	} else {
+		due = t.Recurrence.Next(*t.DueDate)
+		startOfToday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		for due.Before(startOfToday) {
+			due = t.Recurrence.Next(due)
+		}
+	}
+
+	rule := *t.Recurrence
+	tags := make([]Tag, len(t.Tags))
+	copy(tags, t.Tags)
+
+	return Task{
+		UserID:      t.UserID,
+		ParentID:    t.ParentID,
+		Title:       t.Title,
+		Description: t.Description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		DueDate:     &due,
+		Status:      StatusTodo,
+		Priority:    t.Priority,
+		Energy:      t.Energy,
+		Points:      t.Points,
+		Tags:        tags,
+		Recurrence:  &rule,
+	}, true
+}
@@ -0,0 +1,42 @@
+package task
+
+import "time"
+
+// IsDueNow reports whether a task due at due has come due today at now: a date-only
+// task is due all day, a task with a due time once that time has passed. Tasks due on
+// an earlier day are overdue rather than due now. Days follow now's location.
+func IsDueNow(due, now time.Time) bool {
+	due = due.In(now.Location())
+	if due.Year() != now.Year() || due.YearDay() != now.YearDay() {
+		return false
+	}
+	return !HasDueTime(due) || !due.After(now)
+}
+
+// Reminders remembers which tasks a reminder was given for, so every task is
+// reminded of once per due date. The zero value is ready to use.
+type Reminders struct {
+	notified map[int32]time.Time
+}
+
+// Due returns the open tasks among tasks that are due now and were not reminded of
+// yet for their current due date, marking them as reminded. A task whose due date
+// changes is reminded of again once the new date comes due.
+func (r *Reminders) Due(tasks []Task, now time.Time) []Task {
+	if r.notified == nil {
+		r.notified = make(map[int32]time.Time)
+	}
+
+	var due []Task
+	for _, t := range tasks {
+		if t.IsCompleted || t.Status == StatusDone || t.DueDate == nil || !IsDueNow(*t.DueDate, now) {
+			continue
+		}
+		if last, ok := r.notified[t.ID]; ok && last.Equal(*t.DueDate) {
+			continue
+		}
+		r.notified[t.ID] = *t.DueDate
+		due = append(due, t)
+	}
+	return due
+}
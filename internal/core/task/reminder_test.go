@@ -0,0 +1,69 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsDueNow(t *testing.T) {
+	loc := time.FixedZone("UTC-5", -5*60*60)
+	now := time.Date(2025, time.March, 10, 14, 0, 0, 0, loc)
+
+	testCases := []struct {
+		name     string
+		due      time.Time
+		expected bool
+	}{
+		{name: "Yesterday", due: time.Date(2025, time.March, 9, 0, 0, 0, 0, loc), expected: false},
+		{name: "Yesterday at a time", due: time.Date(2025, time.March, 9, 16, 0, 0, 0, loc), expected: false},
+		{name: "Today without a time", due: time.Date(2025, time.March, 10, 0, 0, 0, 0, loc), expected: true},
+		{name: "Earlier today", due: time.Date(2025, time.March, 10, 9, 30, 0, 0, loc), expected: true},
+		{name: "Right now", due: now, expected: true},
+		{name: "Later today", due: time.Date(2025, time.March, 10, 17, 0, 0, 0, loc), expected: false},
+		{name: "Tomorrow", due: time.Date(2025, time.March, 11, 0, 0, 0, 0, loc), expected: false},
+		{name: "Earlier today in another zone", due: time.Date(2025, time.March, 10, 15, 0, 0, 0, time.UTC), expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, IsDueNow(tc.due, now))
+		})
+	}
+}
+
+func TestRemindersDue(t *testing.T) {
+	at := func(hour int) *time.Time {
+		date := time.Date(2025, time.March, 10, hour, 0, 0, 0, time.UTC)
+		return &date
+	}
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+
+	tasks := []Task{
+		{ID: 1, Title: "Date only", DueDate: at(0)},
+		{ID: 2, Title: "This morning", DueDate: at(9)},
+		{ID: 3, Title: "This afternoon", DueDate: at(15)},
+		{ID: 4, Title: "Done", DueDate: at(9), IsCompleted: true, Status: StatusDone},
+		{ID: 5, Title: "Undated"},
+	}
+
+	var r Reminders
+	assert.Equal(t, []int32{1, 2}, taskIDs(r.Due(tasks, now)), "first check")
+	assert.Empty(t, r.Due(tasks, now), "already reminded")
+
+	later := now.Add(4 * time.Hour)
+	assert.Equal(t, []int32{3}, taskIDs(r.Due(tasks, later)), "afternoon task came due")
+
+	tasks[1].DueDate = at(18)
+	assert.Empty(t, r.Due(tasks, later), "moved to later today")
+	assert.Equal(t, []int32{2}, taskIDs(r.Due(tasks, later.Add(3*time.Hour))), "moved task came due again")
+}
+
+func taskIDs(tasks []Task) []int32 {
+	var ids []int32
+	for _, t := range tasks {
+		ids = append(ids, t.ID)
+	}
+	return ids
+}
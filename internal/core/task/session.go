@@ -0,0 +1,40 @@
+package task
+
+import (
+	"sort"
+	"time"
+)
+
+// SessionSummary lists the tasks touched during a focus session
+type SessionSummary struct {
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+	Completed []Task    `json:"completed"` // done tasks last updated during the session
+	Modified  []Task    `json:"modified"`  // other tasks created or updated during the session
+}
+
+// NewSessionSummary picks the tasks last updated in [start, end) out of tasks.
+// Completion is dated by the task's last update, as no separate completion time is
+// recorded. Subtasks are not walked and the returned tasks are ordered by their last
+// update.
+func NewSessionSummary(tasks []Task, start, end time.Time) SessionSummary {
+	s := SessionSummary{Start: start, End: end}
+	for _, t := range tasks {
+		if t.UpdatedAt.Before(start) || !t.UpdatedAt.Before(end) {
+			continue
+		}
+		t.SubTasks = nil
+		if t.IsCompleted || t.Status == StatusDone {
+			s.Completed = append(s.Completed, t)
+		} else {
+			s.Modified = append(s.Modified, t)
+		}
+	}
+
+	byUpdate := func(ts []Task) func(i, j int) bool {
+		return func(i, j int) bool { return ts[i].UpdatedAt.Before(ts[j].UpdatedAt) }
+	}
+	sort.SliceStable(s.Completed, byUpdate(s.Completed))
+	sort.SliceStable(s.Modified, byUpdate(s.Modified))
+	return s
+}
@@ -0,0 +1,134 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SnoozePreset names a point in time a task can be snoozed until.
+type SnoozePreset string
+
+const (
+	// SnoozeOneHour snoozes for one hour from now.
+	SnoozeOneHour SnoozePreset = "1h"
+	// SnoozeEvening snoozes until 18:00 today, or tomorrow once that has passed.
+	SnoozeEvening SnoozePreset = "evening"
+	// SnoozeTomorrow snoozes until tomorrow morning.
+	SnoozeTomorrow SnoozePreset = "tomorrow"
+	// SnoozeWeekend snoozes until Saturday morning.
+	SnoozeWeekend SnoozePreset = "weekend"
+	// SnoozeNextWeek snoozes until Monday morning.
+	SnoozeNextWeek SnoozePreset = "next-week"
+)
+
+// Hours of the day used by the snooze presets.
+const (
+	snoozeMorningHour = 9
+	snoozeEveningHour = 18
+)
+
+// DefaultSnoozePresets returns the presets offered when none are configured.
+func DefaultSnoozePresets() []SnoozePreset {
+	return []SnoozePreset{SnoozeOneHour, SnoozeEvening, SnoozeTomorrow, SnoozeWeekend, SnoozeNextWeek}
+}
+
+// ParseSnoozePresets converts a comma-separated list such as "1h,tomorrow,next-week"
+// into presets, keeping their order. An empty spec yields the defaults.
+func ParseSnoozePresets(spec string) ([]SnoozePreset, error) {
+	var presets []SnoozePreset
+	seen := make(map[SnoozePreset]bool)
+
+	for _, part := range strings.Split(spec, ",") {
+		p := SnoozePreset(strings.ToLower(strings.TrimSpace(part)))
+		if p == "" || seen[p] {
+			continue
+		}
+		switch p {
+		case SnoozeOneHour, SnoozeEvening, SnoozeTomorrow, SnoozeWeekend, SnoozeNextWeek:
+			presets = append(presets, p)
+			seen[p] = true
+		default:
+			return DefaultSnoozePresets(), fmt.Errorf("invalid snooze preset %q (expected 1h, evening, tomorrow, weekend or next-week)", part)
+		}
+	}
+
+	if len(presets) == 0 {
+		return DefaultSnoozePresets(), nil
+	}
+	return presets, nil
+}
+
+// Label returns a human readable name for the preset.
+func (p SnoozePreset) Label() string {
+	switch p {
+	case SnoozeOneHour:
+		return "1 hour"
+	case SnoozeEvening:
+		return "This evening"
+	case SnoozeTomorrow:
+		return "Tomorrow"
+	case SnoozeWeekend:
+		return "This weekend"
+	case SnoozeNextWeek:
+		return "Next week"
+	default:
+		return string(p)
+	}
+}
+
+// Until returns the time the preset snoozes to, relative to now and in now's
+// location. Presets that name a day start at 9:00, "this evening" is 18:00.
+func (p SnoozePreset) Until(now time.Time) time.Time {
+	at := func(days, hour int) time.Time {
+		return time.Date(now.Year(), now.Month(), now.Day()+days, hour, 0, 0, 0, now.Location())
+	}
+
+	switch p {
+	case SnoozeEvening:
+		if evening := at(0, snoozeEveningHour); now.Before(evening) {
+			return evening
+		}
+		return at(1, snoozeEveningHour)
+	case SnoozeTomorrow:
+		return at(1, snoozeMorningHour)
+	case SnoozeWeekend:
+		days := (int(time.Saturday) - int(now.Weekday()) + 7) % 7
+		if days == 0 && !now.Before(at(0, snoozeMorningHour)) {
+			days = 7
+		}
+		return at(days, snoozeMorningHour)
+	case SnoozeNextWeek:
+		days := (int(time.Monday) - int(now.Weekday()) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		return at(days, snoozeMorningHour)
+	default:
+		return now.Add(time.Hour)
+	}
+}
+
+// SnoozeBy returns the due date of a task due at due once it is snoozed by d at now.
+// The snooze counts from the due date, from today for an overdue task (keeping its
+// time of day), and from now for an undated one. Whole days are added on the calendar,
+// so the time of day holds across daylight saving changes. Days follow now's location.
+func SnoozeBy(due *time.Time, d time.Duration, now time.Time) time.Time {
+	var from time.Time
+	switch {
+	case due == nil:
+		from = now.Truncate(time.Minute)
+	case IsOverdue(*due, now):
+		local := due.In(now.Location())
+		from = time.Date(now.Year(), now.Month(), now.Day(),
+			local.Hour(), local.Minute(), local.Second(), 0, now.Location())
+	default:
+		from = due.In(now.Location())
+	}
+
+	const day = 24 * time.Hour
+	if d%day == 0 {
+		return from.AddDate(0, 0, int(d/day))
+	}
+	return from.Add(d)
+}
@@ -0,0 +1,50 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnoozeBy(t *testing.T) {
+	loc := time.FixedZone("UTC-5", -5*60*60)
+	now := time.Date(2025, time.March, 10, 14, 7, 30, 0, loc)
+	at := func(day, hour, minute int) *time.Time {
+		d := time.Date(2025, time.March, day, hour, minute, 0, 0, loc)
+		return &d
+	}
+
+	testCases := []struct {
+		name     string
+		due      *time.Time
+		by       time.Duration
+		expected time.Time
+	}{
+		{name: "Undated task counts from now", due: nil, by: 24 * time.Hour, expected: *at(11, 14, 7)},
+		{name: "Future task counts from its due date", due: at(12, 9, 0), by: 7 * 24 * time.Hour, expected: *at(19, 9, 0)},
+		{name: "Date-only task due today", due: at(10, 0, 0), by: 24 * time.Hour, expected: *at(11, 0, 0)},
+		{name: "Overdue task counts from today", due: at(3, 9, 0), by: 24 * time.Hour, expected: *at(11, 9, 0)},
+		{name: "Overdue date-only task stays date-only", due: at(3, 0, 0), by: 7 * 24 * time.Hour, expected: *at(17, 0, 0)},
+		{name: "Task due earlier today", due: at(10, 9, 0), by: 24 * time.Hour, expected: *at(11, 9, 0)},
+		{name: "Hours are added as they are", due: at(12, 9, 0), by: 3 * time.Hour, expected: *at(12, 12, 0)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, SnoozeBy(tc.due, tc.by, now))
+		})
+	}
+}
+
+func TestSnoozeByKeepsTimeAcrossDaylightSaving(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	// Clocks move forward on March 9, 2025
+	now := time.Date(2025, time.March, 8, 12, 0, 0, 0, loc)
+	due := time.Date(2025, time.March, 8, 18, 0, 0, 0, loc)
+	assert.Equal(t, time.Date(2025, time.March, 9, 18, 0, 0, 0, loc), SnoozeBy(&due, 24*time.Hour, now))
+}
@@ -0,0 +1,132 @@
+package task
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// UndatedPlacement controls where tasks without a due date end up when tasks are
+// ordered by due date.
+type UndatedPlacement string
+
+const (
+	// UndatedTop sorts tasks without a due date before all dated tasks.
+	UndatedTop UndatedPlacement = "top"
+	// UndatedBottom sorts tasks without a due date after all dated tasks.
+	UndatedBottom UndatedPlacement = "bottom"
+)
+
+// ParseUndatedPlacement converts a configuration string into an UndatedPlacement.
+// An empty string selects the default (bottom); unknown values return an error.
+func ParseUndatedPlacement(s string) (UndatedPlacement, error) {
+	switch p := UndatedPlacement(strings.ToLower(strings.TrimSpace(s))); p {
+	case UndatedTop, UndatedBottom:
+		return p, nil
+	case "":
+		return UndatedBottom, nil
+	default:
+		return UndatedBottom, fmt.Errorf("invalid undated placement %q (expected top or bottom)", s)
+	}
+}
+
+// CompareDueDates orders two tasks by due date, placing undated tasks according to
+// placement. It returns a negative number when a sorts before b, positive when after
+// and zero when they are equal.
+func CompareDueDates(a, b Task, placement UndatedPlacement) int {
+	switch {
+	case a.DueDate == nil && b.DueDate == nil:
+		return 0
+	case a.DueDate == nil:
+		if placement == UndatedTop {
+			return -1
+		}
+		return 1
+	case b.DueDate == nil:
+		if placement == UndatedTop {
+			return 1
+		}
+		return -1
+	case a.DueDate.Before(*b.DueDate):
+		return -1
+	case a.DueDate.After(*b.DueDate):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// SortByDueDate sorts tasks in place by ascending due date. The sort is stable so
+// tasks with equal due dates keep their relative order.
+func SortByDueDate(tasks []Task, placement UndatedPlacement) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return CompareDueDates(tasks[i], tasks[j], placement) < 0
+	})
+}
+
+// ListSort is an order the task list can show each section's tasks in.
+type ListSort string
+
+const (
+	// SortManual keeps the order tasks were loaded in, their display order.
+	SortManual ListSort = ""
+	// SortDue orders by ascending due date, tasks without one last.
+	SortDue ListSort = "due"
+	// SortPriority orders from high to low priority.
+	SortPriority ListSort = "priority"
+	// SortTitle orders alphabetically by title, ignoring case.
+	SortTitle ListSort = "title"
+	// SortCreated orders from oldest to newest.
+	SortCreated ListSort = "created"
+)
+
+// listSorts is the order NextListSort cycles through
+var listSorts = []ListSort{SortManual, SortDue, SortPriority, SortTitle, SortCreated}
+
+// NextListSort returns the sort after s, wrapping around to SortManual.
+func NextListSort(s ListSort) ListSort {
+	for i, candidate := range listSorts {
+		if candidate == s {
+			return listSorts[(i+1)%len(listSorts)]
+		}
+	}
+	return SortManual
+}
+
+// Label describes the sort for display.
+func (s ListSort) Label() string {
+	switch s {
+	case SortDue:
+		return "due date"
+	case SortPriority:
+		return "priority"
+	case SortTitle:
+		return "title"
+	case SortCreated:
+		return "created"
+	default:
+		return "manual"
+	}
+}
+
+// SortTasks sorts tasks in place by s. The sort is stable so tasks with equal keys
+// keep their relative order; SortManual leaves the slice untouched.
+func SortTasks(tasks []Task, s ListSort) {
+	var less func(a, b Task) bool
+	switch s {
+	case SortDue:
+		less = func(a, b Task) bool { return CompareDueDates(a, b, UndatedBottom) < 0 }
+	case SortPriority:
+		less = func(a, b Task) bool { return priorityRank(a.Priority) > priorityRank(b.Priority) }
+	case SortTitle:
+		less = func(a, b Task) bool { return strings.ToLower(a.Title) < strings.ToLower(b.Title) }
+	case SortCreated:
+		less = func(a, b Task) bool { return a.CreatedAt.Before(b.CreatedAt) }
+	default:
+		return
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return less(tasks[i], tasks[j])
+	})
+}
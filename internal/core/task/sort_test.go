@@ -0,0 +1,63 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortTasks(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2025, time.March, d, 9, 0, 0, 0, time.UTC)
+	}
+	due := func(d int) *time.Time {
+		date := day(d)
+		return &date
+	}
+
+	tasks := []Task{
+		{ID: 1, Title: "beta", Priority: PriorityLow, DueDate: due(12), CreatedAt: day(3)},
+		{ID: 2, Title: "Alpha", Priority: PriorityHigh, CreatedAt: day(1)},
+		{ID: 3, Title: "gamma", Priority: PriorityMedium, DueDate: due(10), CreatedAt: day(2)},
+		{ID: 4, Title: "alpha", Priority: PriorityHigh, CreatedAt: day(1)},
+		{ID: 5, Title: "delta", Priority: PriorityLow, DueDate: due(10), CreatedAt: day(4)},
+	}
+
+	testCases := []struct {
+		name        string
+		sort        ListSort
+		expectedIDs []int32
+	}{
+		{name: "Manual keeps the order", sort: SortManual, expectedIDs: []int32{1, 2, 3, 4, 5}},
+		{name: "Due date puts undated tasks last", sort: SortDue, expectedIDs: []int32{3, 5, 1, 2, 4}},
+		{name: "Priority goes from high to low", sort: SortPriority, expectedIDs: []int32{2, 4, 3, 1, 5}},
+		{name: "Title ignores case", sort: SortTitle, expectedIDs: []int32{2, 4, 1, 5, 3}},
+		{name: "Created goes from oldest to newest", sort: SortCreated, expectedIDs: []int32{2, 4, 3, 1, 5}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sorted := append([]Task(nil), tasks...)
+			SortTasks(sorted, tc.sort)
+
+			ids := make([]int32, len(sorted))
+			for i, task := range sorted {
+				ids[i] = task.ID
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+		})
+	}
+}
+
+func TestNextListSort(t *testing.T) {
+	s := SortManual
+	var seen []ListSort
+	for range listSorts {
+		s = NextListSort(s)
+		seen = append(seen, s)
+	}
+
+	assert.Equal(t, []ListSort{SortDue, SortPriority, SortTitle, SortCreated, SortManual}, seen)
+	assert.Equal(t, SortManual, NextListSort("unknown"))
+}
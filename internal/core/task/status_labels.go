@@ -0,0 +1,58 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StatusLabels maps each task status to the label shown to the user.
+// Labels only affect presentation; stored status values never change.
+type StatusLabels map[Status]string
+
+// DefaultStatusLabels returns the labels used when none are configured,
+// which are the status values themselves.
+func DefaultStatusLabels() StatusLabels {
+	return StatusLabels{
+		StatusTodo:       string(StatusTodo),
+		StatusInProgress: string(StatusInProgress),
+		StatusDone:       string(StatusDone),
+	}
+}
+
+// Label returns the display label for s, falling back to the status value itself.
+func (l StatusLabels) Label(s Status) string {
+	if label, ok := l[s]; ok && label != "" {
+		return label
+	}
+	return string(s)
+}
+
+// ParseStatusLabels builds a label set from a comma-separated list of status=label
+// pairs, e.g. "todo=Backlog,in-progress=Doing,done=Shipped". Statuses that are not
+// mentioned keep their default label. An empty spec yields the defaults.
+func ParseStatusLabels(spec string) (StatusLabels, error) {
+	labels := DefaultStatusLabels()
+
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(part, "=")
+		status := Status(strings.ToLower(strings.TrimSpace(key)))
+		value = strings.TrimSpace(value)
+		if !ok || value == "" {
+			return DefaultStatusLabels(), fmt.Errorf("invalid status label %q (expected status=label)", part)
+		}
+
+		switch status {
+		case StatusTodo, StatusInProgress, StatusDone:
+			labels[status] = value
+		default:
+			return DefaultStatusLabels(), fmt.Errorf("invalid status %q in status labels (expected todo, in-progress or done)", key)
+		}
+	}
+
+	return labels, nil
+}
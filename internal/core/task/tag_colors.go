@@ -0,0 +1,96 @@
+package task
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// namedTagColors are the color names accepted in tag color rules, with the hex
+// value each one stands for
+var namedTagColors = map[string]string{
+	"red":     "#E53935",
+	"orange":  "#FB8C00",
+	"yellow":  "#FFB300",
+	"green":   "#00E676",
+	"teal":    "#009688",
+	"cyan":    "#00BCD4",
+	"blue":    "#1E88E5",
+	"purple":  "#8E24AA",
+	"magenta": "#D81B60",
+	"gray":    "#909090",
+	"white":   "#FFFFFF",
+}
+
+// hexColorPattern matches #RGB and #RRGGBB colors
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// TagColorRule colors every tag whose name matches Pattern
+type TagColorRule struct {
+	Pattern string // tag name or glob such as "proj-*", matched case-insensitively
+	Color   string // hex color or ANSI color number
+}
+
+// TagColors is an ordered list of tag color rules; the first matching rule wins
+type TagColors []TagColorRule
+
+// Color returns the color of the first rule matching tag, or false when no rule matches
+func (c TagColors) Color(tag string) (string, bool) {
+	name := strings.ToLower(tag)
+	for _, rule := range c {
+		if ok, _ := path.Match(rule.Pattern, name); ok {
+			return rule.Color, true
+		}
+	}
+	return "", false
+}
+
+// ParseTagColors builds tag color rules from a comma-separated list of pattern=color
+// pairs, e.g. "urgent=red,@home=green,proj-*=#8E24AA". Colors are a name (red, orange,
+// yellow, green, teal, cyan, blue, purple, magenta, gray, white), a hex value or an ANSI
+// color number from 0 to 255. An empty spec yields no rules.
+func ParseTagColors(spec string) (TagColors, error) {
+	var colors TagColors
+
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		pattern, value, ok := strings.Cut(part, "=")
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		value = strings.TrimSpace(value)
+		if !ok || pattern == "" || value == "" {
+			return nil, fmt.Errorf("invalid tag color %q (expected tag=color)", part)
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid tag pattern %q: %v", pattern, err)
+		}
+
+		color, err := parseTagColor(value)
+		if err != nil {
+			return nil, err
+		}
+		colors = append(colors, TagColorRule{Pattern: pattern, Color: color})
+	}
+
+	return colors, nil
+}
+
+// parseTagColor validates a color name, hex value or ANSI number and returns it in
+// a form terminals understand
+func parseTagColor(value string) (string, error) {
+	if hex, ok := namedTagColors[strings.ToLower(value)]; ok {
+		return hex, nil
+	}
+	if hexColorPattern.MatchString(value) {
+		return strings.ToUpper(value), nil
+	}
+	if n, err := strconv.Atoi(value); err == nil && n >= 0 && n <= 255 {
+		return value, nil
+	}
+	return "", fmt.Errorf("invalid color %q (use a name, #RRGGBB or 0-255)", value)
+}
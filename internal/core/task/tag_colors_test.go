@@ -0,0 +1,81 @@
+package task
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTagColors(t *testing.T) {
+	testCases := []struct {
+		name           string
+		spec           string
+		expected       TagColors
+		expectedErrMsg string
+	}{
+		{
+			name:     "Empty spec",
+			spec:     "",
+			expected: nil,
+		},
+		{
+			name: "Names, hex and ANSI colors",
+			spec: "Urgent=red, @home=#0f0 ,proj-*=202",
+			expected: TagColors{
+				{Pattern: "urgent", Color: "#E53935"},
+				{Pattern: "@home", Color: "#0F0"},
+				{Pattern: "proj-*", Color: "202"},
+			},
+		},
+		{
+			name:           "Unknown color",
+			spec:           "urgent=crimson",
+			expectedErrMsg: `invalid color "crimson"`,
+		},
+		{
+			name:           "ANSI number out of range",
+			spec:           "urgent=256",
+			expectedErrMsg: `invalid color "256"`,
+		},
+		{
+			name:           "Missing color",
+			spec:           "urgent",
+			expectedErrMsg: "expected tag=color",
+		},
+		{
+			name:           "Malformed pattern",
+			spec:           "proj-[=red",
+			expectedErrMsg: "invalid tag pattern",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			colors, err := ParseTagColors(tc.spec)
+
+			if tc.expectedErrMsg != "" {
+				assert.ErrorContains(t, err, tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, colors)
+		})
+	}
+}
+
+func TestTagColorsFirstMatchWins(t *testing.T) {
+	colors, err := ParseTagColors("proj-tusk=green,proj-*=blue")
+	require.NoError(t, err)
+
+	color, ok := colors.Color("PROJ-Tusk")
+	assert.True(t, ok)
+	assert.Equal(t, "#00E676", color)
+
+	color, ok = colors.Color("proj-home")
+	assert.True(t, ok)
+	assert.Equal(t, "#1E88E5", color)
+
+	_, ok = colors.Color("errand")
+	assert.False(t, ok)
+}
@@ -0,0 +1,49 @@
+package task
+
+import "strings"
+
+// ParseTagList reads tags typed as a comma-separated list, e.g. "work, home", and
+// normalizes them with NormalizeTags
+func ParseTagList(input string) []string {
+	return NormalizeTags(strings.Split(input, ","))
+}
+
+// FormatTagList writes tags as the comma-separated list ParseTagList reads
+func FormatTagList(tags []Tag) string {
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// CompleteTagList completes the last, partly typed tag of a comma-separated list with
+// the first known tag starting with it, ignoring case. Tags already in the list are
+// not suggested again. It returns the completed list and true, or the input and false
+// when nothing matches.
+func CompleteTagList(input string, known []string) (string, bool) {
+	cut := strings.LastIndex(input, ",") + 1
+	partial := strings.TrimSpace(input[cut:])
+	if partial == "" {
+		return input, false
+	}
+
+	listed := make(map[string]bool)
+	for _, name := range ParseTagList(input[:cut]) {
+		listed[strings.ToLower(name)] = true
+	}
+
+	for _, name := range known {
+		lower := strings.ToLower(name)
+		if listed[lower] || len(name) <= len(partial) || !strings.HasPrefix(lower, strings.ToLower(partial)) {
+			continue
+		}
+
+		prefix := strings.TrimRight(input[:cut], " ")
+		if prefix != "" {
+			prefix += " "
+		}
+		return prefix + name, true
+	}
+	return input, false
+}
@@ -0,0 +1,60 @@
+package task
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTagList(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{name: "Empty input", input: "", expected: nil},
+		{name: "Commas separate tags", input: "work, home,urgent", expected: []string{"work", "home", "urgent"}},
+		{name: "Inner whitespace is kept", input: " deep work ,home", expected: []string{"deep work", "home"}},
+		{name: "Blank entries and duplicates are dropped", input: "work,, ,work, home,", expected: []string{"work", "home"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ParseTagList(tc.input))
+		})
+	}
+}
+
+func TestFormatTagList(t *testing.T) {
+	assert.Equal(t, "", FormatTagList(nil))
+	assert.Equal(t, "work, deep work", FormatTagList([]Tag{{Name: "work"}, {Name: "deep work"}}))
+	assert.Equal(t, []string{"work", "deep work"}, ParseTagList(FormatTagList([]Tag{{Name: "work"}, {Name: "deep work"}})))
+}
+
+func TestCompleteTagList(t *testing.T) {
+	known := []string{"home", "urgent", "Work", "workout"}
+
+	testCases := []struct {
+		name      string
+		input     string
+		expected  string
+		completed bool
+	}{
+		{name: "Single partial tag", input: "urg", expected: "urgent", completed: true},
+		{name: "Only the last tag is completed", input: "home,wo", expected: "home, Work", completed: true},
+		{name: "Prefix ignores case", input: "home, WORKO", expected: "home, workout", completed: true},
+		{name: "Listed tags are not suggested again", input: "work, wo", expected: "work, workout", completed: true},
+		{name: "Complete tags are left alone", input: "home", expected: "home", completed: false},
+		{name: "Nothing typed after the comma", input: "home, ", expected: "home, ", completed: false},
+		{name: "No known tag matches", input: "errand", expected: "errand", completed: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			completed, ok := CompleteTagList(tc.input, known)
+
+			assert.Equal(t, tc.completed, ok)
+			assert.Equal(t, tc.expected, completed)
+		})
+	}
+}
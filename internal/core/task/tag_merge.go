@@ -0,0 +1,73 @@
+package task
+
+import (
+	"sort"
+	"strings"
+)
+
+// TagMerge describes merging every spelling of a tag that differs only in case into
+// one canonical spelling
+type TagMerge struct {
+	Canonical string
+	Variants  []string // spellings found on the tasks, sorted; may include Canonical
+	Tasks     []Task   // tasks whose tags change, carrying their merged tags
+}
+
+// PlanTagMerge works out how merging the case variants of canonical changes tasks.
+// Subtasks are walked as well; the returned tasks have their subtasks removed.
+func PlanTagMerge(tasks []Task, canonical string) TagMerge {
+	merge := TagMerge{Canonical: canonical}
+	seen := make(map[string]bool)
+
+	stack := append([]Task{}, tasks...)
+	for len(stack) > 0 {
+		t := stack[len(stack)-1]
+		stack = append(stack[:len(stack)-1], t.SubTasks...)
+
+		for _, tag := range t.Tags {
+			if strings.EqualFold(tag.Name, canonical) && !seen[tag.Name] {
+				seen[tag.Name] = true
+				merge.Variants = append(merge.Variants, tag.Name)
+			}
+		}
+
+		tags, changed := MergeTagCase(t.Tags, canonical)
+		if !changed {
+			continue
+		}
+		t.Tags = tags
+		t.SubTasks = nil
+		merge.Tasks = append(merge.Tasks, t)
+	}
+
+	sort.Strings(merge.Variants)
+	sort.Slice(merge.Tasks, func(i, j int) bool { return merge.Tasks[i].ID < merge.Tasks[j].ID })
+	return merge
+}
+
+// MergeTagCase replaces every tag equal to canonical apart from case with canonical.
+// The first one keeps its position and later ones are dropped. It reports whether
+// the tags changed.
+func MergeTagCase(tags []Tag, canonical string) ([]Tag, bool) {
+	merged := make([]Tag, 0, len(tags))
+	found, changed := false, false
+
+	for _, tag := range tags {
+		if !strings.EqualFold(tag.Name, canonical) {
+			merged = append(merged, tag)
+			continue
+		}
+		if found || tag.Name != canonical {
+			changed = true
+		}
+		if !found {
+			merged = append(merged, Tag{Name: canonical})
+			found = true
+		}
+	}
+
+	if !changed {
+		return tags, false
+	}
+	return merged, true
+}
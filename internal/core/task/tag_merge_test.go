@@ -0,0 +1,87 @@
+package task
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeTagCase(t *testing.T) {
+	testCases := []struct {
+		name            string
+		tags            []string
+		expected        []string
+		expectedChanged bool
+	}{
+		{
+			name:     "Already canonical",
+			tags:     []string{"Work", "home"},
+			expected: []string{"Work", "home"},
+		},
+		{
+			name:            "Other spelling is replaced in place",
+			tags:            []string{"home", "work", "x"},
+			expected:        []string{"home", "Work", "x"},
+			expectedChanged: true,
+		},
+		{
+			name:            "Later spellings are dropped",
+			tags:            []string{"WORK", "home", "Work", "work"},
+			expected:        []string{"Work", "home"},
+			expectedChanged: true,
+		},
+		{
+			name:     "No tags",
+			tags:     nil,
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			merged, changed := MergeTagCase(tagList(tc.tags...), "Work")
+
+			assert.Equal(t, tc.expectedChanged, changed)
+			assert.Equal(t, tc.expected, tagNames(merged))
+		})
+	}
+}
+
+func TestPlanTagMerge(t *testing.T) {
+	parentID := int32(1)
+	root := Task{ID: 1, Title: "Root", Tags: tagList("work")}
+	root.SubTasks = []Task{
+		{ID: 3, ParentID: &parentID, Tags: tagList("Work")},
+		{ID: 2, ParentID: &parentID, Tags: tagList("WORK", "work")},
+	}
+
+	merge := PlanTagMerge([]Task{root, {ID: 4, Tags: tagList("home")}}, "Work")
+
+	assert.Equal(t, "Work", merge.Canonical)
+	assert.Equal(t, []string{"WORK", "Work", "work"}, merge.Variants)
+	if assert.Len(t, merge.Tasks, 2) {
+		assert.Equal(t, int32(1), merge.Tasks[0].ID)
+		assert.Nil(t, merge.Tasks[0].SubTasks)
+		assert.Equal(t, []string{"Work"}, tagNames(merge.Tasks[0].Tags))
+		assert.Equal(t, int32(2), merge.Tasks[1].ID)
+		assert.Equal(t, []string{"Work"}, tagNames(merge.Tasks[1].Tags))
+	}
+	// The caller's tasks are left untouched
+	assert.Equal(t, []string{"work"}, tagNames(root.Tags))
+}
+
+func tagList(names ...string) []Tag {
+	var tags []Tag
+	for _, name := range names {
+		tags = append(tags, Tag{Name: name})
+	}
+	return tags
+}
+
+func tagNames(tags []Tag) []string {
+	var names []string
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
@@ -0,0 +1,61 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// NormalizeTags trims tag names, drops empty ones and removes duplicates while
+// preserving order; the first occurrence of a tag wins. Every interface creating or
+// updating tasks goes through it, so tags are handled the same everywhere.
+func NormalizeTags(names []string) []string {
+	return normalizeTags(names, false)
+}
+
+// NormalizeTagsLowercase normalizes tags like NormalizeTags and lowercases them, so
+// "Work", "work" and " work " all become the single tag "work".
+func NormalizeTagsLowercase(names []string) []string {
+	return normalizeTags(names, true)
+}
+
+// ValidateTag reports why name cannot be used as a tag. Commas separate tags in typed
+// lists, see ParseTagList, and control characters would garble every listing, so
+// neither may appear in a tag. Surrounding whitespace is ignored, as NormalizeTags
+// trims it anyway.
+func ValidateTag(name string) error {
+	for _, r := range strings.TrimSpace(name) {
+		if r == ',' {
+			return fmt.Errorf("tag %q must not contain a comma", name)
+		}
+		if unicode.IsControl(r) {
+			return fmt.Errorf("tag %q must not contain control characters", name)
+		}
+	}
+	return nil
+}
+
+// normalizeTags implements NormalizeTags and NormalizeTagsLowercase
+func normalizeTags(names []string, lowercase bool) []string {
+	var tags []string
+	seen := make(map[string]bool, len(names))
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		if lowercase {
+			name = strings.ToLower(name)
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		tags = append(tags, name)
+	}
+
+	return tags
+}
@@ -0,0 +1,112 @@
+package task
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	testCases := []struct {
+		name          string
+		names         []string
+		expected      []string
+		expectedLower []string
+	}{
+		{
+			name:          "No tags",
+			names:         nil,
+			expected:      nil,
+			expectedLower: nil,
+		},
+		{
+			name:          "Surrounding whitespace is trimmed",
+			names:         []string{"  work", "home\t", "\nurgent\n"},
+			expected:      []string{"work", "home", "urgent"},
+			expectedLower: []string{"work", "home", "urgent"},
+		},
+		{
+			name:          "Inner whitespace is kept",
+			names:         []string{" deep work "},
+			expected:      []string{"deep work"},
+			expectedLower: []string{"deep work"},
+		},
+		{
+			name:          "Empty and blank tags are dropped",
+			names:         []string{"", "work", "   ", "\t"},
+			expected:      []string{"work"},
+			expectedLower: []string{"work"},
+		},
+		{
+			name:          "Only blank tags",
+			names:         []string{"", " "},
+			expected:      nil,
+			expectedLower: nil,
+		},
+		{
+			name:          "Duplicates keep the first occurrence",
+			names:         []string{"work", "home", "work", " home "},
+			expected:      []string{"work", "home"},
+			expectedLower: []string{"work", "home"},
+		},
+		{
+			name:          "Unicode tags are kept as typed",
+			names:         []string{" café", "日本", "🚀 launch", "café "},
+			expected:      []string{"café", "日本", "🚀 launch"},
+			expectedLower: []string{"café", "日本", "🚀 launch"},
+		},
+		{
+			name:          "Unicode case variants are lowercased too",
+			names:         []string{"Ärger", "ärger", "ΣΟΦΙΑ", "σοφια"},
+			expected:      []string{"Ärger", "ärger", "ΣΟΦΙΑ", "σοφια"},
+			expectedLower: []string{"ärger", "σοφια"},
+		},
+		{
+			name:          "Case and whitespace variants become one tag",
+			names:         []string{"Work", "work", " work "},
+			expected:      []string{"Work", "work"},
+			expectedLower: []string{"work"},
+		},
+		{
+			name:          "Lowercased duplicates keep the first position",
+			names:         []string{"Home", "WORK", "home", "Work"},
+			expected:      []string{"Home", "WORK", "home", "Work"},
+			expectedLower: []string{"home", "work"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, NormalizeTags(tc.names))
+			assert.Equal(t, tc.expectedLower, NormalizeTagsLowercase(tc.names))
+		})
+	}
+}
+
+func TestValidateTag(t *testing.T) {
+	testCases := []struct {
+		name           string
+		tag            string
+		expectedErrMsg string
+	}{
+		{name: "Plain tag", tag: "work"},
+		{name: "Inner spaces and unicode", tag: "deep work ☕"},
+		{name: "Surrounding whitespace is trimmed first", tag: " home\n"},
+		{name: "Empty tag is dropped later, not rejected", tag: ""},
+		{name: "Comma", tag: "work,home", expectedErrMsg: "must not contain a comma"},
+		{name: "Tab inside", tag: "deep\twork", expectedErrMsg: "must not contain control characters"},
+		{name: "Escape character", tag: "red\x1b[31m", expectedErrMsg: "must not contain control characters"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateTag(tc.tag)
+
+			if tc.expectedErrMsg != "" {
+				assert.ErrorContains(t, err, tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
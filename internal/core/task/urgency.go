@@ -0,0 +1,125 @@
+package task
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// UrgencyWeights tune how UrgencyScore rates tasks. With the defaults, overdue tasks
+// always outrank tasks that are not yet due, priority breaks ties within a similar
+// timeframe, and tasks due sooner score higher.
+type UrgencyWeights struct {
+	Overdue      float64 // added once a task is overdue
+	PerDayLate   float64 // added per day a task is overdue, up to MaxDaysLate days
+	MaxDaysLate  float64
+	DueHorizon   float64 // days ahead after which the due date stops adding urgency
+	PerDayCloser float64 // added per day a task is due before the horizon
+	Priority     float64 // added per priority step, low counting as one step
+}
+
+// DefaultUrgencyWeights returns the weights used when none are configured.
+func DefaultUrgencyWeights() UrgencyWeights {
+	return UrgencyWeights{
+		Overdue:      100,
+		PerDayLate:   2,
+		MaxDaysLate:  30,
+		DueHorizon:   14,
+		PerDayCloser: 2,
+		Priority:     10,
+	}
+}
+
+// ParseUrgencyWeights reads weights from a comma-separated list of key=number pairs,
+// e.g. "priority=30,overdue=50". Keys are overdue, per_day_late, max_days_late,
+// due_horizon, per_day_closer and priority; missing keys keep their default and an
+// empty spec yields the defaults.
+func ParseUrgencyWeights(spec string) (UrgencyWeights, error) {
+	w := DefaultUrgencyWeights()
+	fields := map[string]*float64{
+		"overdue":        &w.Overdue,
+		"per_day_late":   &w.PerDayLate,
+		"max_days_late":  &w.MaxDaysLate,
+		"due_horizon":    &w.DueHorizon,
+		"per_day_closer": &w.PerDayCloser,
+		"priority":       &w.Priority,
+	}
+
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(part, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+		field, known := fields[key]
+		if !known {
+			return DefaultUrgencyWeights(), fmt.Errorf("unknown urgency weight %q (expected overdue, per_day_late, max_days_late, due_horizon, per_day_closer or priority)", key)
+		}
+		n, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if !ok || err != nil {
+			return DefaultUrgencyWeights(), fmt.Errorf("invalid urgency weight %q (expected key=number)", part)
+		}
+		if n < 0 {
+			return DefaultUrgencyWeights(), fmt.Errorf("urgency weight %s must not be negative", key)
+		}
+		*field = n
+	}
+
+	return w, nil
+}
+
+// Score rates how urgently a task needs attention at the given time. Higher scores
+// are more urgent; completed tasks score zero. Overdue follows IsOverdue, so a task
+// due today without a time is due now rather than late.
+func (w UrgencyWeights) Score(t Task, now time.Time) float64 {
+	if t.IsCompleted || t.Status == StatusDone {
+		return 0
+	}
+
+	score := w.Priority * float64(priorityRank(t.Priority))
+
+	if t.DueDate != nil {
+		days := t.DueDate.Sub(now).Hours() / 24
+		if IsOverdue(*t.DueDate, now) {
+			score += w.Overdue + w.PerDayLate*min(max(-days, 0), w.MaxDaysLate)
+		} else if days = max(days, 0); days < w.DueHorizon {
+			score += w.PerDayCloser * (w.DueHorizon - days)
+		}
+	}
+
+	return score
+}
+
+// Sort orders tasks from most to least urgent, keeping the existing order of tasks
+// with equal scores.
+func (w UrgencyWeights) Sort(tasks []Task, now time.Time) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return w.Score(tasks[i], now) > w.Score(tasks[j], now)
+	})
+}
+
+// UrgencyScore rates a task with the default weights, see UrgencyWeights.Score.
+func UrgencyScore(t Task, now time.Time) float64 {
+	return DefaultUrgencyWeights().Score(t, now)
+}
+
+// SortByUrgency orders tasks by the default weights, see UrgencyWeights.Sort.
+func SortByUrgency(tasks []Task, now time.Time) {
+	DefaultUrgencyWeights().Sort(tasks, now)
+}
+
+// priorityRank maps a priority to 1 (low) through 3 (high)
+func priorityRank(p Priority) int {
+	switch p {
+	case PriorityHigh:
+		return 3
+	case PriorityMedium:
+		return 2
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,133 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUrgencyOrder(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 14, 0, 0, 0, time.UTC)
+	at := func(day, hour int) *time.Time {
+		d := time.Date(2025, time.March, day, hour, 0, 0, 0, time.UTC)
+		return &d
+	}
+
+	testCases := []struct {
+		name        string
+		weights     UrgencyWeights
+		tasks       []Task
+		expectedIDs []int32
+	}{
+		{
+			name:    "Overdue low outranks due today high",
+			weights: DefaultUrgencyWeights(),
+			tasks: []Task{
+				{ID: 1, Priority: PriorityHigh, DueDate: at(10, 18)},
+				{ID: 2, Priority: PriorityLow, DueDate: at(9, 0)},
+			},
+			expectedIDs: []int32{2, 1},
+		},
+		{
+			name:    "Longer overdue comes first",
+			weights: DefaultUrgencyWeights(),
+			tasks: []Task{
+				{ID: 1, DueDate: at(8, 0)},
+				{ID: 2, DueDate: at(3, 0)},
+			},
+			expectedIDs: []int32{2, 1},
+		},
+		{
+			name:    "Today without a time is not overdue",
+			weights: DefaultUrgencyWeights(),
+			tasks: []Task{
+				{ID: 1, Priority: PriorityLow, DueDate: at(10, 0)},
+				{ID: 2, Priority: PriorityLow, DueDate: at(10, 9)},
+			},
+			expectedIDs: []int32{2, 1},
+		},
+		{
+			name:    "Priority breaks ties on the same day",
+			weights: DefaultUrgencyWeights(),
+			tasks: []Task{
+				{ID: 1, Priority: PriorityLow, DueDate: at(12, 0)},
+				{ID: 2, Priority: PriorityHigh, DueDate: at(12, 0)},
+				{ID: 3, Priority: PriorityMedium, DueDate: at(12, 0)},
+			},
+			expectedIDs: []int32{2, 3, 1},
+		},
+		{
+			name:    "Sooner due date wins at equal priority",
+			weights: DefaultUrgencyWeights(),
+			tasks: []Task{
+				{ID: 1, DueDate: at(20, 0)},
+				{ID: 2, DueDate: at(11, 0)},
+			},
+			expectedIDs: []int32{2, 1},
+		},
+		{
+			name:    "Completed tasks sink to the bottom",
+			weights: DefaultUrgencyWeights(),
+			tasks: []Task{
+				{ID: 1, Status: StatusDone, IsCompleted: true, Priority: PriorityHigh, DueDate: at(1, 0)},
+				{ID: 2, Priority: PriorityLow, DueDate: at(30, 0)},
+			},
+			expectedIDs: []int32{2, 1},
+		},
+		{
+			name:    "Heavier priority weight lets high priority beat overdue",
+			weights: UrgencyWeights{Overdue: 10, PerDayLate: 1, MaxDaysLate: 30, DueHorizon: 14, PerDayCloser: 1, Priority: 50},
+			tasks: []Task{
+				{ID: 1, Priority: PriorityLow, DueDate: at(9, 0)},
+				{ID: 2, Priority: PriorityHigh, DueDate: at(20, 0)},
+			},
+			expectedIDs: []int32{2, 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.weights.Sort(tc.tasks, now)
+
+			ids := make([]int32, len(tc.tasks))
+			for i, task := range tc.tasks {
+				ids[i] = task.ID
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+		})
+	}
+}
+
+func TestParseUrgencyWeights(t *testing.T) {
+	custom := DefaultUrgencyWeights()
+	custom.Priority = 30
+	custom.Overdue = 50.5
+
+	testCases := []struct {
+		name           string
+		spec           string
+		expected       UrgencyWeights
+		expectedErrMsg string
+	}{
+		{name: "Empty spec uses the defaults", spec: "", expected: DefaultUrgencyWeights()},
+		{name: "Overrides keep the other defaults", spec: " Priority=30, overdue=50.5 ", expected: custom},
+		{name: "Unknown key", spec: "urgency=3", expected: DefaultUrgencyWeights(), expectedErrMsg: `unknown urgency weight "urgency"`},
+		{name: "Not a number", spec: "priority=high", expected: DefaultUrgencyWeights(), expectedErrMsg: `invalid urgency weight "priority=high"`},
+		{name: "Missing value", spec: "priority", expected: DefaultUrgencyWeights(), expectedErrMsg: `invalid urgency weight "priority"`},
+		{name: "Negative weight", spec: "overdue=-1", expected: DefaultUrgencyWeights(), expectedErrMsg: "must not be negative"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			weights, err := ParseUrgencyWeights(tc.spec)
+
+			if tc.expectedErrMsg != "" {
+				assert.ErrorContains(t, err, tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expected, weights)
+		})
+	}
+}
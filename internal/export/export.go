@@ -0,0 +1,151 @@
+// Package export renders tasks into portable formats for use outside of Tusk.
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/newbpydev/tusk/internal/core/task"
+)
+
+// Format identifies an export format
+type Format string
+
+const (
+	// FormatJSON writes tasks as an indented JSON array
+	FormatJSON Format = "json"
+	// FormatCSV writes one row per task with a header row
+	FormatCSV Format = "csv"
+	// FormatMarkdown writes a nested checklist, see WriteMarkdown
+	FormatMarkdown Format = "markdown"
+	// FormatICS writes tasks with due dates as calendar events, see ExportICS
+	FormatICS Format = "ics"
+)
+
+// csvDateLayout is the layout used for due dates in CSV output
+const csvDateLayout = "2006-01-02"
+
+// ParseFormat converts a user supplied format name into a Format
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(strings.TrimSpace(s))); f {
+	case FormatJSON, FormatCSV, FormatMarkdown, FormatICS:
+		return f, nil
+	case "md":
+		return FormatMarkdown, nil
+	default:
+		return "", fmt.Errorf("unsupported export format %q (expected json, csv, markdown or ics)", s)
+	}
+}
+
+// Extension returns the file extension conventionally used for the format
+func (f Format) Extension() string {
+	if f == FormatMarkdown {
+		return ".md"
+	}
+	return "." + string(f)
+}
+
+// Write renders tasks to w in the given format
+func Write(w io.Writer, format Format, tasks []task.Task) error {
+	switch format {
+	case FormatJSON:
+		return WriteJSON(w, tasks)
+	case FormatCSV:
+		return WriteCSV(w, tasks)
+	case FormatMarkdown:
+		return WriteMarkdown(w, tasks)
+	case FormatICS:
+		return WriteICS(w, tasks)
+	default:
+		return fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+// WriteJSON writes tasks as an indented JSON array. Subtasks are kept nested.
+func WriteJSON(w io.Writer, tasks []task.Task) error {
+	if tasks == nil {
+		tasks = []task.Task{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(tasks)
+}
+
+// WriteCSV writes tasks and all of their subtasks as flat CSV rows.
+// Tags are joined with semicolons and missing values are left empty.
+func WriteCSV(w io.Writer, tasks []task.Task) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write([]string{"id", "title", "status", "priority", "due_date", "tags", "parent_id"}); err != nil {
+		return err
+	}
+
+	for _, t := range Flatten(tasks) {
+		dueDate := ""
+		if t.DueDate != nil {
+			dueDate = t.DueDate.Format(csvDateLayout)
+		}
+
+		parentID := ""
+		if t.ParentID != nil {
+			parentID = strconv.Itoa(int(*t.ParentID))
+		}
+
+		tags := make([]string, len(t.Tags))
+		for i, tag := range t.Tags {
+			tags[i] = tag.Name
+		}
+
+		record := []string{
+			strconv.Itoa(int(t.ID)),
+			t.Title,
+			string(t.Status),
+			string(t.Priority),
+			dueDate,
+			strings.Join(tags, ";"),
+			parentID,
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// Flatten returns the tasks and their subtasks in depth-first order. Each task
+// appears once; the returned copies have their SubTasks cleared.
+func Flatten(tasks []task.Task) []task.Task {
+	var flat []task.Task
+	seen := make(map[int32]bool)
+
+	// Use an explicit stack so very deep trees cannot overflow the call stack
+	stack := make([]task.Task, 0, len(tasks))
+	for i := len(tasks) - 1; i >= 0; i-- {
+		stack = append(stack, tasks[i])
+	}
+
+	for len(stack) > 0 {
+		t := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if seen[t.ID] {
+			continue
+		}
+		seen[t.ID] = true
+
+		for i := len(t.SubTasks) - 1; i >= 0; i-- {
+			stack = append(stack, t.SubTasks[i])
+		}
+
+		t.SubTasks = nil
+		flat = append(flat, t)
+	}
+
+	return flat
+}
@@ -0,0 +1,121 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/newbpydev/tusk/internal/core/task"
+)
+
+const (
+	// icsDateLayout is the layout of all-day dates in iCalendar output
+	icsDateLayout = "20060102"
+	// icsTimeLayout is the layout of UTC date-times in iCalendar output
+	icsTimeLayout = "20060102T150405Z"
+	// icsLineLimit is the maximum length of a content line in octets, line break excluded
+	icsLineLimit = 75
+)
+
+// WriteICS writes tasks as an iCalendar file, see ExportICS
+func WriteICS(w io.Writer, tasks []task.Task) error {
+	cal, err := ExportICS(tasks)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, cal)
+	return err
+}
+
+// ExportICS renders every task with a due date, subtasks included, as a VEVENT of an
+// iCalendar (RFC 5545) file; tasks without due dates are skipped. A due date without
+// a time of day becomes an all-day event. Events are used rather than VTODOs because
+// Google Calendar ignores the latter, so completed tasks get a check mark in their
+// summary and every event carries the task status in its description.
+func ExportICS(tasks []task.Task) (string, error) {
+	var b strings.Builder
+	stamp := time.Now().UTC().Format(icsTimeLayout)
+
+	writeICSLine(&b, "BEGIN:VCALENDAR")
+	writeICSLine(&b, "VERSION:2.0")
+	writeICSLine(&b, "PRODID:-//Tusk//Tusk//EN")
+	writeICSLine(&b, "CALSCALE:GREGORIAN")
+
+	for _, t := range Flatten(tasks) {
+		if t.DueDate == nil {
+			continue
+		}
+		due := *t.DueDate
+
+		summary := t.Title
+		if t.IsCompleted {
+			summary = "✓ " + summary
+		}
+
+		description := fmt.Sprintf("Status: %s\nPriority: %s", t.Status, t.Priority)
+		if t.Description != nil && strings.TrimSpace(*t.Description) != "" {
+			description = strings.TrimSpace(*t.Description) + "\n\n" + description
+		}
+
+		writeICSLine(&b, "BEGIN:VEVENT")
+		writeICSLine(&b, fmt.Sprintf("UID:task-%d@tusk", t.ID))
+		writeICSLine(&b, "DTSTAMP:"+stamp)
+		if !task.HasDueTime(due) {
+			writeICSLine(&b, "DTSTART;VALUE=DATE:"+due.Format(icsDateLayout))
+			writeICSLine(&b, "DTEND;VALUE=DATE:"+due.AddDate(0, 0, 1).Format(icsDateLayout))
+		} else {
+			writeICSLine(&b, "DTSTART:"+due.UTC().Format(icsTimeLayout))
+		}
+		writeICSLine(&b, "SUMMARY:"+escapeICSText(summary))
+		writeICSLine(&b, "DESCRIPTION:"+escapeICSText(description))
+		if len(t.Tags) > 0 {
+			categories := make([]string, len(t.Tags))
+			for i, tag := range t.Tags {
+				categories[i] = escapeICSText(tag.Name)
+			}
+			writeICSLine(&b, "CATEGORIES:"+strings.Join(categories, ","))
+		}
+		if !t.UpdatedAt.IsZero() {
+			writeICSLine(&b, "LAST-MODIFIED:"+t.UpdatedAt.UTC().Format(icsTimeLayout))
+		}
+		writeICSLine(&b, "TRANSP:TRANSPARENT")
+		writeICSLine(&b, "END:VEVENT")
+	}
+
+	writeICSLine(&b, "END:VCALENDAR")
+	return b.String(), nil
+}
+
+// escapeICSText escapes a value of the iCalendar TEXT type
+func escapeICSText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(s)
+}
+
+// writeICSLine writes a content line ending in CRLF, folding it into continuation
+// lines starting with a space so that no line exceeds 75 octets. Lines are only
+// split between characters, never inside a multi-byte UTF-8 sequence.
+func writeICSLine(b *strings.Builder, line string) {
+	limit := icsLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// The leading space of a continuation line counts towards its length
+		limit = icsLineLimit - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
@@ -0,0 +1,77 @@
+package export
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/newbpydev/tusk/internal/core/task"
+)
+
+// markdownDateLayout and markdownTimeLayout are the layouts used for due dates in
+// Markdown output, without and with a time of day
+const (
+	markdownDateLayout = "2006-01-02"
+	markdownTimeLayout = "2006-01-02 15:04"
+)
+
+// markdownIndent indents a subtask one level below its parent
+const markdownIndent = "  "
+
+// WriteMarkdown writes tasks as a nested Markdown checklist: one "- [ ]" item per
+// task ("- [x]" once completed), subtasks indented below their parent and due dates
+// in parentheses. Each task appears once, however deep the tree.
+func WriteMarkdown(w io.Writer, tasks []task.Task) error {
+	type item struct {
+		task  task.Task
+		depth int
+	}
+
+	bw := bufio.NewWriter(w)
+	seen := make(map[int32]bool)
+
+	// Use an explicit stack so very deep trees cannot overflow the call stack
+	stack := make([]item, 0, len(tasks))
+	for i := len(tasks) - 1; i >= 0; i-- {
+		stack = append(stack, item{task: tasks[i]})
+	}
+
+	for len(stack) > 0 {
+		it := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if seen[it.task.ID] {
+			continue
+		}
+		seen[it.task.ID] = true
+
+		for i := len(it.task.SubTasks) - 1; i >= 0; i-- {
+			stack = append(stack, item{task: it.task.SubTasks[i], depth: it.depth + 1})
+		}
+
+		bw.WriteString(strings.Repeat(markdownIndent, it.depth))
+		bw.WriteString(markdownLine(it.task))
+		bw.WriteByte('\n')
+	}
+
+	return bw.Flush()
+}
+
+// markdownLine renders a single task as a Markdown checklist item
+func markdownLine(t task.Task) string {
+	check := "- [ ] "
+	if t.IsCompleted {
+		check = "- [x] "
+	}
+
+	// A line break in the title would end the list item early
+	line := check + strings.Join(strings.Fields(t.Title), " ")
+	if t.DueDate != nil {
+		layout := markdownDateLayout
+		if task.HasDueTime(*t.DueDate) {
+			layout = markdownTimeLayout
+		}
+		line += " (due " + t.DueDate.Format(layout) + ")"
+	}
+	return line
+}
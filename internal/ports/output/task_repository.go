@@ -2,6 +2,7 @@ package output
 
 import (
 	"context"
+	"time"
 
 	"github.com/newbpydev/tusk/internal/core/task"
 )
@@ -24,11 +25,21 @@ type TaskPriorityCounts struct {
 // TaskRepository is an interface that defines the methods for interacting with tasks in the database.
 // It provides methods for creating, retrieving, updating, and deleting tasks.
 type TaskRepository interface {
+	// WithTx runs fn with a repository whose methods all run in a single transaction,
+	// committing it when fn returns nil and rolling it back otherwise. Calling WithTx on
+	// the repository passed to fn joins the running transaction.
+	WithTx(ctx context.Context, fn func(repo TaskRepository) error) error
+
 	// Create creates a new task in the database.
 	// It returns the created task or an error if the task could not be created.
 	// parentID = nil means root task
 	Create(ctx context.Context, t task.Task) (task.Task, error)
 
+	// CreateTree creates a task and its nested SubTasks in a single transaction, so either
+	// the whole tree is created or none of it. Subtasks are placed under their created
+	// parent, whatever their ParentID. It returns the root with its subtasks, all with IDs.
+	CreateTree(ctx context.Context, root task.Task) (task.Task, error)
+
 	// Update updates an existing task in the database.
 	// It returns an error if the task could not be updated.
 	Update(ctx context.Context, t task.Task) error
@@ -37,14 +48,33 @@ type TaskRepository interface {
 	// It returns an error if the task could not be deleted.
 	Delete(ctx context.Context, id int64) error
 
-	// GetByID retrieves a task by its ID from the database.
+	// Archive hides a task and its subtasks from every listing without deleting them.
+	// It returns an error if the task could not be found or is already archived.
+	Archive(ctx context.Context, id int64) error
+
+	// Restore brings back an archived task together with the subtasks archived along with it.
+	// It returns an error if the task could not be found or is not archived.
+	Restore(ctx context.Context, id int64) error
+
+	// GetByID retrieves a task by its ID from the database, including its dependencies.
 	// It returns the task or an error if the task could not be found.
 	GetByID(ctx context.Context, id int64) (task.Task, error)
 
-	// ListRootTasks retrieves all root tasks for a user from the database.
+	// ListRootTasks retrieves all root tasks for a user from the database, archived tasks excluded.
 	// It returns a list of tasks or an error if the tasks could not be retrieved.
 	ListRootTasks(ctx context.Context, userID int64) ([]task.Task, error)
 
+	// ListRootTasksPaged retrieves at most limit root tasks for a user, skipping the first offset.
+	// Pages are ordered by display order then ID, so the order is stable across pages.
+	ListRootTasksPaged(ctx context.Context, userID int64, limit, offset int) ([]task.Task, error)
+
+	// CountRootTasks returns how many root tasks a user has, archived tasks excluded.
+	CountRootTasks(ctx context.Context, userID int64) (int, error)
+
+	// ListArchived retrieves a user's archived tasks, most recently archived first.
+	// Subtasks archived along with their parent are left out; they come back with it.
+	ListArchived(ctx context.Context, userID int64) ([]task.Task, error)
+
 	// ListSubTasks retrieves all subtasks for a task from the database.
 	// It returns a list of tasks or an error if the tasks could not be retrieved.
 	ListSubTasks(ctx context.Context, parentID int64) ([]task.Task, error)
@@ -53,21 +83,94 @@ type TaskRepository interface {
 	// It returns the task and its subtasks or an error if the task could not be found.
 	GetTaskTree(ctx context.Context, rootID int64) (task.Task, error)
 
+	// GetTaskTrees retrieves the trees of several root tasks with a single query, in the
+	// order of rootIDs. Roots that could not be found are left out.
+	GetTaskTrees(ctx context.Context, rootIDs []int64) ([]task.Task, error)
+
+	// GetTaskForest retrieves the trees of all of a user's root tasks with a single query,
+	// in the order of ListRootTasks. Archived tasks are left out.
+	GetTaskForest(ctx context.Context, userID int64) ([]task.Task, error)
+
 	// ReorderTask reorders a task in the database.
 	// It returns an error if the task could not be reordered.
 	ReorderTask(ctx context.Context, taskID int64, newOrder int) error
 
+	// SwapTaskOrder exchanges the display orders of two tasks in a single transaction.
+	// It returns an error if either task could not be found.
+	SwapTaskOrder(ctx context.Context, aID, bID int64) error
+
+	// ReparentTask moves a task under a new parent, or to the root level when
+	// newParentID is nil. The task's subtasks move along with it.
+	// It returns an error if the task could not be found.
+	ReparentTask(ctx context.Context, taskID int64, newParentID *int64) error
+
+	// SetExternalRef anchors a task to an event in an external calendar, or detaches it
+	// when both values are nil. An event can be attached to at most one task.
+	// It returns an error if the task could not be found or the event is already taken.
+	SetExternalRef(ctx context.Context, taskID int64, source, externalID *string) error
+
+	// GetByExternalID retrieves the task anchored to an external calendar event.
+	// It returns an error if no task is attached to the event.
+	GetByExternalID(ctx context.Context, source, externalID string) (task.Task, error)
+
+	// AddDependency records that taskID cannot be completed before dependsOnID is done.
+	// Adding an existing dependency again is a no-op.
+	AddDependency(ctx context.Context, taskID, dependsOnID int64) error
+
+	// RemoveDependency drops a dependency between two tasks.
+	// It returns an error if the dependency does not exist.
+	RemoveDependency(ctx context.Context, taskID, dependsOnID int64) error
+
+	// ListDependencies retrieves the IDs of the tasks that taskID depends on.
+	ListDependencies(ctx context.Context, taskID int64) ([]int32, error)
+
+	// ListBlockedTaskIDs retrieves the IDs of a user's tasks that depend on a task that
+	// is neither completed nor archived.
+	ListBlockedTaskIDs(ctx context.Context, userID int64) ([]int32, error)
+
+	// PinToDay pins a task to the top of its day in the agenda. Pinning a pinned
+	// task again makes it the most recently pinned one.
+	PinToDay(ctx context.Context, taskID int64) error
+
+	// UnpinFromDay removes a task's day pin.
+	// It returns an error if the task is not pinned.
+	UnpinFromDay(ctx context.Context, taskID int64) error
+
+	// ListDayPinnedTaskIDs retrieves the IDs of a user's pinned tasks that are not
+	// archived, most recently pinned first.
+	ListDayPinnedTaskIDs(ctx context.Context, userID int64) ([]int32, error)
+
+	// AddNote appends a note to a task, owned by the task's user.
+	// It returns an error if the task does not exist.
+	AddNote(ctx context.Context, taskID int64, body string) (task.TaskNote, error)
+
+	// ListNotes retrieves the notes of a task, newest first.
+	ListNotes(ctx context.Context, taskID int64) ([]task.TaskNote, error)
+
+	// NormalizeDisplayOrder rewrites the display orders of a user's tasks to a clean
+	// 0..n sequence within each parent group, preserving the current order.
+	// It returns the number of tasks whose order changed.
+	NormalizeDisplayOrder(ctx context.Context, userID int64) (int64, error)
+
 	// Search and filtering methods
 
 	// SearchTasksByTitle searches for tasks with titles matching the given pattern.
 	// Pattern can include % for wildcard matching.
 	SearchTasksByTitle(ctx context.Context, userID int64, titlePattern string) ([]task.Task, error)
 
-	// SearchTasksByTag searches for tasks that have the specified tag.
+	// SearchTasksByText searches for tasks whose title, description or tags contain the
+	// query, ignoring case. The query is matched as plain text, without wildcards.
+	SearchTasksByText(ctx context.Context, userID int64, query string) ([]task.Task, error)
+
+	// SearchTasksByTag searches for tasks that have the specified tag, ignoring case.
 	SearchTasksByTag(ctx context.Context, userID int64, tag string) ([]task.Task, error)
 
-	// ListTasksByStatus retrieves all tasks for a user with the given status.
-	ListTasksByStatus(ctx context.Context, userID int64, status task.Status) ([]task.Task, error)
+	// ListTasksByStatuses retrieves all tasks for a user whose status is any of the given statuses.
+	ListTasksByStatuses(ctx context.Context, userID int64, statuses []task.Status) ([]task.Task, error)
+
+	// ListTasksFiltered retrieves the user's tasks matching every set criterion of the
+	// filter in a single query, sorted by due date with undated tasks last.
+	ListTasksFiltered(ctx context.Context, userID int64, filter task.TaskFilter) ([]task.Task, error)
 
 	// ListTasksByPriority retrieves all tasks for a user with the given priority.
 	ListTasksByPriority(ctx context.Context, userID int64, priority task.Priority) ([]task.Task, error)
@@ -77,12 +180,15 @@ type TaskRepository interface {
 	// ListTasksDueToday retrieves all incomplete tasks due on the current day.
 	ListTasksDueToday(ctx context.Context, userID int64) ([]task.Task, error)
 
-	// ListTasksDueSoon retrieves all incomplete tasks due within the next 7 days.
-	ListTasksDueSoon(ctx context.Context, userID int64) ([]task.Task, error)
+	// ListTasksDueSoon retrieves all incomplete tasks due between today and days from now.
+	ListTasksDueSoon(ctx context.Context, userID int64, days int) ([]task.Task, error)
 
 	// ListOverdueTasks retrieves all incomplete tasks that are past their due date.
 	ListOverdueTasks(ctx context.Context, userID int64) ([]task.Task, error)
 
+	// ListIncompleteDatedTasks retrieves all incomplete tasks that have a due date, without subtasks.
+	ListIncompleteDatedTasks(ctx context.Context, userID int64) ([]task.Task, error)
+
 	// Statistics and metrics
 
 	// GetTaskCountsByStatus retrieves counts of tasks grouped by status.
@@ -94,13 +200,34 @@ type TaskRepository interface {
 	// GetRecentlyCompletedTasks retrieves recently completed tasks, limited by count.
 	GetRecentlyCompletedTasks(ctx context.Context, userID int64, limit int32) ([]task.Task, error)
 
+	// ReplaceTaskMetrics replaces the cached metrics of a user's tasks with the given snapshot.
+	ReplaceTaskMetrics(ctx context.Context, userID int64, metrics []task.CachedMetrics) error
+
+	// ListTaskMetrics retrieves the cached metrics of a user's tasks.
+	ListTaskMetrics(ctx context.Context, userID int64) ([]task.CachedMetrics, error)
+
 	// Batch operations
 
 	// BulkUpdateTaskStatus updates the status of multiple tasks at once.
 	BulkUpdateTaskStatus(ctx context.Context, taskIDs []int32, status task.Status, isCompleted bool) error
 
+	// PostponeTasks moves the due dates of the given incomplete tasks forward by days in a
+	// single statement, so either every task moves or none does.
+	// It returns the number of tasks moved.
+	PostponeTasks(ctx context.Context, taskIDs []int32, days int) (int64, error)
+
+	// SnoozeTasks sets the due date of the given incomplete tasks of a user to until in a
+	// single statement, so either every task moves or none does.
+	// It returns the number of tasks moved.
+	SnoozeTasks(ctx context.Context, userID int64, taskIDs []int32, until time.Time) (int64, error)
+
 	// Tag operations
 
 	// GetAllTagsForUser retrieves all unique tags used by a user.
 	GetAllTagsForUser(ctx context.Context, userID int64) ([]string, error)
+
+	// SetTaskTags replaces the tags of several of a user's tasks in a single transaction,
+	// so either every task changes or none does. Tasks of other users are left alone.
+	// It returns the number of tasks updated.
+	SetTaskTags(ctx context.Context, userID int64, tags map[int32][]string) (int64, error)
 }
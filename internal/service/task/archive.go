@@ -0,0 +1,52 @@
+package task
+
+import (
+	"context"
+
+	"github.com/newbpydev/tusk/internal/core/errors"
+	"github.com/newbpydev/tusk/internal/core/task"
+	"go.uber.org/zap"
+)
+
+// Archive hides a task and its subtasks instead of deleting them, so an accidental
+// removal can be undone with Restore
+func (s *taskService) Archive(ctx context.Context, taskID int64) error {
+	if taskID <= 0 {
+		return errors.InvalidInput("task ID must be positive")
+	}
+
+	if err := s.repo.Archive(ctx, taskID); err != nil {
+		s.log.Error("Failed to archive task",
+			zap.Int64("task_id", taskID),
+			zap.Error(err))
+		return err
+	}
+
+	s.log.Info("Task archived", zap.Int64("task_id", taskID))
+	return nil
+}
+
+// Restore brings back an archived task together with the subtasks archived along with it
+func (s *taskService) Restore(ctx context.Context, taskID int64) (task.Task, error) {
+	if taskID <= 0 {
+		return task.Task{}, errors.InvalidInput("task ID must be positive")
+	}
+
+	if err := s.repo.Restore(ctx, taskID); err != nil {
+		s.log.Error("Failed to restore task",
+			zap.Int64("task_id", taskID),
+			zap.Error(err))
+		return task.Task{}, err
+	}
+
+	s.log.Info("Task restored", zap.Int64("task_id", taskID))
+	return s.repo.GetByID(ctx, taskID)
+}
+
+// ListArchived retrieves the user's archived tasks, most recently archived first
+func (s *taskService) ListArchived(ctx context.Context, userID int64) ([]task.Task, error) {
+	if userID <= 0 {
+		return nil, errors.InvalidInput("user ID must be positive")
+	}
+	return s.repo.ListArchived(ctx, userID)
+}
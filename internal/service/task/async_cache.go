@@ -0,0 +1,181 @@
+package task
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/newbpydev/tusk/internal/core/task"
+)
+
+// DefaultCacheTTL is how long the async task service trusts a cached entry when no
+// other TTL is configured
+const DefaultCacheTTL = time.Minute
+
+// taskKey and userTasksKey are the cache keys of a single task and of a user's task
+// list. Distinct key types keep a task ID from ever matching a user ID.
+type (
+	taskKey      int64
+	userTasksKey int64
+)
+
+// cacheEntry is a cached value together with the time it was stored
+type cacheEntry struct {
+	value    any
+	storedAt time.Time
+}
+
+// WithCacheTTL sets how long cached tasks and task lists are used before they are
+// fetched again. A zero or negative ttl keeps entries until a write drops them.
+func WithCacheTTL(ttl time.Duration) AsyncOption {
+	return func(s *AsyncTaskService) {
+		s.cacheTTL = ttl
+	}
+}
+
+// InvalidateUser drops the cached task list and every cached task of a user, so the
+// next lookups read them from the database again
+func (s *AsyncTaskService) InvalidateUser(userID int64) {
+	s.cache.Range(func(key, value any) bool {
+		if t, ok := value.(*cacheEntry).value.(task.Task); ok && int64(t.UserID) == userID {
+			s.cache.Delete(key)
+		}
+		return true
+	})
+	s.invalidateUserTasks(userID)
+}
+
+// loadCached returns the value stored under key unless it has expired. Expired
+// entries are dropped, unless a fresher value replaced them in the meantime.
+func (s *AsyncTaskService) loadCached(key any) (any, bool) {
+	v, ok := s.cache.Load(key)
+	if !ok {
+		return nil, false
+	}
+
+	entry := v.(*cacheEntry)
+	if s.expired(entry, s.now()) {
+		s.cache.CompareAndDelete(key, entry)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// storeCached stores value under key, restarting its TTL
+func (s *AsyncTaskService) storeCached(key, value any) {
+	s.cache.Store(key, &cacheEntry{value: value, storedAt: s.now()})
+}
+
+// loadTask returns the cached task with the given ID, if it is still fresh
+func (s *AsyncTaskService) loadTask(taskID int64) (task.Task, bool) {
+	v, ok := s.loadCached(taskKey(taskID))
+	if !ok {
+		return task.Task{}, false
+	}
+	return v.(task.Task), true
+}
+
+// cacheTask stores a task in the cache
+func (s *AsyncTaskService) cacheTask(t task.Task) {
+	s.storeCached(taskKey(t.ID), t)
+}
+
+// forgetTask drops a task from the cache
+func (s *AsyncTaskService) forgetTask(taskID int64) {
+	s.cache.Delete(taskKey(taskID))
+}
+
+// loadUserTasks returns the cached task list of a user, if it is still fresh
+func (s *AsyncTaskService) loadUserTasks(userID int64) ([]task.Task, bool) {
+	v, ok := s.loadCached(userTasksKey(userID))
+	if !ok {
+		return nil, false
+	}
+	return v.([]task.Task), true
+}
+
+// storeUserTasks caches the task list of a user
+func (s *AsyncTaskService) storeUserTasks(userID int64, tasks []task.Task) {
+	s.storeCached(userTasksKey(userID), tasks)
+}
+
+// invalidateUserTasks drops the cached task list of a user, forcing the next List
+// to read it from the database
+func (s *AsyncTaskService) invalidateUserTasks(userID int64) {
+	s.cache.Delete(userTasksKey(userID))
+}
+
+// CacheEntry describes an entry of the async task service's cache, for debugging
+// stale data
+type CacheEntry struct {
+	Key     string        // e.g. "task 12" or "user 1 tasks"
+	Summary string        // what is cached, without task contents, e.g. "5 tasks"
+	Age     time.Duration // time since the entry was stored
+	Expired bool          // past the TTL; dropped on the next lookup, or by PruneCache
+	Value   any           // the cached task or task list, only set when asked for verbosely
+
+	key any
+}
+
+// DebugCacheEntries returns a snapshot of the cache ordered by key. Cached tasks are
+// only included in Value when verbose is set. It is safe to call at any time.
+func (s *AsyncTaskService) DebugCacheEntries(verbose bool) []CacheEntry {
+	now := s.now()
+
+	var entries []CacheEntry
+	s.cache.Range(func(key, value any) bool {
+		entry := value.(*cacheEntry)
+		e := CacheEntry{
+			Age:     now.Sub(entry.storedAt),
+			Expired: s.expired(entry, now),
+			key:     key,
+		}
+
+		switch k := key.(type) {
+		case taskKey:
+			e.Key = fmt.Sprintf("task %d", k)
+			e.Summary = "1 task"
+		case userTasksKey:
+			e.Key = fmt.Sprintf("user %d tasks", k)
+			e.Summary = fmt.Sprintf("%d tasks", len(entry.value.([]task.Task)))
+		default:
+			e.Key = fmt.Sprint(k)
+		}
+		if verbose {
+			e.Value = entry.value
+		}
+
+		entries = append(entries, e)
+		return true
+	})
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	return entries
+}
+
+// EvictCacheEntry drops an entry returned by DebugCacheEntries, so its value is read
+// from the database again. It reports whether the entry was still cached.
+func (s *AsyncTaskService) EvictCacheEntry(e CacheEntry) bool {
+	_, ok := s.cache.LoadAndDelete(e.key)
+	return ok
+}
+
+// PruneCache drops every expired entry, including ones nobody looks up anymore, and
+// returns how many were dropped
+func (s *AsyncTaskService) PruneCache() int {
+	now := s.now()
+	pruned := 0
+	s.cache.Range(func(key, value any) bool {
+		entry := value.(*cacheEntry)
+		if s.expired(entry, now) && s.cache.CompareAndDelete(key, entry) {
+			pruned++
+		}
+		return true
+	})
+	return pruned
+}
+
+// expired reports whether entry is past the TTL at now
+func (s *AsyncTaskService) expired(entry *cacheEntry, now time.Time) bool {
+	return s.cacheTTL > 0 && now.Sub(entry.storedAt) >= s.cacheTTL
+}
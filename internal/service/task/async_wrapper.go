@@ -2,7 +2,7 @@ package task
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"sync"
 	"time"
 
@@ -14,18 +14,53 @@ import (
 
 // AsyncTaskService wraps the regular task service with asynchronous capabilities
 type AsyncTaskService struct {
-	taskService Service
-	workerPool  *worker.Pool
-	log         *zap.Logger
-	cache       sync.Map // Used to cache recent operations for faster UI feedback
+	taskService    Service
+	workerPool     *worker.Pool
+	log            *zap.Logger
+	cache          sync.Map         // Used to cache recent operations for faster UI feedback, see async_cache.go
+	cacheTTL       time.Duration    // How long cached entries are used before they are fetched again
+	now            func() time.Time // Clock deciding when cached entries expire
+	completionHook *CompletionHook  // Optional command run in the background when a task is completed
+	jobTimeout     time.Duration    // Deadline of each background job
+}
+
+// DefaultJobTimeout bounds each background job when no other timeout is configured
+const DefaultJobTimeout = 30 * time.Second
+
+// AsyncOption configures optional behavior of the async task service
+type AsyncOption func(*AsyncTaskService)
+
+// WithCompletionHook runs hook on the worker pool every time a task is completed.
+// A nil hook disables it.
+func WithCompletionHook(hook *CompletionHook) AsyncOption {
+	return func(s *AsyncTaskService) {
+		s.completionHook = hook
+	}
+}
+
+// WithJobTimeout bounds how long each background job, such as a cache refresh or the
+// completion hook, may run before its context is canceled. A zero or negative
+// timeout keeps DefaultJobTimeout.
+func WithJobTimeout(timeout time.Duration) AsyncOption {
+	return func(s *AsyncTaskService) {
+		if timeout > 0 {
+			s.jobTimeout = timeout
+		}
+	}
 }
 
 // NewAsyncTaskService creates a new async task service that wraps a regular task service
-func NewAsyncTaskService(taskService Service, logger *zap.Logger) *AsyncTaskService {
+func NewAsyncTaskService(taskService Service, logger *zap.Logger, opts ...AsyncOption) *AsyncTaskService {
 	as := &AsyncTaskService{
 		taskService: taskService,
 		workerPool:  worker.NewPool(10), // 10 concurrent workers for better performance
 		log:         logger.Named("async_task_service"),
+		cacheTTL:    DefaultCacheTTL,
+		now:         time.Now,
+		jobTimeout:  DefaultJobTimeout,
+	}
+	for _, opt := range opts {
+		opt(as)
 	}
 
 	// Start the worker pool
@@ -44,20 +79,18 @@ func NewAsyncTaskService(taskService Service, logger *zap.Logger) *AsyncTaskServ
 // List retrieves all tasks for a user
 func (s *AsyncTaskService) List(ctx context.Context, userID int64) ([]task.Task, error) {
 	// First check if we have tasks in cache for this user
-	if cachedTasks, ok := s.cache.Load("user_tasks_" + fmt.Sprintf("%d", userID)); ok {
+	if tasks, ok := s.loadUserTasks(userID); ok {
 		// Use the cached tasks while refreshing in the background
-		tasks := cachedTasks.([]task.Task)
 
 		// Refresh in background unless the context is already about to expire
 		select {
 		case <-ctx.Done():
 			// Context is already done, don't start background refresh
 		default:
-			s.workerPool.Submit(func() error {
-				bgCtx := context.Background()
+			s.submit("refresh task list", func(bgCtx context.Context) error {
 				freshTasks, err := s.taskService.List(bgCtx, userID)
 				if err == nil {
-					s.cache.Store("user_tasks_"+fmt.Sprintf("%d", userID), freshTasks)
+					s.storeUserTasks(userID, freshTasks)
 				}
 				return err
 			})
@@ -70,7 +103,7 @@ func (s *AsyncTaskService) List(ctx context.Context, userID int64) ([]task.Task,
 	tasks, err := s.taskService.List(ctx, userID)
 	if err == nil {
 		// Cache the results for future use
-		s.cache.Store("user_tasks_"+fmt.Sprintf("%d", userID), tasks)
+		s.storeUserTasks(userID, tasks)
 	}
 	return tasks, err
 }
@@ -84,9 +117,10 @@ func (s *AsyncTaskService) Create(
 	dueDate *time.Time,
 	priority task.Priority,
 	tags []string,
+	recurrence *task.Recurrence,
 ) (task.Task, error) {
 	// Perform the synchronous operation first for immediate feedback
-	createdTask, err := s.taskService.Create(ctx, userID, parentID, title, description, dueDate, priority, tags)
+	createdTask, err := s.taskService.Create(ctx, userID, parentID, title, description, dueDate, priority, tags, recurrence)
 	if err != nil {
 		return task.Task{}, err
 	}
@@ -95,18 +129,18 @@ func (s *AsyncTaskService) Create(
 	s.cacheTask(createdTask)
 
 	// Invalidate user task list cache to force refresh on next list fetch
-	s.cache.Delete("user_tasks_" + fmt.Sprintf("%d", userID))
+	s.invalidateUserTasks(userID)
 
 	// Submit background job to ensure all associated data is properly updated
-	s.workerPool.Submit(func() error {
-		refreshCtx := context.Background()
-		_, err := s.taskService.Show(refreshCtx, int64(createdTask.ID))
+	s.submit("refresh created task", func(refreshCtx context.Context) error {
+		freshTask, err := s.taskService.Show(refreshCtx, int64(createdTask.ID))
 		if err != nil {
 			s.log.Error("Failed to refresh created task data",
 				zap.Int32("task_id", createdTask.ID),
 				zap.Error(err))
 			return err
 		}
+		s.cacheTask(freshTask)
 		return nil
 	})
 
@@ -124,8 +158,7 @@ func (s *AsyncTaskService) Update(
 ) (task.Task, error) {
 	// Get task to determine its user ID for cache invalidation
 	var userID int64
-	if cachedTask, ok := s.cache.Load(taskID); ok {
-		t := cachedTask.(task.Task)
+	if t, ok := s.loadTask(taskID); ok {
 		userID = int64(t.UserID)
 	}
 
@@ -139,13 +172,11 @@ func (s *AsyncTaskService) Update(
 
 	// Invalidate user task list cache
 	if userID > 0 {
-		s.cache.Delete("user_tasks_" + fmt.Sprintf("%d", userID))
+		s.invalidateUserTasks(userID)
 	}
 
 	// Submit background job for any related updates
-	s.workerPool.Submit(func() error {
-		bgCtx := context.Background()
-
+	s.submit("refresh task list after update", func(bgCtx context.Context) error {
 		// If userID wasn't found in cache, get it from the updated task
 		if userID == 0 {
 			userID = int64(updatedTask.UserID)
@@ -153,13 +184,14 @@ func (s *AsyncTaskService) Update(
 
 		// Refresh the user's task list in the background
 		if userID > 0 {
-			_, err := s.taskService.List(bgCtx, userID)
+			freshTasks, err := s.taskService.List(bgCtx, userID)
 			if err != nil {
 				s.log.Error("Failed to refresh task list after update",
 					zap.Int64("user_id", userID),
 					zap.Error(err))
 				return err
 			}
+			s.storeUserTasks(userID, freshTasks)
 		}
 		return nil
 	})
@@ -171,8 +203,7 @@ func (s *AsyncTaskService) Update(
 func (s *AsyncTaskService) Delete(ctx context.Context, taskID int64) error {
 	// First get the task to determine the user ID for cache invalidation
 	var userID int64
-	if cachedTask, ok := s.cache.Load(taskID); ok {
-		t := cachedTask.(task.Task)
+	if t, ok := s.loadTask(taskID); ok {
 		userID = int64(t.UserID)
 	} else {
 		// Try to fetch the task first to get its user ID
@@ -189,19 +220,22 @@ func (s *AsyncTaskService) Delete(ctx context.Context, taskID int64) error {
 	}
 
 	// Remove from cache if deletion was successful
-	s.cache.Delete(taskID)
+	s.forgetTask(taskID)
 
 	// Invalidate user task list cache
 	if userID > 0 {
-		s.cache.Delete("user_tasks_" + fmt.Sprintf("%d", userID))
+		s.invalidateUserTasks(userID)
 	}
 
 	// Background refresh of user's task list if we know the user ID
 	if userID > 0 {
-		s.workerPool.Submit(func() error {
-			bgCtx := context.Background()
-			_, err := s.taskService.List(bgCtx, userID)
-			return err
+		s.submit("refresh task list after delete", func(bgCtx context.Context) error {
+			freshTasks, err := s.taskService.List(bgCtx, userID)
+			if err != nil {
+				return err
+			}
+			s.storeUserTasks(userID, freshTasks)
+			return nil
 		})
 	}
 
@@ -221,27 +255,33 @@ func (s *AsyncTaskService) Complete(ctx context.Context, taskID int64) (task.Tas
 
 	// Invalidate user task list cache
 	userID := int64(completedTask.UserID)
-	s.cache.Delete("user_tasks_" + fmt.Sprintf("%d", userID))
+	s.invalidateUserTasks(userID)
+
+	// Parents may have been completed along with this task
+	s.invalidateAncestors(completedTask)
+
+	s.runCompletionHook(completedTask)
 
 	// Submit background job to ensure all associated data is properly updated
-	s.workerPool.Submit(func() error {
-		refreshCtx := context.Background()
-		_, err := s.taskService.Show(refreshCtx, int64(completedTask.ID))
+	s.submit("refresh completed task", func(refreshCtx context.Context) error {
+		freshTask, err := s.taskService.Show(refreshCtx, int64(completedTask.ID))
 		if err != nil {
 			s.log.Error("Failed to refresh completed task data",
 				zap.Int32("task_id", completedTask.ID),
 				zap.Error(err))
 			return err
 		}
+		s.cacheTask(freshTask)
 
 		// Also refresh the user's task list
-		_, err = s.taskService.List(refreshCtx, userID)
+		freshTasks, err := s.taskService.List(refreshCtx, userID)
 		if err != nil {
 			s.log.Error("Failed to refresh task list after completion",
 				zap.Int64("user_id", userID),
 				zap.Error(err))
 			return err
 		}
+		s.storeUserTasks(userID, freshTasks)
 
 		return nil
 	})
@@ -255,8 +295,8 @@ func (s *AsyncTaskService) ChangeStatus(ctx context.Context, taskID int64, statu
 	var cachedTask task.Task
 	var userID int64
 
-	if taskCache, ok := s.cache.Load(taskID); ok {
-		cachedTask = taskCache.(task.Task)
+	if t, ok := s.loadTask(taskID); ok {
+		cachedTask = t
 		// If we already have the task cached and it has the requested status, return it immediately
 		if cachedTask.Status == status {
 			return cachedTask, nil
@@ -279,11 +319,17 @@ func (s *AsyncTaskService) ChangeStatus(ctx context.Context, taskID int64, statu
 	}
 
 	// Invalidate user task list cache
-	s.cache.Delete("user_tasks_" + fmt.Sprintf("%d", userID))
+	s.invalidateUserTasks(userID)
+
+	// Parents may have been completed along with this task
+	s.invalidateAncestors(updatedTask)
+
+	if status == task.StatusDone {
+		s.runCompletionHook(updatedTask)
+	}
 
 	// Submit background job to ensure changes are properly propagated
-	s.workerPool.Submit(func() error {
-		refreshCtx := context.Background()
+	s.submit("refresh task after status change", func(refreshCtx context.Context) error {
 		freshTask, err := s.taskService.Show(refreshCtx, taskID)
 		if err != nil {
 			s.log.Error("Failed to refresh task status",
@@ -297,13 +343,14 @@ func (s *AsyncTaskService) ChangeStatus(ctx context.Context, taskID int64, statu
 		s.cacheTask(freshTask)
 
 		// Also refresh the user's task list
-		_, err = s.taskService.List(refreshCtx, userID)
+		freshTasks, err := s.taskService.List(refreshCtx, userID)
 		if err != nil {
 			s.log.Error("Failed to refresh task list after status change",
 				zap.Int64("user_id", userID),
 				zap.Error(err))
 			return err
 		}
+		s.storeUserTasks(userID, freshTasks)
 
 		return nil
 	})
@@ -315,8 +362,8 @@ func (s *AsyncTaskService) ChangeStatus(ctx context.Context, taskID int64, statu
 
 // GetByID retrieves a task by ID, utilizing the cache when possible
 func (s *AsyncTaskService) GetByID(ctx context.Context, taskID int64) (task.Task, error) {
-	if taskCache, ok := s.cache.Load(taskID); ok {
-		return taskCache.(task.Task), nil
+	if t, ok := s.loadTask(taskID); ok {
+		return t, nil
 	}
 
 	t, err := s.taskService.Show(ctx, taskID)
@@ -329,8 +376,8 @@ func (s *AsyncTaskService) GetByID(ctx context.Context, taskID int64) (task.Task
 // Show retrieves a task by ID, utilizing cache when possible
 func (s *AsyncTaskService) Show(ctx context.Context, taskID int64) (task.Task, error) {
 	// Try cache first
-	if t, ok := s.cache.Load(taskID); ok {
-		return t.(task.Task), nil
+	if t, ok := s.loadTask(taskID); ok {
+		return t, nil
 	}
 	// Delegate to underlying service
 	result, err := s.taskService.Show(ctx, taskID)
@@ -342,13 +389,66 @@ func (s *AsyncTaskService) Show(ctx context.Context, taskID int64) (task.Task, e
 
 // Helper functions
 
-// cacheTask stores a task in the cache
-func (s *AsyncTaskService) cacheTask(t task.Task) {
-	// Cache by ID for direct lookups
-	s.cache.Store(int64(t.ID), t)
+// invalidateAncestors drops the cached entries for every known ancestor of a task
+func (s *AsyncTaskService) invalidateAncestors(t task.Task) {
+	parentID := t.ParentID
+	for depth := 0; parentID != nil && depth < maxAncestorWalk; depth++ {
+		id := int64(*parentID)
+		cached, ok := s.loadTask(id)
+		s.forgetTask(id)
+		if !ok {
+			return
+		}
+		parentID = cached.ParentID
+	}
 }
 
-// Close shuts down the worker pool
+// runCompletionHook runs the configured completion hook for t on the worker pool.
+// Hook failures are logged and never affect the completion itself.
+func (s *AsyncTaskService) runCompletionHook(t task.Task) {
+	if s.completionHook == nil {
+		return
+	}
+
+	s.submit("completion hook", func(ctx context.Context) error {
+		if err := s.completionHook.Run(ctx, t); err != nil {
+			s.log.Warn("Completion hook failed",
+				zap.Int32("task_id", t.ID),
+				zap.Error(err))
+		}
+		return nil
+	})
+}
+
+// submit runs job on the worker pool with a context that expires after the job
+// timeout, so a hung database cannot hold a worker forever. Jobs that run out of
+// time are logged as such.
+func (s *AsyncTaskService) submit(name string, job func(ctx context.Context) error) {
+	s.workerPool.Submit(func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), s.jobTimeout)
+		defer cancel()
+
+		err := job(ctx)
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			s.log.Warn("Background job timed out",
+				zap.String("job", name),
+				zap.Duration("timeout", s.jobTimeout),
+				zap.Error(err))
+			return nil
+		}
+		return err
+	})
+}
+
+// Wait stops accepting background jobs and blocks until every job submitted so far
+// has finished, or until ctx is done, in which case it returns ctx's error. Use it
+// to flush pending jobs before Close; jobs submitted after Wait are dropped.
+func (s *AsyncTaskService) Wait(ctx context.Context) error {
+	return s.workerPool.Drain(ctx)
+}
+
+// Close shuts down the worker pool once the jobs already submitted have finished.
+// Jobs submitted after Close are dropped.
 func (s *AsyncTaskService) Close() {
 	if s.workerPool != nil {
 		s.workerPool.Stop()
@@ -366,6 +466,26 @@ func (s *AsyncTaskService) Reorder(ctx context.Context, taskID int64, newOrder i
 	return s.taskService.Reorder(ctx, taskID, newOrder)
 }
 
+func (s *AsyncTaskService) NormalizeOrder(ctx context.Context, userID int64) error {
+	err := s.taskService.NormalizeOrder(ctx, userID)
+	if err == nil {
+		// Every cached task of this user may carry a stale display order
+		s.invalidateUserTasks(userID)
+	}
+	return err
+}
+
+func (s *AsyncTaskService) CompactOrders(ctx context.Context, userID int64) (int, error) {
+	affected, err := s.taskService.CompactOrders(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	// Every cached task of this user may carry a stale display order
+	s.InvalidateUser(userID)
+	return affected, nil
+}
+
 func (s *AsyncTaskService) SearchByTitle(ctx context.Context, userID int64, titlePattern string) ([]task.Task, error) {
 	return s.taskService.SearchByTitle(ctx, userID, titlePattern)
 }
@@ -407,18 +527,383 @@ func (s *AsyncTaskService) GetRecentlyCompletedTasks(ctx context.Context, userID
 }
 
 func (s *AsyncTaskService) BulkUpdateStatus(ctx context.Context, taskIDs []int32, status task.Status) error {
-	err := s.taskService.BulkUpdateStatus(ctx, taskIDs, status)
+	// Look the tasks up first to know whose lists go stale and which tasks get completed
+	previous := make([]task.Task, 0, len(taskIDs))
+	for _, id := range taskIDs {
+		if t, err := s.taskService.Show(ctx, int64(id)); err == nil {
+			previous = append(previous, t)
+		}
+	}
 
-	// If successful, refresh the tasks in the background
-	if err == nil {
-		s.workerPool.Submit(func() error {
-			return nil // Basic implementation, could be enhanced to refresh those tasks
-		})
+	if err := s.taskService.BulkUpdateStatus(ctx, taskIDs, status); err != nil {
+		return err
 	}
 
-	return err
+	for _, id := range taskIDs {
+		s.forgetTask(int64(id))
+	}
+
+	// Parents and next occurrences may have changed along with the tasks, so every
+	// cached task of their users is dropped
+	users := make(map[int64]bool)
+	for _, t := range previous {
+		users[int64(t.UserID)] = true
+		if status == task.StatusDone && t.Status != task.StatusDone {
+			t.IsCompleted = true
+			t.Status = task.StatusDone
+			s.runCompletionHook(t)
+		}
+	}
+	for userID := range users {
+		s.InvalidateUser(userID)
+	}
+
+	return nil
 }
 
 func (s *AsyncTaskService) GetAllTags(ctx context.Context, userID int64) ([]string, error) {
 	return s.taskService.GetAllTags(ctx, userID)
 }
+
+func (s *AsyncTaskService) ReadyParents(ctx context.Context, taskID int64) ([]task.Task, error) {
+	return s.taskService.ReadyParents(ctx, taskID)
+}
+
+func (s *AsyncTaskService) CompleteParents(ctx context.Context, taskID int64) ([]task.Task, error) {
+	parents, err := s.taskService.CompleteParents(ctx, taskID)
+	for _, parent := range parents {
+		s.cacheTask(parent)
+		s.invalidateUserTasks(int64(parent.UserID))
+	}
+	return parents, err
+}
+
+func (s *AsyncTaskService) ListChildren(ctx context.Context, parentID int64) ([]task.Task, error) {
+	return s.taskService.ListChildren(ctx, parentID)
+}
+
+func (s *AsyncTaskService) DedupeTags(ctx context.Context, userID int64) (int, error) {
+	updated, err := s.taskService.DedupeTags(ctx, userID)
+	if updated > 0 {
+		s.cache.Clear()
+	}
+	return updated, err
+}
+
+func (s *AsyncTaskService) NextTasks(ctx context.Context, userID int64, limit int) ([]task.Task, error) {
+	return s.taskService.NextTasks(ctx, userID, limit)
+}
+
+func (s *AsyncTaskService) ProjectBurndown(ctx context.Context, rootID int64, from, to time.Time) ([]task.BurndownPoint, error) {
+	return s.taskService.ProjectBurndown(ctx, rootID, from, to)
+}
+
+func (s *AsyncTaskService) SnoozeUntil(ctx context.Context, taskID int64, until time.Time) (task.Task, error) {
+	updatedTask, err := s.taskService.SnoozeUntil(ctx, taskID, until)
+	if err != nil {
+		return task.Task{}, err
+	}
+
+	s.cacheTask(updatedTask)
+	s.invalidateUserTasks(int64(updatedTask.UserID))
+	return updatedTask, nil
+}
+
+func (s *AsyncTaskService) SetEnergy(ctx context.Context, taskID int64, energy task.Energy) (task.Task, error) {
+	updatedTask, err := s.taskService.SetEnergy(ctx, taskID, energy)
+	if err != nil {
+		return task.Task{}, err
+	}
+
+	s.cacheTask(updatedTask)
+	s.invalidateUserTasks(int64(updatedTask.UserID))
+	return updatedTask, nil
+}
+
+func (s *AsyncTaskService) SetPoints(ctx context.Context, taskID int64, points int) (task.Task, error) {
+	updatedTask, err := s.taskService.SetPoints(ctx, taskID, points)
+	if err != nil {
+		return task.Task{}, err
+	}
+
+	s.cacheTask(updatedTask)
+	s.invalidateUserTasks(int64(updatedTask.UserID))
+	return updatedTask, nil
+}
+
+func (s *AsyncTaskService) RecomputeMetrics(ctx context.Context, userID int64) (int, error) {
+	return s.taskService.RecomputeMetrics(ctx, userID)
+}
+
+func (s *AsyncTaskService) CachedMetrics(ctx context.Context, userID int64) ([]task.CachedMetrics, error) {
+	return s.taskService.CachedMetrics(ctx, userID)
+}
+
+func (s *AsyncTaskService) RolloverTodayPreview(ctx context.Context, userID int64) ([]task.Task, error) {
+	return s.taskService.RolloverTodayPreview(ctx, userID)
+}
+
+func (s *AsyncTaskService) RolloverToday(ctx context.Context, userID int64) (int, error) {
+	affected, err := s.taskService.RolloverToday(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	// Any cached task of this user may carry a stale due date
+	s.InvalidateUser(userID)
+	return affected, nil
+}
+
+func (s *AsyncTaskService) SeedDemoTasks(ctx context.Context, userID int64) (int, error) {
+	created, err := s.taskService.SeedDemoTasks(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	s.invalidateUserTasks(userID)
+	return created, nil
+}
+
+func (s *AsyncTaskService) MoveTask(ctx context.Context, taskID int64, newParentID *int64) (task.Task, error) {
+	// Remember the old ancestors before the move so their subtask lists are refreshed too
+	previous, cached := s.loadTask(taskID)
+
+	movedTask, err := s.taskService.MoveTask(ctx, taskID, newParentID)
+	if err != nil {
+		return task.Task{}, err
+	}
+
+	if cached {
+		s.invalidateAncestors(previous)
+	}
+	s.invalidateAncestors(movedTask)
+	s.cacheTask(movedTask)
+	s.invalidateUserTasks(int64(movedTask.UserID))
+	return movedTask, nil
+}
+
+func (s *AsyncTaskService) SwapOrder(ctx context.Context, aID, bID int64) error {
+	if err := s.taskService.SwapOrder(ctx, aID, bID); err != nil {
+		return err
+	}
+
+	// Both tasks changed order, drop them so the next lookup reads fresh values
+	for _, id := range []int64{aID, bID} {
+		if cached, ok := s.loadTask(id); ok {
+			s.invalidateUserTasks(int64(cached.UserID))
+		}
+		s.forgetTask(id)
+	}
+	return nil
+}
+
+func (s *AsyncTaskService) ListByStatuses(ctx context.Context, userID int64, statuses []task.Status) ([]task.Task, error) {
+	return s.taskService.ListByStatuses(ctx, userID, statuses)
+}
+
+func (s *AsyncTaskService) SetExternalID(ctx context.Context, taskID int64, source, externalID string) (task.Task, error) {
+	updatedTask, err := s.taskService.SetExternalID(ctx, taskID, source, externalID)
+	if err != nil {
+		return task.Task{}, err
+	}
+
+	s.cacheTask(updatedTask)
+	s.invalidateUserTasks(int64(updatedTask.UserID))
+	return updatedTask, nil
+}
+
+func (s *AsyncTaskService) GetByExternalID(ctx context.Context, userID int64, source, externalID string) (task.Task, error) {
+	return s.taskService.GetByExternalID(ctx, userID, source, externalID)
+}
+
+func (s *AsyncTaskService) ListFiltered(ctx context.Context, userID int64, filter task.TaskFilter) ([]task.Task, error) {
+	return s.taskService.ListFiltered(ctx, userID, filter)
+}
+
+func (s *AsyncTaskService) Archive(ctx context.Context, taskID int64) error {
+	// Remember the task before it disappears so its parent and list are refreshed
+	previous, cached := s.loadTask(taskID)
+	if !cached {
+		if t, err := s.taskService.Show(ctx, taskID); err == nil {
+			previous, cached = t, true
+		}
+	}
+
+	if err := s.taskService.Archive(ctx, taskID); err != nil {
+		return err
+	}
+
+	s.forgetTask(taskID)
+	if cached {
+		s.invalidateAncestors(previous)
+		s.invalidateUserTasks(int64(previous.UserID))
+	}
+	return nil
+}
+
+func (s *AsyncTaskService) Restore(ctx context.Context, taskID int64) (task.Task, error) {
+	restoredTask, err := s.taskService.Restore(ctx, taskID)
+	if err != nil {
+		return task.Task{}, err
+	}
+
+	s.invalidateAncestors(restoredTask)
+	s.cacheTask(restoredTask)
+	s.invalidateUserTasks(int64(restoredTask.UserID))
+	return restoredTask, nil
+}
+
+func (s *AsyncTaskService) ListArchived(ctx context.Context, userID int64) ([]task.Task, error) {
+	return s.taskService.ListArchived(ctx, userID)
+}
+
+func (s *AsyncTaskService) AddDependency(ctx context.Context, taskID, dependsOnID int64) error {
+	if err := s.taskService.AddDependency(ctx, taskID, dependsOnID); err != nil {
+		return err
+	}
+	s.forgetTask(taskID)
+	return nil
+}
+
+func (s *AsyncTaskService) RemoveDependency(ctx context.Context, taskID, dependsOnID int64) error {
+	if err := s.taskService.RemoveDependency(ctx, taskID, dependsOnID); err != nil {
+		return err
+	}
+	s.forgetTask(taskID)
+	return nil
+}
+
+func (s *AsyncTaskService) ListDependencies(ctx context.Context, taskID int64) ([]task.Task, error) {
+	return s.taskService.ListDependencies(ctx, taskID)
+}
+
+func (s *AsyncTaskService) BlockedTaskIDs(ctx context.Context, userID int64) ([]int32, error) {
+	return s.taskService.BlockedTaskIDs(ctx, userID)
+}
+
+func (s *AsyncTaskService) SearchByText(ctx context.Context, userID int64, query string) ([]task.Task, error) {
+	return s.taskService.SearchByText(ctx, userID, query)
+}
+
+func (s *AsyncTaskService) ListPage(ctx context.Context, userID int64, limit, offset int) (task.Page, error) {
+	return s.taskService.ListPage(ctx, userID, limit, offset)
+}
+
+func (s *AsyncTaskService) MergeTagCasePreview(ctx context.Context, userID int64, canonical string) (task.TagMerge, error) {
+	return s.taskService.MergeTagCasePreview(ctx, userID, canonical)
+}
+
+func (s *AsyncTaskService) MergeTagCase(ctx context.Context, userID int64, canonical string) (int, error) {
+	updated, err := s.taskService.MergeTagCase(ctx, userID, canonical)
+	if updated > 0 {
+		s.InvalidateUser(userID)
+	}
+	return updated, err
+}
+
+func (s *AsyncTaskService) SessionSummary(ctx context.Context, userID int64, start, end time.Time) (task.SessionSummary, error) {
+	return s.taskService.SessionSummary(ctx, userID, start, end)
+}
+
+func (s *AsyncTaskService) SnoozeFilteredPreview(ctx context.Context, userID int64, filter task.TaskFilter) ([]task.Task, error) {
+	return s.taskService.SnoozeFilteredPreview(ctx, userID, filter)
+}
+
+func (s *AsyncTaskService) SnoozeFiltered(ctx context.Context, userID int64, filter task.TaskFilter, until time.Time) (int, error) {
+	moved, err := s.taskService.SnoozeFiltered(ctx, userID, filter, until)
+	if err != nil {
+		return 0, err
+	}
+
+	// Any cached task of this user may carry a stale due date
+	s.InvalidateUser(userID)
+	return moved, nil
+}
+
+func (s *AsyncTaskService) MostUrgent(ctx context.Context, userID int64) (task.Task, bool, error) {
+	return s.taskService.MostUrgent(ctx, userID)
+}
+
+func (s *AsyncTaskService) AddNote(ctx context.Context, userID, taskID int64, body string) (task.TaskNote, error) {
+	return s.taskService.AddNote(ctx, userID, taskID, body)
+}
+
+func (s *AsyncTaskService) ListNotes(ctx context.Context, userID, taskID int64) ([]task.TaskNote, error) {
+	return s.taskService.ListNotes(ctx, userID, taskID)
+}
+
+func (s *AsyncTaskService) PinToDay(ctx context.Context, userID, taskID int64) (task.Task, error) {
+	return s.taskService.PinToDay(ctx, userID, taskID)
+}
+
+func (s *AsyncTaskService) UnpinFromDay(ctx context.Context, userID, taskID int64) error {
+	return s.taskService.UnpinFromDay(ctx, userID, taskID)
+}
+
+func (s *AsyncTaskService) DayPinnedTaskIDs(ctx context.Context, userID int64) ([]int32, error) {
+	return s.taskService.DayPinnedTaskIDs(ctx, userID)
+}
+
+func (s *AsyncTaskService) GetAgenda(ctx context.Context, userID int64) (task.Agenda, error) {
+	return s.taskService.GetAgenda(ctx, userID)
+}
+
+func (s *AsyncTaskService) Clone(ctx context.Context, taskID int64, withSubtasks bool) (task.Task, error) {
+	clone, err := s.taskService.Clone(ctx, taskID, withSubtasks)
+	if err != nil {
+		return task.Task{}, err
+	}
+
+	// The clone's parent gained a subtask
+	s.invalidateAncestors(clone)
+	s.cacheTask(clone)
+	s.invalidateUserTasks(int64(clone.UserID))
+	return clone, nil
+}
+
+func (s *AsyncTaskService) CreateTree(ctx context.Context, userID int64, root TaskInput) (task.Task, error) {
+	created, err := s.taskService.CreateTree(ctx, userID, root)
+	if err != nil {
+		return task.Task{}, err
+	}
+
+	s.cacheTask(created)
+	s.invalidateUserTasks(userID)
+	return created, nil
+}
+
+func (s *AsyncTaskService) MoveTaskUp(ctx context.Context, taskID int64) error {
+	if err := s.taskService.MoveTaskUp(ctx, taskID); err != nil {
+		return err
+	}
+	s.invalidateMovedTask(ctx, taskID)
+	return nil
+}
+
+func (s *AsyncTaskService) MoveTaskDown(ctx context.Context, taskID int64) error {
+	if err := s.taskService.MoveTaskDown(ctx, taskID); err != nil {
+		return err
+	}
+	s.invalidateMovedTask(ctx, taskID)
+	return nil
+}
+
+// invalidateMovedTask drops the cached tasks of the moved task's user: its neighbor
+// changed order too, and so may every other task when the orders were normalized first
+func (s *AsyncTaskService) invalidateMovedTask(ctx context.Context, taskID int64) {
+	s.forgetTask(taskID)
+	if t, err := s.taskService.Show(ctx, taskID); err == nil {
+		s.InvalidateUser(int64(t.UserID))
+	}
+}
+
+func (s *AsyncTaskService) Snooze(ctx context.Context, taskID int64, d time.Duration) (task.Task, error) {
+	updatedTask, err := s.taskService.Snooze(ctx, taskID, d)
+	if err != nil {
+		return task.Task{}, err
+	}
+
+	s.cacheTask(updatedTask)
+	s.invalidateUserTasks(int64(updatedTask.UserID))
+	return updatedTask, nil
+}
@@ -0,0 +1,85 @@
+package task
+
+import (
+	"context"
+	"time"
+
+	"github.com/newbpydev/tusk/internal/core/errors"
+	"github.com/newbpydev/tusk/internal/core/task"
+	"go.uber.org/zap"
+)
+
+// cloneSuffix is appended to the title of a cloned task
+const cloneSuffix = " (copy)"
+
+// Clone copies a task into a new task of the same user, under the same parent. The
+// title gets a " (copy)" suffix; description, priority, due date and tags are copied,
+// while the status starts over as todo. With withSubtasks, the subtasks are cloned
+// as well, keeping their titles. Every clone gets a new ID and timestamps.
+func (s *taskService) Clone(ctx context.Context, taskID int64, withSubtasks bool) (task.Task, error) {
+	if taskID <= 0 {
+		return task.Task{}, errors.InvalidInput("task ID must be positive")
+	}
+
+	source, err := s.Show(ctx, taskID)
+	if err != nil {
+		return task.Task{}, err
+	}
+
+	clone, err := s.cloneTree(ctx, source, source.ParentID, source.Title+cloneSuffix, withSubtasks)
+	if err != nil {
+		s.log.Error("Failed to clone task",
+			zap.Int64("task_id", taskID),
+			zap.Error(err))
+		return task.Task{}, err
+	}
+
+	s.log.Info("Task cloned",
+		zap.Int64("task_id", taskID),
+		zap.Int32("clone_id", clone.ID),
+		zap.Bool("with_subtasks", withSubtasks))
+
+	return clone, nil
+}
+
+// cloneTree creates a copy of source under parentID and, with withSubtasks, copies of
+// its subtasks under the copy
+func (s *taskService) cloneTree(ctx context.Context, source task.Task, parentID *int32, title string, withSubtasks bool) (task.Task, error) {
+	now := time.Now()
+	clone := task.Task{
+		UserID:      source.UserID,
+		ParentID:    parentID,
+		Title:       title,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		IsCompleted: false,
+		Status:      task.StatusTodo,
+		Priority:    source.Priority,
+		Tags:        append([]task.Tag(nil), source.Tags...),
+	}
+	if source.Description != nil {
+		description := *source.Description
+		clone.Description = &description
+	}
+	if source.DueDate != nil {
+		dueDate := *source.DueDate
+		clone.DueDate = &dueDate
+	}
+
+	created, err := s.repo.Create(ctx, clone)
+	if err != nil {
+		return task.Task{}, err
+	}
+	if !withSubtasks {
+		return created, nil
+	}
+
+	for _, sub := range source.SubTasks {
+		subClone, err := s.cloneTree(ctx, sub, &created.ID, sub.Title, true)
+		if err != nil {
+			return task.Task{}, err
+		}
+		created.SubTasks = append(created.SubTasks, subClone)
+	}
+	return created, nil
+}
@@ -0,0 +1,173 @@
+package task
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/newbpydev/tusk/internal/core/task"
+)
+
+// DefaultHookTimeout bounds how long a completion hook may run when no timeout is configured
+const DefaultHookTimeout = 10 * time.Second
+
+// maxHookValueLength caps each substituted value so a long description cannot
+// produce an unreasonably large command line
+const maxHookValueLength = 500
+
+// hookPlaceholders are the task fields a completion hook template may reference
+var hookPlaceholders = map[string]func(task.Task) string{
+	"id":       func(t task.Task) string { return strconv.Itoa(int(t.ID)) },
+	"user_id":  func(t task.Task) string { return strconv.Itoa(int(t.UserID)) },
+	"title":    func(t task.Task) string { return t.Title },
+	"status":   func(t task.Task) string { return string(t.Status) },
+	"priority": func(t task.Task) string { return string(t.Priority) },
+	"tags": func(t task.Task) string {
+		names := make([]string, len(t.Tags))
+		for i, tag := range t.Tags {
+			names[i] = tag.Name
+		}
+		return strings.Join(names, ",")
+	},
+	"due": func(t task.Task) string {
+		if t.DueDate == nil {
+			return ""
+		}
+		return t.DueDate.Format(time.RFC3339)
+	},
+	"parent_id": func(t task.Task) string {
+		if t.ParentID == nil {
+			return ""
+		}
+		return strconv.Itoa(int(*t.ParentID))
+	},
+}
+
+// CompletionHook runs an external command whenever a task is completed.
+// The template is split into arguments before task fields are substituted and the
+// command is run without a shell, so substituted values can never inject extra
+// arguments or shell syntax. An argument that only starts with a dash because of a
+// substituted value is prefixed with a space, so it cannot be taken for an option.
+type CompletionHook struct {
+	args    []string
+	timeout time.Duration
+}
+
+// NewCompletionHook parses a command template such as
+// "notify-send Done {title}". Placeholders are written as {name}; the supported names
+// are id, user_id, parent_id, title, status, priority, tags and due. An empty template
+// disables the hook and returns nil. A timeout of zero or less uses DefaultHookTimeout.
+func NewCompletionHook(template string, timeout time.Duration) (*CompletionHook, error) {
+	args := strings.Fields(template)
+	if len(args) == 0 {
+		return nil, nil
+	}
+	if strings.Contains(args[0], "{") {
+		return nil, fmt.Errorf("completion hook command %q must not contain placeholders", args[0])
+	}
+
+	for _, arg := range args[1:] {
+		for rest := arg; ; {
+			start := strings.Index(rest, "{")
+			if start < 0 {
+				break
+			}
+			end := strings.Index(rest[start:], "}")
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated placeholder in %q", arg)
+			}
+			name := rest[start+1 : start+end]
+			if _, ok := hookPlaceholders[name]; !ok {
+				return nil, fmt.Errorf("unknown placeholder {%s} in completion hook", name)
+			}
+			rest = rest[start+end+1:]
+		}
+	}
+
+	if timeout <= 0 {
+		timeout = DefaultHookTimeout
+	}
+	return &CompletionHook{args: args, timeout: timeout}, nil
+}
+
+// Command returns the program and arguments the hook runs for t
+func (h *CompletionHook) Command(t task.Task) []string {
+	cmd := make([]string, len(h.args))
+	cmd[0] = h.args[0]
+	for i, arg := range h.args[1:] {
+		expanded := expandHookArg(arg, t)
+		// A title such as "--output=/etc/x" must stay a value, not become an option
+		if strings.HasPrefix(expanded, "-") && !strings.HasPrefix(arg, "-") {
+			expanded = " " + expanded
+		}
+		cmd[i+1] = expanded
+	}
+	return cmd
+}
+
+// expandHookArg substitutes the placeholders in arg in a single pass, so braces
+// inside a substituted value are never expanded again
+func expandHookArg(arg string, t task.Task) string {
+	var b strings.Builder
+	for {
+		start := strings.Index(arg, "{")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(arg[start:], "}")
+		if end < 0 {
+			break
+		}
+		b.WriteString(arg[:start])
+		if field, ok := hookPlaceholders[arg[start+1:start+end]]; ok {
+			b.WriteString(sanitizeHookValue(field(t)))
+		} else {
+			b.WriteString(arg[start : start+end+1])
+		}
+		arg = arg[start+end+1:]
+	}
+	b.WriteString(arg)
+	return b.String()
+}
+
+// Run executes the hook for t, killing it once the timeout elapses. The error
+// includes any output the command produced.
+func (h *CompletionHook) Run(ctx context.Context, t task.Task) error {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	args := h.Command(t)
+	var output bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("completion hook timed out after %s", h.timeout)
+		}
+		return fmt.Errorf("completion hook failed: %v: %s", err, strings.TrimSpace(output.String()))
+	}
+	return nil
+}
+
+// sanitizeHookValue replaces control characters (including newlines) with spaces
+// and truncates the value to maxHookValueLength runes
+func sanitizeHookValue(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, s)
+
+	if runes := []rune(s); len(runes) > maxHookValueLength {
+		s = string(runes[:maxHookValueLength])
+	}
+	return s
+}
@@ -0,0 +1,53 @@
+package task
+
+import (
+	"context"
+
+	"github.com/newbpydev/tusk/internal/core/errors"
+	"github.com/newbpydev/tusk/internal/core/task"
+	"go.uber.org/zap"
+)
+
+// PinToDay pins one of the user's tasks to the top of its day in the agenda. The pin
+// is kept with the task, so rescheduling the task moves the pin to the new day.
+// Only tasks with a due date can be pinned.
+func (s *taskService) PinToDay(ctx context.Context, userID, taskID int64) (task.Task, error) {
+	t, err := s.ownedTask(ctx, userID, taskID)
+	if err != nil {
+		return task.Task{}, err
+	}
+	if t.DueDate == nil {
+		return task.Task{}, errors.InvalidInput("only tasks with a due date can be pinned to their day")
+	}
+
+	if err := s.repo.PinToDay(ctx, taskID); err != nil {
+		return task.Task{}, err
+	}
+
+	s.log.Info("Task pinned to its day",
+		zap.Int64("task_id", taskID),
+		zap.Time("due_date", *t.DueDate))
+	return t, nil
+}
+
+// UnpinFromDay removes the day pin of one of the user's tasks
+func (s *taskService) UnpinFromDay(ctx context.Context, userID, taskID int64) error {
+	if _, err := s.ownedTask(ctx, userID, taskID); err != nil {
+		return err
+	}
+
+	if err := s.repo.UnpinFromDay(ctx, taskID); err != nil {
+		return err
+	}
+
+	s.log.Info("Task unpinned from its day", zap.Int64("task_id", taskID))
+	return nil
+}
+
+// DayPinnedTaskIDs retrieves the IDs of the user's pinned tasks, most recently pinned first
+func (s *taskService) DayPinnedTaskIDs(ctx context.Context, userID int64) ([]int32, error) {
+	if userID <= 0 {
+		return nil, errors.InvalidInput("user ID must be positive")
+	}
+	return s.repo.ListDayPinnedTaskIDs(ctx, userID)
+}
@@ -0,0 +1,190 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/newbpydev/tusk/internal/core/errors"
+	"github.com/newbpydev/tusk/internal/core/task"
+	"go.uber.org/zap"
+)
+
+// maxDependencyWalk bounds how many tasks the cycle check visits, so a huge or
+// corrupted dependency graph can never make it run away
+const maxDependencyWalk = 1000
+
+// AddDependency records that taskID cannot be completed before dependsOnID is done.
+// Both tasks must belong to the same user. A task cannot depend on itself, on its
+// own parent or subtask (that link is already the task tree), or on a task that
+// already depends on it, directly or through other tasks.
+func (s *taskService) AddDependency(ctx context.Context, taskID, dependsOnID int64) error {
+	if taskID <= 0 || dependsOnID <= 0 {
+		return errors.InvalidInput("task ID must be positive")
+	}
+	if taskID == dependsOnID {
+		return errors.InvalidInput("a task cannot depend on itself")
+	}
+
+	t, err := s.repo.GetByID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	dependency, err := s.repo.GetByID(ctx, dependsOnID)
+	if err != nil {
+		return err
+	}
+
+	if t.UserID != dependency.UserID {
+		return errors.InvalidInput("a task can only depend on tasks of the same user")
+	}
+	if isParentOf(t, dependency) || isParentOf(dependency, t) {
+		return errors.InvalidInput("a task cannot depend on its own parent or subtask")
+	}
+
+	cycle, err := s.dependsOn(ctx, dependsOnID, taskID)
+	if err != nil {
+		return err
+	}
+	if cycle {
+		return errors.Conflict(fmt.Sprintf("'%s' already depends on '%s'", dependency.Title, t.Title))
+	}
+
+	if err := s.repo.AddDependency(ctx, taskID, dependsOnID); err != nil {
+		return err
+	}
+
+	s.log.Info("Task dependency added",
+		zap.Int64("task_id", taskID),
+		zap.Int64("depends_on_id", dependsOnID))
+	return nil
+}
+
+// RemoveDependency drops the dependency of taskID on dependsOnID
+func (s *taskService) RemoveDependency(ctx context.Context, taskID, dependsOnID int64) error {
+	if taskID <= 0 || dependsOnID <= 0 {
+		return errors.InvalidInput("task ID must be positive")
+	}
+
+	if err := s.repo.RemoveDependency(ctx, taskID, dependsOnID); err != nil {
+		return err
+	}
+
+	s.log.Info("Task dependency removed",
+		zap.Int64("task_id", taskID),
+		zap.Int64("depends_on_id", dependsOnID))
+	return nil
+}
+
+// ListDependencies retrieves the tasks that must be done before taskID can be completed
+func (s *taskService) ListDependencies(ctx context.Context, taskID int64) ([]task.Task, error) {
+	if taskID <= 0 {
+		return nil, errors.InvalidInput("task ID must be positive")
+	}
+
+	t, err := s.repo.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	dependencies := make([]task.Task, 0, len(t.Dependencies))
+	for _, id := range t.Dependencies {
+		dependency, err := s.repo.GetByID(ctx, int64(id))
+		if err != nil {
+			return nil, err
+		}
+		dependencies = append(dependencies, dependency)
+	}
+	return dependencies, nil
+}
+
+// BlockedTaskIDs retrieves the IDs of the user's tasks that still wait on an
+// incomplete dependency
+func (s *taskService) BlockedTaskIDs(ctx context.Context, userID int64) ([]int32, error) {
+	if userID <= 0 {
+		return nil, errors.InvalidInput("user ID must be positive")
+	}
+	return s.repo.ListBlockedTaskIDs(ctx, userID)
+}
+
+// isParentOf reports whether parent is the direct parent of child
+func isParentOf(parent, child task.Task) bool {
+	return child.ParentID != nil && *child.ParentID == parent.ID
+}
+
+// dependsOn reports whether from depends on target, directly or through other tasks
+func (s *taskService) dependsOn(ctx context.Context, from, target int64) (bool, error) {
+	visited := map[int64]bool{from: true}
+	queue := []int64{from}
+
+	for len(queue) > 0 && len(visited) <= maxDependencyWalk {
+		id := queue[0]
+		queue = queue[1:]
+
+		ids, err := s.repo.ListDependencies(ctx, id)
+		if err != nil {
+			return false, err
+		}
+		for _, next := range ids {
+			if int64(next) == target {
+				return true, nil
+			}
+			if !visited[int64(next)] {
+				visited[int64(next)] = true
+				queue = append(queue, int64(next))
+			}
+		}
+	}
+	return false, nil
+}
+
+// incompleteDependencies returns the dependencies of t that are not done yet.
+// Archived dependencies no longer block anything.
+func (s *taskService) incompleteDependencies(ctx context.Context, t task.Task) ([]task.Task, error) {
+	var blocking []task.Task
+	for _, id := range t.Dependencies {
+		dependency, err := s.repo.GetByID(ctx, int64(id))
+		if err != nil {
+			return nil, err
+		}
+		if !dependency.IsCompleted && dependency.ArchivedAt == nil {
+			blocking = append(blocking, dependency)
+		}
+	}
+	return blocking, nil
+}
+
+// checkDependencies returns a conflict naming the open dependencies of t, or nil when
+// t is free to be completed
+func (s *taskService) checkDependencies(ctx context.Context, t task.Task) error {
+	return s.checkDependenciesExcept(ctx, t, nil)
+}
+
+// checkDependenciesExcept is checkDependencies ignoring the dependencies in completing,
+// which are being completed together with t
+func (s *taskService) checkDependenciesExcept(ctx context.Context, t task.Task, completing map[int32]bool) error {
+	open, err := s.incompleteDependencies(ctx, t)
+	if err != nil {
+		return err
+	}
+
+	var blocking []task.Task
+	for _, dependency := range open {
+		if !completing[dependency.ID] {
+			blocking = append(blocking, dependency)
+		}
+	}
+	if len(blocking) == 0 {
+		return nil
+	}
+
+	titles := make([]string, len(blocking))
+	for i, b := range blocking {
+		titles[i] = "'" + b.Title + "'"
+	}
+
+	s.log.Warn("Refusing to complete blocked task",
+		zap.Int32("task_id", t.ID),
+		zap.Int("open_dependencies", len(blocking)))
+	return errors.Conflict(fmt.Sprintf("'%s' is blocked by %s", t.Title, strings.Join(titles, ", ")))
+}
@@ -0,0 +1,77 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/newbpydev/tusk/internal/core/errors"
+	"github.com/newbpydev/tusk/internal/core/task"
+	"go.uber.org/zap"
+)
+
+// Column limits of the external reference, see migration 006
+const (
+	maxExternalSourceLength = 50
+	maxExternalIDLength     = 255
+)
+
+// SetExternalID anchors a task to an event in an external calendar. Empty source and
+// externalID detach the task again.
+func (s *taskService) SetExternalID(ctx context.Context, taskID int64, source, externalID string) (task.Task, error) {
+	if taskID <= 0 {
+		return task.Task{}, errors.InvalidInput("task ID must be positive")
+	}
+
+	source = strings.ToLower(strings.TrimSpace(source))
+	externalID = strings.TrimSpace(externalID)
+
+	var sourcePtr, idPtr *string
+	switch {
+	case source == "" && externalID == "":
+		// Both empty detaches the task
+	case source == "" || externalID == "":
+		return task.Task{}, errors.InvalidInput("external source and ID must be set together")
+	case len(source) > maxExternalSourceLength:
+		return task.Task{}, errors.InvalidInput(fmt.Sprintf("external source cannot be longer than %d characters", maxExternalSourceLength))
+	case len(externalID) > maxExternalIDLength:
+		return task.Task{}, errors.InvalidInput(fmt.Sprintf("external ID cannot be longer than %d characters", maxExternalIDLength))
+	default:
+		sourcePtr, idPtr = &source, &externalID
+	}
+
+	if err := s.repo.SetExternalRef(ctx, taskID, sourcePtr, idPtr); err != nil {
+		return task.Task{}, err
+	}
+
+	s.log.Info("Task external reference updated",
+		zap.Int64("task_id", taskID),
+		zap.String("external_source", source),
+		zap.String("external_id", externalID))
+
+	return s.repo.GetByID(ctx, taskID)
+}
+
+// GetByExternalID looks up the user's task anchored to an external calendar event
+func (s *taskService) GetByExternalID(ctx context.Context, userID int64, source, externalID string) (task.Task, error) {
+	if userID <= 0 {
+		return task.Task{}, errors.InvalidInput("user ID must be positive")
+	}
+
+	source = strings.ToLower(strings.TrimSpace(source))
+	externalID = strings.TrimSpace(externalID)
+	if source == "" || externalID == "" {
+		return task.Task{}, errors.InvalidInput("external source and ID are required")
+	}
+
+	t, err := s.repo.GetByExternalID(ctx, source, externalID)
+	if err != nil {
+		return task.Task{}, err
+	}
+
+	// Another user's task must look exactly like a missing one
+	if int64(t.UserID) != userID {
+		return task.Task{}, errors.NotFound(fmt.Sprintf("no task is attached to external event %s/%s", source, externalID))
+	}
+	return t, nil
+}
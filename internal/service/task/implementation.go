@@ -2,6 +2,8 @@ package task
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/newbpydev/tusk/internal/core/errors"
@@ -15,19 +17,45 @@ import (
 type taskService struct {
 	repo repo.TaskRepository
 	log  *zap.Logger
+
+	parentCompletion ParentCompletionMode
+	foldTagCase      bool
+	maxDepth         int
+	loc              *time.Location
+	strict           bool
+	dueSoonDays      int
+	urgency          task.UrgencyWeights
 }
 
+// DefaultMaxDepth is the default limit on how many levels deep tasks can be nested
+const DefaultMaxDepth = 20
+
+// DefaultDueSoonDays is how many days ahead a task counts as due soon by default
+const DefaultDueSoonDays = 7
+
 // NewTaskService creates a new instance of the task service
-func NewTaskService(r repo.TaskRepository) Service {
-	return &taskService{
-		repo: r,
-		log:  logging.GetFileOnlyLogger("service.task"),
+func NewTaskService(r repo.TaskRepository, opts ...Option) Service {
+	s := &taskService{
+		repo:             r,
+		log:              logging.GetFileOnlyLogger("service.task"),
+		parentCompletion: ParentCompletionNever,
+		maxDepth:         DefaultMaxDepth,
+		foldTagCase:      true,
+		loc:              time.Local,
+		dueSoonDays:      DefaultDueSoonDays,
+		urgency:          task.DefaultUrgencyWeights(),
+	}
+
+	for _, opt := range opts {
+		opt(s)
 	}
+
+	return s
 }
 
 // Create creates a new task with the given parameters
 func (s *taskService) Create(ctx context.Context, userID int64, parentID *int64, title, description string,
-	dueDate *time.Time, priority task.Priority, tags []string) (task.Task, error) {
+	dueDate *time.Time, priority task.Priority, tags []string, recurrence *task.Recurrence) (task.Task, error) {
 
 	// Validate input
 	if userID <= 0 {
@@ -40,12 +68,38 @@ func (s *taskService) Create(ctx context.Context, userID int64, parentID *int64,
 			zap.Int64("user_id", userID))
 		return task.Task{}, errors.InvalidInput("title is required")
 	}
+	if priority != "" && !isValidPriority(priority) && s.strict {
+		s.log.Error("Invalid priority provided for task creation",
+			zap.Int64("user_id", userID),
+			zap.String("given_priority", string(priority)))
+		return task.Task{}, errors.InvalidInput(fmt.Sprintf("invalid priority %q", priority))
+	}
 	if !isValidPriority(priority) {
 		s.log.Warn("Invalid priority provided, defaulting to medium",
 			zap.Int64("user_id", userID),
 			zap.String("given_priority", string(priority)))
 		priority = task.PriorityMedium // Set default priority if invalid
 	}
+	if recurrence != nil {
+		if err := recurrence.Validate(); err != nil {
+			s.log.Error("Invalid recurrence provided for task creation",
+				zap.Int64("user_id", userID),
+				zap.Error(err))
+			return task.Task{}, errors.InvalidInput(err.Error())
+		}
+	}
+	if err := validateTags(tags); err != nil {
+		s.log.Error("Invalid tag provided for task creation",
+			zap.Int64("user_id", userID),
+			zap.Error(err))
+		return task.Task{}, err
+	}
+
+	if parentID != nil {
+		if err := s.checkNestingDepth(ctx, *parentID); err != nil {
+			return task.Task{}, err
+		}
+	}
 
 	// Log task creation attempt - don't log full description which may contain sensitive data
 	s.log.Info("Creating new task",
@@ -56,13 +110,8 @@ func (s *taskService) Create(ctx context.Context, userID int64, parentID *int64,
 		zap.String("priority", string(priority)),
 		zap.Int("tag_count", len(tags)))
 
-	// Convert tags to Tag objects
-	var taskTags []task.Tag
-	for _, tagName := range tags {
-		if tagName != "" {
-			taskTags = append(taskTags, task.Tag{Name: tagName})
-		}
-	}
+	// Convert tags to Tag objects, dropping blanks and duplicates
+	taskTags := s.normalizeTags(tags)
 
 	var desc *string
 	if description != "" {
@@ -88,6 +137,7 @@ func (s *taskService) Create(ctx context.Context, userID int64, parentID *int64,
 		Status:       task.StatusTodo,
 		Priority:     priority,
 		Tags:         taskTags,
+		Recurrence:   recurrence,
 		DisplayOrder: 0, // Will be set by the repository
 	}
 
@@ -106,6 +156,49 @@ func (s *taskService) Create(ctx context.Context, userID int64, parentID *int64,
 	return createdTask, nil
 }
 
+// checkNestingDepth makes sure a task can be added under parentID without the
+// tree exceeding the configured maximum depth
+func (s *taskService) checkNestingDepth(ctx context.Context, parentID int64) error {
+	depth, err := s.taskDepth(ctx, parentID)
+	if err != nil {
+		return err
+	}
+
+	if depth+1 > s.maxDepth {
+		s.log.Warn("Rejected task exceeding maximum nesting depth",
+			zap.Int64("parent_id", parentID),
+			zap.Int("depth", depth+1),
+			zap.Int("max_depth", s.maxDepth))
+		return errors.InvalidInput(fmt.Sprintf("tasks cannot be nested more than %d levels deep", s.maxDepth))
+	}
+	return nil
+}
+
+// taskDepth returns the level of a task in its tree, counting root tasks as depth 1.
+// The walk stops at the depth limit or on a cycle, so corrupted data cannot loop forever.
+func (s *taskService) taskDepth(ctx context.Context, taskID int64) (int, error) {
+	depth := 0
+	visited := make(map[int64]bool)
+
+	for id := taskID; depth <= s.maxDepth; depth++ {
+		if visited[id] {
+			return 0, errors.Conflict(fmt.Sprintf("task %d is part of a parent cycle", taskID))
+		}
+		visited[id] = true
+
+		t, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+		if t.ParentID == nil {
+			return depth + 1, nil
+		}
+		id = int64(*t.ParentID)
+	}
+
+	return depth + 1, nil
+}
+
 // Show retrieves a task by its ID
 func (s *taskService) Show(ctx context.Context, taskID int64) (task.Task, error) {
 	if taskID <= 0 {
@@ -144,28 +237,15 @@ func (s *taskService) List(ctx context.Context, userID int64) ([]task.Task, erro
 	s.log.Debug("Listing all tasks for user",
 		zap.Int64("user_id", userID))
 
-	// Get all root tasks for the user
-	rootTasks, err := s.repo.ListRootTasks(ctx, userID)
+	// Get every root task with its subtasks in one go
+	rootTasks, err := s.repo.GetTaskForest(ctx, userID)
 	if err != nil {
-		s.log.Error("Failed to retrieve user's root tasks",
+		s.log.Error("Failed to retrieve user's task trees",
 			zap.Int64("user_id", userID),
 			zap.Error(err))
 		return nil, err
 	}
 
-	// For each root task, populate its subtasks
-	for i, rootTask := range rootTasks {
-		fullTask, err := s.repo.GetTaskTree(ctx, int64(rootTask.ID))
-		if err != nil {
-			s.log.Warn("Failed to retrieve complete task tree for task",
-				zap.Int64("user_id", userID),
-				zap.Int32("task_id", rootTask.ID),
-				zap.Error(err))
-			continue // Skip this task if we can't get its tree
-		}
-		rootTasks[i] = fullTask
-	}
-
 	s.log.Info("Retrieved all tasks for user",
 		zap.Int64("user_id", userID),
 		zap.Int("task_count", len(rootTasks)))
@@ -173,6 +253,106 @@ func (s *taskService) List(ctx context.Context, userID int64) ([]task.Task, erro
 	return rootTasks, nil
 }
 
+// maxPageSize bounds how many root tasks ListPage returns at once
+const maxPageSize = 500
+
+// ListPage retrieves one page of a user's root tasks with their subtasks, along with
+// the total number of root tasks. Pages are ordered by display order then ID, so
+// walking through them visits every root task exactly once.
+func (s *taskService) ListPage(ctx context.Context, userID int64, limit, offset int) (task.Page, error) {
+	if userID <= 0 {
+		return task.Page{}, errors.InvalidInput("user ID must be positive")
+	}
+	if limit <= 0 || limit > maxPageSize {
+		return task.Page{}, errors.InvalidInput(fmt.Sprintf("page size must be between 1 and %d", maxPageSize))
+	}
+	if offset < 0 {
+		return task.Page{}, errors.InvalidInput("page offset cannot be negative")
+	}
+
+	total, err := s.repo.CountRootTasks(ctx, userID)
+	if err != nil {
+		s.log.Error("Failed to count user's root tasks",
+			zap.Int64("user_id", userID),
+			zap.Error(err))
+		return task.Page{}, err
+	}
+
+	rootTasks, err := s.repo.ListRootTasksPaged(ctx, userID, limit, offset)
+	if err != nil {
+		s.log.Error("Failed to retrieve page of user's root tasks",
+			zap.Int64("user_id", userID),
+			zap.Int("limit", limit),
+			zap.Int("offset", offset),
+			zap.Error(err))
+		return task.Page{}, err
+	}
+
+	return task.Page{
+		Tasks:  s.loadTrees(ctx, userID, rootTasks),
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}, nil
+}
+
+// loadTrees replaces each root task with its full task tree, loading all the trees
+// with one query. When the trees cannot be loaded the roots are returned without
+// their subtasks, so a listing never fails because of them.
+func (s *taskService) loadTrees(ctx context.Context, userID int64, rootTasks []task.Task) []task.Task {
+	if len(rootTasks) == 0 {
+		return rootTasks
+	}
+
+	ids := make([]int64, len(rootTasks))
+	for i, t := range rootTasks {
+		ids[i] = int64(t.ID)
+	}
+
+	trees, err := s.repo.GetTaskTrees(ctx, ids)
+	if err != nil {
+		s.log.Warn("Failed to retrieve complete task trees",
+			zap.Int64("user_id", userID),
+			zap.Int("root_count", len(ids)),
+			zap.Error(err))
+		return rootTasks
+	}
+
+	byID := make(map[int32]task.Task, len(trees))
+	for _, tree := range trees {
+		byID[tree.ID] = tree
+	}
+	for i, t := range rootTasks {
+		if tree, ok := byID[t.ID]; ok {
+			rootTasks[i] = tree
+		}
+	}
+	return rootTasks
+}
+
+// ListChildren retrieves the direct children of a task ordered by display order.
+// Children are returned without their own subtrees, so their metrics are not populated.
+func (s *taskService) ListChildren(ctx context.Context, parentID int64) ([]task.Task, error) {
+	if parentID <= 0 {
+		return nil, errors.InvalidInput("task ID must be positive")
+	}
+
+	// Make sure the parent exists so callers get NotFound rather than an empty list
+	if _, err := s.repo.GetByID(ctx, parentID); err != nil {
+		return nil, err
+	}
+
+	children, err := s.repo.ListSubTasks(ctx, parentID)
+	if err != nil {
+		s.log.Error("Failed to list child tasks",
+			zap.Int64("parent_id", parentID),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return children, nil
+}
+
 // Reorder changes the display order of a task
 func (s *taskService) Reorder(ctx context.Context, taskID int64, newOrder int) error {
 	if taskID <= 0 {
@@ -185,6 +365,226 @@ func (s *taskService) Reorder(ctx context.Context, taskID int64, newOrder int) e
 	return s.repo.ReorderTask(ctx, taskID, newOrder)
 }
 
+// SwapOrder exchanges the display order of two tasks in the same section
+func (s *taskService) SwapOrder(ctx context.Context, aID, bID int64) error {
+	if aID <= 0 || bID <= 0 {
+		return errors.InvalidInput("task ID must be positive")
+	}
+	if aID == bID {
+		return errors.InvalidInput("cannot swap a task with itself")
+	}
+
+	a, err := s.repo.GetByID(ctx, aID)
+	if err != nil {
+		return err
+	}
+	b, err := s.repo.GetByID(ctx, bID)
+	if err != nil {
+		return err
+	}
+
+	if a.UserID != b.UserID {
+		return errors.InvalidInput("tasks belong to different users")
+	}
+	if !task.SameListSection(a, b) {
+		return errors.InvalidInput("tasks must be in the same section to swap their order")
+	}
+
+	// New tasks share display order 0, so swapping them would change nothing.
+	// Give every task a distinct order first.
+	if a.DisplayOrder == b.DisplayOrder {
+		if err := s.NormalizeOrder(ctx, int64(a.UserID)); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.SwapTaskOrder(ctx, aID, bID); err != nil {
+		return err
+	}
+
+	s.log.Info("Task order swapped",
+		zap.Int64("task_a_id", aID),
+		zap.Int64("task_b_id", bID))
+	return nil
+}
+
+// MoveTaskUp swaps a task with the sibling listed right above it in its section
+func (s *taskService) MoveTaskUp(ctx context.Context, taskID int64) error {
+	return s.moveInSection(ctx, taskID, -1)
+}
+
+// MoveTaskDown swaps a task with the sibling listed right below it in its section
+func (s *taskService) MoveTaskDown(ctx context.Context, taskID int64) error {
+	return s.moveInSection(ctx, taskID, 1)
+}
+
+// moveInSection swaps a task with its neighbor step places away among the siblings
+// in its section, in the order they are listed
+func (s *taskService) moveInSection(ctx context.Context, taskID int64, step int) error {
+	if taskID <= 0 {
+		return errors.InvalidInput("task ID must be positive")
+	}
+
+	return s.withTx(ctx, func(tx *taskService) error {
+		t, err := tx.repo.GetByID(ctx, taskID)
+		if err != nil {
+			return err
+		}
+
+		var siblings []task.Task
+		if t.ParentID != nil {
+			siblings, err = tx.repo.ListSubTasks(ctx, int64(*t.ParentID))
+		} else {
+			siblings, err = tx.repo.ListRootTasks(ctx, int64(t.UserID))
+		}
+		if err != nil {
+			return err
+		}
+
+		// Only siblings with the task's status share its section
+		var section []task.Task
+		index := -1
+		for _, sibling := range siblings {
+			if !task.SameListSection(sibling, t) {
+				continue
+			}
+			if sibling.ID == t.ID {
+				index = len(section)
+			}
+			section = append(section, sibling)
+		}
+		if index < 0 {
+			return errors.NotFound(fmt.Sprintf("task %d is not listed under its parent", taskID))
+		}
+
+		neighbor := index + step
+		if neighbor < 0 || neighbor >= len(section) {
+			return nil
+		}
+		return tx.SwapOrder(ctx, taskID, int64(section[neighbor].ID))
+	})
+}
+
+// MoveTask re-parents a task, keeping its subtasks attached to it
+func (s *taskService) MoveTask(ctx context.Context, taskID int64, newParentID *int64) (task.Task, error) {
+	if taskID <= 0 {
+		return task.Task{}, errors.InvalidInput("task ID must be positive")
+	}
+
+	movedTask, err := s.repo.GetByID(ctx, taskID)
+	if err != nil {
+		return task.Task{}, err
+	}
+
+	if newParentID != nil {
+		if *newParentID <= 0 {
+			return task.Task{}, errors.InvalidInput("parent task ID must be positive")
+		}
+
+		parentDepth, err := s.moveTargetDepth(ctx, movedTask, *newParentID)
+		if err != nil {
+			return task.Task{}, err
+		}
+
+		tree, err := s.repo.GetTaskTree(ctx, taskID)
+		if err != nil {
+			return task.Task{}, err
+		}
+		if parentDepth+subtreeHeight(tree) > s.maxDepth {
+			return task.Task{}, errors.InvalidInput(fmt.Sprintf("tasks cannot be nested more than %d levels deep", s.maxDepth))
+		}
+	}
+
+	if err := s.repo.ReparentTask(ctx, taskID, newParentID); err != nil {
+		s.log.Error("Failed to move task",
+			zap.Int64("task_id", taskID),
+			zap.Error(err))
+		return task.Task{}, err
+	}
+
+	s.log.Info("Task moved",
+		zap.Int64("task_id", taskID),
+		zap.Bool("root", newParentID == nil))
+
+	return s.repo.GetByID(ctx, taskID)
+}
+
+// moveTargetDepth checks that parentID can take movedTask as a subtask and returns
+// the parent's depth. Walking up from the new parent must never reach the moved task,
+// otherwise the move would turn the tree into a cycle.
+func (s *taskService) moveTargetDepth(ctx context.Context, movedTask task.Task, parentID int64) (int, error) {
+	if parentID == int64(movedTask.ID) {
+		return 0, errors.InvalidInput("a task cannot be its own parent")
+	}
+
+	depth := 0
+	visited := make(map[int64]bool)
+
+	for id := parentID; ; {
+		if visited[id] {
+			return 0, errors.Conflict(fmt.Sprintf("task %d is part of a parent cycle", parentID))
+		}
+		if depth >= s.maxDepth {
+			return 0, errors.InvalidInput(fmt.Sprintf("tasks cannot be nested more than %d levels deep", s.maxDepth))
+		}
+		visited[id] = true
+		depth++
+
+		ancestor, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+		if id == parentID && ancestor.UserID != movedTask.UserID {
+			return 0, errors.InvalidInput("parent task belongs to another user")
+		}
+		if ancestor.ParentID == nil {
+			return depth, nil
+		}
+
+		id = int64(*ancestor.ParentID)
+		if id == int64(movedTask.ID) {
+			return 0, errors.InvalidInput("cannot move a task under one of its own subtasks")
+		}
+	}
+}
+
+// subtreeHeight returns the number of levels in a task tree, counting the root
+func subtreeHeight(t task.Task) int {
+	height := 0
+	for _, sub := range t.SubTasks {
+		height = max(height, subtreeHeight(sub))
+	}
+	return height + 1
+}
+
+// NormalizeOrder rewrites a user's display orders to a clean 0..n sequence per parent group
+func (s *taskService) NormalizeOrder(ctx context.Context, userID int64) error {
+	_, err := s.CompactOrders(ctx, userID)
+	return err
+}
+
+// CompactOrders renumbers a user's display orders 0..n per parent group, keeping the
+// current order, and returns how many tasks got a new display order
+func (s *taskService) CompactOrders(ctx context.Context, userID int64) (int, error) {
+	if userID <= 0 {
+		return 0, errors.InvalidInput("user ID must be positive")
+	}
+
+	affected, err := s.repo.NormalizeDisplayOrder(ctx, userID)
+	if err != nil {
+		s.log.Error("Failed to normalize task order",
+			zap.Int64("user_id", userID),
+			zap.Error(err))
+		return 0, err
+	}
+
+	s.log.Info("Task order normalized",
+		zap.Int64("user_id", userID),
+		zap.Int64("tasks_updated", affected))
+
+	return int(affected), nil
+}
+
 // Update updates an existing task with the given parameters
 func (s *taskService) Update(ctx context.Context, taskID int64, title, description string,
 	dueDate *time.Time, priority task.Priority, tags []string) (task.Task, error) {
@@ -192,6 +592,12 @@ func (s *taskService) Update(ctx context.Context, taskID int64, title, descripti
 	if taskID <= 0 {
 		return task.Task{}, errors.InvalidInput("task ID must be positive")
 	}
+	if priority != "" && !isValidPriority(priority) && s.strict {
+		return task.Task{}, errors.InvalidInput(fmt.Sprintf("invalid priority %q", priority))
+	}
+	if err := validateTags(tags); err != nil {
+		return task.Task{}, err
+	}
 
 	// Get the existing task
 	existingTask, err := s.repo.GetByID(ctx, taskID)
@@ -220,14 +626,8 @@ func (s *taskService) Update(ctx context.Context, taskID int64, title, descripti
 	}
 
 	if tags != nil {
-		// Convert tags to Tag objects
-		var taskTags []task.Tag
-		for _, tagName := range tags {
-			if tagName != "" {
-				taskTags = append(taskTags, task.Tag{Name: tagName})
-			}
-		}
-		existingTask.Tags = taskTags
+		// Convert tags to Tag objects, dropping blanks and duplicates
+		existingTask.Tags = s.normalizeTags(tags)
 	}
 
 	existingTask.UpdatedAt = time.Now()
@@ -247,94 +647,187 @@ func (s *taskService) Update(ctx context.Context, taskID int64, title, descripti
 	return updatedTask, nil
 }
 
-// Delete removes a task
-func (s *taskService) Delete(ctx context.Context, taskID int64) error {
+// SnoozeUntil moves a task's due date to the given time
+func (s *taskService) SnoozeUntil(ctx context.Context, taskID int64, until time.Time) (task.Task, error) {
 	if taskID <= 0 {
-		s.log.Error("Invalid task ID for task deletion",
-			zap.Int64("task_id", taskID))
-		return errors.InvalidInput("task ID must be positive")
+		return task.Task{}, errors.InvalidInput("task ID must be positive")
+	}
+	if until.IsZero() {
+		return task.Task{}, errors.InvalidInput("snooze time is required")
 	}
 
-	s.log.Info("Attempting to delete task",
-		zap.Int64("task_id", taskID))
-
-	// Check if the task exists
 	existingTask, err := s.repo.GetByID(ctx, taskID)
 	if err != nil {
-		s.log.Error("Failed to find task for deletion",
-			zap.Int64("task_id", taskID),
-			zap.Error(err))
-		return err
+		return task.Task{}, err
+	}
+	if existingTask.IsCompleted || existingTask.Status == task.StatusDone {
+		return task.Task{}, errors.InvalidInput("cannot snooze a completed task")
 	}
 
-	// Log the task deletion but don't include any potentially sensitive content
-	s.log.Info("Deleting task",
-		zap.Int64("task_id", taskID),
-		zap.Int32("user_id", existingTask.UserID))
+	existingTask.DueDate = &until
+	existingTask.UpdatedAt = time.Now()
 
-	err = s.repo.Delete(ctx, taskID)
-	if err != nil {
-		s.log.Error("Failed to delete task",
-			zap.Int64("task_id", taskID),
-			zap.Error(err))
-		return err
+	if err := s.repo.Update(ctx, existingTask); err != nil {
+		return task.Task{}, err
 	}
 
-	s.log.Info("Task deleted successfully",
+	s.log.Info("Task snoozed",
 		zap.Int64("task_id", taskID),
-		zap.Int32("user_id", existingTask.UserID))
+		zap.Time("until", until))
 
-	return nil
+	return s.repo.GetByID(ctx, taskID)
 }
 
-// Complete marks a task as completed
-func (s *taskService) Complete(ctx context.Context, taskID int64) (task.Task, error) {
+// Snooze pushes a task's due date forward by d in the service's time zone
+func (s *taskService) Snooze(ctx context.Context, taskID int64, d time.Duration) (task.Task, error) {
 	if taskID <= 0 {
-		s.log.Error("Invalid task ID for task completion",
-			zap.Int64("task_id", taskID))
 		return task.Task{}, errors.InvalidInput("task ID must be positive")
 	}
+	if d <= 0 {
+		return task.Task{}, errors.InvalidInput("snooze duration must be positive")
+	}
 
-	s.log.Debug("Attempting to mark task as complete",
-		zap.Int64("task_id", taskID))
-
-	// Get the existing task
 	existingTask, err := s.repo.GetByID(ctx, taskID)
 	if err != nil {
-		s.log.Error("Failed to find task for completion",
-			zap.Int64("task_id", taskID),
-			zap.Error(err))
 		return task.Task{}, err
 	}
+	if existingTask.IsCompleted || existingTask.Status == task.StatusDone {
+		return task.Task{}, errors.InvalidInput("cannot snooze a completed task")
+	}
 
-	// Mark as completed and set status to done
-	existingTask.IsCompleted = true
-	existingTask.Status = task.StatusDone
+	until := task.SnoozeBy(existingTask.DueDate, d, time.Now().In(s.loc))
+	existingTask.DueDate = &until
 	existingTask.UpdatedAt = time.Now()
 
-	// Update the task in the repository
-	err = s.repo.Update(ctx, existingTask)
-	if err != nil {
-		s.log.Error("Failed to update task completion status",
-			zap.Int64("task_id", taskID),
-			zap.Error(err))
-		return task.Task{}, err
-	}
-
-	// Get the updated task
-	updatedTask, err := s.repo.GetByID(ctx, taskID)
-	if err != nil {
-		s.log.Error("Failed to retrieve updated task after completion",
-			zap.Int64("task_id", taskID),
-			zap.Error(err))
+	if err := s.repo.Update(ctx, existingTask); err != nil {
 		return task.Task{}, err
 	}
 
-	s.log.Info("Task marked as complete",
+	s.log.Info("Task snoozed",
 		zap.Int64("task_id", taskID),
-		zap.Int32("user_id", updatedTask.UserID))
+		zap.Duration("by", d),
+		zap.Time("until", until))
 
-	return updatedTask, nil
+	return s.repo.GetByID(ctx, taskID)
+}
+
+// Delete removes a task
+func (s *taskService) Delete(ctx context.Context, taskID int64) error {
+	if taskID <= 0 {
+		s.log.Error("Invalid task ID for task deletion",
+			zap.Int64("task_id", taskID))
+		return errors.InvalidInput("task ID must be positive")
+	}
+
+	s.log.Info("Attempting to delete task",
+		zap.Int64("task_id", taskID))
+
+	// The lookup and the delete share a transaction, so the task cannot change in between
+	var existingTask task.Task
+	err := s.withTx(ctx, func(tx *taskService) error {
+		// Check if the task exists
+		var err error
+		existingTask, err = tx.repo.GetByID(ctx, taskID)
+		if err != nil {
+			s.log.Error("Failed to find task for deletion",
+				zap.Int64("task_id", taskID),
+				zap.Error(err))
+			return err
+		}
+
+		// Log the task deletion but don't include any potentially sensitive content
+		s.log.Info("Deleting task",
+			zap.Int64("task_id", taskID),
+			zap.Int32("user_id", existingTask.UserID))
+
+		if err := tx.repo.Delete(ctx, taskID); err != nil {
+			s.log.Error("Failed to delete task",
+				zap.Int64("task_id", taskID),
+				zap.Error(err))
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.log.Info("Task deleted successfully",
+		zap.Int64("task_id", taskID),
+		zap.Int32("user_id", existingTask.UserID))
+
+	return nil
+}
+
+// Complete marks a task as completed
+func (s *taskService) Complete(ctx context.Context, taskID int64) (task.Task, error) {
+	if taskID <= 0 {
+		s.log.Error("Invalid task ID for task completion",
+			zap.Int64("task_id", taskID))
+		return task.Task{}, errors.InvalidInput("task ID must be positive")
+	}
+
+	s.log.Debug("Attempting to mark task as complete",
+		zap.Int64("task_id", taskID))
+
+	// The completion and the re-fetch share a transaction; parents and the next
+	// occurrence are handled afterwards, on a best-effort basis
+	var wasDone bool
+	var updatedTask task.Task
+	err := s.withTx(ctx, func(tx *taskService) error {
+		// Get the existing task
+		existingTask, err := tx.repo.GetByID(ctx, taskID)
+		if err != nil {
+			s.log.Error("Failed to find task for completion",
+				zap.Int64("task_id", taskID),
+				zap.Error(err))
+			return err
+		}
+
+		wasDone = existingTask.Status == task.StatusDone
+		if !wasDone {
+			if err := tx.checkDependencies(ctx, existingTask); err != nil {
+				return err
+			}
+		}
+
+		// Mark as completed and set status to done
+		existingTask.IsCompleted = true
+		existingTask.Status = task.StatusDone
+		existingTask.UpdatedAt = time.Now()
+
+		// Update the task in the repository
+		if err := tx.repo.Update(ctx, existingTask); err != nil {
+			s.log.Error("Failed to update task completion status",
+				zap.Int64("task_id", taskID),
+				zap.Error(err))
+			return err
+		}
+
+		// Get the updated task
+		updatedTask, err = tx.repo.GetByID(ctx, taskID)
+		if err != nil {
+			s.log.Error("Failed to retrieve updated task after completion",
+				zap.Int64("task_id", taskID),
+				zap.Error(err))
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return task.Task{}, err
+	}
+
+	s.log.Info("Task marked as complete",
+		zap.Int64("task_id", taskID),
+		zap.Int32("user_id", updatedTask.UserID))
+
+	s.autoCompleteParents(ctx, updatedTask)
+	if !wasDone {
+		s.spawnNextOccurrence(ctx, updatedTask)
+	}
+
+	return updatedTask, nil
 }
 
 // ChangeStatus updates the status of a task
@@ -356,41 +849,50 @@ func (s *taskService) ChangeStatus(ctx context.Context, taskID int64, status tas
 		zap.Int64("task_id", taskID),
 		zap.String("new_status", string(status)))
 
-	// Get the existing task
-	existingTask, err := s.repo.GetByID(ctx, taskID)
-	if err != nil {
-		s.log.Error("Failed to find task for status change",
-			zap.Int64("task_id", taskID),
-			zap.Error(err))
-		return task.Task{}, err
-	}
+	// The status change and the re-fetch share a transaction, like in Complete
+	var oldStatus task.Status
+	var updatedTask task.Task
+	err := s.withTx(ctx, func(tx *taskService) error {
+		// Get the existing task
+		existingTask, err := tx.repo.GetByID(ctx, taskID)
+		if err != nil {
+			s.log.Error("Failed to find task for status change",
+				zap.Int64("task_id", taskID),
+				zap.Error(err))
+			return err
+		}
 
-	oldStatus := existingTask.Status
+		oldStatus = existingTask.Status
+		if status == task.StatusDone && oldStatus != task.StatusDone {
+			if err := tx.checkDependencies(ctx, existingTask); err != nil {
+				return err
+			}
+		}
 
-	// Update status and completion based on the new status
-	existingTask.Status = status
-	if status == task.StatusDone {
-		existingTask.IsCompleted = true
-	} else {
-		existingTask.IsCompleted = false
-	}
-	existingTask.UpdatedAt = time.Now()
+		// Update status and completion based on the new status
+		existingTask.Status = status
+		existingTask.IsCompleted = status == task.StatusDone
+		existingTask.UpdatedAt = time.Now()
 
-	// Update the task in the repository
-	err = s.repo.Update(ctx, existingTask)
-	if err != nil {
-		s.log.Error("Failed to update task status",
-			zap.Int64("task_id", taskID),
-			zap.Error(err))
-		return task.Task{}, err
-	}
+		// Update the task in the repository
+		if err := tx.repo.Update(ctx, existingTask); err != nil {
+			s.log.Error("Failed to update task status",
+				zap.Int64("task_id", taskID),
+				zap.Error(err))
+			return err
+		}
 
-	// Get the updated task
-	updatedTask, err := s.repo.GetByID(ctx, taskID)
+		// Get the updated task
+		updatedTask, err = tx.repo.GetByID(ctx, taskID)
+		if err != nil {
+			s.log.Error("Failed to retrieve updated task after status change",
+				zap.Int64("task_id", taskID),
+				zap.Error(err))
+			return err
+		}
+		return nil
+	})
 	if err != nil {
-		s.log.Error("Failed to retrieve updated task after status change",
-			zap.Int64("task_id", taskID),
-			zap.Error(err))
 		return task.Task{}, err
 	}
 
@@ -400,6 +902,11 @@ func (s *taskService) ChangeStatus(ctx context.Context, taskID int64, status tas
 		zap.String("old_status", string(oldStatus)),
 		zap.String("new_status", string(status)))
 
+	if status == task.StatusDone && oldStatus != task.StatusDone {
+		s.autoCompleteParents(ctx, updatedTask)
+		s.spawnNextOccurrence(ctx, updatedTask)
+	}
+
 	return updatedTask, nil
 }
 
@@ -438,6 +945,55 @@ func (s *taskService) ChangePriority(ctx context.Context, taskID int64, priority
 	return updatedTask, nil
 }
 
+// SetEnergy changes the energy level of a task. An empty energy clears it.
+func (s *taskService) SetEnergy(ctx context.Context, taskID int64, energy task.Energy) (task.Task, error) {
+	if taskID <= 0 {
+		return task.Task{}, errors.InvalidInput("task ID must be positive")
+	}
+
+	if energy != "" && !isValidEnergy(energy) {
+		return task.Task{}, errors.InvalidInput("invalid energy")
+	}
+
+	existingTask, err := s.repo.GetByID(ctx, taskID)
+	if err != nil {
+		return task.Task{}, err
+	}
+
+	existingTask.Energy = energy
+	existingTask.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(ctx, existingTask); err != nil {
+		return task.Task{}, err
+	}
+
+	return s.repo.GetByID(ctx, taskID)
+}
+
+// SetPoints changes the effort estimate of a task. Zero clears it.
+func (s *taskService) SetPoints(ctx context.Context, taskID int64, points int) (task.Task, error) {
+	if taskID <= 0 {
+		return task.Task{}, errors.InvalidInput("task ID must be positive")
+	}
+	if points < 0 {
+		return task.Task{}, errors.InvalidInput("points cannot be negative")
+	}
+
+	existingTask, err := s.repo.GetByID(ctx, taskID)
+	if err != nil {
+		return task.Task{}, err
+	}
+
+	existingTask.Points = points
+	existingTask.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(ctx, existingTask); err != nil {
+		return task.Task{}, err
+	}
+
+	return s.repo.GetByID(ctx, taskID)
+}
+
 // SearchByTitle searches for tasks with titles matching the given pattern
 func (s *taskService) SearchByTitle(ctx context.Context, userID int64, titlePattern string) ([]task.Task, error) {
 	if userID <= 0 {
@@ -450,11 +1006,26 @@ func (s *taskService) SearchByTitle(ctx context.Context, userID int64, titlePatt
 	return s.repo.SearchTasksByTitle(ctx, userID, titlePattern)
 }
 
+// SearchByText searches the title, description and tags of the user's tasks for the query
+func (s *taskService) SearchByText(ctx context.Context, userID int64, query string) ([]task.Task, error) {
+	if userID <= 0 {
+		return nil, errors.InvalidInput("user ID must be positive")
+	}
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, errors.InvalidInput("search query is required")
+	}
+
+	return s.repo.SearchTasksByText(ctx, userID, query)
+}
+
 // SearchByTag searches for tasks that have the specified tag
 func (s *taskService) SearchByTag(ctx context.Context, userID int64, tag string) ([]task.Task, error) {
 	if userID <= 0 {
 		return nil, errors.InvalidInput("user ID must be positive")
 	}
+	// Stored tags are trimmed, see normalizeTags; the repository ignores case
+	tag = strings.TrimSpace(tag)
 	if tag == "" {
 		return nil, errors.InvalidInput("tag is required")
 	}
@@ -464,14 +1035,55 @@ func (s *taskService) SearchByTag(ctx context.Context, userID int64, tag string)
 
 // ListByStatus retrieves all tasks for a user with the given status
 func (s *taskService) ListByStatus(ctx context.Context, userID int64, status task.Status) ([]task.Task, error) {
+	return s.ListByStatuses(ctx, userID, []task.Status{status})
+}
+
+// ListByStatuses retrieves all tasks for a user with any of the given statuses
+func (s *taskService) ListByStatuses(ctx context.Context, userID int64, statuses []task.Status) ([]task.Task, error) {
 	if userID <= 0 {
 		return nil, errors.InvalidInput("user ID must be positive")
 	}
-	if !isValidStatus(status) {
+	if len(statuses) == 0 {
+		return nil, errors.InvalidInput("at least one status is required")
+	}
+
+	seen := make(map[task.Status]bool, len(statuses))
+	unique := make([]task.Status, 0, len(statuses))
+	for _, status := range statuses {
+		if !isValidStatus(status) {
+			return nil, errors.InvalidInput(fmt.Sprintf("invalid status %q", status))
+		}
+		if !seen[status] {
+			seen[status] = true
+			unique = append(unique, status)
+		}
+	}
+
+	return s.repo.ListTasksByStatuses(ctx, userID, unique)
+}
+
+// ListFiltered retrieves the tasks matching every set field of the filter
+func (s *taskService) ListFiltered(ctx context.Context, userID int64, filter task.TaskFilter) ([]task.Task, error) {
+	if userID <= 0 {
+		return nil, errors.InvalidInput("user ID must be positive")
+	}
+	if filter.Status != nil && !isValidStatus(*filter.Status) {
 		return nil, errors.InvalidInput("invalid status")
 	}
+	if filter.Priority != nil && !isValidPriority(*filter.Priority) {
+		return nil, errors.InvalidInput("invalid priority")
+	}
+	if filter.Energy != nil && !isValidEnergy(*filter.Energy) {
+		return nil, errors.InvalidInput("invalid energy")
+	}
+	if filter.DueAfter != nil && filter.DueBefore != nil && !filter.DueAfter.Before(*filter.DueBefore) {
+		return nil, errors.InvalidInput("due after must be earlier than due before")
+	}
+
+	filter.Tag = strings.TrimSpace(filter.Tag)
+	filter.Text = strings.TrimSpace(filter.Text)
 
-	return s.repo.ListTasksByStatus(ctx, userID, status)
+	return s.repo.ListTasksFiltered(ctx, userID, filter)
 }
 
 // ListByPriority retrieves all tasks for a user with the given priority
@@ -495,13 +1107,13 @@ func (s *taskService) ListTasksDueToday(ctx context.Context, userID int64) ([]ta
 	return s.repo.ListTasksDueToday(ctx, userID)
 }
 
-// ListTasksDueSoon retrieves all incomplete tasks due within the next 7 days
+// ListTasksDueSoon retrieves all incomplete tasks due within the due soon window
 func (s *taskService) ListTasksDueSoon(ctx context.Context, userID int64) ([]task.Task, error) {
 	if userID <= 0 {
 		return nil, errors.InvalidInput("user ID must be positive")
 	}
 
-	return s.repo.ListTasksDueSoon(ctx, userID)
+	return s.repo.ListTasksDueSoon(ctx, userID, s.dueSoonDays)
 }
 
 // ListOverdueTasks retrieves all incomplete tasks that are past their due date
@@ -513,6 +1125,66 @@ func (s *taskService) ListOverdueTasks(ctx context.Context, userID int64) ([]tas
 	return s.repo.ListOverdueTasks(ctx, userID)
 }
 
+// NextTasks returns up to limit incomplete tasks with due dates, most urgent first.
+// It runs a single query and never loads subtask trees, so it is cheap enough for prompts.
+func (s *taskService) NextTasks(ctx context.Context, userID int64, limit int) ([]task.Task, error) {
+	if userID <= 0 {
+		return nil, errors.InvalidInput("user ID must be positive")
+	}
+	if limit <= 0 {
+		return nil, errors.InvalidInput("limit must be positive")
+	}
+
+	tasks, err := s.repo.ListIncompleteDatedTasks(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.urgency.Sort(tasks, time.Now())
+	if len(tasks) > limit {
+		tasks = tasks[:limit]
+	}
+	return tasks, nil
+}
+
+// MostUrgent returns the incomplete dated task that needs attention first, the same
+// one NextTasks lists first. It reports false when there is nothing due.
+func (s *taskService) MostUrgent(ctx context.Context, userID int64) (task.Task, bool, error) {
+	tasks, err := s.NextTasks(ctx, userID, 1)
+	if err != nil || len(tasks) == 0 {
+		return task.Task{}, false, err
+	}
+	return tasks[0], true, nil
+}
+
+// GetAgenda returns the open tasks that are overdue or due today, grouped and sorted
+// by task.NewAgenda, with the pinned tasks moved to the top of their group. Days
+// follow the service's location.
+func (s *taskService) GetAgenda(ctx context.Context, userID int64) (task.Agenda, error) {
+	if userID <= 0 {
+		return task.Agenda{}, errors.InvalidInput("user ID must be positive")
+	}
+
+	overdue, err := s.repo.ListOverdueTasks(ctx, userID)
+	if err != nil {
+		return task.Agenda{}, err
+	}
+	today, err := s.repo.ListTasksDueToday(ctx, userID)
+	if err != nil {
+		return task.Agenda{}, err
+	}
+
+	pinned, err := s.repo.ListDayPinnedTaskIDs(ctx, userID)
+	if err != nil {
+		return task.Agenda{}, err
+	}
+
+	// The two lists may overlap around midnight; NewAgenda lists every task once
+	agenda := task.NewAgenda(append(overdue, today...), time.Now().In(s.loc))
+	agenda.Pin(pinned)
+	return agenda, nil
+}
+
 // GetTaskCountsByStatus retrieves counts of tasks grouped by status
 func (s *taskService) GetTaskCountsByStatus(ctx context.Context, userID int64) (repo.TaskStatusCounts, error) {
 	if userID <= 0 {
@@ -543,7 +1215,274 @@ func (s *taskService) GetRecentlyCompletedTasks(ctx context.Context, userID int6
 	return s.repo.GetRecentlyCompletedTasks(ctx, userID, int32(limit))
 }
 
-// BulkUpdateStatus updates the status of multiple tasks at once
+// ProjectBurndown retrieves the number of open subtasks of a project at the end of
+// each day from from to to. Projects without subtasks yield an empty result.
+func (s *taskService) ProjectBurndown(ctx context.Context, rootID int64, from, to time.Time) ([]task.BurndownPoint, error) {
+	if rootID <= 0 {
+		return nil, errors.InvalidInput("task ID must be positive")
+	}
+	if to.Before(from) {
+		return nil, errors.InvalidInput("end date must not be before start date")
+	}
+
+	root, err := s.repo.GetTaskTree(ctx, rootID)
+	if err != nil {
+		return nil, err
+	}
+
+	return task.Burndown(root, from, to), nil
+}
+
+// RolloverTodayPreview lists the incomplete tasks due today in the service's time zone
+func (s *taskService) RolloverTodayPreview(ctx context.Context, userID int64) ([]task.Task, error) {
+	if userID <= 0 {
+		return nil, errors.InvalidInput("user ID must be positive")
+	}
+
+	tasks, err := s.repo.ListIncompleteDatedTasks(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().In(s.loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, s.loc)
+	tomorrow := today.AddDate(0, 0, 1)
+
+	var due []task.Task
+	for _, t := range tasks {
+		if t.IsCompleted || t.Status == task.StatusDone || t.DueDate == nil {
+			continue
+		}
+		if !t.DueDate.Before(today) && t.DueDate.Before(tomorrow) {
+			due = append(due, t)
+		}
+	}
+	return due, nil
+}
+
+// RolloverToday moves every incomplete task due today to the same time tomorrow.
+// Overdue tasks are left alone; all tasks move in one statement or none do.
+func (s *taskService) RolloverToday(ctx context.Context, userID int64) (int, error) {
+	due, err := s.RolloverTodayPreview(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	if len(due) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]int32, len(due))
+	for i, t := range due {
+		ids[i] = t.ID
+	}
+
+	affected, err := s.repo.PostponeTasks(ctx, ids, 1)
+	if err != nil {
+		s.log.Error("Failed to roll today's tasks over",
+			zap.Int64("user_id", userID),
+			zap.Error(err))
+		return 0, err
+	}
+
+	s.log.Info("Rolled today's tasks over to tomorrow",
+		zap.Int64("user_id", userID),
+		zap.Int64("tasks_moved", affected))
+
+	return int(affected), nil
+}
+
+// SnoozeFilteredPreview lists the incomplete tasks SnoozeFiltered would move. The tasks
+// are matched by ListFiltered, so they are the ones a list with the same filter shows.
+func (s *taskService) SnoozeFilteredPreview(ctx context.Context, userID int64, filter task.TaskFilter) ([]task.Task, error) {
+	if filter.IsEmpty() {
+		return nil, errors.InvalidInput("a filter is required to snooze tasks in bulk")
+	}
+
+	tasks, err := s.ListFiltered(ctx, userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var open []task.Task
+	for _, t := range tasks {
+		if !t.IsCompleted && t.Status != task.StatusDone {
+			open = append(open, t)
+		}
+	}
+	return open, nil
+}
+
+// SnoozeFiltered sets the due date of every incomplete task matching the filter to
+// until. All tasks move in one statement or none do.
+func (s *taskService) SnoozeFiltered(ctx context.Context, userID int64, filter task.TaskFilter, until time.Time) (int, error) {
+	if until.IsZero() {
+		return 0, errors.InvalidInput("snooze time is required")
+	}
+
+	matching, err := s.SnoozeFilteredPreview(ctx, userID, filter)
+	if err != nil {
+		return 0, err
+	}
+	if len(matching) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]int32, len(matching))
+	for i, t := range matching {
+		ids[i] = t.ID
+	}
+
+	affected, err := s.repo.SnoozeTasks(ctx, userID, ids, until)
+	if err != nil {
+		s.log.Error("Failed to snooze filtered tasks",
+			zap.Int64("user_id", userID),
+			zap.String("filter", filter.String()),
+			zap.Error(err))
+		return 0, err
+	}
+
+	s.log.Info("Snoozed filtered tasks",
+		zap.Int64("user_id", userID),
+		zap.String("filter", filter.String()),
+		zap.Time("until", until),
+		zap.Int64("tasks_moved", affected))
+
+	return int(affected), nil
+}
+
+// demoTask describes an example task created by SeedDemoTasks
+type demoTask struct {
+	title       string
+	description string
+	priority    task.Priority
+	tags        []string
+	dueInDays   int // 0 leaves the task undated
+	subtasks    []demoTask
+}
+
+// demoTasks are the example tasks new users start with
+var demoTasks = []demoTask{
+	{
+		title:       "Welcome to Tusk!",
+		description: "Press space to complete this task, e to edit it or n to create your own.",
+		priority:    task.PriorityHigh,
+		tags:        []string{"tusk"},
+	},
+	{
+		title:       "Plan a weekend trip",
+		description: "A sample project: tasks can hold subtasks, and the parent tracks their progress.",
+		priority:    task.PriorityMedium,
+		tags:        []string{"personal"},
+		subtasks: []demoTask{
+			{title: "Pick a destination", priority: task.PriorityMedium},
+			{title: "Book a place to stay", priority: task.PriorityHigh, dueInDays: 3},
+			{title: "Pack the bags", priority: task.PriorityLow},
+		},
+	},
+	{
+		title:       "Review this week's progress",
+		description: "Tasks with due dates show up in the timeline and move between Today, Upcoming and Overdue.",
+		priority:    task.PriorityLow,
+		dueInDays:   1,
+	},
+}
+
+// SeedDemoTasks creates the example tasks for a user who has no tasks yet. It is
+// safe to call repeatedly: once the user has any task nothing is created.
+func (s *taskService) SeedDemoTasks(ctx context.Context, userID int64) (int, error) {
+	if userID <= 0 {
+		return 0, errors.InvalidInput("user ID must be positive")
+	}
+
+	existing, err := s.repo.ListRootTasks(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	if len(existing) > 0 {
+		s.log.Debug("Skipping demo tasks for user with tasks",
+			zap.Int64("user_id", userID))
+		return 0, nil
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 17, 0, 0, 0, s.loc)
+
+	var create func(specs []demoTask, parentID *int64) (int, error)
+	create = func(specs []demoTask, parentID *int64) (int, error) {
+		created := 0
+		for _, spec := range specs {
+			var due *time.Time
+			if spec.dueInDays > 0 {
+				d := today.AddDate(0, 0, spec.dueInDays)
+				due = &d
+			}
+
+			t, err := s.Create(ctx, userID, parentID, spec.title, spec.description, due, spec.priority, spec.tags, nil)
+			if err != nil {
+				return created, err
+			}
+			created++
+
+			id := int64(t.ID)
+			n, err := create(spec.subtasks, &id)
+			created += n
+			if err != nil {
+				return created, err
+			}
+		}
+		return created, nil
+	}
+
+	created, err := create(demoTasks, nil)
+	if err != nil {
+		s.log.Error("Failed to seed demo tasks",
+			zap.Int64("user_id", userID),
+			zap.Int("created", created),
+			zap.Error(err))
+		return created, err
+	}
+
+	s.log.Info("Seeded demo tasks",
+		zap.Int64("user_id", userID),
+		zap.Int("created", created))
+	return created, nil
+}
+
+// RecomputeMetrics snapshots the metrics computed from a user's task trees into the
+// metrics cache, replacing whatever was cached before
+func (s *taskService) RecomputeMetrics(ctx context.Context, userID int64) (int, error) {
+	if userID <= 0 {
+		return 0, errors.InvalidInput("user ID must be positive")
+	}
+
+	trees, err := s.List(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	metrics := task.CollectMetrics(trees)
+	if err := s.repo.ReplaceTaskMetrics(ctx, userID, metrics); err != nil {
+		s.log.Error("Failed to cache task metrics",
+			zap.Int64("user_id", userID),
+			zap.Error(err))
+		return 0, err
+	}
+
+	return len(metrics), nil
+}
+
+// CachedMetrics retrieves the cached metrics of a user's parent tasks
+func (s *taskService) CachedMetrics(ctx context.Context, userID int64) ([]task.CachedMetrics, error) {
+	if userID <= 0 {
+		return nil, errors.InvalidInput("user ID must be positive")
+	}
+
+	return s.repo.ListTaskMetrics(ctx, userID)
+}
+
+// BulkUpdateStatus updates the status of multiple tasks at once. Marking tasks done
+// is refused when one of them is blocked by a dependency outside the batch, and
+// completes parents and creates next occurrences like Complete does.
 func (s *taskService) BulkUpdateStatus(ctx context.Context, taskIDs []int32, status task.Status) error {
 	if len(taskIDs) == 0 {
 		return errors.InvalidInput("task IDs list cannot be empty")
@@ -555,20 +1494,81 @@ func (s *taskService) BulkUpdateStatus(ctx context.Context, taskIDs []int32, sta
 	// Calculate if tasks should be completed based on status
 	isCompleted := status == task.StatusDone
 
-	return s.repo.BulkUpdateTaskStatus(ctx, taskIDs, status, isCompleted)
+	// The dependency checks and the update share a transaction, like in Complete
+	var completed []task.Task
+	err := s.withTx(ctx, func(tx *taskService) error {
+		if isCompleted {
+			batch := make(map[int32]bool, len(taskIDs))
+			for _, id := range taskIDs {
+				batch[id] = true
+			}
+
+			for _, id := range taskIDs {
+				existingTask, err := tx.repo.GetByID(ctx, int64(id))
+				if err != nil {
+					return err
+				}
+				if existingTask.Status == task.StatusDone {
+					continue
+				}
+				if err := tx.checkDependenciesExcept(ctx, existingTask, batch); err != nil {
+					return err
+				}
+				completed = append(completed, existingTask)
+			}
+		}
+
+		return tx.repo.BulkUpdateTaskStatus(ctx, taskIDs, status, isCompleted)
+	})
+	if err != nil {
+		s.log.Error("Failed to update task statuses",
+			zap.Int("task_count", len(taskIDs)),
+			zap.String("status", string(status)),
+			zap.Error(err))
+		return err
+	}
+
+	s.log.Info("Task statuses updated",
+		zap.Int("task_count", len(taskIDs)),
+		zap.String("status", string(status)))
+
+	for _, t := range completed {
+		t.IsCompleted = true
+		t.Status = task.StatusDone
+		s.autoCompleteParents(ctx, t)
+		s.spawnNextOccurrence(ctx, t)
+	}
+
+	return nil
 }
 
-// GetAllTags retrieves all unique tags used by a user
+// GetAllTags retrieves all unique tags used by a user. With tag case folding, tags
+// are listed lowercase, so spellings differing only in case are listed once.
 func (s *taskService) GetAllTags(ctx context.Context, userID int64) ([]string, error) {
 	if userID <= 0 {
 		return nil, errors.InvalidInput("user ID must be positive")
 	}
 
-	return s.repo.GetAllTagsForUser(ctx, userID)
+	tags, err := s.repo.GetAllTagsForUser(ctx, userID)
+	if err != nil || !s.foldTagCase {
+		return tags, err
+	}
+	// Tasks saved before folding may still carry several spellings of a tag
+	return task.NormalizeTagsLowercase(tags), nil
 }
 
 // Helper functions
 
+// withTx runs fn with a copy of the service whose repository works inside a single
+// transaction, see output.TaskRepository.WithTx
+func (s *taskService) withTx(ctx context.Context, fn func(tx *taskService) error) error {
+	return s.repo.WithTx(ctx, func(r repo.TaskRepository) error {
+		tx := *s
+		tx.repo = r
+		return fn(&tx)
+	})
+}
+
 // isValidStatus checks if a status is valid
 func isValidStatus(status task.Status) bool {
 	validStatuses := []task.Status{
@@ -603,6 +1603,23 @@ func isValidPriority(priority task.Priority) bool {
 	return false
 }
 
+// isValidEnergy checks if an energy level is valid
+func isValidEnergy(energy task.Energy) bool {
+	validEnergies := []task.Energy{
+		task.EnergyLow,
+		task.EnergyMedium,
+		task.EnergyHigh,
+	}
+
+	for _, validEnergy := range validEnergies {
+		if energy == validEnergy {
+			return true
+		}
+	}
+
+	return false
+}
+
 // truncateString truncates a string to the given max length
 func truncateString(s string, maxLen int) string {
 	if len(s) <= maxLen {
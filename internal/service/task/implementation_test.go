@@ -19,12 +19,17 @@ import (
 	"context"
 	"errors"
 	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
+	"go.uber.org/zap/zaptest/observer"
 
 	"github.com/newbpydev/tusk/internal/config"
 	domainerrors "github.com/newbpydev/tusk/internal/core/errors"
@@ -38,11 +43,21 @@ type MockTaskRepository struct {
 	mock.Mock
 }
 
+// WithTx runs fn on the mock itself; the mock has no transactions to roll back
+func (m *MockTaskRepository) WithTx(ctx context.Context, fn func(repo output.TaskRepository) error) error {
+	return fn(m)
+}
+
 func (m *MockTaskRepository) Create(ctx context.Context, t task.Task) (task.Task, error) {
 	args := m.Called(ctx, t)
 	return args.Get(0).(task.Task), args.Error(1)
 }
 
+func (m *MockTaskRepository) CreateTree(ctx context.Context, root task.Task) (task.Task, error) {
+	args := m.Called(ctx, root)
+	return args.Get(0).(task.Task), args.Error(1)
+}
+
 func (m *MockTaskRepository) Update(ctx context.Context, t task.Task) error {
 	args := m.Called(ctx, t)
 	return args.Error(0)
@@ -78,6 +93,11 @@ func (m *MockTaskRepository) ReorderTask(ctx context.Context, taskID int64, newO
 	return args.Error(0)
 }
 
+func (m *MockTaskRepository) NormalizeDisplayOrder(ctx context.Context, userID int64) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockTaskRepository) SearchTasksByTitle(ctx context.Context, userID int64, titlePattern string) ([]task.Task, error) {
 	args := m.Called(ctx, userID, titlePattern)
 	return args.Get(0).([]task.Task), args.Error(1)
@@ -88,8 +108,8 @@ func (m *MockTaskRepository) SearchTasksByTag(ctx context.Context, userID int64,
 	return args.Get(0).([]task.Task), args.Error(1)
 }
 
-func (m *MockTaskRepository) ListTasksByStatus(ctx context.Context, userID int64, status task.Status) ([]task.Task, error) {
-	args := m.Called(ctx, userID, status)
+func (m *MockTaskRepository) ListTasksByStatuses(ctx context.Context, userID int64, statuses []task.Status) ([]task.Task, error) {
+	args := m.Called(ctx, userID, statuses)
 	return args.Get(0).([]task.Task), args.Error(1)
 }
 
@@ -103,8 +123,8 @@ func (m *MockTaskRepository) ListTasksDueToday(ctx context.Context, userID int64
 	return args.Get(0).([]task.Task), args.Error(1)
 }
 
-func (m *MockTaskRepository) ListTasksDueSoon(ctx context.Context, userID int64) ([]task.Task, error) {
-	args := m.Called(ctx, userID)
+func (m *MockTaskRepository) ListTasksDueSoon(ctx context.Context, userID int64, days int) ([]task.Task, error) {
+	args := m.Called(ctx, userID, days)
 	return args.Get(0).([]task.Task), args.Error(1)
 }
 
@@ -113,6 +133,11 @@ func (m *MockTaskRepository) ListOverdueTasks(ctx context.Context, userID int64)
 	return args.Get(0).([]task.Task), args.Error(1)
 }
 
+func (m *MockTaskRepository) ListIncompleteDatedTasks(ctx context.Context, userID int64) ([]task.Task, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]task.Task), args.Error(1)
+}
+
 func (m *MockTaskRepository) GetTaskCountsByStatus(ctx context.Context, userID int64) (output.TaskStatusCounts, error) {
 	args := m.Called(ctx, userID)
 	return args.Get(0).(output.TaskStatusCounts), args.Error(1)
@@ -128,6 +153,141 @@ func (m *MockTaskRepository) GetRecentlyCompletedTasks(ctx context.Context, user
 	return args.Get(0).([]task.Task), args.Error(1)
 }
 
+func (m *MockTaskRepository) ReplaceTaskMetrics(ctx context.Context, userID int64, metrics []task.CachedMetrics) error {
+	args := m.Called(ctx, userID, metrics)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) ListTaskMetrics(ctx context.Context, userID int64) ([]task.CachedMetrics, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]task.CachedMetrics), args.Error(1)
+}
+
+func (m *MockTaskRepository) PostponeTasks(ctx context.Context, taskIDs []int32, days int) (int64, error) {
+	args := m.Called(ctx, taskIDs, days)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockTaskRepository) SnoozeTasks(ctx context.Context, userID int64, taskIDs []int32, until time.Time) (int64, error) {
+	args := m.Called(ctx, userID, taskIDs, until)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockTaskRepository) ReparentTask(ctx context.Context, taskID int64, newParentID *int64) error {
+	args := m.Called(ctx, taskID, newParentID)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) SwapTaskOrder(ctx context.Context, aID, bID int64) error {
+	args := m.Called(ctx, aID, bID)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) SetExternalRef(ctx context.Context, taskID int64, source, externalID *string) error {
+	args := m.Called(ctx, taskID, source, externalID)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) GetByExternalID(ctx context.Context, source, externalID string) (task.Task, error) {
+	args := m.Called(ctx, source, externalID)
+	return args.Get(0).(task.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) ListTasksFiltered(ctx context.Context, userID int64, filter task.TaskFilter) ([]task.Task, error) {
+	args := m.Called(ctx, userID, filter)
+	return args.Get(0).([]task.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) Archive(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) Restore(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) ListArchived(ctx context.Context, userID int64) ([]task.Task, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]task.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) AddDependency(ctx context.Context, taskID, dependsOnID int64) error {
+	args := m.Called(ctx, taskID, dependsOnID)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) RemoveDependency(ctx context.Context, taskID, dependsOnID int64) error {
+	args := m.Called(ctx, taskID, dependsOnID)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) ListDependencies(ctx context.Context, taskID int64) ([]int32, error) {
+	args := m.Called(ctx, taskID)
+	return args.Get(0).([]int32), args.Error(1)
+}
+
+func (m *MockTaskRepository) ListBlockedTaskIDs(ctx context.Context, userID int64) ([]int32, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]int32), args.Error(1)
+}
+
+func (m *MockTaskRepository) PinToDay(ctx context.Context, taskID int64) error {
+	args := m.Called(ctx, taskID)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) UnpinFromDay(ctx context.Context, taskID int64) error {
+	args := m.Called(ctx, taskID)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) ListDayPinnedTaskIDs(ctx context.Context, userID int64) ([]int32, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]int32), args.Error(1)
+}
+
+func (m *MockTaskRepository) AddNote(ctx context.Context, taskID int64, body string) (task.TaskNote, error) {
+	args := m.Called(ctx, taskID, body)
+	return args.Get(0).(task.TaskNote), args.Error(1)
+}
+
+func (m *MockTaskRepository) ListNotes(ctx context.Context, taskID int64) ([]task.TaskNote, error) {
+	args := m.Called(ctx, taskID)
+	return args.Get(0).([]task.TaskNote), args.Error(1)
+}
+
+func (m *MockTaskRepository) SearchTasksByText(ctx context.Context, userID int64, query string) ([]task.Task, error) {
+	args := m.Called(ctx, userID, query)
+	return args.Get(0).([]task.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) ListRootTasksPaged(ctx context.Context, userID int64, limit, offset int) ([]task.Task, error) {
+	args := m.Called(ctx, userID, limit, offset)
+	return args.Get(0).([]task.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) CountRootTasks(ctx context.Context, userID int64) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTaskRepository) GetTaskTrees(ctx context.Context, rootIDs []int64) ([]task.Task, error) {
+	args := m.Called(ctx, rootIDs)
+	return args.Get(0).([]task.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) GetTaskForest(ctx context.Context, userID int64) ([]task.Task, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]task.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) SetTaskTags(ctx context.Context, userID int64, tags map[int32][]string) (int64, error) {
+	args := m.Called(ctx, userID, tags)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockTaskRepository) BulkUpdateTaskStatus(ctx context.Context, taskIDs []int32, status task.Status, isCompleted bool) error {
 	args := m.Called(ctx, taskIDs, status, isCompleted)
 	return args.Error(0)
@@ -248,7 +408,7 @@ func TestCreateTask(t *testing.T) {
 			taskService := newTestTaskService(mockRepo)
 
 			// Call the Create function
-			createdTask, err := taskService.Create(context.Background(), tc.userID, tc.parentID, tc.title, tc.description, tc.dueDate, tc.priority, tc.tags)
+			createdTask, err := taskService.Create(context.Background(), tc.userID, tc.parentID, tc.title, tc.description, tc.dueDate, tc.priority, tc.tags, nil)
 
 			// Check error
 			if tc.expectedError {
@@ -347,17 +507,11 @@ func TestListTasks(t *testing.T) {
 			name:   "Valid task listing with root tasks",
 			userID: 1,
 			mockSetup: func(mockRepo *MockTaskRepository) {
-				rootTasks := []task.Task{
-					{ID: 1, UserID: 1, Title: "Task 1"},
-					{ID: 2, UserID: 1, Title: "Task 2"},
-				}
-				mockRepo.On("ListRootTasks", mock.Anything, int64(1)).Return(rootTasks, nil)
-
-				// Set up expectations for GetTaskTree for each root task
-				mockRepo.On("GetTaskTree", mock.Anything, int64(1)).Return(
-					task.Task{ID: 1, UserID: 1, Title: "Task 1", SubTasks: []task.Task{}}, nil)
-				mockRepo.On("GetTaskTree", mock.Anything, int64(2)).Return(
-					task.Task{ID: 2, UserID: 1, Title: "Task 2", SubTasks: []task.Task{}}, nil)
+				// All the trees are loaded with a single call
+				mockRepo.On("GetTaskForest", mock.Anything, int64(1)).Return([]task.Task{
+					{ID: 1, UserID: 1, Title: "Task 1", SubTasks: []task.Task{}},
+					{ID: 2, UserID: 1, Title: "Task 2", SubTasks: []task.Task{}},
+				}, nil)
 			},
 			expectedError: false,
 			expectedCount: 2,
@@ -373,22 +527,24 @@ func TestListTasks(t *testing.T) {
 			name:   "Repository error",
 			userID: 1,
 			mockSetup: func(mockRepo *MockTaskRepository) {
-				mockRepo.On("ListRootTasks", mock.Anything, int64(1)).Return([]task.Task{}, errors.New("repository error"))
+				mockRepo.On("GetTaskForest", mock.Anything, int64(1)).Return([]task.Task(nil), errors.New("repository error"))
 			},
 			expectedError:  true,
 			expectedErrMsg: "repository error",
 		},
 		{
-			name:   "Error retrieving task tree",
+			name:   "Subtasks come with their roots",
 			userID: 1,
 			mockSetup: func(mockRepo *MockTaskRepository) {
-				rootTasks := []task.Task{
-					{ID: 1, UserID: 1, Title: "Task 1"},
-				}
-				mockRepo.On("ListRootTasks", mock.Anything, int64(1)).Return(rootTasks, nil)
-				mockRepo.On("GetTaskTree", mock.Anything, int64(1)).Return(task.Task{}, errors.New("error retrieving tree"))
+				parentID := int32(1)
+				mockRepo.On("GetTaskForest", mock.Anything, int64(1)).Return([]task.Task{
+					{ID: 1, UserID: 1, Title: "Project", TotalCount: 1, SubTasks: []task.Task{
+						{ID: 2, UserID: 1, ParentID: &parentID, Title: "Step"},
+					}},
+				}, nil)
+				// No tree is loaded per root task any more
 			},
-			expectedError: false, // The function continues even if it can't get the tree for a task
+			expectedError: false,
 			expectedCount: 1,
 		},
 	}
@@ -606,6 +762,3683 @@ func TestCompleteTask(t *testing.T) {
 	}
 }
 
+func TestReadyParents(t *testing.T) {
+	// Tree used by the test cases:
+	// 1 (root) -> 2 (project) -> 3 (subtask being completed), 4 (sibling)
+	testCases := []struct {
+		name            string
+		taskID          int64
+		mockSetup       func(*MockTaskRepository)
+		expectedParents []int32
+		expectedError   bool
+		expectedErrMsg  string
+	}{
+		{
+			name:   "Last open subtask readies parent and grandparent",
+			taskID: 3,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(3)).Return(task.Task{ID: 3, ParentID: int32Ptr(2)}, nil)
+				mockRepo.On("GetByID", mock.Anything, int64(2)).Return(task.Task{ID: 2, ParentID: int32Ptr(1)}, nil)
+				mockRepo.On("ListSubTasks", mock.Anything, int64(2)).Return([]task.Task{
+					{ID: 3, ParentID: int32Ptr(2)},
+					{ID: 4, ParentID: int32Ptr(2), IsCompleted: true, Status: task.StatusDone},
+				}, nil)
+				mockRepo.On("GetByID", mock.Anything, int64(1)).Return(task.Task{ID: 1}, nil)
+				mockRepo.On("ListSubTasks", mock.Anything, int64(1)).Return([]task.Task{
+					{ID: 2, ParentID: int32Ptr(1)},
+				}, nil)
+			},
+			expectedParents: []int32{2, 1},
+		},
+		{
+			name:   "Open sibling keeps parent open",
+			taskID: 3,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(3)).Return(task.Task{ID: 3, ParentID: int32Ptr(2)}, nil)
+				mockRepo.On("GetByID", mock.Anything, int64(2)).Return(task.Task{ID: 2, ParentID: int32Ptr(1)}, nil)
+				mockRepo.On("ListSubTasks", mock.Anything, int64(2)).Return([]task.Task{
+					{ID: 3, ParentID: int32Ptr(2)},
+					{ID: 4, ParentID: int32Ptr(2)},
+				}, nil)
+			},
+			expectedParents: nil,
+		},
+		{
+			name:   "Root task has no parents",
+			taskID: 1,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(1)).Return(task.Task{ID: 1}, nil)
+			},
+			expectedParents: nil,
+		},
+		{
+			name:   "Cyclic parent links stop the walk",
+			taskID: 5,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(5)).Return(task.Task{ID: 5, ParentID: int32Ptr(6)}, nil)
+				mockRepo.On("GetByID", mock.Anything, int64(6)).Return(task.Task{ID: 6, ParentID: int32Ptr(5)}, nil)
+				mockRepo.On("ListSubTasks", mock.Anything, int64(6)).Return([]task.Task{
+					{ID: 5, ParentID: int32Ptr(6)},
+				}, nil)
+			},
+			expectedParents: []int32{6},
+		},
+		{
+			name:           "Invalid task ID",
+			taskID:         0,
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedError:  true,
+			expectedErrMsg: "task ID must be positive",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			tc.mockSetup(mockRepo)
+
+			taskService := newTestTaskService(mockRepo)
+
+			parents, err := taskService.ReadyParents(context.Background(), tc.taskID)
+
+			if tc.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+				var ids []int32
+				for _, p := range parents {
+					ids = append(ids, p.ID)
+				}
+				assert.Equal(t, tc.expectedParents, ids)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCompleteRecurringTask(t *testing.T) {
+	year := time.Now().Year() + 1
+	jan31 := time.Date(year, time.January, 31, 9, 0, 0, 0, time.Local)
+	lastOfFeb := time.Date(year, time.March, 0, 9, 0, 0, 0, time.Local)
+
+	testCases := []struct {
+		name        string
+		existing    task.Task
+		viaStatus   bool
+		expectedDue *time.Time
+	}{
+		{
+			name:        "Weekly task due on the 31st",
+			existing:    task.Task{Status: task.StatusTodo, DueDate: &jan31, Recurrence: &task.Recurrence{Frequency: task.FrequencyWeekly, Interval: 1}},
+			expectedDue: timePtr(time.Date(year, time.February, 7, 9, 0, 0, 0, time.Local)),
+		},
+		{
+			name:        "Monthly task due on the 31st clamps to a short month",
+			existing:    task.Task{Status: task.StatusTodo, DueDate: &jan31, Recurrence: &task.Recurrence{Frequency: task.FrequencyMonthly, Interval: 1}},
+			expectedDue: &lastOfFeb,
+		},
+		{
+			name:        "Completed through ChangeStatus",
+			existing:    task.Task{Status: task.StatusInProgress, DueDate: &jan31, Recurrence: &task.Recurrence{Frequency: task.FrequencyDaily, Interval: 2}},
+			viaStatus:   true,
+			expectedDue: timePtr(time.Date(year, time.February, 2, 9, 0, 0, 0, time.Local)),
+		},
+		{
+			name:     "Already done does not spawn again",
+			existing: task.Task{Status: task.StatusDone, IsCompleted: true, DueDate: &jan31, Recurrence: &task.Recurrence{Frequency: task.FrequencyWeekly, Interval: 1}},
+		},
+		{
+			name:     "Non-recurring task does not spawn",
+			existing: task.Task{Status: task.StatusTodo, DueDate: &jan31},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+
+			existing := tc.existing
+			existing.ID, existing.UserID, existing.Title = 1, 1, "Take out the trash"
+			done := existing
+			done.Status, done.IsCompleted = task.StatusDone, true
+
+			mockRepo.On("GetByID", mock.Anything, int64(1)).Return(existing, nil).Once()
+			mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+			mockRepo.On("GetByID", mock.Anything, int64(1)).Return(done, nil).Once()
+			if tc.expectedDue != nil {
+				mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(t task.Task) bool {
+					return t.ID == 0 && t.Title == "Take out the trash" && t.Status == task.StatusTodo &&
+						!t.IsCompleted && t.DueDate.Equal(*tc.expectedDue) &&
+						*t.Recurrence == *existing.Recurrence
+				})).Return(task.Task{ID: 2, DueDate: tc.expectedDue}, nil)
+			}
+
+			taskService := newTestTaskService(mockRepo)
+
+			var err error
+			if tc.viaStatus {
+				_, err = taskService.ChangeStatus(context.Background(), 1, task.StatusDone)
+			} else {
+				_, err = taskService.Complete(context.Background(), 1)
+			}
+
+			assert.NoError(t, err)
+			mockRepo.AssertExpectations(t)
+			if tc.expectedDue == nil {
+				mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestCompleteWithParentCompletion(t *testing.T) {
+	testCases := []struct {
+		name              string
+		mode              ParentCompletionMode
+		expectParentWrite bool
+	}{
+		{name: "Auto completes ready parent", mode: ParentCompletionAuto, expectParentWrite: true},
+		{name: "Prompt leaves parent to the caller", mode: ParentCompletionPrompt, expectParentWrite: false},
+		{name: "Never leaves parent untouched", mode: ParentCompletionNever, expectParentWrite: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+
+			mockRepo.On("GetByID", mock.Anything, int64(2)).Return(task.Task{
+				ID: 2, UserID: 1, ParentID: int32Ptr(1), Status: task.StatusTodo,
+			}, nil).Once()
+			mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(t task.Task) bool {
+				return t.ID == 2 && t.IsCompleted
+			})).Return(nil).Once()
+			mockRepo.On("GetByID", mock.Anything, int64(2)).Return(task.Task{
+				ID: 2, UserID: 1, ParentID: int32Ptr(1), Status: task.StatusDone, IsCompleted: true,
+			}, nil).Once()
+
+			if tc.expectParentWrite {
+				mockRepo.On("GetByID", mock.Anything, int64(1)).Return(task.Task{ID: 1, UserID: 1}, nil)
+				mockRepo.On("ListSubTasks", mock.Anything, int64(1)).Return([]task.Task{
+					{ID: 2, ParentID: int32Ptr(1), IsCompleted: true},
+				}, nil)
+				mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(t task.Task) bool {
+					return t.ID == 1 && t.IsCompleted && t.Status == task.StatusDone
+				})).Return(nil).Once()
+			}
+
+			taskService := NewTaskService(mockRepo, WithParentCompletion(tc.mode))
+
+			completedTask, err := taskService.Complete(context.Background(), 2)
+
+			assert.NoError(t, err)
+			assert.True(t, completedTask.IsCompleted)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestParseParentCompletionMode(t *testing.T) {
+	testCases := []struct {
+		input         string
+		expected      ParentCompletionMode
+		expectedError bool
+	}{
+		{input: "auto", expected: ParentCompletionAuto},
+		{input: " Prompt ", expected: ParentCompletionPrompt},
+		{input: "never", expected: ParentCompletionNever},
+		{input: "", expected: ParentCompletionNever},
+		{input: "sometimes", expected: ParentCompletionNever, expectedError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			mode, err := ParseParentCompletionMode(tc.input)
+			assert.Equal(t, tc.expected, mode)
+			assert.Equal(t, tc.expectedError, err != nil)
+		})
+	}
+}
+
+func TestNormalizeOrder(t *testing.T) {
+	testCases := []struct {
+		name           string
+		userID         int64
+		mockSetup      func(*MockTaskRepository)
+		expectedError  bool
+		expectedErrMsg string
+	}{
+		{
+			name:   "Valid normalization",
+			userID: 1,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("NormalizeDisplayOrder", mock.Anything, int64(1)).Return(int64(3), nil)
+			},
+		},
+		{
+			name:           "Invalid user ID",
+			userID:         0,
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedError:  true,
+			expectedErrMsg: "user ID must be positive",
+		},
+		{
+			name:   "Repository error",
+			userID: 1,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("NormalizeDisplayOrder", mock.Anything, int64(1)).Return(int64(0), errors.New("database error"))
+			},
+			expectedError:  true,
+			expectedErrMsg: "database error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			tc.mockSetup(mockRepo)
+
+			taskService := newTestTaskService(mockRepo)
+
+			err := taskService.NormalizeOrder(context.Background(), tc.userID)
+
+			if tc.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCompactOrders(t *testing.T) {
+	t.Run("Returns how many tasks were renumbered", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("NormalizeDisplayOrder", mock.Anything, int64(1)).Return(int64(3), nil).Once()
+		taskService := newTestTaskService(mockRepo)
+
+		renumbered, err := taskService.CompactOrders(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, renumbered)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid user ID", func(t *testing.T) {
+		taskService := newTestTaskService(new(MockTaskRepository))
+
+		_, err := taskService.CompactOrders(context.Background(), 0)
+
+		assert.True(t, domainerrors.IsInvalidInput(err))
+	})
+
+	t.Run("Async service drops the user's cached tasks", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("NormalizeDisplayOrder", mock.Anything, int64(1)).Return(int64(2), nil).Once()
+		svc := NewAsyncTaskService(newTestTaskService(mockRepo), zaptest.NewLogger(t))
+		t.Cleanup(svc.Close)
+		svc.cacheTask(task.Task{ID: 7, UserID: 1, DisplayOrder: 4})
+		svc.storeUserTasks(1, []task.Task{{ID: 7, UserID: 1, DisplayOrder: 4}})
+
+		renumbered, err := svc.CompactOrders(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, renumbered)
+		_, ok := svc.loadTask(7)
+		assert.False(t, ok)
+		_, ok = svc.loadUserTasks(1)
+		assert.False(t, ok)
+	})
+}
+
+func TestListChildren(t *testing.T) {
+	parentID := int32(1)
+	children := []task.Task{
+		{ID: 2, ParentID: &parentID, Title: "First", DisplayOrder: 0},
+		{ID: 3, ParentID: &parentID, Title: "Second", DisplayOrder: 1},
+	}
+
+	testCases := []struct {
+		name           string
+		parentID       int64
+		mockSetup      func(*MockTaskRepository)
+		expectedTasks  []task.Task
+		expectedError  bool
+		expectedErrMsg string
+	}{
+		{
+			name:     "Returns direct children",
+			parentID: 1,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(1)).Return(task.Task{ID: 1}, nil)
+				mockRepo.On("ListSubTasks", mock.Anything, int64(1)).Return(children, nil)
+			},
+			expectedTasks: children,
+		},
+		{
+			name:           "Invalid parent ID",
+			parentID:       0,
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedError:  true,
+			expectedErrMsg: "task ID must be positive",
+		},
+		{
+			name:     "Parent not found",
+			parentID: 99,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(99)).Return(task.Task{}, errors.New("task not found"))
+			},
+			expectedError:  true,
+			expectedErrMsg: "task not found",
+		},
+		{
+			name:     "Repository error",
+			parentID: 1,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(1)).Return(task.Task{ID: 1}, nil)
+				mockRepo.On("ListSubTasks", mock.Anything, int64(1)).Return([]task.Task(nil), errors.New("database error"))
+			},
+			expectedError:  true,
+			expectedErrMsg: "database error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			tc.mockSetup(mockRepo)
+
+			taskService := newTestTaskService(mockRepo)
+
+			result, err := taskService.ListChildren(context.Background(), tc.parentID)
+
+			if tc.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedTasks, result)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateNormalizesTags(t *testing.T) {
+	testCases := []struct {
+		name         string
+		keepCase     bool
+		tags         []string
+		expectedTags []task.Tag
+	}{
+		{
+			name:         "Trims, drops blanks and duplicates",
+			tags:         []string{" work ", "", "home", "work", "  "},
+			expectedTags: []task.Tag{{Name: "work"}, {Name: "home"}},
+		},
+		{
+			name:         "Case variants are lowercased into one tag",
+			tags:         []string{"Work", "work", " work ", "HOME"},
+			expectedTags: []task.Tag{{Name: "work"}, {Name: "home"}},
+		},
+		{
+			name:         "Unicode tags are lowercased",
+			tags:         []string{"Café", "CAFÉ", "日本"},
+			expectedTags: []task.Tag{{Name: "café"}, {Name: "日本"}},
+		},
+		{
+			name:         "Keeps case variants without folding",
+			keepCase:     true,
+			tags:         []string{"Work", "home", "work", " Work "},
+			expectedTags: []task.Tag{{Name: "Work"}, {Name: "home"}, {Name: "work"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(nt task.Task) bool {
+				return assert.ObjectsAreEqual(tc.expectedTags, nt.Tags)
+			})).Return(task.Task{ID: 1, Tags: tc.expectedTags}, nil)
+
+			taskService := NewTaskService(mockRepo, WithTagCaseFolding(!tc.keepCase))
+
+			result, err := taskService.Create(context.Background(), 1, nil, "Task", "", nil, task.PriorityLow, tc.tags, nil)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedTags, result.Tags)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestInvalidTagsAreRejected(t *testing.T) {
+	testCases := []struct {
+		name           string
+		tags           []string
+		expectedErrMsg string
+	}{
+		{name: "Comma", tags: []string{"work", "home,errands"}, expectedErrMsg: "must not contain a comma"},
+		{name: "Control character", tags: []string{"deep\twork"}, expectedErrMsg: "must not contain control characters"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			taskService := NewTaskService(mockRepo)
+
+			_, err := taskService.Create(context.Background(), 1, nil, "Task", "", nil, task.PriorityLow, tc.tags, nil)
+			assert.ErrorContains(t, err, tc.expectedErrMsg)
+			assert.True(t, domainerrors.IsInvalidInput(err))
+
+			_, err = taskService.Update(context.Background(), 1, "Task", "", nil, task.PriorityLow, tc.tags)
+			assert.ErrorContains(t, err, tc.expectedErrMsg)
+			assert.True(t, domainerrors.IsInvalidInput(err))
+
+			// Nothing reaches the repository
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetAllTags(t *testing.T) {
+	testCases := []struct {
+		name     string
+		keepCase bool
+		expected []string
+	}{
+		{name: "Every spelling without folding", keepCase: true, expected: []string{"Work", "home", "work"}},
+		{name: "Case variants once, lowercased", expected: []string{"work", "home"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			mockRepo.On("GetAllTagsForUser", mock.Anything, int64(1)).
+				Return([]string{"Work", "home", "work"}, nil)
+
+			taskService := NewTaskService(mockRepo, WithTagCaseFolding(!tc.keepCase))
+
+			tags, err := taskService.GetAllTags(context.Background(), 1)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, tags)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSearchByTagTrimsTheTag(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockRepo.On("SearchTasksByTag", mock.Anything, int64(1), "work").
+		Return([]task.Task{{ID: 1}}, nil)
+
+	taskService := NewTaskService(mockRepo)
+
+	tasks, err := taskService.SearchByTag(context.Background(), 1, " work ")
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 1)
+
+	_, err = taskService.SearchByTag(context.Background(), 1, "  ")
+	assert.ErrorContains(t, err, "tag is required")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDedupeTags(t *testing.T) {
+	parentID := int32(1)
+
+	testCases := []struct {
+		name            string
+		userID          int64
+		mockSetup       func(*MockTaskRepository)
+		expectedUpdated int
+		expectedError   bool
+		expectedErrMsg  string
+	}{
+		{
+			name:   "Updates only tasks with duplicate tags",
+			userID: 1,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				root := task.Task{ID: 1, UserID: 1, Tags: []task.Tag{{Name: "work"}}}
+				root.SubTasks = []task.Task{
+					{ID: 2, UserID: 1, ParentID: &parentID, Tags: []task.Tag{{Name: "a"}, {Name: "b"}, {Name: "a"}}},
+				}
+				mockRepo.On("GetTaskForest", mock.Anything, int64(1)).Return([]task.Task{root}, nil)
+				mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(ut task.Task) bool {
+					return ut.ID == 2 && assert.ObjectsAreEqual([]task.Tag{{Name: "a"}, {Name: "b"}}, ut.Tags)
+				})).Return(nil).Once()
+			},
+			expectedUpdated: 1,
+		},
+		{
+			name:   "Lowercases case variants saved before folding",
+			userID: 1,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				root := task.Task{ID: 1, UserID: 1, Tags: []task.Tag{{Name: "Work"}, {Name: "work"}, {Name: "home"}}}
+				mockRepo.On("GetTaskForest", mock.Anything, int64(1)).Return([]task.Task{root}, nil)
+				mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(ut task.Task) bool {
+					return ut.ID == 1 && assert.ObjectsAreEqual([]task.Tag{{Name: "work"}, {Name: "home"}}, ut.Tags)
+				})).Return(nil).Once()
+			},
+			expectedUpdated: 1,
+		},
+		{
+			name:           "Invalid user ID",
+			userID:         0,
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedError:  true,
+			expectedErrMsg: "user ID must be positive",
+		},
+		{
+			name:   "Update error",
+			userID: 1,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				root := task.Task{ID: 1, UserID: 1, Tags: []task.Tag{{Name: "x"}, {Name: "x"}}}
+				mockRepo.On("GetTaskForest", mock.Anything, int64(1)).Return([]task.Task{root}, nil)
+				mockRepo.On("Update", mock.Anything, mock.AnythingOfType("task.Task")).Return(errors.New("database error"))
+			},
+			expectedError:  true,
+			expectedErrMsg: "database error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			tc.mockSetup(mockRepo)
+
+			taskService := newTestTaskService(mockRepo)
+
+			updated, err := taskService.DedupeTags(context.Background(), tc.userID)
+
+			if tc.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedUpdated, updated)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMergeTagCase(t *testing.T) {
+	parentID := int32(1)
+
+	testCases := []struct {
+		name            string
+		userID          int64
+		canonical       string
+		keepCase        bool
+		mockSetup       func(*MockTaskRepository)
+		expectedUpdated int
+		expectedErrMsg  string
+	}{
+		{
+			name:      "Rewrites every spelling in one call",
+			userID:    1,
+			canonical: " Work ",
+			keepCase:  true,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				root := task.Task{ID: 1, UserID: 1, Tags: []task.Tag{{Name: "work"}, {Name: "home"}}}
+				root.SubTasks = []task.Task{
+					{ID: 2, UserID: 1, ParentID: &parentID, Tags: []task.Tag{{Name: "Work"}}},
+					{ID: 3, UserID: 1, ParentID: &parentID, Tags: []task.Tag{{Name: "a"}, {Name: "WORK"}, {Name: "Work"}}},
+				}
+				mockRepo.On("GetTaskForest", mock.Anything, int64(1)).Return([]task.Task{root}, nil)
+				mockRepo.On("SetTaskTags", mock.Anything, int64(1), map[int32][]string{
+					1: {"Work", "home"},
+					3: {"a", "Work"},
+				}).Return(int64(2), nil).Once()
+			},
+			expectedUpdated: 2,
+		},
+		{
+			name:      "Merges into the lowercase spelling with folding",
+			userID:    1,
+			canonical: "Work",
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				root := task.Task{ID: 1, UserID: 1, Tags: []task.Tag{{Name: "work"}}}
+				root.SubTasks = []task.Task{
+					{ID: 2, UserID: 1, ParentID: &parentID, Tags: []task.Tag{{Name: "a"}, {Name: "WORK"}}},
+				}
+				mockRepo.On("GetTaskForest", mock.Anything, int64(1)).Return([]task.Task{root}, nil)
+				mockRepo.On("SetTaskTags", mock.Anything, int64(1), map[int32][]string{
+					2: {"a", "work"},
+				}).Return(int64(1), nil).Once()
+			},
+			expectedUpdated: 1,
+		},
+		{
+			name:      "Nothing to merge",
+			userID:    1,
+			canonical: "work",
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				root := task.Task{ID: 1, UserID: 1, Tags: []task.Tag{{Name: "work"}}}
+				mockRepo.On("GetTaskForest", mock.Anything, int64(1)).Return([]task.Task{root}, nil)
+			},
+			expectedUpdated: 0,
+		},
+		{
+			name:           "Empty tag",
+			userID:         1,
+			canonical:      "  ",
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedErrMsg: "tag cannot be empty",
+		},
+		{
+			name:      "Update error",
+			userID:    1,
+			canonical: "x",
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				root := task.Task{ID: 1, UserID: 1, Tags: []task.Tag{{Name: "X"}}}
+				mockRepo.On("GetTaskForest", mock.Anything, int64(1)).Return([]task.Task{root}, nil)
+				mockRepo.On("SetTaskTags", mock.Anything, int64(1), mock.Anything).Return(int64(0), errors.New("database error"))
+			},
+			expectedErrMsg: "database error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			tc.mockSetup(mockRepo)
+
+			taskService := NewTaskService(mockRepo, WithTagCaseFolding(!tc.keepCase))
+
+			updated, err := taskService.MergeTagCase(context.Background(), tc.userID, tc.canonical)
+
+			if tc.expectedErrMsg != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedUpdated, updated)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNextTasks(t *testing.T) {
+	now := time.Now()
+	overdue := now.Add(-48 * time.Hour)
+	today := now.Add(2 * time.Hour)
+	nextWeek := now.Add(7 * 24 * time.Hour)
+
+	dated := []task.Task{
+		{ID: 1, Title: "Next week high", DueDate: &nextWeek, Priority: task.PriorityHigh},
+		{ID: 2, Title: "Overdue low", DueDate: &overdue, Priority: task.PriorityLow},
+		{ID: 3, Title: "Today medium", DueDate: &today, Priority: task.PriorityMedium},
+		{ID: 4, Title: "Next week low", DueDate: &nextWeek, Priority: task.PriorityLow},
+	}
+
+	testCases := []struct {
+		name           string
+		userID         int64
+		limit          int
+		mockSetup      func(*MockTaskRepository)
+		expectedIDs    []int32
+		expectedError  bool
+		expectedErrMsg string
+	}{
+		{
+			name:   "Most urgent first, capped at limit",
+			userID: 1,
+			limit:  3,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("ListIncompleteDatedTasks", mock.Anything, int64(1)).
+					Return(append([]task.Task(nil), dated...), nil)
+			},
+			expectedIDs: []int32{2, 3, 1},
+		},
+		{
+			name:           "Invalid limit",
+			userID:         1,
+			limit:          0,
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedError:  true,
+			expectedErrMsg: "limit must be positive",
+		},
+		{
+			name:   "Repository error",
+			userID: 1,
+			limit:  3,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("ListIncompleteDatedTasks", mock.Anything, int64(1)).
+					Return([]task.Task(nil), errors.New("database error"))
+			},
+			expectedError:  true,
+			expectedErrMsg: "database error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			tc.mockSetup(mockRepo)
+
+			taskService := newTestTaskService(mockRepo)
+
+			result, err := taskService.NextTasks(context.Background(), tc.userID, tc.limit)
+
+			if tc.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+				ids := make([]int32, len(result))
+				for i, r := range result {
+					ids[i] = r.ID
+				}
+				assert.Equal(t, tc.expectedIDs, ids)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMostUrgent(t *testing.T) {
+	now := time.Now()
+	overdue := now.Add(-48 * time.Hour)
+	nextWeek := now.Add(7 * 24 * time.Hour)
+
+	dated := []task.Task{
+		{ID: 1, Title: "Next week high", DueDate: &nextWeek, Priority: task.PriorityHigh},
+		{ID: 2, Title: "Overdue low", DueDate: &overdue, Priority: task.PriorityLow},
+	}
+	priorityFirst := task.DefaultUrgencyWeights()
+	priorityFirst.Overdue = 0
+	priorityFirst.Priority = 100
+
+	testCases := []struct {
+		name           string
+		userID         int64
+		opts           []Option
+		mockSetup      func(*MockTaskRepository)
+		expectedID     int32
+		expectedFound  bool
+		expectedError  bool
+		expectedErrMsg string
+	}{
+		{
+			name:   "Overdue task with default weights",
+			userID: 1,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("ListIncompleteDatedTasks", mock.Anything, int64(1)).
+					Return(append([]task.Task(nil), dated...), nil)
+			},
+			expectedID:    2,
+			expectedFound: true,
+		},
+		{
+			name:   "Custom weights",
+			userID: 1,
+			opts:   []Option{WithUrgencyWeights(priorityFirst)},
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("ListIncompleteDatedTasks", mock.Anything, int64(1)).
+					Return(append([]task.Task(nil), dated...), nil)
+			},
+			expectedID:    1,
+			expectedFound: true,
+		},
+		{
+			name:   "Nothing due",
+			userID: 1,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("ListIncompleteDatedTasks", mock.Anything, int64(1)).
+					Return([]task.Task{}, nil)
+			},
+			expectedFound: false,
+		},
+		{
+			name:   "Repository error",
+			userID: 1,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("ListIncompleteDatedTasks", mock.Anything, int64(1)).
+					Return([]task.Task(nil), errors.New("database error"))
+			},
+			expectedError:  true,
+			expectedErrMsg: "database error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			tc.mockSetup(mockRepo)
+
+			taskService := NewTaskService(mockRepo, tc.opts...)
+
+			result, found, err := taskService.MostUrgent(context.Background(), tc.userID)
+
+			if tc.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				assert.False(t, found)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedFound, found)
+				assert.Equal(t, tc.expectedID, result.ID)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestClone(t *testing.T) {
+	description := "Notes"
+	due := time.Date(2025, time.March, 10, 9, 0, 0, 0, time.UTC)
+	parentID, sourceID := int32(5), int32(10)
+	created := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	source := task.Task{
+		ID: 10, UserID: 1, ParentID: &parentID, Title: "Write report", Description: &description,
+		DueDate: &due, Priority: task.PriorityHigh, Tags: []task.Tag{{Name: "work"}},
+		Status: task.StatusDone, IsCompleted: true, CreatedAt: created, UpdatedAt: created,
+		SubTasks: []task.Task{
+			{ID: 11, UserID: 1, ParentID: &sourceID, Title: "Outline", Status: task.StatusInProgress, Priority: task.PriorityLow,
+				SubTasks: []task.Task{{ID: 12, UserID: 1, Title: "Headings", Status: task.StatusDone, IsCompleted: true}}},
+		},
+	}
+
+	// isClone checks the fields every clone gets, whatever its source
+	isClone := func(t task.Task, title string, parent int32) bool {
+		return t.ID == 0 && t.UserID == 1 && t.Title == title && t.ParentID != nil && *t.ParentID == parent &&
+			t.Status == task.StatusTodo && !t.IsCompleted && t.CreatedAt.After(created) && t.UpdatedAt.Equal(t.CreatedAt)
+	}
+
+	testCases := []struct {
+		name           string
+		taskID         int64
+		withSubtasks   bool
+		mockSetup      func(*MockTaskRepository)
+		expectedID     int32
+		expectedSubIDs []int32
+		expectedErrMsg string
+	}{
+		{
+			name:   "Copies the task without its subtasks",
+			taskID: 10,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetTaskTree", mock.Anything, int64(10)).Return(source, nil)
+				mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(t task.Task) bool {
+					return isClone(t, "Write report (copy)", 5) && *t.Description == description &&
+						t.DueDate.Equal(due) && t.Priority == task.PriorityHigh &&
+						assert.ObjectsAreEqual([]task.Tag{{Name: "work"}}, t.Tags) && len(t.SubTasks) == 0
+				})).Return(task.Task{ID: 20, UserID: 1, ParentID: &parentID}, nil).Once()
+			},
+			expectedID: 20,
+		},
+		{
+			name:         "Deep clone copies subtasks under the clone",
+			taskID:       10,
+			withSubtasks: true,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetTaskTree", mock.Anything, int64(10)).Return(source, nil)
+				mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(t task.Task) bool {
+					return isClone(t, "Write report (copy)", 5)
+				})).Return(task.Task{ID: 20, UserID: 1}, nil).Once()
+				mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(t task.Task) bool {
+					return isClone(t, "Outline", 20) && t.Priority == task.PriorityLow
+				})).Return(task.Task{ID: 21, UserID: 1}, nil).Once()
+				mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(t task.Task) bool {
+					return isClone(t, "Headings", 21)
+				})).Return(task.Task{ID: 22, UserID: 1}, nil).Once()
+			},
+			expectedID:     20,
+			expectedSubIDs: []int32{21},
+		},
+		{
+			name:           "Invalid task ID",
+			taskID:         0,
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedErrMsg: "task ID must be positive",
+		},
+		{
+			name:   "Task not found",
+			taskID: 99,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetTaskTree", mock.Anything, int64(99)).
+					Return(task.Task{}, domainerrors.NotFound("task 99 not found"))
+			},
+			expectedErrMsg: "task 99 not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			tc.mockSetup(mockRepo)
+
+			taskService := newTestTaskService(mockRepo)
+
+			clone, err := taskService.Clone(context.Background(), tc.taskID, tc.withSubtasks)
+
+			if tc.expectedErrMsg != "" {
+				assert.ErrorContains(t, err, tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedID, clone.ID)
+				var subIDs []int32
+				for _, sub := range clone.SubTasks {
+					subIDs = append(subIDs, sub.ID)
+				}
+				assert.Equal(t, tc.expectedSubIDs, subIDs)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateTree(t *testing.T) {
+	rootID := int32(30)
+	input := TaskInput{
+		Title:    "Plan trip",
+		Priority: task.PriorityHigh,
+		Tags:     []string{" travel ", "travel"},
+		Children: []TaskInput{
+			{Title: "Book flights", Description: "Window seat"},
+			{Title: "Pack", Children: []TaskInput{{Title: "Passport", Priority: task.PriorityLow}}},
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		userID         int64
+		input          TaskInput
+		opts           []Option
+		mockSetup      func(*MockTaskRepository)
+		expectedErrMsg string
+	}{
+		{
+			name:   "Creates the tree in one call",
+			userID: 1,
+			input:  input,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("CreateTree", mock.Anything, mock.MatchedBy(func(root task.Task) bool {
+					if len(root.SubTasks) != 2 || len(root.SubTasks[1].SubTasks) != 1 {
+						return false
+					}
+					flights, pack, passport := root.SubTasks[0], root.SubTasks[1], root.SubTasks[1].SubTasks[0]
+					return root.UserID == 1 && root.Status == task.StatusTodo && root.Priority == task.PriorityHigh &&
+						assert.ObjectsAreEqual([]task.Tag{{Name: "travel"}}, root.Tags) &&
+						*flights.Description == "Window seat" && flights.Priority == task.PriorityMedium && flights.DisplayOrder == 0 &&
+						pack.DisplayOrder == 1 && passport.UserID == 1 && passport.Priority == task.PriorityLow
+				})).Return(task.Task{ID: rootID, UserID: 1, SubTasks: []task.Task{
+					{ID: 31, ParentID: &rootID}, {ID: 32, ParentID: &rootID},
+				}}, nil).Once()
+			},
+		},
+		{
+			name:           "Invalid user ID",
+			userID:         0,
+			input:          input,
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedErrMsg: "user ID must be positive",
+		},
+		{
+			name:           "A child without a title fails the whole tree",
+			userID:         1,
+			input:          TaskInput{Title: "Plan trip", Children: []TaskInput{{Title: "Pack"}, {}}},
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedErrMsg: "title is required",
+		},
+		{
+			name:           "Invalid tag in a grandchild",
+			userID:         1,
+			input:          TaskInput{Title: "Plan trip", Children: []TaskInput{{Title: "Pack", Children: []TaskInput{{Title: "Passport", Tags: []string{"a,b"}}}}}},
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedErrMsg: "comma",
+		},
+		{
+			name:           "Tree deeper than the maximum depth",
+			userID:         1,
+			input:          input,
+			opts:           []Option{WithMaxDepth(2)},
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedErrMsg: "deeper than 2 levels",
+		},
+		{
+			name:   "Repository failure is returned",
+			userID: 1,
+			input:  TaskInput{Title: "Plan trip"},
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("CreateTree", mock.Anything, mock.Anything).
+					Return(task.Task{}, domainerrors.InternalError("failed to create task tree")).Once()
+			},
+			expectedErrMsg: "failed to create task tree",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			tc.mockSetup(mockRepo)
+
+			taskService := NewTaskService(mockRepo, tc.opts...)
+
+			created, err := taskService.CreateTree(context.Background(), tc.userID, tc.input)
+
+			if tc.expectedErrMsg != "" {
+				assert.ErrorContains(t, err, tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, rootID, created.ID)
+				assert.Len(t, created.SubTasks, 2)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetAgenda(t *testing.T) {
+	now := time.Now()
+	overdue := now.Add(-48 * time.Hour)
+	todayNoon := time.Date(now.Year(), now.Month(), now.Day(), 12, 0, 0, 0, now.Location())
+	todayEvening := time.Date(now.Year(), now.Month(), now.Day(), 18, 0, 0, 0, now.Location())
+
+	overdueTasks := []task.Task{
+		{ID: 1, Title: "Overdue low", DueDate: &overdue, Priority: task.PriorityLow},
+		{ID: 2, Title: "Overdue high", DueDate: &overdue, Priority: task.PriorityHigh},
+		{ID: 3, Title: "Done overdue", DueDate: &overdue, Priority: task.PriorityHigh, Status: task.StatusDone, IsCompleted: true},
+	}
+	todayTasks := []task.Task{
+		{ID: 4, Title: "Evening", DueDate: &todayEvening, Priority: task.PriorityMedium},
+		{ID: 5, Title: "Noon", DueDate: &todayNoon, Priority: task.PriorityMedium},
+	}
+
+	testCases := []struct {
+		name            string
+		userID          int64
+		mockSetup       func(*MockTaskRepository)
+		expectedOverdue []int32
+		expectedToday   []int32
+		expectedPinned  []int32
+		expectedErrMsg  string
+	}{
+		{
+			name:   "Grouped and sorted without completed tasks",
+			userID: 1,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("ListOverdueTasks", mock.Anything, int64(1)).Return(overdueTasks, nil)
+				mockRepo.On("ListTasksDueToday", mock.Anything, int64(1)).Return(todayTasks, nil)
+				mockRepo.On("ListDayPinnedTaskIDs", mock.Anything, int64(1)).Return([]int32(nil), nil)
+			},
+			expectedOverdue: []int32{2, 1},
+			expectedToday:   []int32{5, 4},
+		},
+		{
+			name:   "A task in both lists is listed once, under today",
+			userID: 1,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("ListOverdueTasks", mock.Anything, int64(1)).Return(todayTasks[:1], nil)
+				mockRepo.On("ListTasksDueToday", mock.Anything, int64(1)).Return(todayTasks, nil)
+				mockRepo.On("ListDayPinnedTaskIDs", mock.Anything, int64(1)).Return([]int32(nil), nil)
+			},
+			expectedToday: []int32{5, 4},
+		},
+		{
+			name:   "Pinned tasks come first in their group",
+			userID: 1,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("ListOverdueTasks", mock.Anything, int64(1)).Return(overdueTasks, nil)
+				mockRepo.On("ListTasksDueToday", mock.Anything, int64(1)).Return(todayTasks, nil)
+				// 3 is done and 7 is not on the agenda, so neither is listed as pinned
+				mockRepo.On("ListDayPinnedTaskIDs", mock.Anything, int64(1)).Return([]int32{4, 3, 7, 1}, nil)
+			},
+			expectedOverdue: []int32{1, 2},
+			expectedToday:   []int32{4, 5},
+			expectedPinned:  []int32{4, 1},
+		},
+		{
+			name:           "Invalid user ID",
+			userID:         0,
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedErrMsg: "user ID must be positive",
+		},
+		{
+			name:   "Repository error",
+			userID: 1,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("ListOverdueTasks", mock.Anything, int64(1)).
+					Return([]task.Task(nil), errors.New("database error"))
+			},
+			expectedErrMsg: "database error",
+		},
+	}
+
+	ids := func(tasks []task.Task) []int32 {
+		var ids []int32
+		for _, t := range tasks {
+			ids = append(ids, t.ID)
+		}
+		return ids
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			tc.mockSetup(mockRepo)
+
+			taskService := NewTaskService(mockRepo, WithLocation(now.Location()))
+
+			agenda, err := taskService.GetAgenda(context.Background(), tc.userID)
+
+			if tc.expectedErrMsg != "" {
+				assert.ErrorContains(t, err, tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedOverdue, ids(agenda.Overdue))
+				assert.Equal(t, tc.expectedToday, ids(agenda.Today))
+				assert.Equal(t, tc.expectedPinned, agenda.Pinned)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListTasksDueSoon(t *testing.T) {
+	testCases := []struct {
+		name         string
+		opts         []Option
+		expectedDays int
+	}{
+		{name: "Seven days by default", expectedDays: 7},
+		{name: "Configured window", opts: []Option{WithDueSoonDays(3)}, expectedDays: 3},
+		{name: "Invalid window keeps the default", opts: []Option{WithDueSoonDays(0)}, expectedDays: 7},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			mockRepo.On("ListTasksDueSoon", mock.Anything, int64(1), tc.expectedDays).
+				Return([]task.Task{{ID: 1}}, nil)
+
+			taskService := NewTaskService(mockRepo, tc.opts...)
+
+			result, err := taskService.ListTasksDueSoon(context.Background(), 1)
+
+			assert.NoError(t, err)
+			assert.Len(t, result, 1)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateNestingDepth(t *testing.T) {
+	id := func(i int32) *int32 { return &i }
+
+	testCases := []struct {
+		name           string
+		parentID       int64
+		mockSetup      func(*MockTaskRepository)
+		expectedError  bool
+		expectedErrMsg string
+	}{
+		{
+			name:     "Child at the maximum depth is allowed",
+			parentID: 2,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(2)).Return(task.Task{ID: 2, ParentID: id(1)}, nil)
+				mockRepo.On("GetByID", mock.Anything, int64(1)).Return(task.Task{ID: 1}, nil)
+				mockRepo.On("Create", mock.Anything, mock.AnythingOfType("task.Task")).Return(task.Task{ID: 3, ParentID: id(2)}, nil)
+			},
+		},
+		{
+			name:     "Child beyond the maximum depth is rejected",
+			parentID: 3,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(3)).Return(task.Task{ID: 3, ParentID: id(2)}, nil)
+				mockRepo.On("GetByID", mock.Anything, int64(2)).Return(task.Task{ID: 2, ParentID: id(1)}, nil)
+				mockRepo.On("GetByID", mock.Anything, int64(1)).Return(task.Task{ID: 1}, nil)
+			},
+			expectedError:  true,
+			expectedErrMsg: "nested more than 3 levels",
+		},
+		{
+			name:     "Parent cycle is reported",
+			parentID: 1,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(1)).Return(task.Task{ID: 1, ParentID: id(2)}, nil)
+				mockRepo.On("GetByID", mock.Anything, int64(2)).Return(task.Task{ID: 2, ParentID: id(1)}, nil)
+			},
+			expectedError:  true,
+			expectedErrMsg: "parent cycle",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			tc.mockSetup(mockRepo)
+
+			taskService := NewTaskService(mockRepo, WithMaxDepth(3))
+
+			parentID := tc.parentID
+			_, err := taskService.Create(context.Background(), 1, &parentID, "Task", "", nil, task.PriorityLow, nil, nil)
+
+			if tc.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestStrictValidation(t *testing.T) {
+	existing := task.Task{ID: 1, UserID: 1, Title: "Task", Priority: task.PriorityLow}
+
+	testCases := []struct {
+		name             string
+		strict           bool
+		priority         task.Priority
+		expectedPriority task.Priority
+		expectedErrMsg   string
+	}{
+		{name: "Lenient defaults an invalid priority", priority: "urgent", expectedPriority: task.PriorityMedium},
+		{name: "Lenient defaults an empty priority", priority: "", expectedPriority: task.PriorityMedium},
+		{name: "Strict rejects an invalid priority", strict: true, priority: "urgent", expectedErrMsg: `invalid priority "urgent"`},
+		{name: "Strict defaults an empty priority", strict: true, priority: "", expectedPriority: task.PriorityMedium},
+		{name: "Strict accepts a valid priority", strict: true, priority: task.PriorityHigh, expectedPriority: task.PriorityHigh},
+	}
+
+	for _, tc := range testCases {
+		t.Run("Create/"+tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			if tc.expectedErrMsg == "" {
+				mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(t task.Task) bool {
+					return t.Priority == tc.expectedPriority
+				})).Return(task.Task{ID: 1, Priority: tc.expectedPriority}, nil)
+			}
+
+			taskService := NewTaskService(mockRepo, WithStrictValidation(tc.strict))
+
+			created, err := taskService.Create(context.Background(), 1, nil, "Task", "", nil, tc.priority, nil, nil)
+
+			if tc.expectedErrMsg != "" {
+				assert.Error(t, err)
+				assert.True(t, domainerrors.IsInvalidInput(err))
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedPriority, created.Priority)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+
+		t.Run("Update/"+tc.name, func(t *testing.T) {
+			// Update keeps the current priority instead of defaulting it
+			expected := tc.expectedPriority
+			if tc.priority == "" || tc.priority == "urgent" {
+				expected = existing.Priority
+			}
+
+			mockRepo := new(MockTaskRepository)
+			if tc.expectedErrMsg == "" {
+				mockRepo.On("GetByID", mock.Anything, int64(1)).Return(existing, nil)
+				mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(t task.Task) bool {
+					return t.Priority == expected
+				})).Return(nil)
+			}
+
+			taskService := NewTaskService(mockRepo, WithStrictValidation(tc.strict))
+
+			_, err := taskService.Update(context.Background(), 1, "Task", "", nil, tc.priority, nil)
+
+			if tc.expectedErrMsg != "" {
+				assert.Error(t, err)
+				assert.True(t, domainerrors.IsInvalidInput(err))
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestProjectBurndown(t *testing.T) {
+	day := func(d int, hour int) time.Time { return time.Date(2025, time.March, d, hour, 0, 0, 0, time.UTC) }
+	from, to := day(1, 0), day(4, 0)
+
+	project := task.Task{
+		ID: 1,
+		SubTasks: []task.Task{
+			{ID: 2, CreatedAt: day(1, 9), UpdatedAt: day(2, 15), IsCompleted: true},
+			{ID: 3, CreatedAt: day(1, 9), UpdatedAt: day(1, 9), SubTasks: []task.Task{
+				{ID: 4, CreatedAt: day(3, 10), UpdatedAt: day(4, 8), IsCompleted: true},
+			}},
+		},
+	}
+
+	testCases := []struct {
+		name              string
+		rootID            int64
+		to                time.Time
+		mockSetup         func(*MockTaskRepository)
+		expectedRemaining []int
+		expectedError     bool
+		expectedErrMsg    string
+	}{
+		{
+			name:   "Daily remaining counts over the range",
+			rootID: 1,
+			to:     to,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetTaskTree", mock.Anything, int64(1)).Return(project, nil)
+			},
+			expectedRemaining: []int{2, 1, 2, 1},
+		},
+		{
+			name:   "Project without subtasks has no history",
+			rootID: 5,
+			to:     to,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetTaskTree", mock.Anything, int64(5)).Return(task.Task{ID: 5}, nil)
+			},
+			expectedRemaining: []int{},
+		},
+		{
+			name:           "End before start",
+			rootID:         1,
+			to:             from.Add(-time.Hour),
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedError:  true,
+			expectedErrMsg: "end date must not be before start date",
+		},
+		{
+			name:   "Project not found",
+			rootID: 999,
+			to:     to,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetTaskTree", mock.Anything, int64(999)).Return(task.Task{}, domainerrors.NotFound("task not found"))
+			},
+			expectedError:  true,
+			expectedErrMsg: "task not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			tc.mockSetup(mockRepo)
+
+			taskService := newTestTaskService(mockRepo)
+
+			result, err := taskService.ProjectBurndown(context.Background(), tc.rootID, from, tc.to)
+
+			if tc.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+				remaining := make([]int, len(result))
+				for i, p := range result {
+					remaining[i] = p.Remaining
+				}
+				assert.Equal(t, tc.expectedRemaining, remaining)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSessionSummary(t *testing.T) {
+	at := func(hour, min int) time.Time { return time.Date(2025, time.March, 3, hour, min, 0, 0, time.UTC) }
+	start, end := at(9, 0), at(9, 25)
+	allStatuses := []task.Status{task.StatusTodo, task.StatusInProgress, task.StatusDone}
+
+	tasks := []task.Task{
+		{ID: 1, Title: "Before", Status: task.StatusDone, IsCompleted: true, UpdatedAt: at(8, 59)},
+		{ID: 2, Title: "Finished late", Status: task.StatusDone, IsCompleted: true, UpdatedAt: at(9, 20)},
+		{ID: 3, Title: "Started", Status: task.StatusInProgress, UpdatedAt: at(9, 10)},
+		{ID: 4, Title: "Finished early", Status: task.StatusDone, IsCompleted: true, UpdatedAt: at(9, 5)},
+		{ID: 5, Title: "At the end", Status: task.StatusTodo, UpdatedAt: at(9, 25)},
+		{ID: 6, Title: "Added", Status: task.StatusTodo, UpdatedAt: at(9, 0)},
+	}
+
+	testCases := []struct {
+		name              string
+		userID            int64
+		end               time.Time
+		mockSetup         func(*MockTaskRepository)
+		expectedCompleted []int32
+		expectedModified  []int32
+		expectedErrMsg    string
+	}{
+		{
+			name:   "Tasks updated during the session",
+			userID: 1,
+			end:    end,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("ListTasksByStatuses", mock.Anything, int64(1), allStatuses).Return(tasks, nil)
+			},
+			expectedCompleted: []int32{4, 2},
+			expectedModified:  []int32{6, 3},
+		},
+		{
+			name:   "Nothing touched",
+			userID: 1,
+			end:    end,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("ListTasksByStatuses", mock.Anything, int64(1), allStatuses).Return(tasks[:1], nil)
+			},
+		},
+		{
+			name:           "End before start",
+			userID:         1,
+			end:            start.Add(-time.Minute),
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedErrMsg: "end time must not be before start time",
+		},
+		{
+			name:           "Invalid user ID",
+			userID:         0,
+			end:            end,
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedErrMsg: "user ID must be positive",
+		},
+	}
+
+	ids := func(ts []task.Task) []int32 {
+		var result []int32
+		for _, t := range ts {
+			result = append(result, t.ID)
+		}
+		return result
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			tc.mockSetup(mockRepo)
+
+			taskService := newTestTaskService(mockRepo)
+
+			summary, err := taskService.SessionSummary(context.Background(), tc.userID, start, tc.end)
+
+			if tc.expectedErrMsg != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, start, summary.Start)
+				assert.Equal(t, tc.expectedCompleted, ids(summary.Completed))
+				assert.Equal(t, tc.expectedModified, ids(summary.Modified))
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSnoozeUntil(t *testing.T) {
+	until := time.Date(2025, time.March, 8, 9, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		taskID         int64
+		until          time.Time
+		mockSetup      func(*MockTaskRepository)
+		expectedError  bool
+		expectedErrMsg string
+	}{
+		{
+			name:   "Due date moved to the snooze time",
+			taskID: 1,
+			until:  until,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(1)).Return(task.Task{ID: 1, Title: "Task"}, nil).Once()
+				mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(t task.Task) bool {
+					return t.ID == 1 && t.DueDate != nil && t.DueDate.Equal(until)
+				})).Return(nil)
+				mockRepo.On("GetByID", mock.Anything, int64(1)).Return(task.Task{ID: 1, Title: "Task", DueDate: &until}, nil).Once()
+			},
+		},
+		{
+			name:           "Missing snooze time",
+			taskID:         1,
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedError:  true,
+			expectedErrMsg: "snooze time is required",
+		},
+		{
+			name:   "Task not found",
+			taskID: 999,
+			until:  until,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(999)).Return(task.Task{}, domainerrors.NotFound("task not found"))
+			},
+			expectedError:  true,
+			expectedErrMsg: "task not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			tc.mockSetup(mockRepo)
+
+			taskService := newTestTaskService(mockRepo)
+
+			result, err := taskService.SnoozeUntil(context.Background(), tc.taskID, tc.until)
+
+			if tc.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result.DueDate)
+				assert.True(t, result.DueDate.Equal(tc.until))
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSnooze(t *testing.T) {
+	future := time.Now().AddDate(0, 0, 3).Truncate(time.Minute)
+	overdue := time.Date(2020, time.January, 6, 9, 30, 0, 0, time.Local)
+
+	testCases := []struct {
+		name           string
+		taskID         int64
+		by             time.Duration
+		dueDate        *time.Time
+		check          func(t *testing.T, until time.Time)
+		expectedError  bool
+		expectedErrMsg string
+	}{
+		{
+			name:    "Due date pushed forward",
+			taskID:  1,
+			by:      7 * 24 * time.Hour,
+			dueDate: &future,
+			check: func(t *testing.T, until time.Time) {
+				assert.True(t, until.Equal(future.AddDate(0, 0, 7)))
+			},
+		},
+		{
+			name:    "Overdue task snoozed from today",
+			taskID:  1,
+			by:      24 * time.Hour,
+			dueDate: &overdue,
+			check: func(t *testing.T, until time.Time) {
+				tomorrow := time.Now().AddDate(0, 0, 1)
+				assert.Equal(t, tomorrow.Format("2006-01-02"), until.Format("2006-01-02"))
+				assert.Equal(t, "09:30", until.Format("15:04"))
+			},
+		},
+		{
+			name:   "Undated task snoozed from now",
+			taskID: 1,
+			by:     time.Hour,
+			check: func(t *testing.T, until time.Time) {
+				assert.WithinDuration(t, time.Now().Add(time.Hour), until, time.Minute)
+			},
+		},
+		{
+			name:           "Duration must be positive",
+			taskID:         1,
+			expectedError:  true,
+			expectedErrMsg: "snooze duration must be positive",
+		},
+		{
+			name:           "Invalid task ID",
+			taskID:         0,
+			by:             time.Hour,
+			expectedError:  true,
+			expectedErrMsg: "task ID must be positive",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			var saved task.Task
+			if !tc.expectedError {
+				mockRepo.On("GetByID", mock.Anything, tc.taskID).Return(task.Task{ID: 1, Title: "Task", DueDate: tc.dueDate}, nil).Once()
+				mockRepo.On("Update", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					saved = args.Get(1).(task.Task)
+				}).Return(nil)
+				mockRepo.On("GetByID", mock.Anything, tc.taskID).Return(task.Task{ID: 1, Title: "Task"}, nil).Once()
+			}
+
+			taskService := newTestTaskService(mockRepo)
+
+			_, err := taskService.Snooze(context.Background(), tc.taskID, tc.by)
+
+			if tc.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+				if assert.NotNil(t, saved.DueDate) {
+					tc.check(t, *saved.DueDate)
+				}
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSnoozeRejectsCompletedTasks(t *testing.T) {
+	until := time.Date(2025, time.March, 8, 9, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		existing task.Task
+		snooze   func(s Service) (task.Task, error)
+	}{
+		{
+			name:     "Snooze until a completed task",
+			existing: task.Task{ID: 1, Title: "Task", IsCompleted: true, Status: task.StatusDone},
+			snooze: func(s Service) (task.Task, error) {
+				return s.SnoozeUntil(context.Background(), 1, until)
+			},
+		},
+		{
+			name:     "Snooze a completed task by a day",
+			existing: task.Task{ID: 1, Title: "Task", IsCompleted: true, Status: task.StatusDone},
+			snooze: func(s Service) (task.Task, error) {
+				return s.Snooze(context.Background(), 1, 24*time.Hour)
+			},
+		},
+		{
+			name:     "Snooze a task in the done status",
+			existing: task.Task{ID: 1, Title: "Task", Status: task.StatusDone},
+			snooze: func(s Service) (task.Task, error) {
+				return s.Snooze(context.Background(), 1, 7*24*time.Hour)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			mockRepo.On("GetByID", mock.Anything, int64(1)).Return(tc.existing, nil).Once()
+
+			taskService := newTestTaskService(mockRepo)
+
+			_, err := tc.snooze(taskService)
+
+			assert.Error(t, err)
+			assert.True(t, domainerrors.IsInvalidInput(err))
+			assert.Contains(t, err.Error(), "cannot snooze a completed task")
+			mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSetEnergy(t *testing.T) {
+	testCases := []struct {
+		name           string
+		taskID         int64
+		energy         task.Energy
+		mockSetup      func(*MockTaskRepository)
+		expectedError  bool
+		expectedErrMsg string
+	}{
+		{
+			name:   "Energy set",
+			taskID: 1,
+			energy: task.EnergyHigh,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(1)).Return(task.Task{ID: 1}, nil).Once()
+				mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(t task.Task) bool {
+					return t.ID == 1 && t.Energy == task.EnergyHigh
+				})).Return(nil)
+				mockRepo.On("GetByID", mock.Anything, int64(1)).Return(task.Task{ID: 1, Energy: task.EnergyHigh}, nil).Once()
+			},
+		},
+		{
+			name:   "Energy cleared",
+			taskID: 1,
+			energy: "",
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(1)).Return(task.Task{ID: 1, Energy: task.EnergyLow}, nil).Once()
+				mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(t task.Task) bool {
+					return t.ID == 1 && t.Energy == ""
+				})).Return(nil)
+				mockRepo.On("GetByID", mock.Anything, int64(1)).Return(task.Task{ID: 1}, nil).Once()
+			},
+		},
+		{
+			name:           "Invalid energy",
+			taskID:         1,
+			energy:         task.Energy("extreme"),
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedError:  true,
+			expectedErrMsg: "invalid energy",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			tc.mockSetup(mockRepo)
+
+			taskService := newTestTaskService(mockRepo)
+
+			result, err := taskService.SetEnergy(context.Background(), tc.taskID, tc.energy)
+
+			if tc.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.energy, result.Energy)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSetPoints(t *testing.T) {
+	testCases := []struct {
+		name           string
+		taskID         int64
+		points         int
+		mockSetup      func(*MockTaskRepository)
+		expectedError  bool
+		expectedErrMsg string
+	}{
+		{
+			name:   "Points set",
+			taskID: 1,
+			points: 5,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(1)).Return(task.Task{ID: 1}, nil).Once()
+				mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(t task.Task) bool {
+					return t.ID == 1 && t.Points == 5
+				})).Return(nil)
+				mockRepo.On("GetByID", mock.Anything, int64(1)).Return(task.Task{ID: 1, Points: 5}, nil).Once()
+			},
+		},
+		{
+			name:           "Negative points",
+			taskID:         1,
+			points:         -1,
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedError:  true,
+			expectedErrMsg: "points cannot be negative",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			tc.mockSetup(mockRepo)
+
+			taskService := newTestTaskService(mockRepo)
+
+			result, err := taskService.SetPoints(context.Background(), tc.taskID, tc.points)
+
+			if tc.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.points, result.Points)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListByStatuses(t *testing.T) {
+	testCases := []struct {
+		name           string
+		statuses       []task.Status
+		mockSetup      func(*MockTaskRepository)
+		expectedError  bool
+		expectedErrMsg string
+	}{
+		{
+			name:     "Active statuses",
+			statuses: []task.Status{task.StatusTodo, task.StatusInProgress},
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("ListTasksByStatuses", mock.Anything, int64(1), []task.Status{task.StatusTodo, task.StatusInProgress}).
+					Return([]task.Task{{ID: 1, Status: task.StatusTodo}, {ID: 2, Status: task.StatusInProgress}}, nil)
+			},
+		},
+		{
+			name:     "Duplicates removed",
+			statuses: []task.Status{task.StatusDone, task.StatusTodo, task.StatusDone},
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("ListTasksByStatuses", mock.Anything, int64(1), []task.Status{task.StatusDone, task.StatusTodo}).
+					Return([]task.Task{}, nil)
+			},
+		},
+		{
+			name:           "Invalid status",
+			statuses:       []task.Status{task.StatusTodo, "blocked"},
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedError:  true,
+			expectedErrMsg: `invalid status "blocked"`,
+		},
+		{
+			name:           "No statuses",
+			statuses:       nil,
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedError:  true,
+			expectedErrMsg: "at least one status is required",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			tc.mockSetup(mockRepo)
+
+			taskService := newTestTaskService(mockRepo)
+
+			_, err := taskService.ListByStatuses(context.Background(), 1, tc.statuses)
+
+			if tc.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSwapOrder(t *testing.T) {
+	parent := int32(1)
+	otherParent := int32(5)
+
+	testCases := []struct {
+		name           string
+		aID            int64
+		bID            int64
+		mockSetup      func(*MockTaskRepository)
+		expectedError  bool
+		expectedErrMsg string
+	}{
+		{
+			name: "Siblings swapped",
+			aID:  2,
+			bID:  3,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(2)).Return(task.Task{ID: 2, UserID: 1, ParentID: &parent, DisplayOrder: 0}, nil)
+				mockRepo.On("GetByID", mock.Anything, int64(3)).Return(task.Task{ID: 3, UserID: 1, ParentID: &parent, DisplayOrder: 4}, nil)
+				mockRepo.On("SwapTaskOrder", mock.Anything, int64(2), int64(3)).Return(nil)
+			},
+		},
+		{
+			name: "Equal orders normalized first",
+			aID:  2,
+			bID:  3,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(2)).Return(task.Task{ID: 2, UserID: 1}, nil)
+				mockRepo.On("GetByID", mock.Anything, int64(3)).Return(task.Task{ID: 3, UserID: 1}, nil)
+				mockRepo.On("NormalizeDisplayOrder", mock.Anything, int64(1)).Return(int64(2), nil)
+				mockRepo.On("SwapTaskOrder", mock.Anything, int64(2), int64(3)).Return(nil)
+			},
+		},
+		{
+			name: "Different parents",
+			aID:  2,
+			bID:  3,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(2)).Return(task.Task{ID: 2, UserID: 1, ParentID: &parent}, nil)
+				mockRepo.On("GetByID", mock.Anything, int64(3)).Return(task.Task{ID: 3, UserID: 1, ParentID: &otherParent}, nil)
+			},
+			expectedError:  true,
+			expectedErrMsg: "same section",
+		},
+		{
+			name: "Open and completed task",
+			aID:  2,
+			bID:  3,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(2)).Return(task.Task{ID: 2, UserID: 1}, nil)
+				mockRepo.On("GetByID", mock.Anything, int64(3)).Return(task.Task{ID: 3, UserID: 1, IsCompleted: true, Status: task.StatusDone}, nil)
+			},
+			expectedError:  true,
+			expectedErrMsg: "same section",
+		},
+		{
+			name: "Todo and in progress task",
+			aID:  2,
+			bID:  3,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(2)).Return(task.Task{ID: 2, UserID: 1, Status: task.StatusTodo}, nil)
+				mockRepo.On("GetByID", mock.Anything, int64(3)).Return(task.Task{ID: 3, UserID: 1, Status: task.StatusInProgress}, nil)
+			},
+			expectedError:  true,
+			expectedErrMsg: "same section",
+		},
+		{
+			name: "Different users",
+			aID:  2,
+			bID:  3,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(2)).Return(task.Task{ID: 2, UserID: 1}, nil)
+				mockRepo.On("GetByID", mock.Anything, int64(3)).Return(task.Task{ID: 3, UserID: 2}, nil)
+			},
+			expectedError:  true,
+			expectedErrMsg: "different users",
+		},
+		{
+			name:           "Same task",
+			aID:            2,
+			bID:            2,
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedError:  true,
+			expectedErrMsg: "cannot swap a task with itself",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			tc.mockSetup(mockRepo)
+
+			taskService := newTestTaskService(mockRepo)
+
+			err := taskService.SwapOrder(context.Background(), tc.aID, tc.bID)
+
+			if tc.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				mockRepo.AssertNotCalled(t, "SwapTaskOrder", mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMoveTaskUpDown(t *testing.T) {
+	parent := int32(1)
+	siblings := []task.Task{
+		{ID: 2, UserID: 1, ParentID: &parent, DisplayOrder: 0, Status: task.StatusTodo},
+		{ID: 3, UserID: 1, ParentID: &parent, DisplayOrder: 1, Status: task.StatusDone, IsCompleted: true},
+		{ID: 4, UserID: 1, ParentID: &parent, DisplayOrder: 2, Status: task.StatusTodo},
+		{ID: 8, UserID: 1, ParentID: &parent, DisplayOrder: 3, Status: task.StatusInProgress},
+		{ID: 5, UserID: 1, ParentID: &parent, DisplayOrder: 4, Status: task.StatusTodo},
+	}
+
+	testCases := []struct {
+		name           string
+		taskID         int64
+		up             bool
+		mockSetup      func(*MockTaskRepository)
+		expectedSwap   int64
+		expectedError  bool
+		expectedErrMsg string
+	}{
+		{
+			name:   "Up skips a completed sibling",
+			taskID: 4,
+			up:     true,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(4)).Return(siblings[2], nil)
+				mockRepo.On("ListSubTasks", mock.Anything, int64(1)).Return(siblings, nil)
+				mockRepo.On("GetByID", mock.Anything, int64(2)).Return(siblings[0], nil)
+				mockRepo.On("SwapTaskOrder", mock.Anything, int64(4), int64(2)).Return(nil)
+			},
+			expectedSwap: 2,
+		},
+		{
+			name:   "Down skips an in progress sibling",
+			taskID: 4,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(4)).Return(siblings[2], nil)
+				mockRepo.On("ListSubTasks", mock.Anything, int64(1)).Return(siblings, nil)
+				mockRepo.On("GetByID", mock.Anything, int64(5)).Return(siblings[4], nil)
+				mockRepo.On("SwapTaskOrder", mock.Anything, int64(4), int64(5)).Return(nil)
+			},
+			expectedSwap: 5,
+		},
+		{
+			name:   "Root task among root tasks",
+			taskID: 7,
+			up:     true,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(7)).Return(task.Task{ID: 7, UserID: 1, DisplayOrder: 1}, nil)
+				mockRepo.On("ListRootTasks", mock.Anything, int64(1)).Return([]task.Task{{ID: 6, UserID: 1}, {ID: 7, UserID: 1, DisplayOrder: 1}}, nil)
+				mockRepo.On("GetByID", mock.Anything, int64(6)).Return(task.Task{ID: 6, UserID: 1}, nil)
+				mockRepo.On("SwapTaskOrder", mock.Anything, int64(7), int64(6)).Return(nil)
+			},
+			expectedSwap: 6,
+		},
+		{
+			name:   "Already last",
+			taskID: 5,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(5)).Return(siblings[4], nil)
+				mockRepo.On("ListSubTasks", mock.Anything, int64(1)).Return(siblings, nil)
+			},
+		},
+		{
+			name:   "Task not found",
+			taskID: 9,
+			up:     true,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(9)).Return(task.Task{}, domainerrors.NotFound("task not found"))
+			},
+			expectedError:  true,
+			expectedErrMsg: "task not found",
+		},
+		{
+			name:           "Invalid task ID",
+			taskID:         0,
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedError:  true,
+			expectedErrMsg: "task ID must be positive",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			tc.mockSetup(mockRepo)
+
+			taskService := newTestTaskService(mockRepo)
+
+			var err error
+			if tc.up {
+				err = taskService.MoveTaskUp(context.Background(), tc.taskID)
+			} else {
+				err = taskService.MoveTaskDown(context.Background(), tc.taskID)
+			}
+
+			if tc.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+			if tc.expectedSwap == 0 {
+				mockRepo.AssertNotCalled(t, "SwapTaskOrder", mock.Anything, mock.Anything, mock.Anything)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMoveTask(t *testing.T) {
+	parent := int32(1)
+	child := int32(2)
+	newParentID := func(id int64) *int64 { return &id }
+
+	testCases := []struct {
+		name           string
+		taskID         int64
+		newParentID    *int64
+		mockSetup      func(*MockTaskRepository)
+		expectedError  bool
+		expectedErrMsg string
+	}{
+		{
+			name:        "Moved under another task",
+			taskID:      2,
+			newParentID: newParentID(3),
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(2)).Return(task.Task{ID: 2, UserID: 1, ParentID: &parent}, nil).Once()
+				mockRepo.On("GetByID", mock.Anything, int64(3)).Return(task.Task{ID: 3, UserID: 1}, nil)
+				mockRepo.On("GetTaskTree", mock.Anything, int64(2)).Return(task.Task{ID: 2, UserID: 1}, nil)
+				mockRepo.On("ReparentTask", mock.Anything, int64(2), newParentID(3)).Return(nil)
+				mockRepo.On("GetByID", mock.Anything, int64(2)).Return(task.Task{ID: 2, UserID: 1, ParentID: int32Ptr(3)}, nil).Once()
+			},
+		},
+		{
+			name:        "Moved to the root level",
+			taskID:      2,
+			newParentID: nil,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(2)).Return(task.Task{ID: 2, UserID: 1, ParentID: &parent}, nil).Once()
+				mockRepo.On("ReparentTask", mock.Anything, int64(2), (*int64)(nil)).Return(nil)
+				mockRepo.On("GetByID", mock.Anything, int64(2)).Return(task.Task{ID: 2, UserID: 1}, nil).Once()
+			},
+		},
+		{
+			name:        "Moved under its own subtask",
+			taskID:      1,
+			newParentID: newParentID(4),
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(1)).Return(task.Task{ID: 1, UserID: 1}, nil)
+				mockRepo.On("GetByID", mock.Anything, int64(4)).Return(task.Task{ID: 4, UserID: 1, ParentID: &child}, nil)
+				mockRepo.On("GetByID", mock.Anything, int64(2)).Return(task.Task{ID: 2, UserID: 1, ParentID: &parent}, nil)
+			},
+			expectedError:  true,
+			expectedErrMsg: "cannot move a task under one of its own subtasks",
+		},
+		{
+			name:        "Moved under itself",
+			taskID:      2,
+			newParentID: newParentID(2),
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(2)).Return(task.Task{ID: 2, UserID: 1}, nil)
+			},
+			expectedError:  true,
+			expectedErrMsg: "a task cannot be its own parent",
+		},
+		{
+			name:        "Parent owned by another user",
+			taskID:      2,
+			newParentID: newParentID(3),
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(2)).Return(task.Task{ID: 2, UserID: 1}, nil)
+				mockRepo.On("GetByID", mock.Anything, int64(3)).Return(task.Task{ID: 3, UserID: 2}, nil)
+			},
+			expectedError:  true,
+			expectedErrMsg: "parent task belongs to another user",
+		},
+		{
+			name:        "Parent not found",
+			taskID:      2,
+			newParentID: newParentID(99),
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(2)).Return(task.Task{ID: 2, UserID: 1}, nil)
+				mockRepo.On("GetByID", mock.Anything, int64(99)).Return(task.Task{}, domainerrors.NotFound("task 99 not found"))
+			},
+			expectedError:  true,
+			expectedErrMsg: "task 99 not found",
+		},
+		{
+			name:           "Invalid task ID",
+			taskID:         0,
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedError:  true,
+			expectedErrMsg: "task ID must be positive",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			tc.mockSetup(mockRepo)
+
+			taskService := newTestTaskService(mockRepo)
+
+			result, err := taskService.MoveTask(context.Background(), tc.taskID, tc.newParentID)
+
+			if tc.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				mockRepo.AssertNotCalled(t, "ReparentTask", mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				if tc.newParentID == nil {
+					assert.Nil(t, result.ParentID)
+				} else {
+					assert.Equal(t, int32(*tc.newParentID), *result.ParentID)
+				}
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCompletionHookCommand(t *testing.T) {
+	testCases := []struct {
+		name           string
+		template       string
+		task           task.Task
+		expected       []string
+		expectedError  bool
+		expectedErrMsg string
+	}{
+		{
+			name:     "Fields substituted",
+			template: "notify {id} done:{title}",
+			task:     task.Task{ID: 7, Title: "Ship it"},
+			expected: []string{"notify", "7", "done:Ship it"},
+		},
+		{
+			name:     "Values sanitized and not re-expanded",
+			template: "log {title}",
+			task:     task.Task{ID: 7, Title: "line\nbreak {id}"},
+			expected: []string{"log", "line break {id}"},
+		},
+		{
+			name:     "Values cannot become options",
+			template: "log {title} {tags} --note={title} to:{title}",
+			task:     task.Task{ID: 7, Title: "--output=/etc/x", Tags: []task.Tag{{Name: "-rf"}}},
+			expected: []string{"log", " --output=/etc/x", " -rf", "--note=--output=/etc/x", "to:--output=/etc/x"},
+		},
+		{
+			name:           "Unknown placeholder",
+			template:       "log {secret}",
+			expectedError:  true,
+			expectedErrMsg: "unknown placeholder",
+		},
+		{
+			name:           "Placeholder in command",
+			template:       "{title} now",
+			expectedError:  true,
+			expectedErrMsg: "must not contain placeholders",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hook, err := NewCompletionHook(tc.template, 0)
+
+			if tc.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, hook.Command(tc.task))
+		})
+	}
+}
+
+func TestRecomputeMetrics(t *testing.T) {
+	parentID := int32(1)
+	project := task.Task{
+		ID:             1,
+		TotalCount:     2,
+		CompletedCount: 1,
+		Progress:       0.5,
+		SubTasks: []task.Task{
+			{ID: 2, ParentID: &parentID, Status: task.StatusDone},
+			{ID: 3, ParentID: &parentID},
+		},
+	}
+	single := task.Task{ID: 4}
+
+	testCases := []struct {
+		name           string
+		userID         int64
+		mockSetup      func(*MockTaskRepository)
+		expectedCount  int
+		expectedError  bool
+		expectedErrMsg string
+	}{
+		{
+			name:   "Parents cached",
+			userID: 1,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetTaskForest", mock.Anything, int64(1)).Return([]task.Task{project, single}, nil)
+				mockRepo.On("ReplaceTaskMetrics", mock.Anything, int64(1), []task.CachedMetrics{
+					{TaskID: 1, TotalCount: 2, CompletedCount: 1, Progress: 0.5},
+				}).Return(nil)
+			},
+			expectedCount: 1,
+		},
+		{
+			name:   "Cache write fails",
+			userID: 1,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetTaskForest", mock.Anything, int64(1)).Return([]task.Task{}, nil)
+				mockRepo.On("ReplaceTaskMetrics", mock.Anything, int64(1), mock.Anything).
+					Return(domainerrors.InternalError("failed to replace task metrics"))
+			},
+			expectedError:  true,
+			expectedErrMsg: "failed to replace task metrics",
+		},
+		{
+			name:           "Invalid user ID",
+			userID:         0,
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedError:  true,
+			expectedErrMsg: "user ID must be positive",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			tc.mockSetup(mockRepo)
+
+			taskService := newTestTaskService(mockRepo)
+
+			count, err := taskService.RecomputeMetrics(context.Background(), tc.userID)
+
+			if tc.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedCount, count)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRolloverToday(t *testing.T) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 9, 0, 0, 0, time.Local)
+	yesterday := today.AddDate(0, 0, -1)
+	tomorrow := today.AddDate(0, 0, 1)
+
+	dated := []task.Task{
+		{ID: 1, DueDate: &today},
+		{ID: 2, DueDate: &yesterday},
+		{ID: 3, DueDate: &today, Status: task.StatusDone, IsCompleted: true},
+		{ID: 4, DueDate: &tomorrow},
+		{ID: 5, DueDate: &today, Status: task.StatusInProgress},
+	}
+
+	testCases := []struct {
+		name           string
+		userID         int64
+		mockSetup      func(*MockTaskRepository)
+		expectedCount  int
+		expectedError  bool
+		expectedErrMsg string
+	}{
+		{
+			name:   "Only open tasks due today move",
+			userID: 1,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("ListIncompleteDatedTasks", mock.Anything, int64(1)).Return(dated, nil)
+				mockRepo.On("PostponeTasks", mock.Anything, []int32{1, 5}, 1).Return(int64(2), nil)
+			},
+			expectedCount: 2,
+		},
+		{
+			name:   "Nothing due today",
+			userID: 1,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("ListIncompleteDatedTasks", mock.Anything, int64(1)).
+					Return([]task.Task{{ID: 2, DueDate: &yesterday}}, nil)
+			},
+			expectedCount: 0,
+		},
+		{
+			name:   "Postpone fails",
+			userID: 1,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("ListIncompleteDatedTasks", mock.Anything, int64(1)).Return(dated, nil)
+				mockRepo.On("PostponeTasks", mock.Anything, []int32{1, 5}, 1).
+					Return(int64(0), domainerrors.InternalError("failed to postpone tasks"))
+			},
+			expectedError:  true,
+			expectedErrMsg: "failed to postpone tasks",
+		},
+		{
+			name:           "Invalid user ID",
+			userID:         0,
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedError:  true,
+			expectedErrMsg: "user ID must be positive",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			tc.mockSetup(mockRepo)
+
+			taskService := newTestTaskService(mockRepo)
+
+			count, err := taskService.RolloverToday(context.Background(), tc.userID)
+
+			if tc.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedCount, count)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSnoozeFiltered(t *testing.T) {
+	low := task.PriorityLow
+	filter := task.TaskFilter{Priority: &low}
+	until := time.Date(2025, 5, 12, 9, 0, 0, 0, time.Local)
+
+	matching := []task.Task{
+		{ID: 1, Priority: task.PriorityLow},
+		{ID: 2, Priority: task.PriorityLow, Status: task.StatusDone, IsCompleted: true},
+		{ID: 3, Priority: task.PriorityLow, Status: task.StatusInProgress},
+	}
+
+	testCases := []struct {
+		name           string
+		userID         int64
+		filter         task.TaskFilter
+		until          time.Time
+		mockSetup      func(*MockTaskRepository)
+		expectedCount  int
+		expectedError  bool
+		expectedErrMsg string
+	}{
+		{
+			name:   "Open tasks matching the filter move",
+			userID: 1,
+			filter: filter,
+			until:  until,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("ListTasksFiltered", mock.Anything, int64(1), filter).Return(matching, nil)
+				mockRepo.On("SnoozeTasks", mock.Anything, int64(1), []int32{1, 3}, until).Return(int64(2), nil)
+			},
+			expectedCount: 2,
+		},
+		{
+			name:   "Nothing matches",
+			userID: 1,
+			filter: filter,
+			until:  until,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("ListTasksFiltered", mock.Anything, int64(1), filter).Return([]task.Task{matching[1]}, nil)
+			},
+			expectedCount: 0,
+		},
+		{
+			name:   "Snooze fails",
+			userID: 1,
+			filter: filter,
+			until:  until,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("ListTasksFiltered", mock.Anything, int64(1), filter).Return(matching, nil)
+				mockRepo.On("SnoozeTasks", mock.Anything, int64(1), []int32{1, 3}, until).
+					Return(int64(0), domainerrors.InternalError("failed to snooze tasks"))
+			},
+			expectedError:  true,
+			expectedErrMsg: "failed to snooze tasks",
+		},
+		{
+			name:           "Empty filter",
+			userID:         1,
+			until:          until,
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedError:  true,
+			expectedErrMsg: "a filter is required",
+		},
+		{
+			name:           "Missing snooze time",
+			userID:         1,
+			filter:         filter,
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedError:  true,
+			expectedErrMsg: "snooze time is required",
+		},
+		{
+			name:           "Invalid user ID",
+			userID:         0,
+			filter:         filter,
+			until:          until,
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedError:  true,
+			expectedErrMsg: "user ID must be positive",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			tc.mockSetup(mockRepo)
+
+			taskService := newTestTaskService(mockRepo)
+
+			count, err := taskService.SnoozeFiltered(context.Background(), tc.userID, tc.filter, tc.until)
+
+			if tc.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedCount, count)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSeedDemoTasks(t *testing.T) {
+	testCases := []struct {
+		name           string
+		userID         int64
+		mockSetup      func(*MockTaskRepository)
+		expectedCount  int
+		expectedError  bool
+		expectedErrMsg string
+	}{
+		{
+			name:   "New user gets the demo tasks",
+			userID: 1,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("ListRootTasks", mock.Anything, int64(1)).Return([]task.Task{}, nil)
+				mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(t task.Task) bool { return t.ParentID == nil })).
+					Return(task.Task{ID: 10}, nil)
+				mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(t task.Task) bool { return t.ParentID != nil })).
+					Return(task.Task{ID: 11}, nil)
+				mockRepo.On("GetByID", mock.Anything, int64(10)).Return(task.Task{ID: 10}, nil)
+			},
+			expectedCount: 6,
+		},
+		{
+			name:   "User with tasks is left alone",
+			userID: 1,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("ListRootTasks", mock.Anything, int64(1)).Return([]task.Task{{ID: 1}}, nil)
+			},
+			expectedCount: 0,
+		},
+		{
+			name:           "Invalid user ID",
+			userID:         0,
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedError:  true,
+			expectedErrMsg: "user ID must be positive",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			tc.mockSetup(mockRepo)
+
+			taskService := newTestTaskService(mockRepo)
+
+			count, err := taskService.SeedDemoTasks(context.Background(), tc.userID)
+
+			if tc.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedCount, count)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSetExternalID(t *testing.T) {
+	testCases := []struct {
+		name           string
+		source         string
+		externalID     string
+		mockSetup      func(*MockTaskRepository)
+		expectedError  bool
+		expectedErrMsg string
+	}{
+		{
+			name:       "Anchor to calendar event",
+			source:     " Google ",
+			externalID: "evt-123",
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("SetExternalRef", mock.Anything, int64(7), stringPtr("google"), stringPtr("evt-123")).Return(nil)
+				mockRepo.On("GetByID", mock.Anything, int64(7)).Return(task.Task{ID: 7, UserID: 1, ExternalSource: stringPtr("google"), ExternalID: stringPtr("evt-123")}, nil)
+			},
+		},
+		{
+			name: "Detach",
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("SetExternalRef", mock.Anything, int64(7), (*string)(nil), (*string)(nil)).Return(nil)
+				mockRepo.On("GetByID", mock.Anything, int64(7)).Return(task.Task{ID: 7, UserID: 1}, nil)
+			},
+		},
+		{
+			name:           "Source without ID",
+			source:         "google",
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedError:  true,
+			expectedErrMsg: "must be set together",
+		},
+		{
+			name:       "Event already attached elsewhere",
+			source:     "google",
+			externalID: "evt-123",
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("SetExternalRef", mock.Anything, int64(7), stringPtr("google"), stringPtr("evt-123")).
+					Return(domainerrors.Conflict("external event google/evt-123 is already attached to another task"))
+			},
+			expectedError:  true,
+			expectedErrMsg: "already attached",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			tc.mockSetup(mockRepo)
+
+			taskService := newTestTaskService(mockRepo)
+
+			result, err := taskService.SetExternalID(context.Background(), 7, tc.source, tc.externalID)
+
+			if tc.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, int32(7), result.ID)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetByExternalID(t *testing.T) {
+	anchored := task.Task{ID: 7, UserID: 1, ExternalSource: stringPtr("google"), ExternalID: stringPtr("evt-123")}
+
+	testCases := []struct {
+		name           string
+		userID         int64
+		mockSetup      func(*MockTaskRepository)
+		expectedError  bool
+		expectedErrMsg string
+	}{
+		{
+			name:   "Found",
+			userID: 1,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByExternalID", mock.Anything, "google", "evt-123").Return(anchored, nil)
+			},
+		},
+		{
+			name:   "Owned by another user",
+			userID: 2,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByExternalID", mock.Anything, "google", "evt-123").Return(anchored, nil)
+			},
+			expectedError:  true,
+			expectedErrMsg: "no task is attached",
+		},
+		{
+			name:   "Not attached",
+			userID: 1,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByExternalID", mock.Anything, "google", "evt-123").
+					Return(task.Task{}, domainerrors.NotFound("no task is attached to external event google/evt-123"))
+			},
+			expectedError:  true,
+			expectedErrMsg: "no task is attached",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			tc.mockSetup(mockRepo)
+
+			taskService := newTestTaskService(mockRepo)
+
+			result, err := taskService.GetByExternalID(context.Background(), tc.userID, "Google", "evt-123")
+
+			if tc.expectedError {
+				assert.Error(t, err)
+				assert.True(t, domainerrors.IsNotFound(err))
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, anchored, result)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListFiltered(t *testing.T) {
+	high := task.PriorityHigh
+	todo := task.StatusTodo
+	bogus := task.Status("someday")
+	monday := time.Date(2025, 7, 7, 0, 0, 0, 0, time.UTC)
+	sunday := monday.AddDate(0, 0, 6)
+
+	testCases := []struct {
+		name           string
+		filter         task.TaskFilter
+		mockSetup      func(*MockTaskRepository)
+		expectedCount  int
+		expectedErrMsg string
+	}{
+		{
+			name:   "Combined criteria passed in one query",
+			filter: task.TaskFilter{Status: &todo, Priority: &high, DueAfter: &monday, DueBefore: &sunday, Tag: " work "},
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				expected := task.TaskFilter{Status: &todo, Priority: &high, DueAfter: &monday, DueBefore: &sunday, Tag: "work"}
+				mockRepo.On("ListTasksFiltered", mock.Anything, int64(1), expected).
+					Return([]task.Task{{ID: 1}, {ID: 2}}, nil)
+			},
+			expectedCount: 2,
+		},
+		{
+			name:   "Empty filter lists everything",
+			filter: task.TaskFilter{},
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("ListTasksFiltered", mock.Anything, int64(1), task.TaskFilter{}).
+					Return([]task.Task{{ID: 1}}, nil)
+			},
+			expectedCount: 1,
+		},
+		{
+			name:           "Invalid status",
+			filter:         task.TaskFilter{Status: &bogus},
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedErrMsg: "invalid status",
+		},
+		{
+			name:           "Inverted due range",
+			filter:         task.TaskFilter{DueAfter: &sunday, DueBefore: &monday},
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedErrMsg: "due after must be earlier than due before",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			tc.mockSetup(mockRepo)
+
+			taskService := newTestTaskService(mockRepo)
+
+			tasks, err := taskService.ListFiltered(context.Background(), 1, tc.filter)
+
+			if tc.expectedErrMsg != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				mockRepo.AssertNotCalled(t, "ListTasksFiltered", mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, tasks, tc.expectedCount)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestArchive(t *testing.T) {
+	testCases := []struct {
+		name           string
+		taskID         int64
+		mockSetup      func(*MockTaskRepository)
+		expectedErrMsg string
+	}{
+		{
+			name:   "Archive task",
+			taskID: 7,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("Archive", mock.Anything, int64(7)).Return(nil)
+			},
+		},
+		{
+			name:           "Invalid task ID",
+			taskID:         0,
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedErrMsg: "task ID must be positive",
+		},
+		{
+			name:   "Already archived",
+			taskID: 7,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("Archive", mock.Anything, int64(7)).
+					Return(domainerrors.NotFound("task 7 not found or already archived"))
+			},
+			expectedErrMsg: "already archived",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			tc.mockSetup(mockRepo)
+
+			taskService := newTestTaskService(mockRepo)
+
+			err := taskService.Archive(context.Background(), tc.taskID)
+
+			if tc.expectedErrMsg != "" {
+				assert.ErrorContains(t, err, tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRestore(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockRepo.On("Restore", mock.Anything, int64(7)).Return(nil)
+	mockRepo.On("GetByID", mock.Anything, int64(7)).Return(task.Task{ID: 7, UserID: 1, Title: "Back again"}, nil)
+
+	taskService := newTestTaskService(mockRepo)
+
+	restored, err := taskService.Restore(context.Background(), 7)
+	assert.NoError(t, err)
+	assert.Equal(t, "Back again", restored.Title)
+	assert.Nil(t, restored.ArchivedAt)
+
+	_, err = taskService.Restore(context.Background(), -1)
+	assert.ErrorContains(t, err, "task ID must be positive")
+
+	_, err = taskService.ListArchived(context.Background(), 0)
+	assert.ErrorContains(t, err, "user ID must be positive")
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAddDependency(t *testing.T) {
+	parentID := int32(1)
+	testCases := []struct {
+		name           string
+		taskID         int64
+		dependsOnID    int64
+		mockSetup      func(*MockTaskRepository)
+		expectedErrMsg string
+	}{
+		{
+			name:        "Add dependency",
+			taskID:      2,
+			dependsOnID: 3,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(2)).Return(task.Task{ID: 2, UserID: 1, Title: "Deploy"}, nil)
+				mockRepo.On("GetByID", mock.Anything, int64(3)).Return(task.Task{ID: 3, UserID: 1, Title: "Write tests"}, nil)
+				mockRepo.On("ListDependencies", mock.Anything, int64(3)).Return([]int32{4}, nil)
+				mockRepo.On("ListDependencies", mock.Anything, int64(4)).Return([]int32{}, nil)
+				mockRepo.On("AddDependency", mock.Anything, int64(2), int64(3)).Return(nil)
+			},
+		},
+		{
+			name:           "Self dependency",
+			taskID:         2,
+			dependsOnID:    2,
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedErrMsg: "cannot depend on itself",
+		},
+		{
+			name:        "Different users",
+			taskID:      2,
+			dependsOnID: 3,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(2)).Return(task.Task{ID: 2, UserID: 1}, nil)
+				mockRepo.On("GetByID", mock.Anything, int64(3)).Return(task.Task{ID: 3, UserID: 2}, nil)
+			},
+			expectedErrMsg: "same user",
+		},
+		{
+			name:        "Subtask of the dependency",
+			taskID:      2,
+			dependsOnID: 1,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(2)).Return(task.Task{ID: 2, UserID: 1, ParentID: &parentID}, nil)
+				mockRepo.On("GetByID", mock.Anything, int64(1)).Return(task.Task{ID: 1, UserID: 1}, nil)
+			},
+			expectedErrMsg: "own parent or subtask",
+		},
+		{
+			name:        "Indirect cycle",
+			taskID:      2,
+			dependsOnID: 3,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(2)).Return(task.Task{ID: 2, UserID: 1, Title: "Deploy"}, nil)
+				mockRepo.On("GetByID", mock.Anything, int64(3)).Return(task.Task{ID: 3, UserID: 1, Title: "Release notes"}, nil)
+				mockRepo.On("ListDependencies", mock.Anything, int64(3)).Return([]int32{4}, nil)
+				mockRepo.On("ListDependencies", mock.Anything, int64(4)).Return([]int32{2}, nil)
+			},
+			expectedErrMsg: "'Release notes' already depends on 'Deploy'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			tc.mockSetup(mockRepo)
+
+			taskService := newTestTaskService(mockRepo)
+
+			err := taskService.AddDependency(context.Background(), tc.taskID, tc.dependsOnID)
+
+			if tc.expectedErrMsg != "" {
+				assert.ErrorContains(t, err, tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCompleteBlockedByDependency(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockRepo.On("GetByID", mock.Anything, int64(2)).
+		Return(task.Task{ID: 2, UserID: 1, Title: "Deploy", Status: task.StatusTodo, Dependencies: []int32{3, 4}}, nil)
+	mockRepo.On("GetByID", mock.Anything, int64(3)).
+		Return(task.Task{ID: 3, UserID: 1, Title: "Write tests", Status: task.StatusTodo}, nil)
+	mockRepo.On("GetByID", mock.Anything, int64(4)).
+		Return(task.Task{ID: 4, UserID: 1, Title: "Review", Status: task.StatusDone, IsCompleted: true}, nil)
+
+	taskService := newTestTaskService(mockRepo)
+
+	_, err := taskService.Complete(context.Background(), 2)
+	assert.ErrorContains(t, err, "'Deploy' is blocked by 'Write tests'")
+
+	_, err = taskService.ChangeStatus(context.Background(), 2, task.StatusDone)
+	assert.ErrorContains(t, err, "'Deploy' is blocked by 'Write tests'")
+
+	var domainErr domainerrors.DomainError
+	assert.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, domainerrors.CodeConflict, domainErr.Code())
+
+	// Nothing may be written while the task is blocked
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestBulkUpdateStatus(t *testing.T) {
+	due := time.Now().AddDate(0, 0, 1).Truncate(time.Minute)
+
+	testCases := []struct {
+		name           string
+		taskIDs        []int32
+		status         task.Status
+		mockSetup      func(*MockTaskRepository)
+		expectedErrMsg string
+	}{
+		{
+			name:    "Blocked by a dependency outside the batch",
+			taskIDs: []int32{2, 5},
+			status:  task.StatusDone,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(2)).
+					Return(task.Task{ID: 2, UserID: 1, Title: "Deploy", Status: task.StatusTodo, Dependencies: []int32{3}}, nil)
+				mockRepo.On("GetByID", mock.Anything, int64(3)).
+					Return(task.Task{ID: 3, UserID: 1, Title: "Write tests", Status: task.StatusTodo}, nil)
+			},
+			expectedErrMsg: "'Deploy' is blocked by 'Write tests'",
+		},
+		{
+			name:    "Dependency completed in the same batch",
+			taskIDs: []int32{2, 3},
+			status:  task.StatusDone,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(2)).
+					Return(task.Task{ID: 2, UserID: 1, Title: "Deploy", Status: task.StatusTodo, Dependencies: []int32{3}}, nil)
+				mockRepo.On("GetByID", mock.Anything, int64(3)).
+					Return(task.Task{ID: 3, UserID: 1, Title: "Write tests", Status: task.StatusTodo}, nil)
+				mockRepo.On("BulkUpdateTaskStatus", mock.Anything, []int32{2, 3}, task.StatusDone, true).Return(nil).Once()
+			},
+		},
+		{
+			name:    "Recurring task gets its next occurrence",
+			taskIDs: []int32{4},
+			status:  task.StatusDone,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(4)).Return(task.Task{ID: 4, UserID: 1, Title: "Water plants",
+					Status: task.StatusTodo, DueDate: &due, Recurrence: &task.Recurrence{Frequency: task.FrequencyWeekly, Interval: 1}}, nil)
+				mockRepo.On("BulkUpdateTaskStatus", mock.Anything, []int32{4}, task.StatusDone, true).Return(nil).Once()
+				mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(t task.Task) bool {
+					return t.Title == "Water plants" && t.DueDate.Equal(due.AddDate(0, 0, 7))
+				})).Return(task.Task{ID: 6, DueDate: timePtr(due.AddDate(0, 0, 7))}, nil).Once()
+			},
+		},
+		{
+			name:    "Back to todo skips the completion checks",
+			taskIDs: []int32{2},
+			status:  task.StatusTodo,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("BulkUpdateTaskStatus", mock.Anything, []int32{2}, task.StatusTodo, false).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			tc.mockSetup(mockRepo)
+
+			taskService := newTestTaskService(mockRepo)
+
+			err := taskService.BulkUpdateStatus(context.Background(), tc.taskIDs, tc.status)
+
+			if tc.expectedErrMsg != "" {
+				assert.ErrorContains(t, err, tc.expectedErrMsg)
+				assert.True(t, domainerrors.IsConflict(err))
+				mockRepo.AssertNotCalled(t, "BulkUpdateTaskStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAddNote(t *testing.T) {
+	testCases := []struct {
+		name           string
+		userID         int64
+		taskID         int64
+		body           string
+		mockSetup      func(*MockTaskRepository)
+		expectedBody   string
+		expectedErrMsg string
+	}{
+		{
+			name:   "Trimmed note is added",
+			userID: 1,
+			taskID: 2,
+			body:   "  Called the vendor  \n",
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(2)).Return(task.Task{ID: 2, UserID: 1}, nil)
+				mockRepo.On("AddNote", mock.Anything, int64(2), "Called the vendor").
+					Return(task.TaskNote{ID: 5, TaskID: 2, UserID: 1, Body: "Called the vendor"}, nil)
+			},
+			expectedBody: "Called the vendor",
+		},
+		{
+			name:           "Empty note",
+			userID:         1,
+			taskID:         2,
+			body:           "   ",
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedErrMsg: "note is required",
+		},
+		{
+			name:           "Note too long",
+			userID:         1,
+			taskID:         2,
+			body:           strings.Repeat("a", task.MaxNoteLength+1),
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedErrMsg: "at most 2000 characters",
+		},
+		{
+			name:   "Another user's task",
+			userID: 1,
+			taskID: 2,
+			body:   "Sneaky",
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(2)).Return(task.Task{ID: 2, UserID: 9}, nil)
+			},
+			expectedErrMsg: "task 2 not found",
+		},
+		{
+			name:   "Missing task",
+			userID: 1,
+			taskID: 2,
+			body:   "Lost",
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(2)).
+					Return(task.Task{}, domainerrors.NotFound("task 2 not found"))
+			},
+			expectedErrMsg: "task 2 not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			tc.mockSetup(mockRepo)
+
+			taskService := newTestTaskService(mockRepo)
+
+			note, err := taskService.AddNote(context.Background(), tc.userID, tc.taskID, tc.body)
+
+			if tc.expectedErrMsg != "" {
+				assert.ErrorContains(t, err, tc.expectedErrMsg)
+				mockRepo.AssertNotCalled(t, "AddNote", mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedBody, note.Body)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListNotes(t *testing.T) {
+	now := time.Now()
+	notes := []task.TaskNote{
+		{ID: 2, TaskID: 3, UserID: 1, Body: "Quote received", CreatedAt: now},
+		{ID: 1, TaskID: 3, UserID: 1, Body: "Called the vendor", CreatedAt: now.Add(-time.Hour)},
+	}
+
+	mockRepo := new(MockTaskRepository)
+	mockRepo.On("GetByID", mock.Anything, int64(3)).Return(task.Task{ID: 3, UserID: 1}, nil)
+	mockRepo.On("ListNotes", mock.Anything, int64(3)).Return(notes, nil)
+
+	taskService := newTestTaskService(mockRepo)
+
+	result, err := taskService.ListNotes(context.Background(), 1, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, notes, result)
+
+	// Another user's notes stay hidden
+	_, err = taskService.ListNotes(context.Background(), 2, 3)
+	assert.ErrorContains(t, err, "task 3 not found")
+	mockRepo.AssertNumberOfCalls(t, "ListNotes", 1)
+}
+
+func TestPinToDay(t *testing.T) {
+	due := time.Now().Add(24 * time.Hour)
+
+	testCases := []struct {
+		name           string
+		userID         int64
+		taskID         int64
+		mockSetup      func(*MockTaskRepository)
+		expectedErrMsg string
+	}{
+		{
+			name:   "Dated task is pinned",
+			userID: 1,
+			taskID: 2,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(2)).Return(task.Task{ID: 2, UserID: 1, DueDate: &due}, nil)
+				mockRepo.On("PinToDay", mock.Anything, int64(2)).Return(nil)
+			},
+		},
+		{
+			name:   "Task without a due date",
+			userID: 1,
+			taskID: 2,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(2)).Return(task.Task{ID: 2, UserID: 1}, nil)
+			},
+			expectedErrMsg: "only tasks with a due date",
+		},
+		{
+			name:   "Another user's task",
+			userID: 1,
+			taskID: 2,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("GetByID", mock.Anything, int64(2)).Return(task.Task{ID: 2, UserID: 9, DueDate: &due}, nil)
+			},
+			expectedErrMsg: "task 2 not found",
+		},
+		{
+			name:           "Invalid task ID",
+			userID:         1,
+			taskID:         0,
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedErrMsg: "task ID must be positive",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			tc.mockSetup(mockRepo)
+
+			taskService := newTestTaskService(mockRepo)
+
+			pinned, err := taskService.PinToDay(context.Background(), tc.userID, tc.taskID)
+
+			if tc.expectedErrMsg != "" {
+				assert.ErrorContains(t, err, tc.expectedErrMsg)
+				mockRepo.AssertNotCalled(t, "PinToDay", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, int32(tc.taskID), pinned.ID)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUnpinFromDay(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockRepo.On("GetByID", mock.Anything, int64(3)).Return(task.Task{ID: 3, UserID: 1}, nil)
+	mockRepo.On("UnpinFromDay", mock.Anything, int64(3)).Return(nil).Once()
+	mockRepo.On("UnpinFromDay", mock.Anything, int64(3)).Return(domainerrors.NotFound("task 3 is not pinned"))
+
+	taskService := newTestTaskService(mockRepo)
+
+	assert.NoError(t, taskService.UnpinFromDay(context.Background(), 1, 3))
+	assert.ErrorContains(t, taskService.UnpinFromDay(context.Background(), 1, 3), "task 3 is not pinned")
+
+	// Another user cannot unpin the task
+	assert.ErrorContains(t, taskService.UnpinFromDay(context.Background(), 2, 3), "task 3 not found")
+	mockRepo.AssertNumberOfCalls(t, "UnpinFromDay", 2)
+}
+
+func TestSearchByText(t *testing.T) {
+	testCases := []struct {
+		name           string
+		userID         int64
+		query          string
+		mockSetup      func(*MockTaskRepository)
+		expectedCount  int
+		expectedErrMsg string
+	}{
+		{
+			name:   "Query is trimmed before searching",
+			userID: 1,
+			query:  "  quarterly report ",
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("SearchTasksByText", mock.Anything, int64(1), "quarterly report").
+					Return([]task.Task{{ID: 3, Title: "Finance"}}, nil)
+			},
+			expectedCount: 1,
+		},
+		{
+			name:           "Blank query",
+			userID:         1,
+			query:          "   ",
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedErrMsg: "search query is required",
+		},
+		{
+			name:           "Invalid user ID",
+			userID:         0,
+			query:          "report",
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedErrMsg: "user ID must be positive",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			tc.mockSetup(mockRepo)
+
+			taskService := newTestTaskService(mockRepo)
+
+			tasks, err := taskService.SearchByText(context.Background(), tc.userID, tc.query)
+
+			if tc.expectedErrMsg != "" {
+				assert.ErrorContains(t, err, tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, tasks, tc.expectedCount)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListPage(t *testing.T) {
+	testCases := []struct {
+		name           string
+		limit          int
+		offset         int
+		mockSetup      func(*MockTaskRepository)
+		expectedIDs    []int32
+		expectedTotal  int
+		expectedMore   bool
+		expectedErrMsg string
+	}{
+		{
+			name:   "Page of trees loaded in one call",
+			limit:  2,
+			offset: 2,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("CountRootTasks", mock.Anything, int64(1)).Return(5, nil)
+				mockRepo.On("ListRootTasksPaged", mock.Anything, int64(1), 2, 2).
+					Return([]task.Task{{ID: 7}, {ID: 3}}, nil)
+				mockRepo.On("GetTaskTrees", mock.Anything, []int64{7, 3}).Return([]task.Task{
+					{ID: 3, SubTasks: []task.Task{{ID: 4}}, TotalCount: 1},
+					{ID: 7},
+				}, nil).Once()
+			},
+			expectedIDs:   []int32{7, 3},
+			expectedTotal: 5,
+			expectedMore:  true,
+		},
+		{
+			name:   "Last page",
+			limit:  10,
+			offset: 0,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("CountRootTasks", mock.Anything, int64(1)).Return(1, nil)
+				mockRepo.On("ListRootTasksPaged", mock.Anything, int64(1), 10, 0).
+					Return([]task.Task{{ID: 1}}, nil)
+				mockRepo.On("GetTaskTrees", mock.Anything, []int64{1}).Return([]task.Task{{ID: 1}}, nil)
+			},
+			expectedIDs:   []int32{1},
+			expectedTotal: 1,
+		},
+		{
+			name:   "Past the end",
+			limit:  10,
+			offset: 20,
+			mockSetup: func(mockRepo *MockTaskRepository) {
+				mockRepo.On("CountRootTasks", mock.Anything, int64(1)).Return(1, nil)
+				mockRepo.On("ListRootTasksPaged", mock.Anything, int64(1), 10, 20).Return([]task.Task{}, nil)
+			},
+			expectedIDs:   []int32{},
+			expectedTotal: 1,
+		},
+		{
+			name:           "Page too large",
+			limit:          maxPageSize + 1,
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedErrMsg: "page size must be between 1 and 500",
+		},
+		{
+			name:           "Negative offset",
+			limit:          10,
+			offset:         -1,
+			mockSetup:      func(mockRepo *MockTaskRepository) {},
+			expectedErrMsg: "page offset cannot be negative",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			tc.mockSetup(mockRepo)
+
+			taskService := newTestTaskService(mockRepo)
+
+			page, err := taskService.ListPage(context.Background(), 1, tc.limit, tc.offset)
+
+			if tc.expectedErrMsg != "" {
+				assert.ErrorContains(t, err, tc.expectedErrMsg)
+				return
+			}
+			assert.NoError(t, err)
+
+			ids := make([]int32, len(page.Tasks))
+			for i, pt := range page.Tasks {
+				ids[i] = pt.ID
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+			assert.Equal(t, tc.expectedTotal, page.Total)
+			assert.Equal(t, tc.expectedMore, page.HasMore())
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAsyncCacheTTL(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	newAsync := func(mockRepo *MockTaskRepository) *AsyncTaskService {
+		svc := NewAsyncTaskService(newTestTaskService(mockRepo), zaptest.NewLogger(t), WithCacheTTL(time.Minute))
+		svc.now = func() time.Time { return now }
+		t.Cleanup(svc.Close)
+		return svc
+	}
+
+	t.Run("Fresh entries are served from the cache", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("GetTaskTree", mock.Anything, int64(7)).Return(task.Task{ID: 7, UserID: 1, Title: "Cached"}, nil).Once()
+		svc := newAsync(mockRepo)
+
+		_, err := svc.GetByID(ctx, 7)
+		assert.NoError(t, err)
+		now = now.Add(59 * time.Second)
+		got, err := svc.GetByID(ctx, 7)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Cached", got.Title)
+		mockRepo.AssertNumberOfCalls(t, "GetTaskTree", 1)
+	})
+
+	t.Run("Expired entries are fetched again", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		mockRepo.On("GetTaskTree", mock.Anything, int64(7)).Return(task.Task{ID: 7, UserID: 1, Title: "Old"}, nil).Once()
+		mockRepo.On("GetTaskTree", mock.Anything, int64(7)).Return(task.Task{ID: 7, UserID: 1, Title: "Edited elsewhere"}, nil).Once()
+		svc := newAsync(mockRepo)
+
+		_, err := svc.GetByID(ctx, 7)
+		assert.NoError(t, err)
+		now = now.Add(time.Minute)
+		got, err := svc.GetByID(ctx, 7)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Edited elsewhere", got.Title)
+		mockRepo.AssertNumberOfCalls(t, "GetTaskTree", 2)
+	})
+
+	t.Run("InvalidateUser drops the user's tasks only", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		svc := newAsync(mockRepo)
+		svc.cacheTask(task.Task{ID: 7, UserID: 1})
+		svc.cacheTask(task.Task{ID: 8, UserID: 2})
+		svc.storeUserTasks(1, []task.Task{{ID: 7, UserID: 1}})
+
+		svc.InvalidateUser(1)
+
+		_, ok := svc.loadTask(7)
+		assert.False(t, ok)
+		_, ok = svc.loadUserTasks(1)
+		assert.False(t, ok)
+		_, ok = svc.loadTask(8)
+		assert.True(t, ok)
+	})
+
+	t.Run("Bulk status updates show in the next list", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		todo := []task.Task{{ID: 7, UserID: 1, Status: task.StatusTodo}, {ID: 8, UserID: 1, Status: task.StatusTodo}}
+		done := []task.Task{{ID: 7, UserID: 1, Status: task.StatusDone}, {ID: 8, UserID: 1, Status: task.StatusDone}}
+		mockRepo.On("GetTaskForest", mock.Anything, int64(1)).Return(todo, nil).Once()
+		mockRepo.On("GetTaskForest", mock.Anything, int64(1)).Return(done, nil)
+		for _, listed := range todo {
+			mockRepo.On("GetTaskTree", mock.Anything, int64(listed.ID)).Return(listed, nil)
+			mockRepo.On("GetByID", mock.Anything, int64(listed.ID)).Return(listed, nil)
+		}
+		mockRepo.On("BulkUpdateTaskStatus", mock.Anything, []int32{7, 8}, task.StatusDone, true).Return(nil).Once()
+		svc := newAsync(mockRepo)
+
+		_, err := svc.List(ctx, 1)
+		assert.NoError(t, err)
+		assert.NoError(t, svc.BulkUpdateStatus(ctx, []int32{7, 8}, task.StatusDone))
+		got, err := svc.List(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, done, got)
+		_, ok := svc.loadTask(7)
+		assert.False(t, ok)
+	})
+
+	t.Run("Task and task list keys never collide", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		svc := newAsync(mockRepo)
+		svc.cacheTask(task.Task{ID: 7, UserID: 3})
+		svc.storeUserTasks(7, []task.Task{{ID: 11, UserID: 7}})
+
+		cached, ok := svc.loadTask(7)
+		assert.True(t, ok)
+		assert.Equal(t, int32(3), cached.UserID)
+		tasks, ok := svc.loadUserTasks(7)
+		assert.True(t, ok)
+		assert.Len(t, tasks, 1)
+	})
+}
+
+func TestAsyncCacheDebugEntries(t *testing.T) {
+	now := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	newAsync := func() *AsyncTaskService {
+		svc := NewAsyncTaskService(newTestTaskService(new(MockTaskRepository)), zaptest.NewLogger(t), WithCacheTTL(time.Minute))
+		svc.now = func() time.Time { return now }
+		t.Cleanup(svc.Close)
+		return svc
+	}
+
+	t.Run("Snapshot lists keys and ages without payloads", func(t *testing.T) {
+		svc := newAsync()
+		svc.cacheTask(task.Task{ID: 7, UserID: 1, Title: "Private"})
+		now = now.Add(90 * time.Second)
+		svc.storeUserTasks(1, []task.Task{{ID: 7}, {ID: 8}})
+		now = now.Add(10 * time.Second)
+
+		entries := svc.DebugCacheEntries(false)
+
+		assert.Len(t, entries, 2)
+		assert.Equal(t, "task 7", entries[0].Key)
+		assert.Equal(t, "1 task", entries[0].Summary)
+		assert.Equal(t, 100*time.Second, entries[0].Age)
+		assert.True(t, entries[0].Expired)
+		assert.Nil(t, entries[0].Value)
+		assert.Equal(t, "user 1 tasks", entries[1].Key)
+		assert.Equal(t, "2 tasks", entries[1].Summary)
+		assert.False(t, entries[1].Expired)
+
+		verbose := svc.DebugCacheEntries(true)
+		assert.Equal(t, "Private", verbose[0].Value.(task.Task).Title)
+	})
+
+	t.Run("Entries can be evicted one by one", func(t *testing.T) {
+		svc := newAsync()
+		svc.cacheTask(task.Task{ID: 7, UserID: 1})
+		svc.cacheTask(task.Task{ID: 8, UserID: 1})
+
+		entries := svc.DebugCacheEntries(false)
+		assert.True(t, svc.EvictCacheEntry(entries[0]))
+		assert.False(t, svc.EvictCacheEntry(entries[0]))
+
+		_, ok := svc.loadTask(7)
+		assert.False(t, ok)
+		_, ok = svc.loadTask(8)
+		assert.True(t, ok)
+	})
+
+	t.Run("PruneCache drops expired entries only", func(t *testing.T) {
+		svc := newAsync()
+		svc.cacheTask(task.Task{ID: 7, UserID: 1})
+		now = now.Add(time.Minute)
+		svc.cacheTask(task.Task{ID: 8, UserID: 1})
+
+		assert.Equal(t, 1, svc.PruneCache())
+
+		entries := svc.DebugCacheEntries(false)
+		assert.Len(t, entries, 1)
+		assert.Equal(t, "task 8", entries[0].Key)
+	})
+
+	t.Run("Snapshots are safe while the cache changes", func(t *testing.T) {
+		svc := newAsync()
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(2)
+			go func(id int32) {
+				defer wg.Done()
+				for j := int32(0); j < 100; j++ {
+					svc.cacheTask(task.Task{ID: id*100 + j, UserID: 1})
+				}
+			}(int32(i))
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					for _, e := range svc.DebugCacheEntries(false) {
+						svc.EvictCacheEntry(e)
+					}
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
+
+func TestAsyncBackgroundJobs(t *testing.T) {
+	newAsync := func(logger *zap.Logger) *AsyncTaskService {
+		svc := NewAsyncTaskService(newTestTaskService(new(MockTaskRepository)), logger, WithJobTimeout(20*time.Millisecond))
+		t.Cleanup(svc.Close)
+		return svc
+	}
+
+	t.Run("Jobs that run out of time are canceled and logged", func(t *testing.T) {
+		core, logs := observer.New(zap.WarnLevel)
+		svc := newAsync(zap.New(core))
+
+		var jobErr error
+		svc.submit("hung refresh", func(ctx context.Context) error {
+			<-ctx.Done()
+			jobErr = ctx.Err()
+			return jobErr
+		})
+
+		assert.NoError(t, svc.Wait(context.Background()))
+		assert.ErrorIs(t, jobErr, context.DeadlineExceeded)
+		timedOut := logs.FilterMessage("Background job timed out").All()
+		assert.Len(t, timedOut, 1)
+		assert.Equal(t, "hung refresh", timedOut[0].ContextMap()["job"])
+	})
+
+	t.Run("Wait gives up when its context is done", func(t *testing.T) {
+		svc := newAsync(zaptest.NewLogger(t))
+		release := make(chan struct{})
+		svc.submit("blocked job", func(ctx context.Context) error {
+			<-release
+			return nil
+		})
+
+		waitCtx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.ErrorIs(t, svc.Wait(waitCtx), context.Canceled)
+
+		close(release)
+		assert.NoError(t, svc.Wait(context.Background()))
+	})
+
+	t.Run("Jobs submitted after Wait are dropped", func(t *testing.T) {
+		svc := newAsync(zaptest.NewLogger(t))
+		var ran int32
+		svc.submit("before wait", func(ctx context.Context) error {
+			atomic.AddInt32(&ran, 1)
+			return nil
+		})
+
+		assert.NoError(t, svc.Wait(context.Background()))
+		svc.submit("after wait", func(ctx context.Context) error {
+			atomic.AddInt32(&ran, 10)
+			return nil
+		})
+		svc.Close()
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&ran))
+	})
+
+	t.Run("Close finishes the submitted jobs", func(t *testing.T) {
+		svc := NewAsyncTaskService(newTestTaskService(new(MockTaskRepository)), zaptest.NewLogger(t))
+		var done int32
+		for i := 0; i < 5; i++ {
+			svc.submit("slow job", func(ctx context.Context) error {
+				time.Sleep(5 * time.Millisecond)
+				atomic.AddInt32(&done, 1)
+				return nil
+			})
+		}
+
+		svc.Close()
+
+		assert.Equal(t, int32(5), atomic.LoadInt32(&done))
+	})
+}
+
 // Helper functions for testing
 func stringPtr(s string) *string {
 	return &s
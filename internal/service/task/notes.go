@@ -0,0 +1,66 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/newbpydev/tusk/internal/core/errors"
+	"github.com/newbpydev/tusk/internal/core/task"
+	"go.uber.org/zap"
+)
+
+// AddNote appends a dated note to one of the user's tasks. The body is trimmed and
+// must not be empty or longer than task.MaxNoteLength characters.
+func (s *taskService) AddNote(ctx context.Context, userID, taskID int64, body string) (task.TaskNote, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return task.TaskNote{}, errors.InvalidInput("note is required")
+	}
+	if utf8.RuneCountInString(body) > task.MaxNoteLength {
+		return task.TaskNote{}, errors.InvalidInput(fmt.Sprintf("note must be at most %d characters", task.MaxNoteLength))
+	}
+
+	if _, err := s.ownedTask(ctx, userID, taskID); err != nil {
+		return task.TaskNote{}, err
+	}
+
+	note, err := s.repo.AddNote(ctx, taskID, body)
+	if err != nil {
+		return task.TaskNote{}, err
+	}
+
+	s.log.Info("Task note added",
+		zap.Int64("task_id", taskID),
+		zap.Int32("note_id", note.ID))
+	return note, nil
+}
+
+// ListNotes retrieves the notes of one of the user's tasks, newest first
+func (s *taskService) ListNotes(ctx context.Context, userID, taskID int64) ([]task.TaskNote, error) {
+	if _, err := s.ownedTask(ctx, userID, taskID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListNotes(ctx, taskID)
+}
+
+// ownedTask fetches a task of the given user. Another user's task looks exactly
+// like a missing one.
+func (s *taskService) ownedTask(ctx context.Context, userID, taskID int64) (task.Task, error) {
+	if userID <= 0 {
+		return task.Task{}, errors.InvalidInput("user ID must be positive")
+	}
+	if taskID <= 0 {
+		return task.Task{}, errors.InvalidInput("task ID must be positive")
+	}
+
+	t, err := s.repo.GetByID(ctx, taskID)
+	if err != nil {
+		return task.Task{}, err
+	}
+	if int64(t.UserID) != userID {
+		return task.Task{}, errors.NotFound(fmt.Sprintf("task %d not found", taskID))
+	}
+	return t, nil
+}
@@ -0,0 +1,75 @@
+package task
+
+import (
+	"time"
+
+	"github.com/newbpydev/tusk/internal/core/task"
+)
+
+// Option configures optional behavior of the task service
+type Option func(*taskService)
+
+// WithParentCompletion sets how the service reacts when the last incomplete
+// subtask of a parent is completed
+func WithParentCompletion(mode ParentCompletionMode) Option {
+	return func(s *taskService) {
+		s.parentCompletion = mode
+	}
+}
+
+// WithTagCaseFolding controls whether tags are lowercased before they are saved, so
+// "Work" and "work" are the same tag. Folding is on unless turned off here.
+func WithTagCaseFolding(fold bool) Option {
+	return func(s *taskService) {
+		s.foldTagCase = fold
+	}
+}
+
+// WithStrictValidation makes Create and Update reject an invalid priority with an
+// InvalidInput error instead of falling back to medium or keeping the old one. An
+// empty priority still means the default. Meant for API clients, where silently
+// fixing a bad value would hide the bug that sent it. Statuses are always checked,
+// and tasks read from the database stay lenient so one bad row cannot hide the rest.
+func WithStrictValidation(strict bool) Option {
+	return func(s *taskService) {
+		s.strict = strict
+	}
+}
+
+// WithLocation sets the time zone that decides where "today" starts and ends.
+// A nil location keeps the local time zone.
+func WithLocation(loc *time.Location) Option {
+	return func(s *taskService) {
+		if loc != nil {
+			s.loc = loc
+		}
+	}
+}
+
+// WithDueSoonDays sets how many days ahead ListTasksDueSoon looks. Values below 1
+// keep the default of 7.
+func WithDueSoonDays(days int) Option {
+	return func(s *taskService) {
+		if days >= 1 {
+			s.dueSoonDays = days
+		}
+	}
+}
+
+// WithUrgencyWeights sets how NextTasks and MostUrgent rank tasks, see
+// task.ParseUrgencyWeights.
+func WithUrgencyWeights(weights task.UrgencyWeights) Option {
+	return func(s *taskService) {
+		s.urgency = weights
+	}
+}
+
+// WithMaxDepth limits how many levels deep tasks can be nested. Values below 1
+// keep the default.
+func WithMaxDepth(depth int) Option {
+	return func(s *taskService) {
+		if depth >= 1 {
+			s.maxDepth = depth
+		}
+	}
+}
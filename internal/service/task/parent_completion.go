@@ -0,0 +1,180 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/newbpydev/tusk/internal/core/errors"
+	"github.com/newbpydev/tusk/internal/core/task"
+	"go.uber.org/zap"
+)
+
+// ParentCompletionMode controls what happens to a parent task once all of its
+// subtasks have been completed
+type ParentCompletionMode string
+
+const (
+	// ParentCompletionAuto completes the parent (and any ancestors that become
+	// fully completed as a result) automatically
+	ParentCompletionAuto ParentCompletionMode = "auto"
+	// ParentCompletionPrompt leaves the decision to the caller, which can use
+	// ReadyParents and CompleteParents to ask the user first
+	ParentCompletionPrompt ParentCompletionMode = "prompt"
+	// ParentCompletionNever leaves parent tasks untouched
+	ParentCompletionNever ParentCompletionMode = "never"
+)
+
+// maxAncestorWalk bounds how far up the tree we look for ready parents so that
+// corrupted (cyclic) parent links can never make us loop forever
+const maxAncestorWalk = 100
+
+// ParseParentCompletionMode converts a configuration string into a ParentCompletionMode.
+// It returns an error for unknown values.
+func ParseParentCompletionMode(s string) (ParentCompletionMode, error) {
+	switch mode := ParentCompletionMode(strings.ToLower(strings.TrimSpace(s))); mode {
+	case ParentCompletionAuto, ParentCompletionPrompt, ParentCompletionNever:
+		return mode, nil
+	case "":
+		return ParentCompletionNever, nil
+	default:
+		return ParentCompletionNever, fmt.Errorf("invalid parent completion mode %q (expected auto, prompt or never)", s)
+	}
+}
+
+// ReadyParents returns the incomplete ancestors of a task whose subtasks would all be
+// completed once the task itself is done, ordered from the nearest parent upwards
+func (s *taskService) ReadyParents(ctx context.Context, taskID int64) ([]task.Task, error) {
+	if taskID <= 0 {
+		return nil, errors.InvalidInput("task ID must be positive")
+	}
+
+	t, err := s.repo.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.readyParents(ctx, t)
+}
+
+// CompleteParents marks every ready ancestor of a task as complete and returns the
+// tasks that were completed, nearest parent first
+func (s *taskService) CompleteParents(ctx context.Context, taskID int64) ([]task.Task, error) {
+	if taskID <= 0 {
+		return nil, errors.InvalidInput("task ID must be positive")
+	}
+
+	t, err := s.repo.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !t.IsCompleted {
+		// Parents can only become ready once the task itself is done
+		return nil, nil
+	}
+
+	return s.completeReadyParents(ctx, t)
+}
+
+// readyParents walks up the tree from t collecting parents whose remaining
+// subtasks are all completed, treating t and every collected parent as done
+func (s *taskService) readyParents(ctx context.Context, t task.Task) ([]task.Task, error) {
+	var ready []task.Task
+	visited := map[int32]bool{t.ID: true}
+	current := t
+
+	for depth := 0; current.ParentID != nil && depth < maxAncestorWalk; depth++ {
+		parentID := *current.ParentID
+		if visited[parentID] {
+			s.log.Warn("Cycle detected while walking task ancestors",
+				zap.Int32("task_id", t.ID),
+				zap.Int32("parent_id", parentID))
+			break
+		}
+		visited[parentID] = true
+
+		parent, err := s.repo.GetByID(ctx, int64(parentID))
+		if err != nil {
+			return nil, err
+		}
+		if parent.IsCompleted {
+			break
+		}
+
+		// A parent still waiting on its dependencies is not ready, however done its subtasks are
+		blocking, err := s.incompleteDependencies(ctx, parent)
+		if err != nil {
+			return nil, err
+		}
+		if len(blocking) > 0 {
+			break
+		}
+
+		children, err := s.repo.ListSubTasks(ctx, int64(parentID))
+		if err != nil {
+			return nil, err
+		}
+
+		allDone := true
+		for _, child := range children {
+			if child.ID != current.ID && !child.IsCompleted {
+				allDone = false
+				break
+			}
+		}
+		if !allDone {
+			break
+		}
+
+		ready = append(ready, parent)
+		current = parent
+	}
+
+	return ready, nil
+}
+
+// completeReadyParents completes the ready ancestors of t in order, stopping at the first failure
+func (s *taskService) completeReadyParents(ctx context.Context, t task.Task) ([]task.Task, error) {
+	parents, err := s.readyParents(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+
+	completed := make([]task.Task, 0, len(parents))
+	for _, parent := range parents {
+		parent.IsCompleted = true
+		parent.Status = task.StatusDone
+		parent.UpdatedAt = time.Now()
+
+		if err := s.repo.Update(ctx, parent); err != nil {
+			s.log.Error("Failed to complete parent task",
+				zap.Int32("task_id", t.ID),
+				zap.Int32("parent_id", parent.ID),
+				zap.Error(err))
+			return completed, err
+		}
+
+		s.log.Info("Parent task completed after all subtasks were done",
+			zap.Int32("task_id", t.ID),
+			zap.Int32("parent_id", parent.ID))
+		completed = append(completed, parent)
+	}
+
+	return completed, nil
+}
+
+// autoCompleteParents applies the automatic parent completion policy after t was completed.
+// Failures are logged rather than returned so the original completion still succeeds.
+func (s *taskService) autoCompleteParents(ctx context.Context, t task.Task) {
+	if s.parentCompletion != ParentCompletionAuto || t.ParentID == nil {
+		return
+	}
+
+	if _, err := s.completeReadyParents(ctx, t); err != nil {
+		s.log.Warn("Automatic parent completion failed",
+			zap.Int32("task_id", t.ID),
+			zap.Error(err))
+	}
+}
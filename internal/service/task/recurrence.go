@@ -0,0 +1,32 @@
+package task
+
+import (
+	"context"
+	"time"
+
+	"github.com/newbpydev/tusk/internal/core/task"
+	"go.uber.org/zap"
+)
+
+// spawnNextOccurrence creates the next occurrence of a recurring task after t was
+// completed. Failures are logged rather than returned so the completion still succeeds.
+func (s *taskService) spawnNextOccurrence(ctx context.Context, t task.Task) {
+	next, ok := task.GenerateNextOccurrence(t, time.Now().In(s.loc))
+	if !ok {
+		return
+	}
+
+	created, err := s.repo.Create(ctx, next)
+	if err != nil {
+		s.log.Error("Failed to create next occurrence of recurring task",
+			zap.Int32("task_id", t.ID),
+			zap.String("recurrence", t.Recurrence.String()),
+			zap.Error(err))
+		return
+	}
+
+	s.log.Info("Created next occurrence of recurring task",
+		zap.Int32("task_id", t.ID),
+		zap.Int32("next_task_id", created.ID),
+		zap.Time("due_date", *created.DueDate))
+}
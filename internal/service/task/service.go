@@ -13,29 +13,91 @@ import (
 // completing, changing status, and changing priority of tasks.
 // Each method takes a context and relevant parameters, and returns the task or an error.
 type Service interface {
+	// Create creates a task. A non-nil recurrence makes the task repeat: completing it
+	// creates the next occurrence.
 	Create(ctx context.Context, userID int64, parentID *int64, title, description string,
-		dueDate *time.Time, priority task.Priority, tags []string) (task.Task, error)
+		dueDate *time.Time, priority task.Priority, tags []string, recurrence *task.Recurrence) (task.Task, error)
+	// CreateTree creates a root task with its nested children in one transaction: either
+	// the whole tree is created or, when any insert fails, none of it.
+	CreateTree(ctx context.Context, userID int64, root TaskInput) (task.Task, error)
 	Show(ctx context.Context, taskID int64) (task.Task, error)
 	List(ctx context.Context, userID int64) ([]task.Task, error)
+	// ListPage retrieves limit root tasks with their subtasks, skipping the first offset,
+	// along with the user's total number of root tasks. Pages are ordered by display
+	// order then ID, so the order is stable across pages.
+	ListPage(ctx context.Context, userID int64, limit, offset int) (task.Page, error)
+	ListChildren(ctx context.Context, parentID int64) ([]task.Task, error)
 	Reorder(ctx context.Context, taskID int64, newOrder int) error
+	// NormalizeOrder compacts a user's display orders to 0..n under each parent, keeping
+	// the order tasks are listed in.
+	NormalizeOrder(ctx context.Context, userID int64) error
+	// CompactOrders renumbers a user's display orders 0..n under each parent like
+	// NormalizeOrder, and returns how many tasks got a new display order.
+	CompactOrders(ctx context.Context, userID int64) (int, error)
+	// SwapOrder exchanges the display order of two tasks. Both tasks must belong to the
+	// same user and sit in the same section: under the same parent and with the same
+	// status (see task.SameListSection).
+	SwapOrder(ctx context.Context, aID, bID int64) error
+	// MoveTaskUp swaps a task with the sibling listed right above it in its section,
+	// see SwapOrder. A task already first in its section is left where it is.
+	MoveTaskUp(ctx context.Context, taskID int64) error
+	// MoveTaskDown swaps a task with the sibling listed right below it in its section.
+	MoveTaskDown(ctx context.Context, taskID int64) error
+	// MoveTask re-parents a task and its subtasks under newParentID, or makes it a
+	// root task when newParentID is nil. The new parent must belong to the same user
+	// and cannot be the task itself or one of its descendants.
+	MoveTask(ctx context.Context, taskID int64, newParentID *int64) (task.Task, error)
+	// Clone copies a task into a new todo task titled "<title> (copy)" under the same
+	// parent, deep-copying its subtasks when withSubtasks is set.
+	Clone(ctx context.Context, taskID int64, withSubtasks bool) (task.Task, error)
 	Update(ctx context.Context, taskID int64, title, description string,
 		dueDate *time.Time, priority task.Priority, tags []string) (task.Task, error)
 	Delete(ctx context.Context, taskID int64) error
+	// Archive hides a task and its subtasks from every listing instead of deleting them.
+	Archive(ctx context.Context, taskID int64) error
+	// Restore brings back an archived task with the subtasks archived along with it.
+	Restore(ctx context.Context, taskID int64) (task.Task, error)
+	// ListArchived retrieves the user's archived tasks, most recently archived first.
+	ListArchived(ctx context.Context, userID int64) ([]task.Task, error)
 	Complete(ctx context.Context, taskID int64) (task.Task, error)
 	ChangeStatus(ctx context.Context, taskID int64, status task.Status) (task.Task, error)
 	ChangePriority(ctx context.Context, taskID int64, priority task.Priority) (task.Task, error)
+	SetEnergy(ctx context.Context, taskID int64, energy task.Energy) (task.Task, error)
+	SetPoints(ctx context.Context, taskID int64, points int) (task.Task, error)
+	SnoozeUntil(ctx context.Context, taskID int64, until time.Time) (task.Task, error)
+	// Snooze pushes a task's due date forward by d, counting from today when the task
+	// is overdue and from now when it has no due date (see task.SnoozeBy)
+	Snooze(ctx context.Context, taskID int64, d time.Duration) (task.Task, error)
+	// SetExternalID anchors a task to an event in an external calendar, identified by
+	// source (e.g. "google") and the event's ID there. Empty values detach the task.
+	SetExternalID(ctx context.Context, taskID int64, source, externalID string) (task.Task, error)
+	// GetByExternalID retrieves the user's task anchored to an external calendar event.
+	GetByExternalID(ctx context.Context, userID int64, source, externalID string) (task.Task, error)
 
 	// Search and filtering methods
 
 	// SearchByTitle searches for tasks with titles matching the given pattern.
 	SearchByTitle(ctx context.Context, userID int64, titlePattern string) ([]task.Task, error)
 
+	// SearchByText searches for tasks whose title, description or tags contain the query,
+	// ignoring case and surrounding whitespace.
+	SearchByText(ctx context.Context, userID int64, query string) ([]task.Task, error)
+
 	// SearchByTag searches for tasks that have the specified tag.
 	SearchByTag(ctx context.Context, userID int64, tag string) ([]task.Task, error)
 
 	// ListByStatus retrieves all tasks for a user with the given status.
 	ListByStatus(ctx context.Context, userID int64, status task.Status) ([]task.Task, error)
 
+	// ListByStatuses retrieves all tasks for a user whose status is any of the given
+	// statuses, e.g. todo and in-progress for every active task.
+	ListByStatuses(ctx context.Context, userID int64, statuses []task.Status) ([]task.Task, error)
+
+	// ListFiltered retrieves the user's tasks matching every set field of the filter,
+	// e.g. high priority, due this week and not done, sorted by due date ascending.
+	// An empty filter returns every task flat.
+	ListFiltered(ctx context.Context, userID int64, filter task.TaskFilter) ([]task.Task, error)
+
 	// ListByPriority retrieves all tasks for a user with the given priority.
 	ListByPriority(ctx context.Context, userID int64, priority task.Priority) ([]task.Task, error)
 
@@ -44,12 +106,42 @@ type Service interface {
 	// ListTasksDueToday retrieves all incomplete tasks due on the current day.
 	ListTasksDueToday(ctx context.Context, userID int64) ([]task.Task, error)
 
-	// ListTasksDueSoon retrieves all incomplete tasks due within the next 7 days.
+	// ListTasksDueSoon retrieves all incomplete tasks due within the next 7 days, or the
+	// window set with WithDueSoonDays.
 	ListTasksDueSoon(ctx context.Context, userID int64) ([]task.Task, error)
 
 	// ListOverdueTasks retrieves all incomplete tasks that are past their due date.
 	ListOverdueTasks(ctx context.Context, userID int64) ([]task.Task, error)
 
+	// NextTasks retrieves up to limit incomplete dated tasks, most urgent first.
+	NextTasks(ctx context.Context, userID int64, limit int) ([]task.Task, error)
+
+	// MostUrgent retrieves the single incomplete dated task to work on right now, or
+	// false when nothing is due.
+	MostUrgent(ctx context.Context, userID int64) (task.Task, bool, error)
+
+	// GetAgenda retrieves the incomplete tasks that are overdue or due today, grouped
+	// and sorted by priority, then due time, with pinned tasks first in their group.
+	GetAgenda(ctx context.Context, userID int64) (task.Agenda, error)
+
+	// RolloverTodayPreview lists the tasks RolloverToday would move, without changing anything.
+	RolloverTodayPreview(ctx context.Context, userID int64) ([]task.Task, error)
+
+	// RolloverToday moves every incomplete task due today to the same time tomorrow
+	// and returns how many tasks were moved.
+	RolloverToday(ctx context.Context, userID int64) (int, error)
+
+	// SnoozeFilteredPreview lists the tasks SnoozeFiltered would move, without changing anything.
+	SnoozeFilteredPreview(ctx context.Context, userID int64, filter task.TaskFilter) ([]task.Task, error)
+
+	// SnoozeFiltered sets the due date of every incomplete task matching the filter to until,
+	// e.g. all low priority overdue tasks to next week, and returns how many tasks were moved.
+	SnoozeFiltered(ctx context.Context, userID int64, filter task.TaskFilter, until time.Time) (int, error)
+
+	// SeedDemoTasks creates a few example tasks that show off the UI for a user who has
+	// no tasks yet, and returns how many were created. Users with tasks are left alone.
+	SeedDemoTasks(ctx context.Context, userID int64) (int, error)
+
 	// Statistics and metrics
 
 	// GetTaskCountsByStatus retrieves counts of tasks grouped by status.
@@ -61,13 +153,86 @@ type Service interface {
 	// GetRecentlyCompletedTasks retrieves recently completed tasks, limited by count.
 	GetRecentlyCompletedTasks(ctx context.Context, userID int64, limit int) ([]task.Task, error)
 
+	// ProjectBurndown retrieves the number of open subtasks of a project for each day in a range.
+	ProjectBurndown(ctx context.Context, rootID int64, from, to time.Time) ([]task.BurndownPoint, error)
+
+	// SessionSummary retrieves the tasks completed or modified between start and end.
+	SessionSummary(ctx context.Context, userID int64, start, end time.Time) (task.SessionSummary, error)
+
+	// RecomputeMetrics recomputes the cached metrics of every parent task of a user
+	// and returns how many tasks were cached.
+	RecomputeMetrics(ctx context.Context, userID int64) (int, error)
+
+	// CachedMetrics retrieves the cached metrics of a user's parent tasks. The values
+	// are derived and may be stale; loaded task trees always carry fresh metrics.
+	CachedMetrics(ctx context.Context, userID int64) ([]task.CachedMetrics, error)
+
 	// Batch operations
 
-	// BulkUpdateStatus updates the status of multiple tasks at once.
+	// BulkUpdateStatus updates the status of multiple tasks at once. Marking tasks
+	// done fails with a conflict when one of them is blocked by an open dependency
+	// outside the batch, and otherwise runs the same follow-ups as Complete.
 	BulkUpdateStatus(ctx context.Context, taskIDs []int32, status task.Status) error
 
 	// Tag operations
 
-	// GetAllTags retrieves all unique tags used by a user.
+	// GetAllTags retrieves all unique tags used by a user, listing case variants once
+	// when tag case folding is enabled.
 	GetAllTags(ctx context.Context, userID int64) ([]string, error)
+
+	// DedupeTags removes duplicate tags from all of a user's tasks and returns how many changed.
+	DedupeTags(ctx context.Context, userID int64) (int, error)
+
+	// MergeTagCasePreview lists the tasks MergeTagCase would change and the spellings of
+	// the tag in use, without changing anything.
+	MergeTagCasePreview(ctx context.Context, userID int64, canonical string) (task.TagMerge, error)
+
+	// MergeTagCase rewrites every spelling of a tag that differs only in case to canonical
+	// on all of a user's tasks, in a single transaction. It returns how many tasks changed.
+	MergeTagCase(ctx context.Context, userID int64, canonical string) (int, error)
+
+	// Parent completion
+
+	// ReadyParents returns the incomplete ancestors that would have all subtasks done
+	// once the given task is completed, nearest parent first.
+	ReadyParents(ctx context.Context, taskID int64) ([]task.Task, error)
+
+	// CompleteParents completes every ancestor of a completed task whose subtasks are all done.
+	CompleteParents(ctx context.Context, taskID int64) ([]task.Task, error)
+
+	// Dependencies
+
+	// AddDependency records that taskID cannot be completed before dependsOnID is done.
+	// Complete and ChangeStatus refuse to finish a task while a dependency is still open.
+	AddDependency(ctx context.Context, taskID, dependsOnID int64) error
+
+	// RemoveDependency drops the dependency of taskID on dependsOnID.
+	RemoveDependency(ctx context.Context, taskID, dependsOnID int64) error
+
+	// ListDependencies retrieves the tasks that must be done before taskID can be completed.
+	ListDependencies(ctx context.Context, taskID int64) ([]task.Task, error)
+
+	// BlockedTaskIDs retrieves the IDs of the user's tasks that still wait on an incomplete dependency.
+	BlockedTaskIDs(ctx context.Context, userID int64) ([]int32, error)
+
+	// Notes
+
+	// AddNote appends a dated note to one of the user's tasks, leaving its description
+	// and earlier notes untouched.
+	AddNote(ctx context.Context, userID, taskID int64, body string) (task.TaskNote, error)
+
+	// ListNotes retrieves the notes of one of the user's tasks, newest first.
+	ListNotes(ctx context.Context, userID, taskID int64) ([]task.TaskNote, error)
+
+	// Day pins
+
+	// PinToDay pins one of the user's dated tasks to the top of its day in the agenda,
+	// ahead of the usual sort. The pin follows the task when its due date changes.
+	PinToDay(ctx context.Context, userID, taskID int64) (task.Task, error)
+
+	// UnpinFromDay removes the day pin of one of the user's tasks.
+	UnpinFromDay(ctx context.Context, userID, taskID int64) error
+
+	// DayPinnedTaskIDs retrieves the IDs of the user's pinned tasks, most recently pinned first.
+	DayPinnedTaskIDs(ctx context.Context, userID int64) ([]int32, error)
 }
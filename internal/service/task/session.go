@@ -0,0 +1,27 @@
+package task
+
+import (
+	"context"
+	"time"
+
+	"github.com/newbpydev/tusk/internal/core/errors"
+	"github.com/newbpydev/tusk/internal/core/task"
+)
+
+// SessionSummary lists the tasks a user completed or changed between start and end,
+// e.g. during a focus session
+func (s *taskService) SessionSummary(ctx context.Context, userID int64, start, end time.Time) (task.SessionSummary, error) {
+	if userID <= 0 {
+		return task.SessionSummary{}, errors.InvalidInput("user ID must be positive")
+	}
+	if end.Before(start) {
+		return task.SessionSummary{}, errors.InvalidInput("end time must not be before start time")
+	}
+
+	tasks, err := s.repo.ListTasksByStatuses(ctx, userID, append(task.ActiveStatuses(), task.StatusDone))
+	if err != nil {
+		return task.SessionSummary{}, err
+	}
+
+	return task.NewSessionSummary(tasks, start, end), nil
+}
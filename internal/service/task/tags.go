@@ -0,0 +1,156 @@
+package task
+
+import (
+	"context"
+	"strings"
+
+	"github.com/newbpydev/tusk/internal/core/errors"
+	"github.com/newbpydev/tusk/internal/core/task"
+	"go.uber.org/zap"
+)
+
+// normalizeTags normalizes tag names with task.NormalizeTagsLowercase, or with
+// task.NormalizeTags, keeping the typed case, when tag case folding is turned off
+func (s *taskService) normalizeTags(names []string) []task.Tag {
+	normalize := task.NormalizeTagsLowercase
+	if !s.foldTagCase {
+		normalize = task.NormalizeTags
+	}
+
+	var tags []task.Tag
+	for _, name := range normalize(names) {
+		tags = append(tags, task.Tag{Name: name})
+	}
+	return tags
+}
+
+// validateTags rejects tags that task.ValidateTag does not allow
+func validateTags(names []string) error {
+	for _, name := range names {
+		if err := task.ValidateTag(name); err != nil {
+			return errors.InvalidInput(err.Error())
+		}
+	}
+	return nil
+}
+
+// DedupeTags normalizes the tags of every task of a user, removing duplicates left
+// behind by older versions. It returns the number of tasks that were changed.
+func (s *taskService) DedupeTags(ctx context.Context, userID int64) (int, error) {
+	if userID <= 0 {
+		return 0, errors.InvalidInput("user ID must be positive")
+	}
+
+	roots, err := s.List(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	updated := 0
+	stack := roots
+	for len(stack) > 0 {
+		t := stack[len(stack)-1]
+		stack = append(stack[:len(stack)-1], t.SubTasks...)
+
+		names := make([]string, len(t.Tags))
+		for i, tag := range t.Tags {
+			names[i] = tag.Name
+		}
+
+		tags := s.normalizeTags(names)
+		if tagsEqual(tags, t.Tags) {
+			continue
+		}
+
+		t.Tags = tags
+		t.SubTasks = nil
+		if err := s.repo.Update(ctx, t); err != nil {
+			s.log.Error("Failed to dedupe task tags",
+				zap.Int64("user_id", userID),
+				zap.Int32("task_id", t.ID),
+				zap.Error(err))
+			return updated, err
+		}
+		updated++
+	}
+
+	s.log.Info("Deduplicated task tags",
+		zap.Int64("user_id", userID),
+		zap.Int("updated_count", updated))
+
+	return updated, nil
+}
+
+// MergeTagCasePreview works out which of a user's tasks MergeTagCase would change and
+// which spellings of the tag they use, without changing anything
+func (s *taskService) MergeTagCasePreview(ctx context.Context, userID int64, canonical string) (task.TagMerge, error) {
+	if userID <= 0 {
+		return task.TagMerge{}, errors.InvalidInput("user ID must be positive")
+	}
+	canonical = strings.TrimSpace(canonical)
+	if canonical == "" {
+		return task.TagMerge{}, errors.InvalidInput("tag cannot be empty")
+	}
+	// With case folding tags are stored lowercase, so that is the spelling to keep
+	if s.foldTagCase {
+		canonical = strings.ToLower(canonical)
+	}
+
+	roots, err := s.List(ctx, userID)
+	if err != nil {
+		return task.TagMerge{}, err
+	}
+	return task.PlanTagMerge(roots, canonical), nil
+}
+
+// MergeTagCase rewrites every spelling of a tag that differs only in case, e.g. "work"
+// and "WORK", to canonical on all of a user's tasks. The tasks change in a single
+// transaction. It returns the number of tasks that were changed.
+func (s *taskService) MergeTagCase(ctx context.Context, userID int64, canonical string) (int, error) {
+	merge, err := s.MergeTagCasePreview(ctx, userID, canonical)
+	if err != nil {
+		return 0, err
+	}
+	if len(merge.Tasks) == 0 {
+		return 0, nil
+	}
+
+	tags := make(map[int32][]string, len(merge.Tasks))
+	for _, t := range merge.Tasks {
+		names := make([]string, len(t.Tags))
+		for i, tag := range t.Tags {
+			names[i] = tag.Name
+		}
+		tags[t.ID] = names
+	}
+
+	affected, err := s.repo.SetTaskTags(ctx, userID, tags)
+	if err != nil {
+		s.log.Error("Failed to merge tag spellings",
+			zap.Int64("user_id", userID),
+			zap.String("tag", merge.Canonical),
+			zap.Error(err))
+		return 0, err
+	}
+
+	s.log.Info("Merged tag spellings",
+		zap.Int64("user_id", userID),
+		zap.String("tag", merge.Canonical),
+		zap.Strings("variants", merge.Variants),
+		zap.Int64("updated_count", affected))
+
+	return int(affected), nil
+}
+
+// tagsEqual reports whether two tag lists hold the same names in the same order
+func tagsEqual(a, b []task.Tag) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Name != b[i].Name {
+			return false
+		}
+	}
+	return true
+}
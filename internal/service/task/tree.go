@@ -0,0 +1,105 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/newbpydev/tusk/internal/core/errors"
+	"github.com/newbpydev/tusk/internal/core/task"
+	"go.uber.org/zap"
+)
+
+// TaskInput describes a task to create with CreateTree, along with its subtasks
+type TaskInput struct {
+	Title       string
+	Description string
+	DueDate     *time.Time
+	Priority    task.Priority
+	Tags        []string
+	Children    []TaskInput
+}
+
+// CreateTree creates a root task of the user together with its nested children in a
+// single transaction, so a failing insert leaves nothing behind. Every task is
+// validated like Create before anything is written. It returns the root with its
+// subtasks, all with their new IDs.
+func (s *taskService) CreateTree(ctx context.Context, userID int64, root TaskInput) (task.Task, error) {
+	if userID <= 0 {
+		return task.Task{}, errors.InvalidInput("user ID must be positive")
+	}
+
+	count := 0
+	tree, err := s.buildTree(int32(userID), root, 1, time.Now(), &count)
+	if err != nil {
+		s.log.Error("Invalid task tree provided for creation",
+			zap.Int64("user_id", userID),
+			zap.Error(err))
+		return task.Task{}, err
+	}
+
+	created, err := s.repo.CreateTree(ctx, tree)
+	if err != nil {
+		s.log.Error("Failed to create task tree",
+			zap.Int64("user_id", userID),
+			zap.Error(err))
+		return task.Task{}, err
+	}
+
+	s.log.Info("Task tree created successfully",
+		zap.Int64("user_id", userID),
+		zap.Int32("task_id", created.ID),
+		zap.Int("task_count", count))
+
+	return created, nil
+}
+
+// buildTree validates input and turns it into a new task at the given depth, with its
+// children as subtasks, counting the tasks it builds
+func (s *taskService) buildTree(userID int32, input TaskInput, depth int, now time.Time, count *int) (task.Task, error) {
+	if depth > s.maxDepth {
+		return task.Task{}, errors.InvalidInput(fmt.Sprintf("task tree is deeper than %d levels", s.maxDepth))
+	}
+	if input.Title == "" {
+		return task.Task{}, errors.InvalidInput("title is required")
+	}
+
+	priority := input.Priority
+	if priority != "" && !isValidPriority(priority) && s.strict {
+		return task.Task{}, errors.InvalidInput(fmt.Sprintf("invalid priority %q", priority))
+	}
+	if !isValidPriority(priority) {
+		priority = task.PriorityMedium
+	}
+	if err := validateTags(input.Tags); err != nil {
+		return task.Task{}, err
+	}
+
+	t := task.Task{
+		UserID:      userID,
+		Title:       input.Title,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		DueDate:     input.DueDate,
+		IsCompleted: false,
+		Status:      task.StatusTodo,
+		Priority:    priority,
+		Tags:        s.normalizeTags(input.Tags),
+	}
+	if input.Description != "" {
+		description := input.Description
+		t.Description = &description
+	}
+	*count++
+
+	for i, child := range input.Children {
+		sub, err := s.buildTree(userID, child, depth+1, now, count)
+		if err != nil {
+			return task.Task{}, err
+		}
+		// Children keep the order they were given in
+		sub.DisplayOrder = i
+		t.SubTasks = append(t.SubTasks, sub)
+	}
+	return t, nil
+}
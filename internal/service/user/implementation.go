@@ -151,6 +151,15 @@ func (s *userService) Login(ctx context.Context, username, password string) (use
 	return updatedUser, nil
 }
 
+// Get retrieves the user with the given ID.
+// It returns a not found error if no such user exists.
+func (s *userService) Get(ctx context.Context, userID int64) (user.User, error) {
+	if userID <= 0 {
+		return user.User{}, errors.InvalidInput("user ID must be positive")
+	}
+	return s.repo.GetByID(ctx, userID)
+}
+
 // emailDomain extracts domain part from an email address
 // Returns empty string if invalid email format
 func emailDomain(email string) string {
@@ -7,8 +7,10 @@ import (
 )
 
 // Service is the interface that defines the methods for managing users.
-// It includes methods for creating and logging in users.
+// It includes methods for creating, looking up and logging in users.
 type Service interface {
 	Create(ctx context.Context, username, email, password string) (user.User, error)
 	Login(ctx context.Context, username, password string) (user.User, error)
+	// Get returns the user with the given ID, such as the user an API token was issued to.
+	Get(ctx context.Context, userID int64) (user.User, error)
 }
@@ -43,6 +43,10 @@ var (
 
 	// Flag to indicate if we're in quiet mode (no console output)
 	quietMode bool
+
+	// Ring holds the most recent log lines for the TUI debug panel; nil unless
+	// DEBUG_PANEL is set
+	Ring *RingBuffer
 )
 
 // Component specific loggers for different parts of the application
@@ -157,6 +161,26 @@ func InitWithOptions(cfg *config.Config, quiet bool) error {
 		}
 	}
 
+	// Also keep the latest lines in memory for the debug panel when it is enabled
+	Ring = nil
+	if cfg.DebugPanel {
+		Ring = NewRingBuffer(min(cfg.DebugLogLines, config.MaxDebugLogLines))
+		ringEncoderConfig := zap.NewDevelopmentEncoderConfig()
+		ringEncoderConfig.EncodeTime = zapcore.TimeEncoderOfLayout("15:04:05.000")
+		ringCore := zapcore.NewCore(zapcore.NewConsoleEncoder(ringEncoderConfig), Ring, zapLevel)
+		withRing := zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+			return zapcore.NewTee(c, ringCore)
+		})
+
+		fileOnlyIsLogger := FileOnlyLogger == Logger
+		Logger = Logger.WithOptions(withRing)
+		if fileOnlyIsLogger {
+			FileOnlyLogger = Logger
+		} else if FileOnlyLogger != nil {
+			FileOnlyLogger = FileOnlyLogger.WithOptions(withRing)
+		}
+	}
+
 	// Create component specific loggers
 	CLILogger = Logger.Named("cli")
 	DBLogger = Logger.Named("db")
@@ -242,6 +266,11 @@ func getLogDirectory() string {
 	return filepath.Join(baseDir, "logs")
 }
 
+// LogFilePath returns the path of the rotating log file, whether or not it exists yet
+func LogFilePath() string {
+	return filepath.Join(getLogDirectory(), "tusk.log")
+}
+
 // Sync flushes any buffered log entries.
 func Sync() error {
 	return Logger.Sync()
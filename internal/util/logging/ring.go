@@ -0,0 +1,70 @@
+// Copyright (C) 2025 Juan Antonio Gomez Pena
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+package logging
+
+import (
+	"strings"
+	"sync"
+)
+
+// RingBuffer keeps the most recent log lines in memory, dropping the oldest once
+// it is full. It is safe for concurrent use and can be used as a zap write syncer.
+type RingBuffer struct {
+	mu    sync.Mutex
+	lines []string
+	next  int // where the next line is written once the buffer is full
+	full  bool
+}
+
+// NewRingBuffer creates a buffer holding at most size lines (at least one)
+func NewRingBuffer(size int) *RingBuffer {
+	return &RingBuffer{lines: make([]string, 0, max(1, size))}
+}
+
+// Write stores every non-empty line of p, overwriting the oldest lines when needed
+func (r *RingBuffer) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, line := range strings.Split(strings.TrimRight(string(p), "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		if !r.full {
+			r.lines = append(r.lines, line)
+			r.full = len(r.lines) == cap(r.lines)
+			continue
+		}
+		r.lines[r.next] = line
+		r.next = (r.next + 1) % len(r.lines)
+	}
+	return len(p), nil
+}
+
+// Sync does nothing; the lines are kept in memory only
+func (r *RingBuffer) Sync() error {
+	return nil
+}
+
+// Lines returns a copy of the stored lines, oldest first
+func (r *RingBuffer) Lines() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]string, 0, len(r.lines))
+	out = append(out, r.lines[r.next:]...)
+	return append(out, r.lines[:r.next]...)
+}
@@ -19,7 +19,10 @@ type Pool struct {
 	size    int
 	started bool
 	closed  bool
-	mu      sync.Mutex // Protects started and closed flags
+	drained bool          // Set by Drain; no more tasks are accepted
+	pending int           // Tasks submitted but not finished yet
+	idle    chan struct{} // Closed once pending drops to zero while draining
+	mu      sync.Mutex    // Protects the flags, pending and idle
 }
 
 // NewPool creates a new worker pool with the specified number of workers
@@ -72,7 +75,7 @@ func (p *Pool) worker() {
 				return
 			}
 
-			p.wg.Done()
+			p.taskDone()
 
 		case <-p.ctx.Done():
 			// Context canceled, exit worker
@@ -85,28 +88,42 @@ func (p *Pool) worker() {
 // If the pool hasn't been started, it starts it automatically
 func (p *Pool) Submit(task Task) {
 	p.mu.Lock()
-	if p.closed {
+	if p.closed || p.drained {
 		p.mu.Unlock()
 		return
 	}
 
-	if !p.started {
-		p.started = true
-		p.mu.Unlock()
+	// Counted while holding the lock, so Stop and Drain either wait for this task
+	// or have already refused it
+	p.wg.Add(1)
+	p.pending++
+
+	started := p.started
+	p.mu.Unlock()
+	if !started {
 		p.Start()
-	} else {
-		p.mu.Unlock()
 	}
 
-	p.wg.Add(1)
-
 	select {
 	case p.tasks <- task:
 		// Task submitted successfully
 	case <-p.ctx.Done():
 		// Context canceled, don't submit
-		p.wg.Done()
+		p.taskDone()
+	}
+}
+
+// taskDone records that a submitted task has finished
+func (p *Pool) taskDone() {
+	p.mu.Lock()
+	p.pending--
+	if p.pending == 0 && p.idle != nil {
+		close(p.idle)
+		p.idle = nil
 	}
+	p.mu.Unlock()
+
+	p.wg.Done()
 }
 
 // CollectResults collects results from tasks in a non-blocking way
@@ -135,6 +152,30 @@ func (p *Pool) Wait() {
 	p.wg.Wait()
 }
 
+// Drain stops the pool from accepting new tasks and blocks until the tasks already
+// submitted have finished, or until ctx is done, in which case it returns ctx's
+// error. Tasks submitted after Drain are dropped; Stop still has to be called.
+func (p *Pool) Drain(ctx context.Context) error {
+	p.mu.Lock()
+	p.drained = true
+	if p.pending == 0 {
+		p.mu.Unlock()
+		return nil
+	}
+	if p.idle == nil {
+		p.idle = make(chan struct{})
+	}
+	idle := p.idle
+	p.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // Stop stops the worker pool gracefully, waiting for all tasks to complete
 func (p *Pool) Stop() {
 	p.mu.Lock()